@@ -236,6 +236,90 @@ func IsNamespacedKind(
 	return false, &NoNamespaceInfoErr{gvk}
 }
 
+// NamespaceScopeResolver determines whether kinds are namespaced, as IsNamespacedKind does, for a set of objects
+// (e.g. the objects of a component). Unlike IsNamespacedKind, it finds a kind in any version of its group that the
+// cluster serves, and it refreshes the cached discovery information once if a kind isn't found, so that kinds
+// installed since the cache was populated (e.g. by another tool, or earlier in the deployment) are found. The scope
+// of each kind is memoized.
+type NamespaceScopeResolver struct {
+	clientSet *DynamicClientSet
+	objs      []unstructured.Unstructured
+	scopes    map[schema.GroupKind]bool
+	refreshed bool
+}
+
+// NewNamespaceScopeResolver returns a resolver that consults the given objects for CRDs, then the client set's CRD
+// cache and discovery client.
+func NewNamespaceScopeResolver(clientSet *DynamicClientSet, objs ...unstructured.Unstructured) *NamespaceScopeResolver {
+	contract.Requiref(clientSet != nil, "clientSet", "expected a clientSet")
+	return &NamespaceScopeResolver{
+		clientSet: clientSet,
+		objs:      objs,
+		scopes:    map[schema.GroupKind]bool{},
+	}
+}
+
+// IsNamespaced checks if a given GVK is namespace-scoped. If the GVK cannot be found, a NoNamespaceInfoErr is
+// returned.
+func (r *NamespaceScopeResolver) IsNamespaced(gvk schema.GroupVersionKind) (bool, error) {
+	if gvk.Group == "core" {
+		gvk.Group = ""
+	}
+	if namespaced, ok := r.scopes[gvk.GroupKind()]; ok {
+		return namespaced, nil
+	}
+	namespaced, err := IsNamespacedKind(gvk, r.clientSet, r.objs...)
+	if IsNoNamespaceInfoErr(err) && r.clientSet.DiscoveryClientCached != nil {
+		namespaced, err = r.discover(gvk)
+	}
+	if err != nil {
+		return false, err
+	}
+	r.scopes[gvk.GroupKind()] = namespaced
+	return namespaced, nil
+}
+
+// discover looks up the kind in the served versions of its group, refreshing the discovery cache once if need be.
+func (r *NamespaceScopeResolver) discover(gvk schema.GroupVersionKind) (bool, error) {
+	if namespaced, ok := discoverGroupKind(r.clientSet.DiscoveryClientCached, gvk.GroupKind()); ok {
+		return namespaced, nil
+	}
+	if r.refreshed {
+		return false, &NoNamespaceInfoErr{gvk}
+	}
+	r.refreshed = true
+	r.clientSet.DiscoveryClientCached.Invalidate()
+	if r.clientSet.RESTMapper != nil {
+		r.clientSet.RESTMapper.Reset()
+	}
+	if namespaced, err := IsNamespacedKind(gvk, r.clientSet); err == nil {
+		return namespaced, nil
+	}
+	if namespaced, ok := discoverGroupKind(r.clientSet.DiscoveryClientCached, gvk.GroupKind()); ok {
+		return namespaced, nil
+	}
+	return false, &NoNamespaceInfoErr{gvk}
+}
+
+// discoverGroupKind looks up the kind in all the served versions of its group.
+func discoverGroupKind(disco discovery.DiscoveryInterface, gk schema.GroupKind) (namespaced, found bool) {
+	// The resource lists are returned even if some group versions fail, along with an ErrGroupDiscoveryFailed.
+	_, resourceLists, _ := disco.ServerGroupsAndResources()
+	for _, resourceList := range resourceLists {
+		gv, err := schema.ParseGroupVersion(resourceList.GroupVersion)
+		if err != nil || gv.Group != gk.Group {
+			continue
+		}
+		for _, resource := range resourceList.APIResources {
+			// Skip the subresources (e.g. "deployments/scale"), whose kinds may be those of other resources.
+			if resource.Kind == gk.Kind && !strings.Contains(resource.Name, "/") {
+				return resource.Namespaced, true
+			}
+		}
+	}
+	return false, false
+}
+
 type LogClient struct {
 	client clientcorev1.CoreV1Interface
 	ctx    context.Context
@@ -178,3 +178,62 @@ func (d *simpleDiscovery) Fresh() bool {
 	return true
 }
 func (d *simpleDiscovery) Invalidate() {}
+
+func TestNamespaceScopeResolver(t *testing.T) {
+	version := kubeversion.Info{Major: "1", Minor: "29"}
+	disco := &refreshingDiscovery{
+		simpleDiscovery: simpleDiscovery{
+			FakeDiscovery: discoveryfake.FakeDiscovery{
+				Fake: &kubetesting.Fake{
+					Resources: fakeResources,
+				},
+				FakedServerVersion: &version,
+			},
+		},
+		// the resources that are installed after the discovery information is first cached.
+		installed: []*metav1.APIResourceList{{
+			GroupVersion: "stable.example.com/v1",
+			APIResources: []metav1.APIResource{
+				{Name: "crontabs", Namespaced: true, Kind: "CronTab"},
+			},
+		}},
+	}
+	r := NewNamespaceScopeResolver(&DynamicClientSet{DiscoveryClientCached: disco})
+
+	// a kind served by another version of its group.
+	got, err := r.IsNamespaced(schema.GroupVersionKind{Group: "postgresql.example.com", Version: "v1", Kind: "Role"})
+	if err != nil || got {
+		t.Errorf("IsNamespaced() = %v, %v; want false, nil", got, err)
+	}
+	if disco.invalidations != 0 {
+		t.Errorf("expected no invalidations, got %d", disco.invalidations)
+	}
+
+	// a kind installed since the discovery information was cached.
+	got, err = r.IsNamespaced(schema.GroupVersionKind{Group: "stable.example.com", Version: "v1", Kind: "CronTab"})
+	if err != nil || !got {
+		t.Errorf("IsNamespaced() = %v, %v; want true, nil", got, err)
+	}
+
+	// a kind that isn't installed, which doesn't refresh the discovery information again.
+	_, err = r.IsNamespaced(schema.GroupVersionKind{Group: "stable.example.com", Version: "v1", Kind: "Missing"})
+	if !IsNoNamespaceInfoErr(err) {
+		t.Errorf("IsNamespaced() error = %v, want a NoNamespaceInfoErr", err)
+	}
+	if disco.invalidations != 1 {
+		t.Errorf("expected 1 invalidation, got %d", disco.invalidations)
+	}
+}
+
+// refreshingDiscovery is a discovery client that serves the installed resources once it's invalidated.
+type refreshingDiscovery struct {
+	simpleDiscovery
+	installed     []*metav1.APIResourceList
+	invalidations int
+}
+
+func (d *refreshingDiscovery) Invalidate() {
+	d.invalidations++
+	d.Resources = append(d.Resources, d.installed...)
+	d.installed = nil
+}
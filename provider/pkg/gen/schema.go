@@ -204,13 +204,25 @@ func PulumiSchema(swagger map[string]any, opts ...schemaGeneratorOption) pschema
 					TypeSpec:    pschema.TypeSpec{Type: "boolean"},
 				},
 				"renderYamlToDirectory": {
-					Description: "BETA FEATURE - If present, render resource manifests to this directory. In this mode, resources will not\nbe created on a Kubernetes cluster, but the rendered manifests will be kept in sync with changes\nto the Pulumi program. This feature is in developer preview, and is disabled by default.\n\nRender mode attempts to connect to the cluster identified by your kubeconfig to determine whether\ncustom resources are namespaced or cluster-scoped. When no cluster is reachable, rendering proceeds\nanyway. Affected resources are written without a namespace scope, falling back to kubectl's default\nnamespace behavior on apply, and a warning naming each unresolved kind is emitted.\n\nNote that some computed Outputs such as status fields will not be populated\nsince the resources are not created on a Kubernetes cluster. These Output values will remain undefined,\nand may result in an error if they are referenced by other resources. Also note that any secret values\nused in these resources will be rendered in plaintext to the resulting YAML.",
+					Description: "BETA FEATURE - If present, render resource manifests to this directory. In this mode, resources will not\nbe created on a Kubernetes cluster, but the rendered manifests will be kept in sync with changes\nto the Pulumi program. This feature is in developer preview, and is disabled by default.\n\nRender mode attempts to connect to the cluster identified by your kubeconfig to determine whether\ncustom resources are namespaced or cluster-scoped. When no cluster is reachable, rendering proceeds\nanyway. Affected resources are written without a namespace scope, falling back to kubectl's default\nnamespace behavior on apply, and a warning naming each unresolved kind is emitted.\n\nNote that some computed Outputs such as status fields will not be populated\nsince the resources are not created on a Kubernetes cluster. These Output values will remain undefined,\nand may result in an error if they are referenced by other resources. Also note that any secret values\nused in these resources will be rendered in plaintext to the resulting YAML, except for the data of\nSecrets when renderYamlAgeRecipients is set.",
 					TypeSpec:    pschema.TypeSpec{Type: "string"},
 				},
 				"alwaysRender": {
 					Description: "If present and set to true, all resources will be rendered to the directory specified by renderYamlToDirectory on every update, even if the resource has not changed. This is useful for tools like ArgoCD Config Management Plugin that require all manifests to be regenerated on each run. Only valid when renderYamlToDirectory is set.",
 					TypeSpec:    pschema.TypeSpec{Type: "boolean"},
 				},
+				"renderYamlFormat": {
+					Description: "The layout of the manifests rendered to the directory specified by renderYamlToDirectory. One of:\n- `files` (the default): each object in its own file, in the `0-crd` and `1-manifest` directories.\n- `namespaces`: the objects of each namespace in a single multi-document file named after the namespace, and the objects without a namespace in `_cluster.yaml`.\n- `kustomization`: each object in its own file, as with `files`, along with a `kustomization.yaml` that lists the files.\n\nThe objects of a multi-document file, and the files of a `kustomization.yaml`, are in a deterministic dependency order. Only valid when renderYamlToDirectory is set.",
+					TypeSpec:    pschema.TypeSpec{Type: "string"},
+				},
+				"renderYamlSnapshot": {
+					Description: "The path of a JSON file from which to resolve the unknown values of the manifests rendered to the directory specified by renderYamlToDirectory, such as a generated name or an output of another stack. The file is either a deployment, as exported by `pulumi stack export --show-secrets`, from which an unknown value is resolved from the same property of the resource with the same URN, or a JSON object of resolved values keyed by resource URN and then by property path (e.g. `spec.loadBalancerIP`). With a snapshot, previews show the resolved values, although they never write the manifests. The unknown values that aren't resolved are rendered as `${unresolved:<property path>}` placeholders, and listed in `render-report.txt`. Only valid when renderYamlToDirectory is set.",
+					TypeSpec:    pschema.TypeSpec{Type: "string"},
+				},
+				"renderYamlAgeRecipients": {
+					Description: "A comma-separated list of age recipients (e.g. `age1...`) to which to encrypt the Secrets rendered to the directory specified by renderYamlToDirectory. The `data` and `stringData` of the Secrets are encrypted with SOPS, such that the manifests may be committed to git and decrypted by SOPS or by Flux's kustomize-controller with the recipients' age keys. When the recipients change, the Secrets are rendered again for the new recipients. A Secret that is rendered again unchanged (e.g. with alwaysRender) keeps its ciphertext, so that its file doesn't change. Not supported with the `namespaces` renderYamlFormat, since `sops -d` can't decrypt a file of several SOPS documents. Only valid when renderYamlToDirectory is set.",
+					TypeSpec:    pschema.TypeSpec{Type: "string"},
+				},
 				"suppressDeprecationWarnings": {
 					Description: "If present and set to true, suppress apiVersion deprecation warnings from the CLI.\n\nThis config can be specified in the following ways, using this precedence:\n1. This `suppressDeprecationWarnings` parameter.\n2. The `PULUMI_K8S_SUPPRESS_DEPRECATION_WARNINGS` environment variable.",
 					TypeSpec:    pschema.TypeSpec{Type: "boolean"},
@@ -219,6 +231,10 @@ func PulumiSchema(swagger map[string]any, opts ...schemaGeneratorOption) pschema
 					Description: "If present and set to true, suppress unsupported Helm hook warnings from the CLI.\n\nThis config can be specified in the following ways, using this precedence:\n1. This `suppressHelmHookWarnings` parameter.\n2. The `PULUMI_K8S_SUPPRESS_HELM_HOOK_WARNINGS` environment variable.",
 					TypeSpec:    pschema.TypeSpec{Type: "boolean"},
 				},
+				"manifestCacheOffline": {
+					Description: "If present and set to true, the manifests of `yaml/v2.ConfigGroup` and `yaml/v2.ConfigFile` that are given by URL are read from the on-disk manifest cache only, and never downloaded. A manifest that isn't cached is an error.\n\nThis config can be specified in the following ways, using this precedence:\n1. This `manifestCacheOffline` parameter.\n2. The `PULUMI_K8S_MANIFEST_CACHE_OFFLINE` environment variable.",
+					TypeSpec:    pschema.TypeSpec{Type: "boolean"},
+				},
 				"strictMode": {
 					Description: "If present and set to true, the provider will use strict configuration mode. Recommended for production stacks. In this mode, the default Kubernetes provider is disabled, and the `kubeconfig` and `context` settings are required for Provider configuration. These settings unambiguously ensure that every Kubernetes resource is associated with a particular cluster.",
 					TypeSpec:    pschema.TypeSpec{Type: "boolean"},
@@ -326,13 +342,25 @@ func PulumiSchema(swagger map[string]any, opts ...schemaGeneratorOption) pschema
 					TypeSpec:    pschema.TypeSpec{Type: "boolean"},
 				},
 				"renderYamlToDirectory": {
-					Description: "BETA FEATURE - If present, render resource manifests to this directory. In this mode, resources will not\nbe created on a Kubernetes cluster, but the rendered manifests will be kept in sync with changes\nto the Pulumi program. This feature is in developer preview, and is disabled by default.\n\nRender mode attempts to connect to the cluster identified by your kubeconfig to determine whether\ncustom resources are namespaced or cluster-scoped. When no cluster is reachable, rendering proceeds\nanyway. Affected resources are written without a namespace scope, falling back to kubectl's default\nnamespace behavior on apply, and a warning naming each unresolved kind is emitted.\n\nNote that some computed Outputs such as status fields will not be populated\nsince the resources are not created on a Kubernetes cluster. These Output values will remain undefined,\nand may result in an error if they are referenced by other resources. Also note that any secret values\nused in these resources will be rendered in plaintext to the resulting YAML.",
+					Description: "BETA FEATURE - If present, render resource manifests to this directory. In this mode, resources will not\nbe created on a Kubernetes cluster, but the rendered manifests will be kept in sync with changes\nto the Pulumi program. This feature is in developer preview, and is disabled by default.\n\nRender mode attempts to connect to the cluster identified by your kubeconfig to determine whether\ncustom resources are namespaced or cluster-scoped. When no cluster is reachable, rendering proceeds\nanyway. Affected resources are written without a namespace scope, falling back to kubectl's default\nnamespace behavior on apply, and a warning naming each unresolved kind is emitted.\n\nNote that some computed Outputs such as status fields will not be populated\nsince the resources are not created on a Kubernetes cluster. These Output values will remain undefined,\nand may result in an error if they are referenced by other resources. Also note that any secret values\nused in these resources will be rendered in plaintext to the resulting YAML, except for the data of\nSecrets when renderYamlAgeRecipients is set.",
 					TypeSpec:    pschema.TypeSpec{Type: "string"},
 				},
 				"alwaysRender": {
 					Description: "If present and set to true, all resources will be rendered to the directory specified by renderYamlToDirectory on every update, even if the resource has not changed. This is useful for tools like ArgoCD Config Management Plugin that require all manifests to be regenerated on each run. Only valid when renderYamlToDirectory is set.",
 					TypeSpec:    pschema.TypeSpec{Type: "boolean"},
 				},
+				"renderYamlFormat": {
+					Description: "The layout of the manifests rendered to the directory specified by renderYamlToDirectory. One of:\n- `files` (the default): each object in its own file, in the `0-crd` and `1-manifest` directories.\n- `namespaces`: the objects of each namespace in a single multi-document file named after the namespace, and the objects without a namespace in `_cluster.yaml`.\n- `kustomization`: each object in its own file, as with `files`, along with a `kustomization.yaml` that lists the files.\n\nThe objects of a multi-document file, and the files of a `kustomization.yaml`, are in a deterministic dependency order. Only valid when renderYamlToDirectory is set.",
+					TypeSpec:    pschema.TypeSpec{Type: "string"},
+				},
+				"renderYamlSnapshot": {
+					Description: "The path of a JSON file from which to resolve the unknown values of the manifests rendered to the directory specified by renderYamlToDirectory, such as a generated name or an output of another stack. The file is either a deployment, as exported by `pulumi stack export --show-secrets`, from which an unknown value is resolved from the same property of the resource with the same URN, or a JSON object of resolved values keyed by resource URN and then by property path (e.g. `spec.loadBalancerIP`). With a snapshot, previews show the resolved values, although they never write the manifests. The unknown values that aren't resolved are rendered as `${unresolved:<property path>}` placeholders, and listed in `render-report.txt`. Only valid when renderYamlToDirectory is set.",
+					TypeSpec:    pschema.TypeSpec{Type: "string"},
+				},
+				"renderYamlAgeRecipients": {
+					Description: "A comma-separated list of age recipients (e.g. `age1...`) to which to encrypt the Secrets rendered to the directory specified by renderYamlToDirectory. The `data` and `stringData` of the Secrets are encrypted with SOPS, such that the manifests may be committed to git and decrypted by SOPS or by Flux's kustomize-controller with the recipients' age keys. When the recipients change, the Secrets are rendered again for the new recipients. A Secret that is rendered again unchanged (e.g. with alwaysRender) keeps its ciphertext, so that its file doesn't change. Not supported with the `namespaces` renderYamlFormat, since `sops -d` can't decrypt a file of several SOPS documents. Only valid when renderYamlToDirectory is set.",
+					TypeSpec:    pschema.TypeSpec{Type: "string"},
+				},
 				"suppressDeprecationWarnings": {
 					DefaultInfo: &pschema.DefaultSpec{
 						Environment: []string{
@@ -359,6 +387,15 @@ func PulumiSchema(swagger map[string]any, opts ...schemaGeneratorOption) pschema
 					Description: "If present and set to true, suppress unsupported Helm hook warnings from the CLI.",
 					TypeSpec:    pschema.TypeSpec{Type: "boolean"},
 				},
+				"manifestCacheOffline": {
+					DefaultInfo: &pschema.DefaultSpec{
+						Environment: []string{
+							"PULUMI_K8S_MANIFEST_CACHE_OFFLINE",
+						},
+					},
+					Description: "If present and set to true, the manifests of `yaml/v2.ConfigGroup` and `yaml/v2.ConfigFile` that are given by URL are read from the on-disk manifest cache only, and never downloaded. A manifest that isn't cached is an error.",
+					TypeSpec:    pschema.TypeSpec{Type: "boolean"},
+				},
 			},
 		},
 
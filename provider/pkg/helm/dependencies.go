@@ -0,0 +1,343 @@
+// Copyright 2016-2026, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package helm
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"helm.sh/helm/v3/pkg/action"
+	"helm.sh/helm/v3/pkg/chart"
+	"helm.sh/helm/v3/pkg/chart/loader"
+	"helm.sh/helm/v3/pkg/cli"
+	"helm.sh/helm/v3/pkg/downloader"
+	"helm.sh/helm/v3/pkg/getter"
+	"helm.sh/helm/v3/pkg/provenance"
+	"helm.sh/helm/v3/pkg/registry"
+	"helm.sh/helm/v3/pkg/repo"
+	"sigs.k8s.io/yaml"
+)
+
+// DependencyMode controls how the dependencies of a chart (its subcharts) are resolved.
+type DependencyMode string
+
+const (
+	// DependencyModeDefault leaves the dependencies to each resource's own, long-standing behavior:
+	// missing dependencies are fetched when requested (via dependencyUpdate), and by Chart also when
+	// a Chart.lock is present. ResolveDependencies fetches them only when requested.
+	DependencyModeDefault DependencyMode = ""
+	// DependencyModeLocked requires a Chart.lock that matches Chart.yaml. Missing dependencies are
+	// fetched at exactly the locked versions.
+	DependencyModeLocked DependencyMode = "locked"
+	// DependencyModeVendor resolves the dependencies into the charts/ directory of a local chart,
+	// so that they may be committed alongside the program.
+	DependencyModeVendor DependencyMode = "vendor"
+	// DependencyModeOffline never fetches dependencies; they must already be present in the chart.
+	DependencyModeOffline DependencyMode = "offline"
+)
+
+// ParseDependencyMode parses the given string as a DependencyMode.
+func ParseDependencyMode(s string) (DependencyMode, error) {
+	switch m := DependencyMode(s); m {
+	case DependencyModeDefault, DependencyModeLocked, DependencyModeVendor, DependencyModeOffline:
+		return m, nil
+	default:
+		return "", fmt.Errorf("unsupported dependency mode %q; expected one of: %s, %s, %s",
+			s, DependencyModeLocked, DependencyModeVendor, DependencyModeOffline)
+	}
+}
+
+// DependencyOptions configures the resolution of a chart's dependencies.
+type DependencyOptions struct {
+	// Mode is the dependency mode.
+	Mode DependencyMode
+	// Update re-resolves the dependencies, i.e. `helm dependency update`.
+	Update bool
+	// Preview leaves the chart directory as it is. In the locked and vendor modes, missing or out-of-date
+	// dependencies are resolved into a temporary copy of the chart instead, and the chart is loaded from the copy.
+	Preview bool
+	// Keyring is the location of the public keys used to verify the dependencies.
+	Keyring string
+	// Settings are the Helm environment settings.
+	Settings *cli.EnvSettings
+	// RegistryClient is used to fetch OCI dependencies.
+	RegistryClient *registry.Client
+	// Out receives the output of the dependency manager.
+	Out io.Writer
+}
+
+// ResolveDependencies ensures that the dependencies of the chart located at path are present,
+// in accordance with the dependency mode. It returns true if the chart's dependencies were changed
+// on disk, in which case the chart should be loaded again.
+func ResolveDependencies(c *chart.Chart, path string, opts DependencyOptions) (bool, error) {
+	req := c.Metadata.Dependencies
+	if req == nil {
+		return false, nil
+	}
+	if opts.Update && (opts.Mode == DependencyModeLocked || opts.Mode == DependencyModeOffline) {
+		return false, fmt.Errorf("dependencyUpdate is not compatible with dependency mode %q", opts.Mode)
+	}
+
+	missing := action.CheckDependencies(c, req)
+
+	switch opts.Mode {
+	case DependencyModeLocked:
+		if c.Lock == nil {
+			return false, errors.New("dependency mode \"locked\" requires a Chart.lock file; " +
+				"run `helm dependency update` to create one")
+		}
+		if err := verifyLock(c, opts.Settings); err != nil {
+			return false, err
+		}
+		if missing == nil {
+			return false, nil
+		}
+		// Fetch the dependencies at exactly the locked versions; a preview fetches them into a copy of the chart.
+		if opts.Preview {
+			return false, vendorPreview(c, path, opts)
+		}
+		return true, newDependencyManager(path, opts).Build()
+
+	case DependencyModeOffline:
+		if missing != nil {
+			return false, fmt.Errorf("%w; dependency mode \"offline\" does not fetch dependencies, "+
+				"vendor them into the chart's charts/ directory first", missing)
+		}
+		if c.Lock != nil {
+			return false, verifyLock(c, opts.Settings)
+		}
+		return false, nil
+
+	case DependencyModeVendor:
+		if fi, err := os.Stat(path); err != nil || !fi.IsDir() {
+			return false, fmt.Errorf("dependency mode \"vendor\" requires an unpacked chart directory, got %q", path)
+		}
+		if !opts.Update && missing == nil && c.Lock != nil && verifyLock(c, opts.Settings) == nil {
+			// The vendored dependencies are up-to-date.
+			return false, nil
+		}
+		if opts.Preview {
+			return false, vendorPreview(c, path, opts)
+		}
+		return true, vendor(path, opts)
+
+	default:
+		if missing == nil {
+			return false, nil
+		}
+		if opts.Update {
+			return true, newDependencyManager(path, opts).Update()
+		}
+		return false, missing
+	}
+}
+
+// vendor resolves the dependencies into the charts/ directory of the chart located at path: it updates them if
+// requested, or else builds them from the lock file, resolving and writing a new lock file if there is none.
+func vendor(path string, opts DependencyOptions) error {
+	man := newDependencyManager(path, opts)
+	if opts.Update {
+		return man.Update()
+	}
+	return man.Build()
+}
+
+// vendorPreview vendors the dependencies into a temporary copy of the chart located at path, and replaces the
+// chart with the copy, such that a preview shows the vendored dependencies without writing them.
+func vendorPreview(c *chart.Chart, path string, opts DependencyOptions) error {
+	tmp, err := os.MkdirTemp("", "chart-")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(tmp)
+
+	copyPath := filepath.Join(tmp, filepath.Base(path))
+	if err := os.CopyFS(copyPath, os.DirFS(path)); err != nil {
+		return fmt.Errorf("failed to copy the chart: %w", err)
+	}
+	if err := absoluteFileRepositories(c, path, copyPath, opts.Settings); err != nil {
+		return fmt.Errorf("failed to copy the chart: %w", err)
+	}
+	if err := vendor(copyPath, opts); err != nil {
+		return err
+	}
+	vendored, err := loader.Load(copyPath)
+	if err != nil {
+		return err
+	}
+	// The chart's own metadata is as it was, not as rewritten for the copy.
+	vendored.Metadata = c.Metadata
+	if c.Lock != nil {
+		vendored.Lock = c.Lock
+	}
+	*c = *vendored
+	return nil
+}
+
+// absoluteFileRepositories rewrites the relative `file://` repositories of the chart's dependencies in the copy
+// of the chart, which are relative to the chart's directory at path, to absolute ones. The digest of the copy's
+// lock file is updated to match, unless it was out of sync to begin with.
+func absoluteFileRepositories(c *chart.Chart, path, copyPath string, settings *cli.EnvSettings) error {
+	absPath, err := filepath.Abs(path)
+	if err != nil {
+		return err
+	}
+	rewrite := func(deps []*chart.Dependency) ([]*chart.Dependency, bool) {
+		result := make([]*chart.Dependency, 0, len(deps))
+		rewritten := false
+		for _, dep := range deps {
+			d := *dep
+			if p, ok := strings.CutPrefix(d.Repository, "file://"); ok && !filepath.IsAbs(p) {
+				d.Repository = "file://" + filepath.Join(absPath, p)
+				rewritten = true
+			}
+			result = append(result, &d)
+		}
+		return result, rewritten
+	}
+
+	metadata := *c.Metadata
+	deps, rewritten := rewrite(metadata.Dependencies)
+	if !rewritten {
+		return nil
+	}
+	metadata.Dependencies = deps
+	if err := writeYAML(filepath.Join(copyPath, "Chart.yaml"), &metadata); err != nil {
+		return err
+	}
+	if c.Lock == nil {
+		return nil
+	}
+
+	lock := *c.Lock
+	lock.Dependencies, _ = rewrite(lock.Dependencies)
+	req, err := resolveRepositoryAliases(c.Metadata.Dependencies, settings)
+	if err != nil {
+		return err
+	}
+	if sum, err := hashReq(req, c.Lock.Dependencies); err == nil && sum == c.Lock.Digest {
+		if req, err = resolveRepositoryAliases(metadata.Dependencies, settings); err != nil {
+			return err
+		}
+		if lock.Digest, err = hashReq(req, lock.Dependencies); err != nil {
+			return err
+		}
+	}
+	return writeYAML(filepath.Join(copyPath, "Chart.lock"), &lock)
+}
+
+func writeYAML(path string, v any) error {
+	data, err := yaml.Marshal(v)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0o644)
+}
+
+func newDependencyManager(path string, opts DependencyOptions) *downloader.Manager {
+	out := opts.Out
+	if out == nil {
+		out = io.Discard
+	}
+	return &downloader.Manager{
+		Out:              out,
+		ChartPath:        path,
+		Keyring:          opts.Keyring,
+		SkipUpdate:       false,
+		Getters:          getter.All(opts.Settings),
+		RepositoryConfig: opts.Settings.RepositoryConfig,
+		RepositoryCache:  opts.Settings.RepositoryCache,
+		RegistryClient:   opts.RegistryClient,
+		Debug:            opts.Settings.Debug,
+	}
+}
+
+// verifyLock checks that the chart's lock file is in sync with its declared dependencies,
+// and that any subcharts present in the chart are at the locked versions.
+func verifyLock(c *chart.Chart, settings *cli.EnvSettings) error {
+	req, err := resolveRepositoryAliases(c.Metadata.Dependencies, settings)
+	if err != nil {
+		return err
+	}
+	sum, err := hashReq(req, c.Lock.Dependencies)
+	if err != nil || sum != c.Lock.Digest {
+		return errors.New("the lock file (Chart.lock) is out of sync with the dependencies file (Chart.yaml); " +
+			"run `helm dependency update` to update it")
+	}
+
+	locked := map[string]string{}
+	for _, dep := range c.Lock.Dependencies {
+		locked[dep.Name] = dep.Version
+	}
+	for _, sub := range c.Dependencies() {
+		version, ok := locked[sub.Name()]
+		if ok && version != sub.Metadata.Version {
+			return fmt.Errorf("subchart %q is at version %q but Chart.lock requires version %q",
+				sub.Name(), sub.Metadata.Version, version)
+		}
+	}
+	return nil
+}
+
+// resolveRepositoryAliases returns a copy of the dependencies with repository aliases
+// (e.g. "@stable" or "alias:stable") replaced by the URL of the configured repository,
+// consistent with how Helm computes the lock digest.
+func resolveRepositoryAliases(deps []*chart.Dependency, settings *cli.EnvSettings) ([]*chart.Dependency, error) {
+	var repos []*repo.Entry
+	if settings != nil {
+		rf, err := repo.LoadFile(settings.RepositoryConfig)
+		switch {
+		case errors.Is(err, fs.ErrNotExist):
+		case err != nil:
+			return nil, err
+		default:
+			repos = rf.Repositories
+		}
+	}
+
+	result := make([]*chart.Dependency, 0, len(deps))
+	for _, dep := range deps {
+		d := *dep
+		alias := strings.TrimPrefix(strings.TrimPrefix(d.Repository, "@"), "alias:")
+		if alias != d.Repository {
+			for _, r := range repos {
+				if r.Name == alias {
+					d.Repository = r.URL
+					break
+				}
+			}
+		}
+		result = append(result, &d)
+	}
+	return result, nil
+}
+
+// hashReq generates a hash of the dependencies.
+// https://github.com/helm/helm/blob/v3.20.2/internal/resolver/resolver.go#L214-L221
+func hashReq(req, lock []*chart.Dependency) (string, error) {
+	data, err := json.Marshal([2][]*chart.Dependency{req, lock})
+	if err != nil {
+		return "", err
+	}
+	s, err := provenance.Digest(bytes.NewBuffer(data))
+	return "sha256:" + s, err
+}
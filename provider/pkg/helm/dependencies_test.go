@@ -0,0 +1,261 @@
+// Copyright 2016-2026, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package helm
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"helm.sh/helm/v3/pkg/chart"
+	"helm.sh/helm/v3/pkg/chart/loader"
+	"helm.sh/helm/v3/pkg/cli"
+)
+
+func TestParseDependencyMode(t *testing.T) {
+	for _, s := range []string{"", "locked", "vendor", "offline"} {
+		mode, err := ParseDependencyMode(s)
+		require.NoError(t, err)
+		assert.Equal(t, DependencyMode(s), mode)
+	}
+
+	_, err := ParseDependencyMode("online")
+	assert.ErrorContains(t, err, `unsupported dependency mode "online"`)
+}
+
+func TestResolveDependencies(t *testing.T) {
+	const repository = "https://charts.example.com"
+
+	newChart := func(subchartVersion string, lock bool) *chart.Chart {
+		c := &chart.Chart{
+			Metadata: &chart.Metadata{
+				APIVersion: chart.APIVersionV2,
+				Name:       "parent",
+				Version:    "1.0.0",
+				Dependencies: []*chart.Dependency{
+					{Name: "child", Version: "1.x", Repository: repository},
+				},
+			},
+		}
+		if subchartVersion != "" {
+			c.AddDependency(&chart.Chart{Metadata: &chart.Metadata{
+				APIVersion: chart.APIVersionV2,
+				Name:       "child",
+				Version:    subchartVersion,
+			}})
+		}
+		if lock {
+			locked := []*chart.Dependency{{Name: "child", Version: "1.2.3", Repository: repository}}
+			digest, err := hashReq(c.Metadata.Dependencies, locked)
+			require.NoError(t, err)
+			c.Lock = &chart.Lock{Dependencies: locked, Digest: digest}
+		}
+		return c
+	}
+
+	settings := cli.New()
+	settings.RepositoryConfig = filepath.Join(t.TempDir(), "repositories.yaml")
+	settings.RepositoryCache = t.TempDir()
+
+	tests := []struct {
+		name    string
+		chart   *chart.Chart
+		path    string
+		opts    DependencyOptions
+		wantErr string
+	}{
+		{
+			name:  "default mode with dependencies present",
+			chart: newChart("1.2.3", false),
+		},
+		{
+			name:    "default mode with missing dependencies",
+			chart:   newChart("", false),
+			wantErr: "found in Chart.yaml, but missing in charts/ directory: child",
+		},
+		{
+			name:    "locked mode without a lock file",
+			chart:   newChart("1.2.3", false),
+			opts:    DependencyOptions{Mode: DependencyModeLocked},
+			wantErr: "requires a Chart.lock file",
+		},
+		{
+			name: "locked mode with an out-of-sync lock file",
+			chart: func() *chart.Chart {
+				c := newChart("1.2.3", true)
+				c.Metadata.Dependencies[0].Version = "2.x"
+				return c
+			}(),
+			opts:    DependencyOptions{Mode: DependencyModeLocked},
+			wantErr: "the lock file (Chart.lock) is out of sync",
+		},
+		{
+			name:    "locked mode with a subchart at the wrong version",
+			chart:   newChart("1.2.4", true),
+			opts:    DependencyOptions{Mode: DependencyModeLocked},
+			wantErr: `subchart "child" is at version "1.2.4" but Chart.lock requires version "1.2.3"`,
+		},
+		{
+			name:  "locked mode with dependencies present",
+			chart: newChart("1.2.3", true),
+			opts:  DependencyOptions{Mode: DependencyModeLocked},
+		},
+		{
+			name:    "locked mode with dependencyUpdate",
+			chart:   newChart("1.2.3", true),
+			opts:    DependencyOptions{Mode: DependencyModeLocked, Update: true},
+			wantErr: "dependencyUpdate is not compatible",
+		},
+		{
+			name:    "offline mode with missing dependencies",
+			chart:   newChart("", true),
+			opts:    DependencyOptions{Mode: DependencyModeOffline},
+			wantErr: `dependency mode "offline" does not fetch dependencies`,
+		},
+		{
+			name:  "offline mode without a lock file",
+			chart: newChart("1.2.3", false),
+			opts:  DependencyOptions{Mode: DependencyModeOffline},
+		},
+		{
+			name:    "offline mode with a subchart at the wrong version",
+			chart:   newChart("1.2.4", true),
+			opts:    DependencyOptions{Mode: DependencyModeOffline},
+			wantErr: "Chart.lock requires version",
+		},
+		{
+			name:    "vendor mode with a chart archive",
+			chart:   newChart("1.2.3", true),
+			path:    filepath.Join(t.TempDir(), "parent-1.0.0.tgz"),
+			opts:    DependencyOptions{Mode: DependencyModeVendor},
+			wantErr: "requires an unpacked chart directory",
+		},
+		{
+			name:  "vendor mode with up-to-date dependencies",
+			chart: newChart("1.2.3", true),
+			path:  t.TempDir(),
+			opts:  DependencyOptions{Mode: DependencyModeVendor},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts := tt.opts
+			opts.Settings = settings
+			updated, err := ResolveDependencies(tt.chart, tt.path, opts)
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.False(t, updated)
+		})
+	}
+}
+
+func TestResolveDependenciesVendorPreview(t *testing.T) {
+	dir := t.TempDir()
+	writeFile := func(name, content string) {
+		require.NoError(t, os.MkdirAll(filepath.Dir(filepath.Join(dir, name)), 0o755))
+		require.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte(content), 0o600))
+	}
+	writeFile("child/Chart.yaml", "apiVersion: v2\nname: child\nversion: 1.2.3\n")
+	writeFile("parent/Chart.yaml", "apiVersion: v2\nname: parent\nversion: 1.0.0\n"+
+		"dependencies:\n- name: child\n  version: 1.x\n  repository: file://../child\n")
+	path := filepath.Join(dir, "parent")
+
+	settings := cli.New()
+	settings.RepositoryConfig = filepath.Join(t.TempDir(), "repositories.yaml")
+	settings.RepositoryCache = t.TempDir()
+
+	c, err := loader.Load(path)
+	require.NoError(t, err)
+	updated, err := ResolveDependencies(c, path, DependencyOptions{
+		Mode: DependencyModeVendor, Preview: true, Settings: settings,
+	})
+	require.NoError(t, err)
+	assert.False(t, updated)
+	require.Len(t, c.Dependencies(), 1, "the chart should be loaded with its vendored dependencies")
+	assert.Equal(t, "1.2.3", c.Dependencies()[0].Metadata.Version)
+	assert.NoDirExists(t, filepath.Join(path, "charts"), "a preview mustn't write into the chart")
+	assert.NoFileExists(t, filepath.Join(path, "Chart.lock"))
+
+	updated, err = ResolveDependencies(c, path, DependencyOptions{Mode: DependencyModeVendor, Settings: settings})
+	require.NoError(t, err)
+	assert.True(t, updated)
+	assert.FileExists(t, filepath.Join(path, "charts", "child-1.2.3.tgz"))
+	assert.FileExists(t, filepath.Join(path, "Chart.lock"))
+
+	// A preview builds the dependencies from the lock file.
+	require.NoError(t, os.RemoveAll(filepath.Join(path, "charts")))
+	c, err = loader.Load(path)
+	require.NoError(t, err)
+	require.NotNil(t, c.Lock)
+	updated, err = ResolveDependencies(c, path, DependencyOptions{
+		Mode: DependencyModeVendor, Preview: true, Settings: settings,
+	})
+	require.NoError(t, err)
+	assert.False(t, updated)
+	require.Len(t, c.Dependencies(), 1)
+	assert.Equal(t, "file://../child", c.Lock.Dependencies[0].Repository)
+	assert.NoDirExists(t, filepath.Join(path, "charts"))
+}
+
+func TestResolveDependenciesLockedPreview(t *testing.T) {
+	dir := t.TempDir()
+	writeFile := func(name, content string) {
+		require.NoError(t, os.MkdirAll(filepath.Dir(filepath.Join(dir, name)), 0o755))
+		require.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte(content), 0o600))
+	}
+	writeFile("child/Chart.yaml", "apiVersion: v2\nname: child\nversion: 1.2.3\n")
+	writeFile("parent/Chart.yaml", "apiVersion: v2\nname: parent\nversion: 1.0.0\n"+
+		"dependencies:\n- name: child\n  version: 1.x\n  repository: file://../child\n")
+	path := filepath.Join(dir, "parent")
+
+	settings := cli.New()
+	settings.RepositoryConfig = filepath.Join(t.TempDir(), "repositories.yaml")
+	settings.RepositoryCache = t.TempDir()
+
+	// Lock the dependencies, then remove them.
+	c, err := loader.Load(path)
+	require.NoError(t, err)
+	_, err = ResolveDependencies(c, path, DependencyOptions{Mode: DependencyModeVendor, Settings: settings})
+	require.NoError(t, err)
+	require.NoError(t, os.RemoveAll(filepath.Join(path, "charts")))
+	lock, err := os.ReadFile(filepath.Join(path, "Chart.lock"))
+	require.NoError(t, err)
+
+	c, err = loader.Load(path)
+	require.NoError(t, err)
+	updated, err := ResolveDependencies(c, path, DependencyOptions{
+		Mode: DependencyModeLocked, Preview: true, Settings: settings,
+	})
+	require.NoError(t, err)
+	assert.False(t, updated)
+	require.Len(t, c.Dependencies(), 1, "the chart should be loaded with its locked dependencies")
+	assert.Equal(t, "1.2.3", c.Dependencies()[0].Metadata.Version)
+	assert.NoDirExists(t, filepath.Join(path, "charts"), "a preview mustn't write into the chart")
+	after, err := os.ReadFile(filepath.Join(path, "Chart.lock"))
+	require.NoError(t, err)
+	assert.Equal(t, string(lock), string(after))
+
+	c, err = loader.Load(path)
+	require.NoError(t, err)
+	updated, err = ResolveDependencies(c, path, DependencyOptions{Mode: DependencyModeLocked, Settings: settings})
+	require.NoError(t, err)
+	assert.True(t, updated)
+	assert.FileExists(t, filepath.Join(path, "charts", "child-1.2.3.tgz"))
+}
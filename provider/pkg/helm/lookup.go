@@ -0,0 +1,336 @@
+// Copyright 2016-2026, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package helm
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+	"sync"
+
+	"helm.sh/helm/v3/pkg/action"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/rest"
+
+	logger "github.com/pulumi/pulumi/sdk/v3/go/common/util/logging"
+)
+
+// AllNamespaces allows the lookup of objects in any namespace.
+const AllNamespaces = "*"
+
+// ClusterLookup scopes the cluster access of the Helm `lookup` function. The function is given a
+// read-only client that may only get or list objects of the allowed kinds, in the allowed namespaces.
+type ClusterLookup struct {
+	// Kinds are the kinds of objects that may be looked up, either as "Kind" (in any API group) or as
+	// "group/Kind". All kinds are allowed if empty.
+	Kinds []string `mapstructure:"kinds"`
+	// Namespaces are the namespaces in which objects may be looked up, or "*" for all namespaces. The
+	// release namespace is allowed if empty. Cluster-scoped objects may be looked up regardless.
+	Namespaces []string `mapstructure:"namespaces"`
+}
+
+func (l *ClusterLookup) allowsKind(gk schema.GroupKind) bool {
+	if len(l.Kinds) == 0 {
+		return true
+	}
+	return slices.Contains(l.Kinds, gk.Kind) || (gk.Group != "" && slices.Contains(l.Kinds, gk.Group+"/"+gk.Kind))
+}
+
+func (l *ClusterLookup) allowsNamespace(namespace, releaseNamespace string) bool {
+	if len(l.Namespaces) == 0 {
+		return namespace == releaseNamespace
+	}
+	if slices.Contains(l.Namespaces, AllNamespaces) {
+		return true
+	}
+	return namespace != "" && slices.Contains(l.Namespaces, namespace)
+}
+
+// LookupCache caches the responses to the lookups of Helm charts, so that all the charts of a
+// deployment see the same cluster data and the cluster isn't asked for the same object over and over.
+type LookupCache struct {
+	mu        sync.Mutex
+	responses map[string]cachedResponse
+}
+
+type cachedResponse struct {
+	statusCode int
+	header     http.Header
+	body       []byte
+}
+
+// NewLookupCache returns an empty lookup cache.
+func NewLookupCache() *LookupCache {
+	return &LookupCache{responses: map[string]cachedResponse{}}
+}
+
+func (c *LookupCache) get(key string) (cachedResponse, bool) {
+	if c == nil {
+		return cachedResponse{}, false
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	cached, ok := c.responses[key]
+	return cached, ok
+}
+
+func (c *LookupCache) put(key string, cached cachedResponse) {
+	if c == nil {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.responses[key] = cached
+}
+
+// lookupRESTClientGetter provides a REST config that applies the lookup scope to the requests of the
+// Helm template engine.
+type lookupRESTClientGetter struct {
+	action.RESTClientGetter
+	transport *lookupTransport
+}
+
+var _ action.RESTClientGetter = &lookupRESTClientGetter{}
+
+func (g *lookupRESTClientGetter) ToRESTConfig() (*rest.Config, error) {
+	config, err := g.RESTClientGetter.ToRESTConfig()
+	if err != nil {
+		return nil, err
+	}
+	mapper, err := g.ToRESTMapper()
+	if err != nil {
+		return nil, err
+	}
+	g.transport.mapper = mapper
+	if u, err := url.Parse(config.Host); err == nil {
+		g.transport.pathPrefix = strings.TrimSuffix(u.Path, "/")
+	}
+
+	config = rest.CopyConfig(config)
+	config.Wrap(func(rt http.RoundTripper) http.RoundTripper {
+		t := *g.transport
+		t.next = rt
+		return &t
+	})
+	return config, nil
+}
+
+// lookupTransport enforces the lookup scope and serves the lookups from the cache. It also records the
+// values of the looked up secrets, so that the rendered objects that contain them can be marked secret.
+type lookupTransport struct {
+	next             http.RoundTripper
+	scope            *ClusterLookup
+	releaseNamespace string
+	mapper           meta.RESTMapper
+	pathPrefix       string
+	cache            *LookupCache
+	secrets          *secretValues
+}
+
+func (t *lookupTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Method != http.MethodGet {
+		return statusResponse(req, http.StatusMethodNotAllowed, metav1.StatusReasonMethodNotAllowed,
+			fmt.Sprintf("the lookup function is read-only (%s %s)", req.Method, req.URL.Path)), nil
+	}
+
+	p, ok := parseLookupPath(strings.TrimPrefix(req.URL.Path, t.pathPrefix))
+	isSecret := false
+	if ok && !p.discovery {
+		gvk, err := t.mapper.KindFor(p.resource)
+		if err != nil {
+			return nil, err
+		}
+		mapping, err := t.mapper.RESTMapping(gvk.GroupKind(), gvk.Version)
+		if err != nil {
+			return nil, err
+		}
+		if !t.scope.allowsKind(gvk.GroupKind()) {
+			return statusResponse(req, http.StatusForbidden, metav1.StatusReasonForbidden,
+				fmt.Sprintf("lookup of kind %q is not allowed by allowClusterLookup", gvk.GroupKind())), nil
+		}
+		if mapping.Scope.Name() == meta.RESTScopeNameNamespace &&
+			!t.scope.allowsNamespace(p.namespace, t.releaseNamespace) {
+			msg := fmt.Sprintf("lookup of kind %q in namespace %q is not allowed by allowClusterLookup",
+				gvk.GroupKind(), p.namespace)
+			if p.namespace == "" {
+				msg = fmt.Sprintf("lookup of kind %q in all namespaces is not allowed by allowClusterLookup",
+					gvk.GroupKind())
+			}
+			return statusResponse(req, http.StatusForbidden, metav1.StatusReasonForbidden, msg), nil
+		}
+		isSecret = gvk.Group == "" && gvk.Kind == "Secret"
+	}
+
+	key := req.URL.String()
+	cached, ok := t.cache.get(key)
+	if !ok {
+		resp, err := t.next.RoundTrip(req)
+		if err != nil {
+			return nil, err
+		}
+		body, err := io.ReadAll(resp.Body)
+		_ = resp.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		cached = cachedResponse{statusCode: resp.StatusCode, header: resp.Header, body: body}
+		// A missing object is a valid lookup result, whereas other errors may be transient.
+		if resp.StatusCode == http.StatusOK || resp.StatusCode == http.StatusNotFound {
+			t.cache.put(key, cached)
+		}
+	} else {
+		logger.V(9).Infof("Using the cached lookup of %q", req.URL.Path)
+	}
+
+	if isSecret && cached.statusCode == http.StatusOK {
+		t.secrets.record(cached.body)
+	}
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", cached.statusCode, http.StatusText(cached.statusCode)),
+		StatusCode:    cached.statusCode,
+		Header:        cached.header.Clone(),
+		Body:          io.NopCloser(bytes.NewReader(cached.body)),
+		ContentLength: int64(len(cached.body)),
+		Request:       req,
+	}, nil
+}
+
+// lookupPath is the parsed path of a Kubernetes API request.
+type lookupPath struct {
+	discovery bool
+	resource  schema.GroupVersionResource
+	namespace string
+	name      string
+}
+
+// parseLookupPath parses the path of a request to the Kubernetes API, e.g.
+// "/api/v1/namespaces/default/secrets/foo" or "/apis/apps/v1/deployments".
+func parseLookupPath(path string) (lookupPath, bool) {
+	segments := strings.Split(strings.Trim(path, "/"), "/")
+	var gv schema.GroupVersion
+	switch {
+	case len(segments) >= 1 && segments[0] == "api":
+		if len(segments) <= 2 {
+			return lookupPath{discovery: true}, true
+		}
+		gv = schema.GroupVersion{Version: segments[1]}
+		segments = segments[2:]
+	case len(segments) >= 1 && segments[0] == "apis":
+		if len(segments) <= 3 {
+			return lookupPath{discovery: true}, true
+		}
+		gv = schema.GroupVersion{Group: segments[1], Version: segments[2]}
+		segments = segments[3:]
+	default:
+		return lookupPath{}, false
+	}
+
+	p := lookupPath{}
+	// "namespaces/<namespace>/<resource>" is namespaced, whereas "namespaces/<name>" is a Namespace.
+	if len(segments) >= 3 && segments[0] == "namespaces" {
+		p.namespace = segments[1]
+		segments = segments[2:]
+	}
+	switch len(segments) {
+	case 1:
+		p.resource = gv.WithResource(segments[0])
+	case 2:
+		p.resource = gv.WithResource(segments[0])
+		p.name = segments[1]
+	default:
+		return lookupPath{}, false
+	}
+	return p, true
+}
+
+func statusResponse(req *http.Request, code int, reason metav1.StatusReason, message string) *http.Response {
+	status := metav1.Status{
+		TypeMeta: metav1.TypeMeta{APIVersion: "v1", Kind: "Status"},
+		Status:   metav1.StatusFailure,
+		Message:  message,
+		Reason:   reason,
+		Code:     int32(code), //nolint:gosec // HTTP status codes fit in an int32.
+	}
+	body, _ := json.Marshal(status)
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", code, http.StatusText(code)),
+		StatusCode:    code,
+		Header:        http.Header{"Content-Type": []string{"application/json"}},
+		Body:          io.NopCloser(bytes.NewReader(body)),
+		ContentLength: int64(len(body)),
+		Request:       req,
+	}
+}
+
+// secretValues are the values of the secrets looked up by a chart, both encoded and decoded.
+type secretValues struct {
+	mu     sync.Mutex
+	values map[string]struct{}
+}
+
+// record records the values of a Secret or a SecretList.
+func (s *secretValues) record(body []byte) {
+	var obj struct {
+		Data       map[string]string `json:"data"`
+		StringData map[string]string `json:"stringData"`
+		Items      []struct {
+			Data map[string]string `json:"data"`
+		} `json:"items"`
+	}
+	if err := json.Unmarshal(body, &obj); err != nil {
+		logger.V(3).Infof("Unable to record the looked up secret values: %v", err)
+		return
+	}
+	datas := []map[string]string{obj.Data, obj.StringData}
+	for _, item := range obj.Items {
+		datas = append(datas, item.Data)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.values == nil {
+		s.values = map[string]struct{}{}
+	}
+	for _, data := range datas {
+		for _, v := range data {
+			if v == "" {
+				continue
+			}
+			s.values[v] = struct{}{}
+			if decoded, err := base64.StdEncoding.DecodeString(v); err == nil && len(decoded) > 0 {
+				s.values[string(decoded)] = struct{}{}
+			}
+		}
+	}
+}
+
+func (s *secretValues) list() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	values := make([]string, 0, len(s.values))
+	for v := range s.values {
+		values = append(values, v)
+	}
+	slices.Sort(values)
+	return values
+}
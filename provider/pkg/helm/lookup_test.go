@@ -0,0 +1,164 @@
+// Copyright 2016-2026, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package helm
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+func TestParseLookupPath(t *testing.T) {
+	tests := []struct {
+		path string
+		want lookupPath
+		ok   bool
+	}{
+		{path: "/api", want: lookupPath{discovery: true}, ok: true},
+		{path: "/api/v1", want: lookupPath{discovery: true}, ok: true},
+		{path: "/apis/apps/v1", want: lookupPath{discovery: true}, ok: true},
+		{
+			path: "/api/v1/namespaces/default/secrets/db",
+			want: lookupPath{
+				resource:  schema.GroupVersionResource{Version: "v1", Resource: "secrets"},
+				namespace: "default",
+				name:      "db",
+			},
+			ok: true,
+		},
+		{
+			path: "/api/v1/namespaces/default/secrets",
+			want: lookupPath{resource: schema.GroupVersionResource{Version: "v1", Resource: "secrets"}, namespace: "default"},
+			ok:   true,
+		},
+		{
+			path: "/api/v1/namespaces/default",
+			want: lookupPath{resource: schema.GroupVersionResource{Version: "v1", Resource: "namespaces"}, name: "default"},
+			ok:   true,
+		},
+		{
+			path: "/apis/apps/v1/deployments",
+			want: lookupPath{resource: schema.GroupVersionResource{Group: "apps", Version: "v1", Resource: "deployments"}},
+			ok:   true,
+		},
+		{path: "/version", ok: false},
+		{path: "/api/v1/namespaces/default/secrets/db/status", ok: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			got, ok := parseLookupPath(tt.path)
+			assert.Equal(t, tt.ok, ok)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestLookupTransport(t *testing.T) {
+	mapper := meta.NewDefaultRESTMapper(nil)
+	mapper.Add(schema.GroupVersionKind{Version: "v1", Kind: "Secret"}, meta.RESTScopeNamespace)
+	mapper.Add(schema.GroupVersionKind{Version: "v1", Kind: "ConfigMap"}, meta.RESTScopeNamespace)
+	mapper.Add(schema.GroupVersionKind{Version: "v1", Kind: "Namespace"}, meta.RESTScopeRoot)
+	mapper.Add(schema.GroupVersionKind{Group: "apps", Version: "v1", Kind: "Deployment"}, meta.RESTScopeNamespace)
+
+	var requests int
+	next := roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		requests++
+		body := `{"kind":"ConfigMap"}`
+		if strings.Contains(req.URL.Path, "/secrets/") {
+			body = `{"kind":"Secret","data":{"password":"aHVudGVyMg=="}}`
+		}
+		return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(body))}, nil
+	})
+
+	newTransport := func(scope *ClusterLookup, cache *LookupCache) *lookupTransport {
+		return &lookupTransport{
+			next:             next,
+			scope:            scope,
+			releaseNamespace: "default",
+			mapper:           mapper,
+			pathPrefix:       "/k8s",
+			cache:            cache,
+			secrets:          &secretValues{},
+		}
+	}
+	get := func(t *testing.T, rt http.RoundTripper, method, path string) int {
+		t.Helper()
+		resp, err := rt.RoundTrip(httptest.NewRequest(method, "https://cluster.example.com/k8s"+path, nil))
+		require.NoError(t, err)
+		_, _ = io.Copy(io.Discard, resp.Body)
+		return resp.StatusCode
+	}
+
+	t.Run("read-only", func(t *testing.T) {
+		rt := newTransport(&ClusterLookup{}, nil)
+		assert.Equal(t, http.StatusMethodNotAllowed, get(t, rt, http.MethodPost, "/api/v1/namespaces/default/configmaps"))
+		assert.Equal(t, http.StatusMethodNotAllowed, get(t, rt, http.MethodDelete, "/api/v1/namespaces/default/configmaps/a"))
+	})
+
+	t.Run("kinds", func(t *testing.T) {
+		rt := newTransport(&ClusterLookup{Kinds: []string{"ConfigMap", "apps/Deployment"}}, nil)
+		assert.Equal(t, http.StatusOK, get(t, rt, http.MethodGet, "/api/v1"))
+		assert.Equal(t, http.StatusOK, get(t, rt, http.MethodGet, "/api/v1/namespaces/default/configmaps/a"))
+		assert.Equal(t, http.StatusOK, get(t, rt, http.MethodGet, "/apis/apps/v1/namespaces/default/deployments/a"))
+		assert.Equal(t, http.StatusForbidden, get(t, rt, http.MethodGet, "/api/v1/namespaces/default/secrets/a"))
+	})
+
+	t.Run("namespaces", func(t *testing.T) {
+		rt := newTransport(&ClusterLookup{}, nil)
+		assert.Equal(t, http.StatusOK, get(t, rt, http.MethodGet, "/api/v1/namespaces/default/configmaps/a"))
+		assert.Equal(t, http.StatusForbidden, get(t, rt, http.MethodGet, "/api/v1/namespaces/other/configmaps/a"))
+		assert.Equal(t, http.StatusForbidden, get(t, rt, http.MethodGet, "/api/v1/configmaps"))
+		assert.Equal(t, http.StatusOK, get(t, rt, http.MethodGet, "/api/v1/namespaces/other"))
+
+		rt = newTransport(&ClusterLookup{Namespaces: []string{"other"}}, nil)
+		assert.Equal(t, http.StatusForbidden, get(t, rt, http.MethodGet, "/api/v1/namespaces/default/configmaps/a"))
+		assert.Equal(t, http.StatusOK, get(t, rt, http.MethodGet, "/api/v1/namespaces/other/configmaps/a"))
+
+		rt = newTransport(&ClusterLookup{Namespaces: []string{AllNamespaces}}, nil)
+		assert.Equal(t, http.StatusOK, get(t, rt, http.MethodGet, "/api/v1/configmaps"))
+	})
+
+	t.Run("cache", func(t *testing.T) {
+		requests = 0
+		cache := NewLookupCache()
+		for range 2 {
+			rt := newTransport(&ClusterLookup{}, cache)
+			assert.Equal(t, http.StatusOK, get(t, rt, http.MethodGet, "/api/v1/namespaces/default/configmaps/a"))
+		}
+		assert.Equal(t, 1, requests)
+	})
+
+	t.Run("secrets", func(t *testing.T) {
+		cache := NewLookupCache()
+		for range 2 {
+			rt := newTransport(&ClusterLookup{}, cache)
+			assert.Equal(t, http.StatusOK, get(t, rt, http.MethodGet, "/api/v1/namespaces/default/secrets/db"))
+			assert.Equal(t, []string{"aHVudGVyMg==", "hunter2"}, rt.secrets.list())
+		}
+	})
+}
+
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
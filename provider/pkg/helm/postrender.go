@@ -0,0 +1,211 @@
+// Copyright 2016-2026, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package helm
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"slices"
+
+	"helm.sh/helm/v3/pkg/postrender"
+	"sigs.k8s.io/kustomize/api/krusty"
+	ktypes "sigs.k8s.io/kustomize/api/types"
+	"sigs.k8s.io/kustomize/kyaml/filesys"
+	"sigs.k8s.io/kustomize/kyaml/kio"
+	"sigs.k8s.io/kustomize/kyaml/resid"
+	kyaml "sigs.k8s.io/kustomize/kyaml/yaml"
+	"sigs.k8s.io/yaml"
+)
+
+// ChainPostRenderer is a post-renderer that runs a sequence of post-renderers, passing the output of
+// each to the next.
+type ChainPostRenderer []postrender.PostRenderer
+
+var _ postrender.PostRenderer = ChainPostRenderer{}
+
+func (c ChainPostRenderer) Run(renderedManifests *bytes.Buffer) (*bytes.Buffer, error) {
+	var err error
+	for _, pr := range c {
+		if renderedManifests, err = pr.Run(renderedManifests); err != nil {
+			return nil, err
+		}
+	}
+	return renderedManifests, nil
+}
+
+// KustomizePatch is a Kustomize patch, either a strategic merge patch or a JSON 6902 patch.
+type KustomizePatch struct {
+	// Patch is the content of the patch, in YAML or JSON.
+	Patch string `mapstructure:"patch"`
+	// Target selects the resources to patch. A strategic merge patch may omit the target,
+	// in which case the patch identifies the resource to patch.
+	Target *KustomizePatchTarget `mapstructure:"target"`
+}
+
+// KustomizePatchTarget selects the resources that a Kustomize patch applies to.
+type KustomizePatchTarget struct {
+	Group              string `mapstructure:"group"`
+	Version            string `mapstructure:"version"`
+	Kind               string `mapstructure:"kind"`
+	Name               string `mapstructure:"name"`
+	Namespace          string `mapstructure:"namespace"`
+	LabelSelector      string `mapstructure:"labelSelector"`
+	AnnotationSelector string `mapstructure:"annotationSelector"`
+}
+
+// KustomizePostRenderer is a post-renderer that applies a set of Kustomize patches to the rendered
+// manifests, in-process.
+type KustomizePostRenderer struct {
+	Patches []KustomizePatch `mapstructure:"patches"`
+}
+
+var _ postrender.PostRenderer = &KustomizePostRenderer{}
+
+func (r *KustomizePostRenderer) Run(renderedManifests *bytes.Buffer) (*bytes.Buffer, error) {
+	out, err := ApplyKustomizePatches(renderedManifests.Bytes(), r.Patches)
+	if err != nil {
+		return nil, fmt.Errorf("kustomize post-renderer: %w", err)
+	}
+	return bytes.NewBuffer(out), nil
+}
+
+// ApplyKustomizePatches applies a set of Kustomize patches to the given manifests, in-process.
+func ApplyKustomizePatches(manifests []byte, patches []KustomizePatch) ([]byte, error) {
+	const dir = "/kustomize"
+	kustomization := ktypes.Kustomization{
+		TypeMeta: ktypes.TypeMeta{
+			APIVersion: ktypes.KustomizationVersion,
+			Kind:       ktypes.KustomizationKind,
+		},
+		Resources: []string{"manifests.yaml"},
+	}
+	for i, p := range patches {
+		if p.Patch == "" {
+			return nil, fmt.Errorf("kustomize patch %d: patch is required", i)
+		}
+		patch := ktypes.Patch{Patch: p.Patch}
+		if t := p.Target; t != nil {
+			patch.Target = &ktypes.Selector{
+				ResId: resid.ResId{
+					Gvk:       resid.Gvk{Group: t.Group, Version: t.Version, Kind: t.Kind},
+					Name:      t.Name,
+					Namespace: t.Namespace,
+				},
+				LabelSelector:      t.LabelSelector,
+				AnnotationSelector: t.AnnotationSelector,
+			}
+		}
+		kustomization.Patches = append(kustomization.Patches, patch)
+	}
+	data, err := yaml.Marshal(kustomization)
+	if err != nil {
+		return nil, err
+	}
+
+	fSys := filesys.MakeFsInMemory()
+	if err := fSys.WriteFile(dir+"/kustomization.yaml", data); err != nil {
+		return nil, err
+	}
+	if err := fSys.WriteFile(dir+"/manifests.yaml", manifests); err != nil {
+		return nil, err
+	}
+
+	opts := krusty.MakeDefaultOptions()
+	opts.Reorder = krusty.ReorderOptionNone
+	opts.AddManagedbyLabel = false
+	rm, err := krusty.MakeKustomizer(opts).Run(fSys, dir)
+	if err != nil {
+		return nil, err
+	}
+	return rm.AsYaml()
+}
+
+// MetadataPostRenderer is a post-renderer that injects labels and annotations into the metadata of the
+// rendered objects. Pod templates and selectors are not affected.
+type MetadataPostRenderer struct {
+	// Labels to inject.
+	Labels map[string]string `mapstructure:"labels"`
+	// Annotations to inject.
+	Annotations map[string]string `mapstructure:"annotations"`
+	// Kinds restricts the injection to objects of the given kinds. All objects are affected if empty.
+	Kinds []string `mapstructure:"kinds"`
+	// Overwrite replaces existing labels and annotations with the same key. By default, the values
+	// set by the chart take precedence.
+	Overwrite bool `mapstructure:"overwrite"`
+}
+
+var _ postrender.PostRenderer = &MetadataPostRenderer{}
+
+func (r *MetadataPostRenderer) Run(renderedManifests *bytes.Buffer) (*bytes.Buffer, error) {
+	nodes, err := (&kio.ByteReader{
+		Reader:                renderedManifests,
+		OmitReaderAnnotations: true,
+	}).Read()
+	if err != nil {
+		return nil, fmt.Errorf("metadata post-renderer: %w", err)
+	}
+
+	for _, node := range nodes {
+		if len(r.Kinds) > 0 && !slices.Contains(r.Kinds, node.GetKind()) {
+			continue
+		}
+		labels := node.GetLabels()
+		for k, v := range r.Labels {
+			if _, ok := labels[k]; ok && !r.Overwrite {
+				continue
+			}
+			if err := node.PipeE(kyaml.SetLabel(k, v)); err != nil {
+				return nil, err
+			}
+		}
+		annotations := node.GetAnnotations()
+		for k, v := range r.Annotations {
+			if _, ok := annotations[k]; ok && !r.Overwrite {
+				continue
+			}
+			if err := node.PipeE(kyaml.SetAnnotation(k, v)); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	var out bytes.Buffer
+	if err := (kio.ByteWriter{Writer: &out}).Write(nodes); err != nil {
+		return nil, fmt.Errorf("metadata post-renderer: %w", err)
+	}
+	return &out, nil
+}
+
+// BuiltinPostRenderer is the specification of a post-renderer that runs in-process. Exactly one of
+// the post-renderers must be specified.
+type BuiltinPostRenderer struct {
+	Kustomize *KustomizePostRenderer `mapstructure:"kustomize"`
+	Metadata  *MetadataPostRenderer  `mapstructure:"metadata"`
+}
+
+// PostRenderer returns the post-renderer for the specification.
+func (s BuiltinPostRenderer) PostRenderer() (postrender.PostRenderer, error) {
+	switch {
+	case s.Kustomize != nil && s.Metadata != nil:
+		return nil, errors.New("only one of kustomize or metadata may be specified")
+	case s.Kustomize != nil:
+		return s.Kustomize, nil
+	case s.Metadata != nil:
+		return s.Metadata, nil
+	default:
+		return nil, errors.New("one of kustomize or metadata must be specified")
+	}
+}
@@ -0,0 +1,162 @@
+// Copyright 2016-2026, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package helm
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const renderedManifests = `---
+# Source: app/templates/deployment.yaml
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: app
+  labels:
+    team: platform
+spec:
+  replicas: 1
+  template:
+    spec:
+      containers:
+      - name: app
+        image: nginx:1.25
+---
+# Source: app/templates/service.yaml
+apiVersion: v1
+kind: Service
+metadata:
+  name: app
+spec:
+  ports:
+  - port: 80
+`
+
+func TestKustomizePostRenderer(t *testing.T) {
+	tests := []struct {
+		name    string
+		patches []KustomizePatch
+		want    []string
+		wantErr string
+	}{
+		{
+			name: "strategic merge patch",
+			patches: []KustomizePatch{{
+				Patch: `
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: app
+spec:
+  replicas: 3
+`,
+			}},
+			want: []string{"replicas: 3", "kind: Service"},
+		},
+		{
+			name: "JSON 6902 patch",
+			patches: []KustomizePatch{{
+				Patch:  `[{"op": "replace", "path": "/spec/template/spec/containers/0/image", "value": "nginx:1.26"}]`,
+				Target: &KustomizePatchTarget{Kind: "Deployment", Name: "app"},
+			}},
+			want: []string{"image: nginx:1.26", "replicas: 1"},
+		},
+		{
+			name:    "missing patch",
+			patches: []KustomizePatch{{}},
+			wantErr: "kustomize patch 0: patch is required",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pr := &KustomizePostRenderer{Patches: tt.patches}
+			out, err := pr.Run(bytes.NewBufferString(renderedManifests))
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			for _, s := range tt.want {
+				assert.Contains(t, out.String(), s)
+			}
+		})
+	}
+}
+
+func TestMetadataPostRenderer(t *testing.T) {
+	pr := ChainPostRenderer{
+		&MetadataPostRenderer{
+			Labels:      map[string]string{"team": "apps", "env": "prod"},
+			Annotations: map[string]string{"example.com/owner": "apps"},
+		},
+		&MetadataPostRenderer{
+			Labels:    map[string]string{"exposed": "true"},
+			Kinds:     []string{"Service"},
+			Overwrite: true,
+		},
+	}
+	out, err := pr.Run(bytes.NewBufferString(renderedManifests))
+	require.NoError(t, err)
+
+	nodes, err := (&KustomizePostRenderer{}).Run(out)
+	require.NoError(t, err)
+	assert.Equal(t, `apiVersion: apps/v1
+kind: Deployment
+metadata:
+  annotations:
+    example.com/owner: apps
+  labels:
+    env: prod
+    team: platform
+  name: app
+spec:
+  replicas: 1
+  template:
+    spec:
+      containers:
+      - image: nginx:1.25
+        name: app
+---
+apiVersion: v1
+kind: Service
+metadata:
+  annotations:
+    example.com/owner: apps
+  labels:
+    env: prod
+    exposed: "true"
+    team: apps
+  name: app
+spec:
+  ports:
+  - port: 80
+`, nodes.String())
+}
+
+func TestBuiltinPostRenderer(t *testing.T) {
+	_, err := BuiltinPostRenderer{}.PostRenderer()
+	assert.ErrorContains(t, err, "one of kustomize or metadata must be specified")
+
+	_, err = BuiltinPostRenderer{Kustomize: &KustomizePostRenderer{}, Metadata: &MetadataPostRenderer{}}.PostRenderer()
+	assert.ErrorContains(t, err, "only one of kustomize or metadata may be specified")
+
+	pr, err := BuiltinPostRenderer{Metadata: &MetadataPostRenderer{}}.PostRenderer()
+	require.NoError(t, err)
+	assert.IsType(t, &MetadataPostRenderer{}, pr)
+}
@@ -0,0 +1,303 @@
+// Copyright 2016-2026, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package helm
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/sync/singleflight"
+	"helm.sh/helm/v3/pkg/action"
+	"helm.sh/helm/v3/pkg/cli"
+	"helm.sh/helm/v3/pkg/getter"
+	"helm.sh/helm/v3/pkg/helmpath"
+	"helm.sh/helm/v3/pkg/registry"
+	"helm.sh/helm/v3/pkg/repo"
+
+	logger "github.com/pulumi/pulumi/sdk/v3/go/common/util/logging"
+)
+
+// DefaultIndexTTL is the default time for which a downloaded repository index is used before it's
+// downloaded again.
+const DefaultIndexTTL = 5 * time.Minute
+
+// IndexCache caches the indexes of chart repositories, so that the charts of a program can be located
+// without downloading (and parsing) the same index over and over. The parsed indexes are kept in memory,
+// and the downloaded indexes are kept in the Helm repository cache directory for use by other processes.
+// Concurrent requests for the same repository share a single download, and the on-disk cache is guarded
+// by a file lock. A nil cache doesn't cache anything.
+type IndexCache struct {
+	dir string
+	ttl time.Duration
+	now func() time.Time
+
+	mu      sync.Mutex
+	indexes map[string]cachedIndex
+	files   map[string]cachedIndex
+	group   singleflight.Group
+}
+
+type cachedIndex struct {
+	index   *repo.IndexFile
+	modTime time.Time
+	size    int64
+}
+
+// NewIndexCache returns a cache that keeps the downloaded indexes in the given directory, for the given
+// time-to-live. A zero TTL downloads an index whenever it is needed.
+func NewIndexCache(dir string, ttl time.Duration) *IndexCache {
+	return &IndexCache{
+		dir:     dir,
+		ttl:     ttl,
+		now:     time.Now,
+		indexes: map[string]cachedIndex{},
+		files:   map[string]cachedIndex{},
+	}
+}
+
+// Index returns the index of the given repository, downloading it if the cached copy is expired.
+func (c *IndexCache) Index(entry repo.Entry, getters getter.Providers) (*repo.IndexFile, error) {
+	index, _, err := c.index(entry, getters, false)
+	return index, err
+}
+
+// index returns the index of the given repository, and whether it was just downloaded rather than read from
+// the cache. With force, the index is downloaded even if the cached copy hasn't expired.
+func (c *IndexCache) index(entry repo.Entry, getters getter.Providers, force bool) (*repo.IndexFile, bool, error) {
+	key := indexCacheKey(entry)
+	if !force {
+		if index, ok := c.fresh(key); ok {
+			return index, false, nil
+		}
+	}
+
+	group := key
+	if force {
+		group = "force:" + key
+	}
+	v, err, shared := c.group.Do(group, func() (any, error) {
+		if !force {
+			if index, ok := c.fresh(key); ok {
+				return refreshedIndex{index: index}, nil
+			}
+		}
+		return c.refresh(key, entry, getters, force)
+	})
+	if err != nil {
+		return nil, false, err
+	}
+	logger.V(9).Infof("Refreshed the index of repository %q (shared: %v)", entry.URL, shared)
+	r := v.(refreshedIndex)
+	return r.index, r.downloaded, nil
+}
+
+// refreshedIndex is the result of a refresh: the index, and whether it was downloaded.
+type refreshedIndex struct {
+	index      *repo.IndexFile
+	downloaded bool
+}
+
+// fresh returns the in-memory index for the key, if it hasn't expired.
+func (c *IndexCache) fresh(key string) (*repo.IndexFile, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	cached, ok := c.indexes[key]
+	if !ok || c.now().Sub(cached.modTime) >= c.ttl {
+		return nil, false
+	}
+	return cached.index, true
+}
+
+// refresh loads the index from the on-disk cache, or downloads it if the on-disk copy is expired too, or if
+// forced to.
+func (c *IndexCache) refresh(
+	key string, entry repo.Entry, getters getter.Providers, force bool,
+) (refreshedIndex, error) {
+	if err := os.MkdirAll(c.dir, 0o755); err != nil {
+		return refreshedIndex{}, err
+	}
+	unlock, err := lockFile(filepath.Join(c.dir, key+".lock"))
+	if err != nil {
+		return refreshedIndex{}, fmt.Errorf("locking the repository cache: %w", err)
+	}
+	defer func() {
+		if err := unlock(); err != nil {
+			logger.V(3).Infof("Unable to unlock the repository cache: %v", err)
+		}
+	}()
+
+	// Another process may have downloaded the index while we waited for the lock.
+	path := filepath.Join(c.dir, helmpath.CacheIndexFile(key))
+	if fi, err := os.Stat(path); err == nil && !force && c.now().Sub(fi.ModTime()) < c.ttl {
+		index, err := repo.LoadIndexFile(path)
+		if err == nil {
+			c.store(key, index, fi.ModTime())
+			return refreshedIndex{index: index}, nil
+		}
+		logger.V(9).Infof("Ignoring the cached index of repository %q: %v", entry.URL, err)
+	}
+
+	entry.Name = key
+	r, err := repo.NewChartRepository(&entry, getters)
+	if err != nil {
+		return refreshedIndex{}, err
+	}
+	r.CachePath = c.dir
+	if _, err := r.DownloadIndexFile(); err != nil {
+		return refreshedIndex{}, fmt.Errorf("looks like %q is not a valid chart repository or cannot be reached: %w",
+			entry.URL, err)
+	}
+	index, err := repo.LoadIndexFile(path)
+	if err != nil {
+		return refreshedIndex{}, err
+	}
+	c.store(key, index, c.now())
+	return refreshedIndex{index: index, downloaded: true}, nil
+}
+
+func (c *IndexCache) store(key string, index *repo.IndexFile, modTime time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.indexes[key] = cachedIndex{index: index, modTime: modTime}
+}
+
+// LoadIndexFile loads an index file, e.g. of a repository in the Helm repository cache. The parsed index
+// is reused for as long as the file is unchanged.
+func (c *IndexCache) LoadIndexFile(path string) (*repo.IndexFile, error) {
+	if c == nil {
+		return repo.LoadIndexFile(path)
+	}
+	fi, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+	c.mu.Lock()
+	cached, ok := c.files[path]
+	c.mu.Unlock()
+	if ok && cached.modTime.Equal(fi.ModTime()) && cached.size == fi.Size() {
+		return cached.index, nil
+	}
+
+	index, err := repo.LoadIndexFile(path)
+	if err != nil {
+		return nil, err
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.files[path] = cachedIndex{index: index, modTime: fi.ModTime(), size: fi.Size()}
+	return index, nil
+}
+
+// FindChart returns the URL of the given chart version in the given repository. It is equivalent to
+// repo.FindChartInAuthAndTLSAndPassRepoURL, using the cached index of the repository.
+func (c *IndexCache) FindChart(entry repo.Entry, name, version string, getters getter.Providers) (string, error) {
+	if c == nil {
+		return repo.FindChartInAuthAndTLSAndPassRepoURL(entry.URL, entry.Username, entry.Password, name, version,
+			entry.CertFile, entry.KeyFile, entry.CAFile, entry.InsecureSkipTLSverify, entry.PassCredentialsAll, getters)
+	}
+	index, downloaded, err := c.index(entry, getters, false)
+	if err != nil {
+		return "", err
+	}
+
+	errMsg := fmt.Sprintf("chart %q", name)
+	if version != "" {
+		errMsg = fmt.Sprintf("%s version %q", errMsg, version)
+	}
+	cv, err := index.Get(name, version)
+	if err != nil && !downloaded {
+		// The chart or version may have been published since the index was cached; look again in a new index.
+		if index, _, err = c.index(entry, getters, true); err != nil {
+			return "", err
+		}
+		cv, err = index.Get(name, version)
+	}
+	if err != nil {
+		return "", fmt.Errorf("%s not found in %s repository", errMsg, entry.URL)
+	}
+	if len(cv.URLs) == 0 {
+		return "", fmt.Errorf("%s has no downloadable URLs", errMsg)
+	}
+	chartURL, err := repo.ResolveReferenceURL(entry.URL, cv.URLs[0])
+	if err != nil {
+		return "", fmt.Errorf("failed to make chart URL absolute: %w", err)
+	}
+	return chartURL, nil
+}
+
+// LocateChart locates the chart like action.ChartPathOptions.LocateChart does, except that a chart in a
+// repository given by URL is looked up in the cached index of the repository.
+func (c *IndexCache) LocateChart(cpo action.ChartPathOptions, name string, settings *cli.EnvSettings) (string, error) {
+	name = strings.TrimSpace(name)
+	if cpo.RepoURL == "" || registry.IsOCI(name) {
+		return cpo.LocateChart(name, settings)
+	}
+
+	chartURL, err := c.FindChart(RepositoryEntry(cpo), name, strings.TrimSpace(cpo.Version), getter.All(settings))
+	if err != nil {
+		return "", err
+	}
+	// Only pass the credentials on when the user has said to or when the location of the chart repo
+	// and the chart are the same domain.
+	if !cpo.PassCredentialsAll && !sameHost(cpo.RepoURL, chartURL) {
+		cpo.Username, cpo.Password = "", ""
+	}
+	cpo.RepoURL = ""
+	return cpo.LocateChart(chartURL, settings)
+}
+
+// RepositoryEntry returns the repository entry for the repository given by URL in the options.
+func RepositoryEntry(cpo action.ChartPathOptions) repo.Entry {
+	return repo.Entry{
+		URL:                   cpo.RepoURL,
+		Username:              cpo.Username,
+		Password:              cpo.Password,
+		PassCredentialsAll:    cpo.PassCredentialsAll,
+		CertFile:              cpo.CertFile,
+		KeyFile:               cpo.KeyFile,
+		CAFile:                cpo.CaFile,
+		InsecureSkipTLSverify: cpo.InsecureSkipTLSverify,
+	}
+}
+
+func sameHost(a, b string) bool {
+	u1, err := url.Parse(a)
+	if err != nil {
+		return false
+	}
+	u2, err := url.Parse(b)
+	if err != nil {
+		return false
+	}
+	// Host contains the port if present, so that credentials are not passed between different
+	// services on different ports.
+	return u1.Scheme == u2.Scheme && u1.Host == u2.Host
+}
+
+// indexCacheKey returns the key of the repository in the cache. The key is also used to name the files
+// in the on-disk cache. The credentials are part of the key, since a repository may serve a different
+// index to different users.
+func indexCacheKey(entry repo.Entry) string {
+	h := sha256.New()
+	fmt.Fprintf(h, "%s\x00%s\x00%s", strings.TrimSuffix(entry.URL, "/"), entry.Username, entry.Password)
+	return "pulumi-" + hex.EncodeToString(h.Sum(nil))[:16]
+}
@@ -0,0 +1,195 @@
+// Copyright 2016-2026, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package helm
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"helm.sh/helm/v3/pkg/cli"
+	"helm.sh/helm/v3/pkg/getter"
+	"helm.sh/helm/v3/pkg/repo"
+)
+
+const repositoryIndex = `apiVersion: v1
+entries:
+  nginx:
+  - name: nginx
+    version: 1.1.0
+    urls:
+    - charts/nginx-1.1.0.tgz
+  - name: nginx
+    version: 1.0.0
+    urls:
+    - https://charts.example.com/nginx-1.0.0.tgz
+`
+
+// newRepositoryServer serves a chart repository index, counting the downloads.
+func newRepositoryServer(t *testing.T) (*httptest.Server, *atomic.Int32) {
+	t.Helper()
+	var downloads atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/index.yaml" {
+			http.NotFound(w, r)
+			return
+		}
+		downloads.Add(1)
+		// Simulate a large index, so that concurrent requests overlap.
+		time.Sleep(50 * time.Millisecond)
+		_, _ = w.Write([]byte(repositoryIndex))
+	}))
+	t.Cleanup(srv.Close)
+	return srv, &downloads
+}
+
+func TestIndexCache(t *testing.T) {
+	srv, downloads := newRepositoryServer(t)
+	getters := getter.All(cli.New())
+	entry := repo.Entry{URL: srv.URL}
+
+	t.Run("concurrent requests share a download", func(t *testing.T) {
+		downloads.Store(0)
+		c := NewIndexCache(t.TempDir(), time.Minute)
+		var wg sync.WaitGroup
+		for range 10 {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				index, err := c.Index(entry, getters)
+				assert.NoError(t, err)
+				assert.True(t, index.Has("nginx", "1.1.0"))
+			}()
+		}
+		wg.Wait()
+		assert.Equal(t, int32(1), downloads.Load())
+	})
+
+	t.Run("expired indexes are downloaded again", func(t *testing.T) {
+		downloads.Store(0)
+		now := time.Now()
+		c := NewIndexCache(t.TempDir(), time.Minute)
+		c.now = func() time.Time { return now }
+
+		_, err := c.Index(entry, getters)
+		require.NoError(t, err)
+		_, err = c.Index(entry, getters)
+		require.NoError(t, err)
+		assert.Equal(t, int32(1), downloads.Load())
+
+		now = now.Add(2 * time.Minute)
+		_, err = c.Index(entry, getters)
+		require.NoError(t, err)
+		assert.Equal(t, int32(2), downloads.Load())
+	})
+
+	t.Run("the on-disk cache is shared", func(t *testing.T) {
+		downloads.Store(0)
+		dir := t.TempDir()
+		_, err := NewIndexCache(dir, time.Minute).Index(entry, getters)
+		require.NoError(t, err)
+		_, err = NewIndexCache(dir, time.Minute).Index(entry, getters)
+		require.NoError(t, err)
+		assert.Equal(t, int32(1), downloads.Load())
+	})
+
+	t.Run("a zero TTL disables caching", func(t *testing.T) {
+		downloads.Store(0)
+		c := NewIndexCache(t.TempDir(), 0)
+		for range 2 {
+			_, err := c.Index(entry, getters)
+			require.NoError(t, err)
+		}
+		assert.Equal(t, int32(2), downloads.Load())
+	})
+
+	t.Run("find chart", func(t *testing.T) {
+		c := NewIndexCache(t.TempDir(), time.Minute)
+		chartURL, err := c.FindChart(entry, "nginx", "", getters)
+		require.NoError(t, err)
+		assert.Equal(t, srv.URL+"/charts/nginx-1.1.0.tgz", chartURL)
+
+		chartURL, err = c.FindChart(entry, "nginx", "1.0.0", getters)
+		require.NoError(t, err)
+		assert.Equal(t, "https://charts.example.com/nginx-1.0.0.tgz", chartURL)
+
+		_, err = c.FindChart(entry, "redis", "", getters)
+		assert.ErrorContains(t, err, `chart "redis" not found`)
+	})
+}
+
+func TestSameHost(t *testing.T) {
+	assert.True(t, sameHost("https://charts.example.com", "https://charts.example.com/nginx-1.0.0.tgz"))
+	assert.False(t, sameHost("https://charts.example.com", "https://cdn.example.com/nginx-1.0.0.tgz"))
+	assert.False(t, sameHost("https://charts.example.com:8443", "https://charts.example.com/nginx-1.0.0.tgz"))
+	assert.False(t, sameHost("http://charts.example.com", "https://charts.example.com/nginx-1.0.0.tgz"))
+}
+
+func TestIndexCacheFindChartRefreshes(t *testing.T) {
+	var mu sync.Mutex
+	index := repositoryIndex
+	var downloads atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/index.yaml" {
+			http.NotFound(w, r)
+			return
+		}
+		downloads.Add(1)
+		mu.Lock()
+		defer mu.Unlock()
+		_, _ = w.Write([]byte(index))
+	}))
+	t.Cleanup(srv.Close)
+	getters := getter.All(cli.New())
+	entry := repo.Entry{URL: srv.URL}
+
+	c := NewIndexCache(t.TempDir(), time.Hour)
+	_, err := c.FindChart(entry, "nginx", "1.1.0", getters)
+	require.NoError(t, err)
+	assert.Equal(t, int32(1), downloads.Load())
+
+	// A version published since the index was cached is found by refreshing the index.
+	mu.Lock()
+	index += `  - name: nginx
+    version: 1.2.0
+    urls:
+    - charts/nginx-1.2.0.tgz
+`
+	mu.Unlock()
+	chartURL, err := c.FindChart(entry, "nginx", "1.2.0", getters)
+	require.NoError(t, err)
+	assert.Equal(t, srv.URL+"/charts/nginx-1.2.0.tgz", chartURL)
+	assert.Equal(t, int32(2), downloads.Load())
+
+	// The refreshed index is cached.
+	_, err = c.FindChart(entry, "nginx", "1.2.0", getters)
+	require.NoError(t, err)
+	assert.Equal(t, int32(2), downloads.Load())
+
+	// A missing chart is looked up once more before failing.
+	_, err = c.FindChart(entry, "redis", "", getters)
+	assert.ErrorContains(t, err, `chart "redis" not found`)
+	assert.Equal(t, int32(3), downloads.Load())
+
+	// A just-downloaded index isn't downloaded again.
+	_, err = NewIndexCache(t.TempDir(), time.Hour).FindChart(entry, "redis", "", getters)
+	assert.ErrorContains(t, err, `chart "redis" not found`)
+	assert.Equal(t, int32(4), downloads.Load())
+}
@@ -0,0 +1,248 @@
+// Copyright 2016-2026, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package helm
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/santhosh-tekuri/jsonschema/v6"
+	"github.com/santhosh-tekuri/jsonschema/v6/kind"
+	"golang.org/x/text/language"
+	"golang.org/x/text/message"
+	"helm.sh/helm/v3/pkg/chart"
+	"helm.sh/helm/v3/pkg/chartutil"
+)
+
+// SchemaViolation describes a chart value that does not conform to the chart's values schema
+// (values.schema.json).
+type SchemaViolation struct {
+	// Chart is the name of the chart (or subchart) whose schema was violated.
+	Chart string
+	// Path is the property path of the offending value, relative to the top-level values
+	// (e.g. "image.tag" or "tolerations[0]"). An empty path refers to the values themselves.
+	Path string
+	// Keyword is the keyword of the schema that the value violates (e.g. "pattern" or "type").
+	Keyword string
+	// Message describes the violation. It may include the offending value.
+	Message string
+}
+
+// Redact returns the violation with a message that names the violated keyword but not the offending value,
+// for values that may be secret.
+func (v SchemaViolation) Redact() SchemaViolation {
+	v.Message = fmt.Sprintf("value doesn't satisfy the %q keyword of the schema", v.Keyword)
+	return v
+}
+
+// PropertyPath returns the property path of the offending value, relative to the given root property.
+func (v SchemaViolation) PropertyPath(root string) string {
+	switch {
+	case v.Path == "":
+		return root
+	case strings.HasPrefix(v.Path, "["):
+		return root + v.Path
+	default:
+		return root + "." + v.Path
+	}
+}
+
+// ValuesSchemaError is returned when chart values do not conform to the chart's values schema.
+type ValuesSchemaError struct {
+	Violations []SchemaViolation
+}
+
+func (e *ValuesSchemaError) Error() string {
+	var sb strings.Builder
+	sb.WriteString("values don't meet the specifications of the schema(s) in the following chart(s):")
+	for _, v := range e.Violations {
+		fmt.Fprintf(&sb, "\n%s: %s: %s", v.Chart, v.PropertyPath("values"), v.Message)
+	}
+	return sb.String()
+}
+
+// ValidateValues validates the given values, coalesced with the chart's default values, against the JSON
+// schema of the chart and of its subcharts. It returns a violation for each value that does not conform.
+func ValidateValues(c *chart.Chart, values map[string]any) ([]SchemaViolation, error) {
+	coalesced, err := chartutil.CoalesceValues(c, values)
+	if err != nil {
+		return nil, err
+	}
+	return validateValues(c, coalesced.AsMap(), nil)
+}
+
+func validateValues(c *chart.Chart, values map[string]any, prefix []string) ([]SchemaViolation, error) {
+	var violations []SchemaViolation
+	if c.Schema != nil {
+		errs, err := validateAgainstSchema(c.Schema, values)
+		if err != nil {
+			return nil, fmt.Errorf("validating values of chart %q: %w", c.Name(), err)
+		}
+		for _, e := range errs {
+			violations = append(violations, SchemaViolation{
+				Chart:   c.Name(),
+				Path:    propertyPath(prefix, values, e.location),
+				Keyword: e.keyword,
+				Message: e.message,
+			})
+		}
+	}
+
+	for _, sub := range c.Dependencies() {
+		raw, ok := values[sub.Name()]
+		if !ok || raw == nil {
+			continue
+		}
+		subPrefix := append(append([]string{}, prefix...), sub.Name())
+		subValues, ok := raw.(map[string]any)
+		if !ok {
+			violations = append(violations, SchemaViolation{
+				Chart:   sub.Name(),
+				Path:    propertyPath(subPrefix, nil, nil),
+				Keyword: "type",
+				Message: fmt.Sprintf("invalid type for values: expected object (map), got %T", raw),
+			})
+			continue
+		}
+		vs, err := validateValues(sub, subValues, subPrefix)
+		if err != nil {
+			return nil, err
+		}
+		violations = append(violations, vs...)
+	}
+	return violations, nil
+}
+
+var printer = message.NewPrinter(language.English)
+
+// schemaError is a leaf error reported by the schema validator.
+type schemaError struct {
+	// location is the location of the offending value, as a list of keys and indexes.
+	location []string
+	keyword  string
+	message  string
+}
+
+// validateAgainstSchema validates the values against the given schema, in the same manner as
+// chartutil.ValidateAgainstSingleSchema.
+func validateAgainstSchema(schemaJSON []byte, values map[string]any) ([]schemaError, error) {
+	schema, err := jsonschema.UnmarshalJSON(bytes.NewReader(schemaJSON))
+	if err != nil {
+		return nil, err
+	}
+
+	httpLoader := chartutil.HTTPURLLoader(http.Client{Timeout: 15 * time.Second})
+	compiler := jsonschema.NewCompiler()
+	compiler.UseLoader(jsonschema.SchemeURLLoader{
+		"file":  jsonschema.FileLoader{},
+		"http":  &httpLoader,
+		"https": &httpLoader,
+	})
+	if err := compiler.AddResource("file:///values.schema.json", schema); err != nil {
+		return nil, err
+	}
+	validator, err := compiler.Compile("file:///values.schema.json")
+	if err != nil {
+		return nil, err
+	}
+
+	// Normalize the values to JSON types, as the values parser does.
+	data, err := json.Marshal(values)
+	if err != nil {
+		return nil, err
+	}
+	instance, err := jsonschema.UnmarshalJSON(bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+
+	err = validator.Validate(instance)
+	var verr *jsonschema.ValidationError
+	switch {
+	case err == nil:
+		return nil, nil
+	case errors.As(err, &verr):
+		return collectErrors(verr, nil), nil
+	default:
+		return nil, err
+	}
+}
+
+// collectErrors flattens the validation error into an error per offending value.
+// Alternatives (anyOf, oneOf) are reported as a whole rather than per branch.
+func collectErrors(e *jsonschema.ValidationError, errs []schemaError) []schemaError {
+	switch e.ErrorKind.(type) {
+	case *kind.AnyOf, *kind.OneOf:
+	default:
+		if len(e.Causes) > 0 {
+			for _, cause := range e.Causes {
+				errs = collectErrors(cause, errs)
+			}
+			return errs
+		}
+	}
+	return append(errs, schemaError{
+		location: e.InstanceLocation,
+		keyword:  strings.Join(e.ErrorKind.KeywordPath(), "/"),
+		message:  e.ErrorKind.LocalizedString(printer),
+	})
+}
+
+var identifierRegexp = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// propertyPath formats a location as a property path, e.g. `image.tag`, `tolerations[0]` or
+// `podAnnotations["example.com/key"]`. The prefix consists of map keys, and the location is resolved
+// against the given values to tell array indexes apart from map keys.
+func propertyPath(prefix []string, values any, location []string) string {
+	var sb strings.Builder
+	key := func(k string) {
+		if identifierRegexp.MatchString(k) {
+			if sb.Len() > 0 {
+				sb.WriteByte('.')
+			}
+			sb.WriteString(k)
+		} else {
+			fmt.Fprintf(&sb, "[%s]", strconv.Quote(k))
+		}
+	}
+	for _, k := range prefix {
+		key(k)
+	}
+	for _, el := range location {
+		switch v := values.(type) {
+		case []any:
+			fmt.Fprintf(&sb, "[%s]", el)
+			if i, err := strconv.Atoi(el); err == nil && i >= 0 && i < len(v) {
+				values = v[i]
+			} else {
+				values = nil
+			}
+		case map[string]any:
+			key(el)
+			values = v[el]
+		default:
+			key(el)
+			values = nil
+		}
+	}
+	return sb.String()
+}
@@ -0,0 +1,148 @@
+// Copyright 2016-2026, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package helm
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"helm.sh/helm/v3/pkg/chart"
+)
+
+func TestValidateValues(t *testing.T) {
+	newChart := func() *chart.Chart {
+		c := &chart.Chart{
+			Metadata: &chart.Metadata{APIVersion: chart.APIVersionV2, Name: "parent", Version: "1.0.0"},
+			Values: map[string]any{
+				"image": map[string]any{"repository": "nginx", "tag": "1.25"},
+			},
+			Schema: []byte(`{
+				"type": "object",
+				"properties": {
+					"image": {
+						"type": "object",
+						"properties": {
+							"repository": {"type": "string"},
+							"tag": {"type": "string"}
+						}
+					},
+					"replicas": {"type": "integer", "minimum": 1},
+					"tolerations": {"type": "array", "items": {"type": "object"}},
+					"podAnnotations": {"type": "object", "additionalProperties": {"type": "string"}},
+					"password": {"type": "string", "pattern": "^[a-z]+$"}
+				}
+			}`),
+		}
+		c.AddDependency(&chart.Chart{
+			Metadata: &chart.Metadata{APIVersion: chart.APIVersionV2, Name: "child", Version: "1.0.0"},
+			Values:   map[string]any{"enabled": true},
+			Schema: []byte(`{
+				"type": "object",
+				"properties": {"enabled": {"type": "boolean"}}
+			}`),
+		})
+		return c
+	}
+
+	tests := []struct {
+		name   string
+		values map[string]any
+		want   []SchemaViolation
+	}{
+		{
+			name:   "defaults",
+			values: map[string]any{},
+		},
+		{
+			name: "valid values",
+			values: map[string]any{
+				"replicas":    3,
+				"tolerations": []any{map[string]any{"key": "a"}},
+			},
+		},
+		{
+			name: "nested value",
+			values: map[string]any{
+				"image": map[string]any{"tag": 1.26},
+			},
+			want: []SchemaViolation{
+				{Chart: "parent", Path: "image.tag", Keyword: "type", Message: "got number, want string"},
+			},
+		},
+		{
+			name: "multiple violations",
+			values: map[string]any{
+				"replicas":    0,
+				"tolerations": []any{"a"},
+			},
+			want: []SchemaViolation{
+				{Chart: "parent", Path: "replicas", Keyword: "minimum", Message: "minimum: got 0, want 1"},
+				{Chart: "parent", Path: "tolerations[0]", Keyword: "type", Message: "got string, want object"},
+			},
+		},
+		{
+			name: "quoted key",
+			values: map[string]any{
+				"podAnnotations": map[string]any{"example.com/key": true},
+			},
+			want: []SchemaViolation{
+				{Chart: "parent", Path: `podAnnotations["example.com/key"]`, Keyword: "type",
+					Message: "got boolean, want string"},
+			},
+		},
+		{
+			name: "pattern",
+			values: map[string]any{
+				"password": "hunter2",
+			},
+			want: []SchemaViolation{
+				{Chart: "parent", Path: "password", Keyword: "pattern", Message: "'hunter2' does not match pattern '^[a-z]+$'"},
+			},
+		},
+		{
+			name: "subchart value",
+			values: map[string]any{
+				"child": map[string]any{"enabled": "yes"},
+			},
+			want: []SchemaViolation{
+				{Chart: "child", Path: "child.enabled", Keyword: "type", Message: "got string, want boolean"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			violations, err := ValidateValues(newChart(), tt.values)
+			require.NoError(t, err)
+			assert.ElementsMatch(t, tt.want, violations)
+		})
+	}
+}
+
+func TestSchemaViolationRedact(t *testing.T) {
+	v := SchemaViolation{
+		Chart: "parent", Path: "password", Keyword: "pattern", Message: "'hunter2' does not match pattern '^[a-z]+$'",
+	}
+	assert.Equal(t, SchemaViolation{
+		Chart: "parent", Path: "password", Keyword: "pattern",
+		Message: `value doesn't satisfy the "pattern" keyword of the schema`,
+	}, v.Redact())
+}
+
+func TestSchemaViolationPropertyPath(t *testing.T) {
+	assert.Equal(t, "values", SchemaViolation{}.PropertyPath("values"))
+	assert.Equal(t, "values.image.tag", SchemaViolation{Path: "image.tag"}.PropertyPath("values"))
+	assert.Equal(t, `values["example.com/key"]`, SchemaViolation{Path: `["example.com/key"]`}.PropertyPath("values"))
+}
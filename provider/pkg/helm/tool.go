@@ -58,6 +58,10 @@ type ExecuteF func(
 type Tool struct {
 	EnvSettings *cli.EnvSettings
 	HelmDriver  string
+	// IndexCache, if set, is used to look up the charts of repositories given by URL.
+	IndexCache *IndexCache
+	// LookupCache, if set, caches the cluster lookups of the charts that are given a ClusterLookup.
+	LookupCache *LookupCache
 
 	initActionConfig InitActionConfigF
 	locateChart      LocateChartF
@@ -69,7 +73,7 @@ func NewTool(settings *cli.EnvSettings) *Tool {
 	helmDriver := os.Getenv("HELM_DRIVER")
 	logger.V(6).Infof("initializing Helm tool: driver=%q, settings=%+v", helmDriver, *settings)
 
-	return &Tool{
+	t := &Tool{
 		EnvSettings: settings,
 		HelmDriver:  helmDriver,
 		initActionConfig: func(actionConfig *action.Configuration, namespaceOverride string) error {
@@ -79,15 +83,19 @@ func NewTool(settings *cli.EnvSettings) *Tool {
 			// https://github.com/helm/helm/blob/635b8cf33d25a86131635c32f35b2a76256e40cb/cmd/helm/helm.go#L72-L81
 			return actionConfig.Init(settings.RESTClientGetter(), namespaceOverride, helmDriver, debug)
 		},
-		locateChart: func(i *action.Install, name string, settings *cli.EnvSettings) (string, error) {
-			return i.LocateChart(name, settings)
-		},
 		execute: func(
 			ctx context.Context, i *action.Install, chrt *chart.Chart, vals map[string]interface{},
 		) (*release.Release, error) {
 			return i.RunWithContext(ctx, chrt, vals)
 		},
 	}
+	t.locateChart = func(i *action.Install, name string, settings *cli.EnvSettings) (string, error) {
+		if t.IndexCache != nil {
+			return t.IndexCache.LocateChart(i.ChartPathOptions, name, settings)
+		}
+		return i.LocateChart(name, settings)
+	}
+	return t
 }
 
 func (t *Tool) AllGetters() getter.Providers {
@@ -108,6 +116,12 @@ type TemplateOrInstallCommand struct {
 	// Values to be applied to the chart.
 	Values ValueOpts
 
+	// DependencyMode controls how the chart's dependencies are resolved.
+	DependencyMode DependencyMode
+
+	// Preview leaves the chart directory as it is when resolving the chart's dependencies.
+	Preview bool
+
 	tool         *Tool
 	actionConfig *action.Configuration
 }
@@ -175,6 +189,11 @@ type TemplateCommand struct {
 	Validate    bool
 	IncludeCRDs bool
 	SkipTests   bool
+
+	// ClusterLookup, if set, restricts the `lookup` function to a read-only view of the cluster.
+	ClusterLookup *ClusterLookup
+
+	lookupSecrets secretValues
 }
 
 // Template returns a new `helm template` command.
@@ -209,6 +228,24 @@ func (cmd *TemplateCommand) Execute(ctx context.Context) (*release.Release, erro
 	if err != nil {
 		return nil, err
 	}
+	if cmd.ClusterLookup != nil && cmd.actionConfig.RESTClientGetter != nil {
+		releaseNamespace := cmd.Namespace
+		if releaseNamespace == "" {
+			releaseNamespace = cmd.tool.EnvSettings.Namespace()
+		}
+		// The template engine uses the REST config for the `lookup` function, when rendering
+		// with a server-side dry-run.
+		cmd.actionConfig.RESTClientGetter = &lookupRESTClientGetter{
+			RESTClientGetter: cmd.actionConfig.RESTClientGetter,
+			transport: &lookupTransport{
+				scope:            cmd.ClusterLookup,
+				releaseNamespace: releaseNamespace,
+				cache:            cmd.tool.LookupCache,
+				secrets:          &cmd.lookupSecrets,
+			},
+		}
+		client.DryRunOption = "server"
+	}
 
 	// https://github.com/helm/helm/blob/635b8cf33d25a86131635c32f35b2a76256e40cb/cmd/helm/template.go#L76-L81
 	registryClient, registryCleanup, err := NewRegistryClient(
@@ -241,6 +278,12 @@ func (cmd *TemplateCommand) Execute(ctx context.Context) (*release.Release, erro
 	return cmd.runInstall(ctx)
 }
 
+// LookupSecrets returns the values of the secrets that the chart looked up, both base64-encoded and
+// decoded, so that the objects which contain them can be treated as sensitive.
+func (cmd *TemplateCommand) LookupSecrets() []string {
+	return cmd.lookupSecrets.list()
+}
+
 // runInstall runs the install action.
 // https://github.com/helm/helm/blob/14d0c13e9eefff5b4a1b511cf50643529692ec94/cmd/helm/install.go#L221
 func (cmd *TemplateOrInstallCommand) runInstall(ctx context.Context) (*release.Release, error) {
@@ -283,7 +326,28 @@ func (cmd *TemplateOrInstallCommand) runInstall(ctx context.Context) (*release.R
 		return nil, err
 	}
 
-	if req := chartRequested.Metadata.Dependencies; req != nil {
+	if req := chartRequested.Metadata.Dependencies; req != nil && cmd.DependencyMode != DependencyModeDefault {
+		logStream := debugStream()
+		defer logStream.Close()
+
+		updated, err := ResolveDependencies(chartRequested, cp, DependencyOptions{
+			Mode:           cmd.DependencyMode,
+			Update:         client.DependencyUpdate,
+			Preview:        cmd.Preview,
+			Keyring:        client.Keyring,
+			Settings:       settings,
+			RegistryClient: client.GetRegistryClient(),
+			Out:            logStream,
+		})
+		if err != nil {
+			return nil, errors.Wrap(err, "unable to resolve the chart dependencies")
+		}
+		if updated {
+			if chartRequested, err = loader.Load(cp); err != nil {
+				return nil, errors.Wrap(err, "failed reloading chart after dependency update")
+			}
+		}
+	} else if req != nil {
 		// If CheckDependencies returns an error, we have unfulfilled dependencies.
 		if err := action.CheckDependencies(chartRequested, req); err != nil {
 			err = errors.Wrap(
@@ -329,6 +393,17 @@ func (cmd *TemplateOrInstallCommand) runInstall(ctx context.Context) (*release.R
 		}
 	}
 
+	// Validate the values against the chart's schema(s), reporting each violation individually.
+	if !client.SkipSchemaValidation {
+		violations, err := ValidateValues(chartRequested, vals)
+		if err != nil {
+			return nil, err
+		}
+		if len(violations) > 0 {
+			return nil, &ValuesSchemaError{Violations: violations}
+		}
+	}
+
 	if client.Namespace == "" {
 		client.Namespace = settings.Namespace()
 	}
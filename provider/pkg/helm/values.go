@@ -30,6 +30,8 @@ import (
 type ValueOpts struct {
 	// ValuesFiles is a list of Helm values files encapsulated as Pulumi assets.
 	ValuesFiles []pulumi.Asset
+	// ReferencedValues are the values read from ConfigMaps and Secrets (see ReadValuesFrom).
+	ReferencedValues []map[string]any
 	// Values is a map of Pulumi values.
 	Values map[string]any
 }
@@ -54,6 +56,11 @@ func (opts *ValueOpts) MergeValues(p getter.Providers) (map[string]interface{},
 		base = MergeMaps(base, currentMap)
 	}
 
+	// User specified values held in ConfigMaps and Secrets
+	for _, currentMap := range opts.ReferencedValues {
+		base = MergeMaps(base, currentMap)
+	}
+
 	// User specified a literal value map (possibly containing assets)
 	values, err := marshalValue(p, opts.Values)
 	if err != nil {
@@ -0,0 +1,258 @@
+// Copyright 2016-2026, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package helm
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+
+	"helm.sh/helm/v3/pkg/strvals"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+	"sigs.k8s.io/yaml"
+)
+
+// DefaultValuesKey is the key of the values in a referenced ConfigMap or Secret, if not specified.
+const DefaultValuesKey = "values.yaml"
+
+// ValuesReference is a reference to Helm values held in a ConfigMap or a Secret, like the `valuesFrom`
+// of a Flux HelmRelease.
+type ValuesReference struct {
+	// Kind is the kind of the object holding the values: ConfigMap or Secret.
+	Kind string `mapstructure:"kind"`
+	// Name is the name of the object.
+	Name string `mapstructure:"name"`
+	// Namespace is the namespace of the object. It defaults to the release namespace.
+	Namespace string `mapstructure:"namespace"`
+	// ValuesKey is the data key of the values. It defaults to "values.yaml".
+	ValuesKey string `mapstructure:"valuesKey"`
+	// TargetPath, if set, is the path (in `--set` notation) at which to set the value of the key as a
+	// string. Otherwise, the value of the key is a YAML document that is merged into the values.
+	TargetPath string `mapstructure:"targetPath"`
+	// Optional allows the object or key to be missing.
+	Optional bool `mapstructure:"optional"`
+}
+
+// ReferencedValues are the values read from ConfigMaps and Secrets.
+type ReferencedValues struct {
+	// Values are the values of each reference, in order.
+	Values []map[string]any
+	// secrets are the leaves of the values read from Secrets, which are to be treated as sensitive.
+	secrets []secretLeaf
+}
+
+// secretLeaf is a string or number read from a Secret, at the given path (of map keys and list indices) of the
+// values.
+type secretLeaf struct {
+	path  []any
+	value string
+}
+
+// HasSecrets reports whether any values were read from Secrets.
+func (r *ReferencedValues) HasSecrets() bool {
+	return len(r.secrets) > 0
+}
+
+// Secrets returns the values read from Secrets that are substituted into the given values, i.e. the values of the
+// release once merged: those that the merged values hold at the same path, rather than values which override
+// them. Numbers are given in their string form (see ScalarString).
+func (r *ReferencedValues) Secrets(values map[string]any) []string {
+	var result []string
+	for _, leaf := range r.secrets {
+		if s, ok := ScalarString(valueAt(values, leaf.path)); ok && s == leaf.value {
+			result = append(result, s)
+		}
+	}
+	slices.Sort(result)
+	return slices.Compact(result)
+}
+
+// valueAt returns the value at the given path of map keys and list indices, or nil if there is none.
+func valueAt(v any, path []any) any {
+	for _, p := range path {
+		switch p := p.(type) {
+		case string:
+			m, ok := v.(map[string]any)
+			if !ok {
+				return nil
+			}
+			v = m[p]
+		case int:
+			l, ok := v.([]any)
+			if !ok || p >= len(l) {
+				return nil
+			}
+			v = l[p]
+		}
+	}
+	return v
+}
+
+var (
+	configMapsResource = schema.GroupVersionResource{Version: "v1", Resource: "configmaps"}
+	secretsResource    = schema.GroupVersionResource{Version: "v1", Resource: "secrets"}
+)
+
+// ReadValuesFrom reads the referenced values from the cluster. The namespace is the default namespace of
+// the references.
+func ReadValuesFrom(
+	ctx context.Context, client dynamic.Interface, namespace string, refs []ValuesReference,
+) (*ReferencedValues, error) {
+	result := &ReferencedValues{}
+	if len(refs) == 0 {
+		return result, nil
+	}
+	if client == nil {
+		return nil, errors.New("valuesFrom requires access to the cluster")
+	}
+
+	for i, ref := range refs {
+		values, secrets, err := readValuesReference(ctx, client, namespace, ref)
+		if err != nil {
+			return nil, fmt.Errorf("valuesFrom[%d]: %w", i, err)
+		}
+		if values != nil {
+			result.Values = append(result.Values, values)
+		}
+		result.secrets = append(result.secrets, secrets...)
+	}
+	return result, nil
+}
+
+func readValuesReference(
+	ctx context.Context, client dynamic.Interface, namespace string, ref ValuesReference,
+) (map[string]any, []secretLeaf, error) {
+	var gvr schema.GroupVersionResource
+	switch ref.Kind {
+	case "ConfigMap":
+		gvr = configMapsResource
+	case "Secret":
+		gvr = secretsResource
+	default:
+		return nil, nil, fmt.Errorf("unsupported kind %q (expected ConfigMap or Secret)", ref.Kind)
+	}
+	if ref.Name == "" {
+		return nil, nil, errors.New("name is required")
+	}
+	if ref.Namespace != "" {
+		namespace = ref.Namespace
+	}
+	key := ref.ValuesKey
+	if key == "" {
+		key = DefaultValuesKey
+	}
+
+	obj, err := client.Resource(gvr).Namespace(namespace).Get(ctx, ref.Name, metav1.GetOptions{})
+	if err != nil {
+		if apierrors.IsNotFound(err) && ref.Optional {
+			return nil, nil, nil
+		}
+		return nil, nil, fmt.Errorf("unable to read %s %s/%s: %w", ref.Kind, namespace, ref.Name, err)
+	}
+	data, found, err := referencedData(obj, ref.Kind == "Secret", key)
+	if err != nil {
+		return nil, nil, fmt.Errorf("%s %s/%s: %w", ref.Kind, namespace, ref.Name, err)
+	}
+	if !found {
+		if ref.Optional {
+			return nil, nil, nil
+		}
+		return nil, nil, fmt.Errorf("%s %s/%s has no key %q", ref.Kind, namespace, ref.Name, key)
+	}
+
+	values := map[string]any{}
+	if ref.TargetPath != "" {
+		if err := strvals.ParseLiteralInto(ref.TargetPath+"="+data, values); err != nil {
+			return nil, nil, fmt.Errorf("targetPath %q: %w", ref.TargetPath, err)
+		}
+	} else if err := yaml.Unmarshal([]byte(data), &values); err != nil {
+		return nil, nil, fmt.Errorf("%s %s/%s: unable to parse key %q: %w", ref.Kind, namespace, ref.Name, key, err)
+	}
+
+	var secrets []secretLeaf
+	if ref.Kind == "Secret" {
+		secrets = secretLeaves(values, nil)
+	}
+	return values, secrets, nil
+}
+
+// referencedData returns the value of the given key of a ConfigMap or Secret, decoding the data of a
+// Secret.
+func referencedData(obj *unstructured.Unstructured, secret bool, key string) (string, bool, error) {
+	if v, found, _ := unstructured.NestedString(obj.Object, "data", key); found {
+		if !secret {
+			return v, true, nil
+		}
+		decoded, err := base64.StdEncoding.DecodeString(v)
+		if err != nil {
+			return "", false, fmt.Errorf("unable to decode key %q: %w", key, err)
+		}
+		return string(decoded), true, nil
+	}
+	if !secret {
+		if v, found, _ := unstructured.NestedString(obj.Object, "binaryData", key); found {
+			decoded, err := base64.StdEncoding.DecodeString(v)
+			if err != nil {
+				return "", false, fmt.Errorf("unable to decode key %q: %w", key, err)
+			}
+			return string(decoded), true, nil
+		}
+	}
+	return "", false, nil
+}
+
+// secretLeaves returns the string and number leaves of the given values (see ScalarString), with their paths.
+// Booleans are left out, as they would match every other flag of a chart.
+func secretLeaves(v any, path []any) []secretLeaf {
+	var result []secretLeaf
+	switch v := v.(type) {
+	case map[string]any:
+		for k, e := range v {
+			result = append(result, secretLeaves(e, append(slices.Clone(path), k))...)
+		}
+	case []any:
+		for i, e := range v {
+			result = append(result, secretLeaves(e, append(slices.Clone(path), i))...)
+		}
+	default:
+		if s, ok := ScalarString(v); ok && s != "" {
+			result = append(result, secretLeaf{path: path, value: s})
+		}
+	}
+	return result
+}
+
+// ScalarString returns the string form of a string or number value, such that a number read from a YAML or JSON
+// document (e.g. 5432) compares equal to the same number in a rendered object, or in a string.
+func ScalarString(v any) (string, bool) {
+	switch v := v.(type) {
+	case string:
+		return v, true
+	case int:
+		return strconv.Itoa(v), true
+	case int64:
+		return strconv.FormatInt(v, 10), true
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64), true
+	}
+	return "", false
+}
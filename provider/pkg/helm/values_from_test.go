@@ -0,0 +1,148 @@
+// Copyright 2016-2026, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package helm
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	"k8s.io/client-go/kubernetes/scheme"
+)
+
+func TestReadValuesFrom(t *testing.T) {
+	client := dynamicfake.NewSimpleDynamicClient(scheme.Scheme,
+		&corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: "shared", Namespace: "platform"},
+			Data: map[string]string{
+				"values.yaml": "image:\n  registry: registry.example.com\nreplicas: 2\n",
+				"region":      "eu-west-1",
+			},
+		},
+		&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "db", Namespace: "default"},
+			Data: map[string][]byte{
+				"values.yaml": []byte("database:\n  password: hunter2\n  port: 5432\n"),
+				"token":       []byte("s3cr3t"),
+			},
+		},
+	)
+
+	tests := []struct {
+		name        string
+		refs        []ValuesReference
+		wantValues  []map[string]any
+		wantSecrets []string
+		wantErr     string
+	}{
+		{
+			name:       "config map",
+			refs:       []ValuesReference{{Kind: "ConfigMap", Name: "shared", Namespace: "platform"}},
+			wantValues: []map[string]any{{"image": map[string]any{"registry": "registry.example.com"}, "replicas": float64(2)}},
+		},
+		{
+			name: "target path",
+			refs: []ValuesReference{
+				{Kind: "ConfigMap", Name: "shared", Namespace: "platform", ValuesKey: "region", TargetPath: "cloud.region"},
+			},
+			wantValues: []map[string]any{{"cloud": map[string]any{"region": "eu-west-1"}}},
+		},
+		{
+			name:        "secret",
+			refs:        []ValuesReference{{Kind: "Secret", Name: "db"}},
+			wantValues:  []map[string]any{{"database": map[string]any{"password": "hunter2", "port": float64(5432)}}},
+			wantSecrets: []string{"5432", "hunter2"},
+		},
+		{
+			name:        "secret target path",
+			refs:        []ValuesReference{{Kind: "Secret", Name: "db", ValuesKey: "token", TargetPath: "auth.token"}},
+			wantValues:  []map[string]any{{"auth": map[string]any{"token": "s3cr3t"}}},
+			wantSecrets: []string{"s3cr3t"},
+		},
+		{
+			name: "optional",
+			refs: []ValuesReference{
+				{Kind: "ConfigMap", Name: "missing", Optional: true},
+				{Kind: "Secret", Name: "db", ValuesKey: "missing", Optional: true},
+			},
+		},
+		{
+			name:    "missing object",
+			refs:    []ValuesReference{{Kind: "ConfigMap", Name: "missing"}},
+			wantErr: "valuesFrom[0]: unable to read ConfigMap default/missing",
+		},
+		{
+			name:    "missing key",
+			refs:    []ValuesReference{{Kind: "ConfigMap", Name: "shared", Namespace: "platform", ValuesKey: "missing"}},
+			wantErr: `valuesFrom[0]: ConfigMap platform/shared has no key "missing"`,
+		},
+		{
+			name:    "unsupported kind",
+			refs:    []ValuesReference{{Kind: "Deployment", Name: "shared"}},
+			wantErr: `valuesFrom[0]: unsupported kind "Deployment"`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ReadValuesFrom(context.Background(), client, "default", tt.refs)
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantValues, got.Values)
+			merged := map[string]any{}
+			for _, v := range got.Values {
+				merged = MergeMaps(merged, v)
+			}
+			assert.Equal(t, tt.wantSecrets, got.Secrets(merged))
+			assert.Equal(t, len(tt.wantSecrets) > 0, got.HasSecrets())
+		})
+	}
+
+	t.Run("no cluster", func(t *testing.T) {
+		_, err := ReadValuesFrom(context.Background(), nil, "default", []ValuesReference{{Kind: "ConfigMap", Name: "shared"}})
+		assert.ErrorContains(t, err, "valuesFrom requires access to the cluster")
+	})
+}
+
+func TestReferencedValuesSecrets(t *testing.T) {
+	client := dynamicfake.NewSimpleDynamicClient(scheme.Scheme,
+		&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "db", Namespace: "default"},
+			Data: map[string][]byte{
+				"values.yaml": []byte("database:\n  user: admin\n  password: hunter2\n  port: 5432\n" +
+					"hosts:\n  - db-0.example.com\n"),
+			},
+		},
+	)
+	got, err := ReadValuesFrom(context.Background(), client, "default", []ValuesReference{{Kind: "Secret", Name: "db"}})
+	require.NoError(t, err)
+
+	// The user and the port are overridden by the inline values, and the hosts replaced.
+	merged := MergeMaps(got.Values[0], map[string]any{
+		"database": map[string]any{"user": "postgres", "port": 5433},
+		"hosts":    []any{"db.example.com"},
+	})
+	assert.Equal(t, []string{"hunter2"}, got.Secrets(merged))
+
+	// An override with the same value is still the Secret's value.
+	merged = MergeMaps(got.Values[0], map[string]any{"database": map[string]any{"port": 5432}})
+	assert.Equal(t, []string{"5432", "admin", "db-0.example.com", "hunter2"}, got.Secrets(merged))
+}
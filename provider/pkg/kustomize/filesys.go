@@ -0,0 +1,283 @@
+// Copyright 2016-2026, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package kustomize prepares kustomizations for kustomize: it fetches their remote bases into a cache,
+// and inflates their Helm charts in-process.
+package kustomize
+
+import (
+	"context"
+	"fmt"
+	iofs "io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+
+	"sigs.k8s.io/kustomize/api/konfig"
+	ktypes "sigs.k8s.io/kustomize/api/types"
+	kfilesys "sigs.k8s.io/kustomize/kyaml/filesys"
+	"sigs.k8s.io/yaml"
+
+	"github.com/pulumi/pulumi-kubernetes/provider/v4/pkg/helm"
+)
+
+// Options configures the preparation of kustomizations.
+type Options struct {
+	// LoadRestrictions are kustomize's load restrictions, which apply to the values files of the charts too.
+	LoadRestrictions ktypes.LoadRestrictions
+	// HelmTool, if set, returns the Helm tool with which to inflate the `helmCharts` of the kustomizations
+	// in-process. Otherwise, the charts are left to kustomize, which inflates them with a helm binary.
+	HelmTool func() *helm.Tool
+	// RemoteCache, if set, is used to fetch the remote bases of the kustomizations. Otherwise, the remote
+	// bases are left to kustomize, which clones them for every build.
+	RemoteCache *RemoteCache
+	// RequirePinnedRefs requires the remote bases to be pinned to a ref.
+	RequirePinnedRefs bool
+}
+
+// The fields of a kustomization that refer to other kustomizations.
+var kustomizationRefFields = []string{"resources", "bases", "components"}
+
+// FileSystem is a kustomize file system that prepares the kustomizations that are read from it. The
+// remote bases are replaced with their local clones, and the `helmCharts` with the rendered manifests.
+type FileSystem struct {
+	kfilesys.FileSystem
+	ctx  context.Context
+	opts Options
+
+	mu        sync.Mutex
+	prepared  map[string][]byte
+	generated map[string][]byte
+	err       error
+}
+
+var _ kfilesys.FileSystem = &FileSystem{}
+
+// NewFileSystem returns a file system that prepares the kustomizations read from the given file system.
+func NewFileSystem(ctx context.Context, fSys kfilesys.FileSystem, opts Options) *FileSystem {
+	return &FileSystem{
+		FileSystem: fSys,
+		ctx:        ctx,
+		opts:       opts,
+		prepared:   map[string][]byte{},
+		generated:  map[string][]byte{},
+	}
+}
+
+// Err returns the first error in preparing a kustomization. Kustomize doesn't report the errors in
+// reading a kustomization file; it reports that the kustomization is missing instead.
+func (fs *FileSystem) Err() error {
+	fs.mu.Lock()
+	defer fs.mu.Unlock()
+	return fs.err
+}
+
+// LocateDirectory returns the local directory of a kustomization, fetching it if it's a remote base.
+func (fs *FileSystem) LocateDirectory(path string) (string, error) {
+	base, ok := ParseRemoteBase(path)
+	if !ok {
+		return path, nil
+	}
+	return fs.fetchRemoteBase(path, base)
+}
+
+func (fs *FileSystem) fetchRemoteBase(path string, base *RemoteBase) (string, error) {
+	if fs.opts.RequirePinnedRefs && !base.Pinned() {
+		return "", fmt.Errorf("remote base %q must be pinned to a ref (e.g. ?ref=v1.0.0)", path)
+	}
+	if fs.opts.RemoteCache == nil {
+		return path, nil
+	}
+	dir, err := fs.opts.RemoteCache.Fetch(fs.ctx, base)
+	if err != nil {
+		return "", err
+	}
+	if err := fs.copyRemoteBase(dir, base); err != nil {
+		return "", fmt.Errorf("copying remote base %q: %w", path, err)
+	}
+	return dir, nil
+}
+
+// copyRemoteBase copies the local clone of a remote base into the file system, if the file system isn't
+// backed by the disk (e.g. an in-memory file system). The whole repository is copied, since the base may
+// refer to other directories of the repository.
+func (fs *FileSystem) copyRemoteBase(dir string, base *RemoteBase) error {
+	if fs.FileSystem.Exists(dir) {
+		return nil
+	}
+	repoDir := dir
+	if base.Path != "" {
+		for range strings.Split(base.Path, "/") {
+			repoDir = filepath.Dir(repoDir)
+		}
+	}
+	return filepath.WalkDir(repoDir, func(path string, d iofs.DirEntry, err error) error {
+		switch {
+		case err != nil:
+			return err
+		case d.IsDir() && d.Name() == ".git":
+			return filepath.SkipDir
+		case d.IsDir():
+			return fs.FileSystem.MkdirAll(path)
+		case !d.Type().IsRegular():
+			return nil
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		return fs.FileSystem.WriteFile(path, data)
+	})
+}
+
+func (fs *FileSystem) Exists(path string) bool {
+	if _, ok := fs.generatedFile(path); ok {
+		return true
+	}
+	return fs.FileSystem.Exists(path)
+}
+
+func (fs *FileSystem) CleanedAbs(path string) (kfilesys.ConfirmedDir, string, error) {
+	if _, ok := fs.generatedFile(path); ok {
+		dir, _, err := fs.FileSystem.CleanedAbs(filepath.Dir(path))
+		return dir, filepath.Base(path), err
+	}
+	return fs.FileSystem.CleanedAbs(path)
+}
+
+func (fs *FileSystem) ReadFile(path string) ([]byte, error) {
+	if data, ok := fs.generatedFile(path); ok {
+		return data, nil
+	}
+	data, err := fs.FileSystem.ReadFile(path)
+	if err != nil || !slices.Contains(konfig.RecognizedKustomizationFileNames(), filepath.Base(path)) {
+		return data, err
+	}
+
+	path = filepath.Clean(path)
+	fs.mu.Lock()
+	prepared, ok := fs.prepared[path]
+	fs.mu.Unlock()
+	if ok {
+		return prepared, nil
+	}
+	prepared, err = fs.prepare(path, data)
+	fs.mu.Lock()
+	defer fs.mu.Unlock()
+	if err != nil {
+		err = fmt.Errorf("%s: %w", path, err)
+		if fs.err == nil {
+			fs.err = err
+		}
+		return nil, err
+	}
+	fs.prepared[path] = prepared
+	return prepared, nil
+}
+
+func (fs *FileSystem) generatedFile(path string) ([]byte, bool) {
+	fs.mu.Lock()
+	defer fs.mu.Unlock()
+	data, ok := fs.generated[filepath.Clean(path)]
+	return data, ok
+}
+
+// prepare prepares the kustomization file at the given path.
+func (fs *FileSystem) prepare(path string, data []byte) ([]byte, error) {
+	var kustomization map[string]any
+	if err := yaml.Unmarshal(data, &kustomization); err != nil {
+		// Leave it to kustomize to report the error.
+		return data, nil //nolint:nilerr
+	}
+	dir := filepath.Dir(path)
+	changed := false
+
+	for _, field := range kustomizationRefFields {
+		refs, _ := kustomization[field].([]any)
+		for i, ref := range refs {
+			s, ok := ref.(string)
+			if !ok {
+				continue
+			}
+			base, ok := ParseRemoteBase(s)
+			if !ok {
+				continue
+			}
+			local, err := fs.fetchRemoteBase(s, base)
+			if err != nil {
+				return nil, err
+			}
+			if local == s {
+				continue
+			}
+			// Kustomize requires the bases to be relative to the kustomization.
+			if refs[i], err = filepath.Rel(dir, local); err != nil {
+				return nil, err
+			}
+			changed = true
+		}
+	}
+
+	if fs.opts.HelmTool != nil {
+		if _, ok := kustomization["helmCharts"]; ok {
+			if err := fs.inflateHelmCharts(path, kustomization); err != nil {
+				return nil, err
+			}
+			changed = true
+		}
+	}
+
+	if !changed {
+		return data, nil
+	}
+	return yaml.Marshal(kustomization)
+}
+
+// inflateHelmCharts replaces the `helmCharts` of the kustomization with the rendered manifests, which are
+// added to its `resources` as generated files.
+func (fs *FileSystem) inflateHelmCharts(path string, kustomization map[string]any) error {
+	var spec struct {
+		HelmGlobals ktypes.HelmGlobals `json:"helmGlobals,omitempty"`
+		HelmCharts  []ktypes.HelmChart `json:"helmCharts,omitempty"`
+	}
+	data, err := yaml.Marshal(kustomization)
+	if err != nil {
+		return err
+	}
+	if err := yaml.Unmarshal(data, &spec); err != nil {
+		return err
+	}
+
+	dir := filepath.Dir(path)
+	resources, _ := kustomization["resources"].([]any)
+	for i, chart := range spec.HelmCharts {
+		manifest, err := fs.inflateHelmChart(fs.ctx, dir, spec.HelmGlobals, chart)
+		if err != nil {
+			return fmt.Errorf("helmCharts[%d] (%s): %w", i, chart.Name, err)
+		}
+		name := fmt.Sprintf(".%s.helm-%d.yaml", filepath.Base(path), i)
+		fs.mu.Lock()
+		fs.generated[filepath.Join(dir, name)] = manifest
+		fs.mu.Unlock()
+		resources = append(resources, name)
+	}
+	if len(resources) > 0 {
+		kustomization["resources"] = resources
+	}
+	delete(kustomization, "helmCharts")
+	delete(kustomization, "helmGlobals")
+	return nil
+}
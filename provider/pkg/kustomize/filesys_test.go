@@ -0,0 +1,189 @@
+// Copyright 2016-2026, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kustomize
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"helm.sh/helm/v3/pkg/cli"
+	"sigs.k8s.io/kustomize/api/krusty"
+	ktypes "sigs.k8s.io/kustomize/api/types"
+	kfilesys "sigs.k8s.io/kustomize/kyaml/filesys"
+
+	"github.com/pulumi/pulumi-kubernetes/provider/v4/pkg/helm"
+)
+
+var testChart = map[string]string{
+	"charts/greeter/Chart.yaml":  "apiVersion: v2\nname: greeter\nversion: 1.0.0\n",
+	"charts/greeter/values.yaml": "greeting: hello\nname: world\n",
+	"charts/greeter/templates/configmap.yaml": `apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: {{ .Release.Name }}
+data:
+  message: "{{ .Values.greeting }}, {{ .Values.name }}"
+`,
+	"charts/greeter/templates/hook.yaml": `apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: {{ .Release.Name }}-test
+  annotations:
+    helm.sh/hook: test
+`,
+}
+
+func build(t *testing.T, dir string, opts Options) (string, error) {
+	t.Helper()
+	return buildFs(t, kfilesys.MakeFsOnDisk(), dir, opts)
+}
+
+func buildFs(t *testing.T, fSys kfilesys.FileSystem, dir string, opts Options) (string, error) {
+	t.Helper()
+	fs := NewFileSystem(context.Background(), fSys, opts)
+	kopts := krusty.MakeDefaultOptions()
+	kopts.LoadRestrictions = opts.LoadRestrictions
+	rm, err := krusty.MakeKustomizer(kopts).Run(fs, dir)
+	if err != nil {
+		if fsErr := fs.Err(); fsErr != nil {
+			return "", fsErr
+		}
+		return "", err
+	}
+	out, err := rm.AsYaml()
+	return string(out), err
+}
+
+func TestInflateHelmCharts(t *testing.T) {
+	opts := Options{
+		LoadRestrictions: ktypes.LoadRestrictionsRootOnly,
+		HelmTool:         func() *helm.Tool { return helm.NewTool(cli.New()) },
+	}
+
+	t.Run("inline values", func(t *testing.T) {
+		dir := t.TempDir()
+		writeTestFiles(t, dir, testChart)
+		writeTestFiles(t, dir, map[string]string{
+			"kustomization.yaml": `namePrefix: dev-
+helmCharts:
+- name: greeter
+  releaseName: hello
+  valuesInline:
+    name: kustomize
+`,
+		})
+		out, err := build(t, dir, opts)
+		require.NoError(t, err)
+		assert.Contains(t, out, "name: dev-hello\n")
+		assert.Contains(t, out, "message: hello, kustomize\n")
+		assert.Contains(t, out, "name: dev-hello-test\n")
+	})
+
+	t.Run("values merge", func(t *testing.T) {
+		dir := t.TempDir()
+		writeTestFiles(t, dir, testChart)
+		writeTestFiles(t, dir, map[string]string{
+			"values.yaml": "greeting: hi\n",
+			"kustomization.yaml": `helmCharts:
+- name: greeter
+  skipTests: true
+  valuesFile: values.yaml
+  valuesMerge: merge
+  valuesInline:
+    greeting: ignored
+    name: there
+`,
+		})
+		out, err := build(t, dir, opts)
+		require.NoError(t, err)
+		assert.Contains(t, out, "name: release-name\n")
+		assert.Contains(t, out, "message: hi, there\n")
+		assert.NotContains(t, out, "release-name-test")
+	})
+
+	t.Run("load restrictions", func(t *testing.T) {
+		dir := t.TempDir()
+		writeTestFiles(t, dir, map[string]string{"values.yaml": "greeting: hi\n"})
+		writeTestFiles(t, filepath.Join(dir, "app"), testChart)
+		writeTestFiles(t, filepath.Join(dir, "app"), map[string]string{
+			"kustomization.yaml": "helmCharts:\n- name: greeter\n  valuesFile: ../values.yaml\n",
+		})
+		_, err := build(t, filepath.Join(dir, "app"), opts)
+		assert.ErrorContains(t, err, "is not in or below")
+
+		opts := opts
+		opts.LoadRestrictions = ktypes.LoadRestrictionsNone
+		out, err := build(t, filepath.Join(dir, "app"), opts)
+		require.NoError(t, err)
+		assert.Contains(t, out, "message: hi, world\n")
+	})
+}
+
+func TestRemoteBases(t *testing.T) {
+	repo := newTestRepo(t, map[string]string{
+		"base/kustomization.yaml": "resources:\n- configmap.yaml\n",
+		"base/configmap.yaml":     "apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: remote\n",
+	})
+	runGit(t, repo, "tag", "v1.0.0")
+
+	dir := t.TempDir()
+	writeTestFiles(t, dir, map[string]string{
+		"pinned/kustomization.yaml":   "namePrefix: pinned-\nresources:\n- file://" + repo + "//base?ref=v1.0.0\n",
+		"unpinned/kustomization.yaml": "resources:\n- file://" + repo + "//base\n",
+	})
+	opts := Options{
+		LoadRestrictions:  ktypes.LoadRestrictionsRootOnly,
+		RemoteCache:       NewRemoteCache(t.TempDir()),
+		RequirePinnedRefs: true,
+	}
+
+	out, err := build(t, filepath.Join(dir, "pinned"), opts)
+	require.NoError(t, err)
+	assert.Contains(t, out, "name: pinned-remote\n")
+
+	_, err = build(t, filepath.Join(dir, "unpinned"), opts)
+	assert.ErrorContains(t, err, "must be pinned to a ref")
+
+	t.Run("in memory", func(t *testing.T) {
+		fSys, err := NewInMemoryFileSystem(map[string]string{
+			"kustomization.yaml": "namePrefix: memory-\nresources:\n- file://" + repo + "//base?ref=v1.0.0\n",
+		})
+		require.NoError(t, err)
+		out, err := buildFs(t, fSys, InMemoryRoot, opts)
+		require.NoError(t, err)
+		assert.Contains(t, out, "name: memory-remote\n")
+	})
+}
+
+func TestNewInMemoryFileSystem(t *testing.T) {
+	fSys, err := NewInMemoryFileSystem(map[string]string{
+		"kustomization.yaml":      "resources:\n- base\npatches:\n- path: patch.yaml\n",
+		"patch.yaml":              "apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: cm\ndata:\n  key: patched\n",
+		"base/kustomization.yaml": "resources:\n- configmap.yaml\n",
+		"base/configmap.yaml":     "apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: cm\ndata:\n  key: value\n",
+	})
+	require.NoError(t, err)
+	out, err := buildFs(t, fSys, InMemoryRoot, Options{LoadRestrictions: ktypes.LoadRestrictionsRootOnly})
+	require.NoError(t, err)
+	assert.Contains(t, out, "key: patched\n")
+
+	for _, name := range []string{"", ".", "/etc/passwd", "../kustomization.yaml", "base/../../secret"} {
+		_, err := NewInMemoryFileSystem(map[string]string{name: ""})
+		assert.ErrorContains(t, err, "invalid file name", name)
+	}
+}
@@ -0,0 +1,196 @@
+// Copyright 2016-2026, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kustomize
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"helm.sh/helm/v3/pkg/chartutil"
+	"helm.sh/helm/v3/pkg/release"
+	ktypes "sigs.k8s.io/kustomize/api/types"
+	kfilesys "sigs.k8s.io/kustomize/kyaml/filesys"
+	"sigs.k8s.io/yaml"
+
+	"github.com/pulumi/pulumi/sdk/v3/go/pulumi"
+)
+
+// The ways of combining the inline values of a chart with its values file.
+const (
+	valuesMergeOverride = "override"
+	valuesMergeMerge    = "merge"
+	valuesMergeReplace  = "replace"
+)
+
+// defaultReleaseName is the release name of a chart without one, as in `helm template`.
+const defaultReleaseName = "release-name"
+
+// inflateHelmChart renders a chart of the `helmCharts` of the kustomization in the given directory, as
+// kustomize does with `helm template`.
+func (fs *FileSystem) inflateHelmChart(
+	ctx context.Context, dir string, globals ktypes.HelmGlobals, chart ktypes.HelmChart,
+) ([]byte, error) {
+	if chart.Name == "" {
+		return nil, errors.New("chart name cannot be empty")
+	}
+	chartHome := globals.ChartHome
+	if chartHome == "" {
+		chartHome = ktypes.HelmDefaultHome
+	}
+	if !filepath.IsAbs(chartHome) {
+		chartHome = filepath.Join(dir, chartHome)
+	}
+	if chart.Version != "" && chart.Repo != "" {
+		chartHome = filepath.Join(chartHome, fmt.Sprintf("%s-%s", chart.Name, chart.Version))
+	}
+
+	tool := fs.opts.HelmTool()
+	cmd := tool.Template()
+	localChart := filepath.Join(chartHome, chart.Name)
+	switch {
+	case fs.IsDir(localChart):
+		cmd.Chart = localChart
+	case chart.Repo == "":
+		return nil, fmt.Errorf("no repo specified for chart %q, and no chart found at %q", chart.Name, localChart)
+	case strings.HasPrefix(chart.Repo, "oci://"):
+		cmd.Chart = strings.TrimSuffix(chart.Repo, "/") + "/" + chart.Name
+	default:
+		cmd.Chart = chart.Name
+		cmd.RepoURL = chart.Repo
+	}
+	cmd.Version = chart.Version
+	cmd.Devel = chart.Devel
+
+	values, err := fs.helmChartValues(dir, localChart, chart)
+	if err != nil {
+		return nil, err
+	}
+	cmd.Values.ValuesFiles = values
+
+	cmd.ReleaseName = chart.ReleaseName
+	if cmd.ReleaseName == "" {
+		cmd.ReleaseName = defaultReleaseName
+	}
+	cmd.NameTemplate = chart.NameTemplate
+	cmd.Namespace = chart.Namespace
+	cmd.IncludeCRDs = chart.IncludeCRDs
+	cmd.SkipTests = chart.SkipTests
+	cmd.DisableHooks = chart.SkipHooks
+	cmd.APIVersions = chartutil.VersionSet(chart.ApiVersions)
+	if chart.KubeVersion != "" {
+		kubeVersion, err := chartutil.ParseKubeVersion(chart.KubeVersion)
+		if err != nil {
+			return nil, fmt.Errorf("invalid kube version %q: %w", chart.KubeVersion, err)
+		}
+		cmd.KubeVersion = kubeVersion
+	}
+
+	rel, err := cmd.Execute(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// https://github.com/helm/helm/blob/v3.20.2/cmd/helm/template.go#L106-L116
+	var manifests bytes.Buffer
+	fmt.Fprintln(&manifests, strings.TrimSpace(rel.Manifest))
+	if !chart.SkipHooks {
+		for _, h := range rel.Hooks {
+			if chart.SkipTests && slices.Contains(h.Events, release.HookTest) {
+				continue
+			}
+			fmt.Fprintf(&manifests, "---\n# Source: %s\n%s\n", h.Path, h.Manifest)
+		}
+	}
+	return manifests.Bytes(), nil
+}
+
+// helmChartValues returns the values files of a chart, combining its inline values with its values file
+// as kustomize does.
+func (fs *FileSystem) helmChartValues(dir, localChart string, chart ktypes.HelmChart) ([]pulumi.Asset, error) {
+	valuesFile := chart.ValuesFile
+	if valuesFile == "" {
+		// The default values of a chart that isn't local are applied by Helm regardless.
+		valuesFile = filepath.Join(localChart, "values.yaml")
+		if !fs.Exists(valuesFile) {
+			valuesFile = ""
+		}
+	}
+	values := map[string]any{}
+	if valuesFile != "" {
+		data, err := fs.readValuesFile(dir, valuesFile)
+		if err != nil {
+			return nil, err
+		}
+		if err := yaml.Unmarshal(data, &values); err != nil {
+			return nil, fmt.Errorf("parsing values file %q: %w", valuesFile, err)
+		}
+	}
+
+	if len(chart.ValuesInline) > 0 {
+		inline := chartutil.Values(chart.ValuesInline).AsMap()
+		switch chart.ValuesMerge {
+		case "", valuesMergeOverride:
+			values = chartutil.CoalesceTables(inline, values)
+		case valuesMergeMerge:
+			values = chartutil.CoalesceTables(values, inline)
+		case valuesMergeReplace:
+			values = inline
+		default:
+			return nil, fmt.Errorf("valuesMerge must be one of %q, %q or %q",
+				valuesMergeMerge, valuesMergeOverride, valuesMergeReplace)
+		}
+	}
+	data, err := yaml.Marshal(values)
+	if err != nil {
+		return nil, err
+	}
+	assets := []pulumi.Asset{pulumi.NewStringAsset(string(data))}
+
+	for _, file := range chart.AdditionalValuesFiles {
+		data, err := fs.readValuesFile(dir, file)
+		if err != nil {
+			return nil, fmt.Errorf("could not load additionalValuesFile: %w", err)
+		}
+		assets = append(assets, pulumi.NewStringAsset(string(data)))
+	}
+	return assets, nil
+}
+
+// readValuesFile reads a values file of a chart, relative to the kustomization directory, subject to the
+// load restrictions.
+func (fs *FileSystem) readValuesFile(dir, path string) ([]byte, error) {
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(dir, path)
+	}
+	if fs.opts.LoadRestrictions == ktypes.LoadRestrictionsRootOnly {
+		root, err := kfilesys.ConfirmDir(fs, dir)
+		if err != nil {
+			return nil, err
+		}
+		d, f, err := fs.CleanedAbs(path)
+		if err != nil {
+			return nil, err
+		}
+		if f == "" || !d.HasPrefix(root) {
+			return nil, fmt.Errorf("security; file '%s' is not in or below '%s'", path, root)
+		}
+	}
+	return fs.ReadFile(path)
+}
@@ -0,0 +1,49 @@
+// Copyright 2016-2026, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kustomize
+
+import (
+	"fmt"
+	"path"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	kfilesys "sigs.k8s.io/kustomize/kyaml/filesys"
+)
+
+// InMemoryRoot is the root directory of the files of an in-memory file system.
+const InMemoryRoot = kfilesys.Separator
+
+// NewInMemoryFileSystem returns an in-memory file system with the given files, keyed by their slash-separated
+// paths relative to InMemoryRoot.
+func NewInMemoryFileSystem(files map[string]string) (kfilesys.FileSystem, error) {
+	fSys := kfilesys.MakeFsInMemory()
+	names := make([]string, 0, len(files))
+	for name := range files {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	for _, name := range names {
+		clean := path.Clean(name)
+		if name == "" || path.IsAbs(name) || clean == "." || clean == ".." || strings.HasPrefix(clean, "../") {
+			return nil, fmt.Errorf("invalid file name %q: must be a relative path within the kustomization", name)
+		}
+		if err := fSys.WriteFile(filepath.Join(InMemoryRoot, filepath.FromSlash(clean)), []byte(files[name])); err != nil {
+			return nil, fmt.Errorf("writing %q: %w", name, err)
+		}
+	}
+	return fSys, nil
+}
@@ -0,0 +1,319 @@
+// Copyright 2016-2026, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kustomize
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/url"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/sync/singleflight"
+
+	logger "github.com/pulumi/pulumi/sdk/v3/go/common/util/logging"
+)
+
+// defaultRemoteTimeout is the time allowed for fetching a remote base, as in kustomize.
+const defaultRemoteTimeout = 27 * time.Second
+
+// knownHosts are the git hosts whose repositories are given as "host/org/repo", such that the path of a
+// base within the repository may follow without a "//" separator.
+var knownHosts = []string{"github.com", "gitlab.com", "bitbucket.org"}
+
+var commitPattern = regexp.MustCompile(`^([0-9a-f]{40}|[0-9a-f]{64})$`)
+
+// RemoteBase is a kustomization in a git repository, given in kustomize's remote URL format, e.g.
+// "https://github.com/org/repo//path?ref=v1.0.0".
+type RemoteBase struct {
+	// CloneURL is the URL with which to clone the repository.
+	CloneURL string
+	// Path is the path of the kustomization within the repository.
+	Path string
+	// Ref is the branch, tag or commit to check out. The default branch is checked out if empty.
+	Ref string
+	// Submodules indicates whether to check out the submodules of the repository.
+	Submodules bool
+	// Timeout is the time allowed for fetching the repository.
+	Timeout time.Duration
+}
+
+// Pinned reports whether the remote base is pinned to a ref.
+func (b *RemoteBase) Pinned() bool {
+	return b.Ref != ""
+}
+
+// ParseRemoteBase parses a kustomize remote URL. It reports false if the path is not a git repository,
+// e.g. a local path or the URL of a file.
+func ParseRemoteBase(path string) (*RemoteBase, bool) {
+	s, rawQuery, _ := strings.Cut(path, "?")
+	forced := strings.HasPrefix(s, "git::")
+	s = strings.TrimPrefix(s, "git::")
+
+	var prefix, host, repoPath string
+	isGit := forced
+	switch {
+	case strings.HasPrefix(s, "git@"):
+		// an scp-like address, e.g. "git@github.com:org/repo"
+		var ok bool
+		host, repoPath, ok = strings.Cut(s, ":")
+		if !ok {
+			return nil, false
+		}
+		prefix = host + ":"
+		host = strings.TrimPrefix(host, "git@")
+		isGit = true
+	case strings.Contains(s, "://"):
+		scheme, rest, _ := strings.Cut(s, "://")
+		host, repoPath, _ = strings.Cut(rest, "/")
+		prefix = scheme + "://" + host + "/"
+		isGit = isGit || (scheme != "http" && scheme != "https")
+	case slices.ContainsFunc(knownHosts, func(host string) bool { return strings.HasPrefix(s, host+"/") }):
+		host, repoPath, _ = strings.Cut(s, "/")
+		prefix = "https://" + host + "/"
+		isGit = true
+	default:
+		return nil, false
+	}
+
+	repo, subPath, found := strings.Cut(repoPath, "//")
+	if found {
+		isGit = true
+	} else {
+		// Without a "//" separator, the repository ends with a ".git" segment, a "_git/<repo>" segment
+		// (Azure DevOps), or the "org/repo" segments of a known host.
+		segments := strings.Split(repoPath, "/")
+		n := len(segments)
+		if i := slices.IndexFunc(segments, func(s string) bool { return strings.HasSuffix(s, ".git") }); i >= 0 {
+			n = i + 1
+		} else if i := slices.Index(segments, "_git"); i >= 0 {
+			n = min(i+2, len(segments))
+		} else if slices.Contains(knownHosts, host) {
+			n = min(2, len(segments))
+		}
+		repo = strings.Join(segments[:n], "/")
+		subPath = strings.Join(segments[n:], "/")
+		isGit = isGit || n < len(segments) || strings.HasSuffix(repo, ".git") || slices.Contains(knownHosts, host)
+	}
+
+	query, err := url.ParseQuery(rawQuery)
+	if err != nil {
+		return nil, false
+	}
+	ref := query.Get("ref")
+	if ref == "" {
+		ref = query.Get("version")
+	}
+	isGit = isGit || ref != ""
+	if !isGit || repo == "" {
+		return nil, false
+	}
+
+	base := &RemoteBase{
+		CloneURL:   prefix + repo,
+		Path:       strings.Trim(subPath, "/"),
+		Ref:        ref,
+		Submodules: query.Get("submodules") != "false",
+		Timeout:    defaultRemoteTimeout,
+	}
+	if v := query.Get("timeout"); v != "" {
+		if seconds, err := strconv.Atoi(v); err == nil {
+			base.Timeout = time.Duration(seconds) * time.Second
+		} else if d, err := time.ParseDuration(v); err == nil {
+			base.Timeout = d
+		}
+	}
+	return base, true
+}
+
+// RemoteCache caches the git repositories of remote bases. A repository is cloned once for each commit,
+// and the clones are kept in the cache directory for use by other processes. The refs of the remote bases
+// are resolved to commits once per process, so that all the kustomizations of a deployment see the same
+// commits.
+type RemoteCache struct {
+	dir string
+
+	mu      sync.Mutex
+	commits map[string]string
+	group   singleflight.Group
+}
+
+// NewRemoteCache returns a cache that keeps the cloned repositories in the given directory.
+func NewRemoteCache(dir string) *RemoteCache {
+	return &RemoteCache{
+		dir:     dir,
+		commits: map[string]string{},
+	}
+}
+
+// Fetch returns the local directory of the remote base, cloning its repository if needed.
+func (c *RemoteCache) Fetch(ctx context.Context, base *RemoteBase) (string, error) {
+	ctx, cancel := context.WithTimeout(ctx, base.Timeout)
+	defer cancel()
+
+	key := fmt.Sprintf("%s@%s?submodules=%t", base.CloneURL, base.Ref, base.Submodules)
+	v, err, _ := c.group.Do(key, func() (any, error) {
+		return c.fetch(ctx, key, base)
+	})
+	if err != nil {
+		return "", fmt.Errorf("fetching %s: %w", base.CloneURL, err)
+	}
+	repoDir := v.(string)
+	dir := filepath.Join(repoDir, filepath.FromSlash(base.Path))
+	if rel, err := filepath.Rel(repoDir, dir); err != nil || strings.HasPrefix(rel, "..") {
+		return "", fmt.Errorf("%q refers to a directory outside of repository %s", base.Path, base.CloneURL)
+	}
+	return dir, nil
+}
+
+func (c *RemoteCache) fetch(ctx context.Context, key string, base *RemoteBase) (string, error) {
+	commit, err := c.resolve(ctx, key, base)
+	if err != nil {
+		return "", err
+	}
+	dir := c.path(base, commit)
+	if fi, err := os.Stat(dir); err == nil && fi.IsDir() {
+		logger.V(9).Infof("Using the cached clone of %s at %s", base.CloneURL, commit)
+		return dir, nil
+	}
+
+	if err := os.MkdirAll(c.dir, 0o755); err != nil {
+		return "", err
+	}
+	tmp, err := os.MkdirTemp(c.dir, "tmp-")
+	if err != nil {
+		return "", err
+	}
+	defer os.RemoveAll(tmp)
+
+	if err := clone(ctx, tmp, base); err != nil {
+		return "", err
+	}
+	// The ref may have moved since it was resolved.
+	head, err := git(ctx, tmp, "rev-parse", "HEAD")
+	if err != nil {
+		return "", err
+	}
+	if head != commit && commitPattern.MatchString(commit) {
+		c.setCommit(key, head)
+		dir = c.path(base, head)
+	}
+	if err := os.Rename(tmp, dir); err != nil {
+		// Another process may have cloned the same commit in the meantime.
+		if fi, statErr := os.Stat(dir); statErr != nil || !fi.IsDir() {
+			return "", err
+		}
+	}
+	return dir, nil
+}
+
+// resolve returns the commit of the remote base's ref, resolving the ref once per process. A ref that
+// can't be resolved (e.g. an abbreviated commit) is returned as is.
+func (c *RemoteCache) resolve(ctx context.Context, key string, base *RemoteBase) (string, error) {
+	if commitPattern.MatchString(base.Ref) {
+		return base.Ref, nil
+	}
+	c.mu.Lock()
+	commit, ok := c.commits[key]
+	c.mu.Unlock()
+	if ok {
+		return commit, nil
+	}
+
+	ref := base.Ref
+	if ref == "" {
+		ref = "HEAD"
+	}
+	out, err := git(ctx, "", "ls-remote", base.CloneURL, ref)
+	if err != nil {
+		return "", err
+	}
+	commit = ref
+	found := map[string]string{}
+	for _, line := range strings.Split(out, "\n") {
+		if sha, name, ok := strings.Cut(line, "\t"); ok {
+			found[name] = sha
+		}
+	}
+	for _, name := range []string{ref, "refs/tags/" + ref + "^{}", "refs/tags/" + ref, "refs/heads/" + ref} {
+		if sha, ok := found[name]; ok {
+			commit = sha
+			break
+		}
+	}
+	c.setCommit(key, commit)
+	return commit, nil
+}
+
+func (c *RemoteCache) setCommit(key, commit string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.commits[key] = commit
+}
+
+// path returns the cache directory of the given commit of the remote base's repository.
+func (c *RemoteCache) path(base *RemoteBase, commit string) string {
+	h := sha256.Sum256([]byte(fmt.Sprintf("%s@%s?submodules=%t", base.CloneURL, commit, base.Submodules)))
+	return filepath.Join(c.dir, hex.EncodeToString(h[:]))
+}
+
+// clone clones the remote base's repository into the given directory, in the way that kustomize does.
+func clone(ctx context.Context, dir string, base *RemoteBase) error {
+	ref := base.Ref
+	if ref == "" {
+		ref = "HEAD"
+	}
+	commands := [][]string{
+		{"init"},
+		{"remote", "add", "origin", base.CloneURL},
+		{"fetch", "--depth=1", "origin", ref},
+		{"checkout", "FETCH_HEAD"},
+	}
+	if base.Submodules {
+		commands = append(commands, []string{"submodule", "update", "--init", "--recursive"})
+	}
+	for _, args := range commands {
+		if _, err := git(ctx, dir, args...); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// git runs a git command in the given directory, returning its trimmed output.
+func git(ctx context.Context, dir string, args ...string) (string, error) {
+	cmd := exec.CommandContext(ctx, "git", args...)
+	cmd.Dir = dir
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		if ctx.Err() != nil {
+			return "", fmt.Errorf("git %s: %w", args[0], ctx.Err())
+		}
+		return "", fmt.Errorf("git %s: %w: %s", args[0], err, strings.TrimSpace(stderr.String()))
+	}
+	return strings.TrimSpace(stdout.String()), nil
+}
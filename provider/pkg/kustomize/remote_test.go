@@ -0,0 +1,164 @@
+// Copyright 2016-2026, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kustomize
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseRemoteBase(t *testing.T) {
+	tests := []struct {
+		path string
+		want *RemoteBase
+	}{
+		{
+			path: "https://github.com/org/repo//deploy/base?ref=v1.0.0",
+			want: &RemoteBase{CloneURL: "https://github.com/org/repo", Path: "deploy/base", Ref: "v1.0.0", Submodules: true},
+		},
+		{
+			path: "github.com/org/repo/deploy/base?version=v1.0.0",
+			want: &RemoteBase{CloneURL: "https://github.com/org/repo", Path: "deploy/base", Ref: "v1.0.0", Submodules: true},
+		},
+		{
+			path: "git@github.com:org/repo.git/deploy?ref=main",
+			want: &RemoteBase{CloneURL: "git@github.com:org/repo.git", Path: "deploy", Ref: "main", Submodules: true},
+		},
+		{
+			path: "ssh://git@example.com/org/repo",
+			want: &RemoteBase{CloneURL: "ssh://git@example.com/org/repo", Submodules: true},
+		},
+		{
+			path: "https://dev.azure.com/org/project/_git/repo/deploy?ref=v1",
+			want: &RemoteBase{
+				CloneURL: "https://dev.azure.com/org/project/_git/repo", Path: "deploy", Ref: "v1", Submodules: true,
+			},
+		},
+		{
+			path: "https://example.com/repo.git?ref=v1&submodules=false&timeout=90",
+			want: &RemoteBase{CloneURL: "https://example.com/repo.git", Ref: "v1", Timeout: 90 * time.Second},
+		},
+		{path: "https://example.com/manifests/deployment.yaml"},
+		{path: "../base"},
+		{path: "deployment.yaml"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			got, ok := ParseRemoteBase(tt.path)
+			if tt.want == nil {
+				assert.False(t, ok)
+				return
+			}
+			require.True(t, ok)
+			if tt.want.Timeout == 0 {
+				tt.want.Timeout = defaultRemoteTimeout
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestRemoteCache(t *testing.T) {
+	repo := newTestRepo(t, map[string]string{
+		"base/kustomization.yaml": "resources:\n- configmap.yaml\n",
+		"base/configmap.yaml":     "apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: remote\n",
+	})
+	commit := runGit(t, repo, "rev-parse", "HEAD")
+	runGit(t, repo, "tag", "v1.0.0")
+
+	cacheDir := t.TempDir()
+	cache := NewRemoteCache(cacheDir)
+	base, ok := ParseRemoteBase("file://" + repo + "//base?ref=v1.0.0")
+	require.True(t, ok)
+
+	dir, err := cache.Fetch(context.Background(), base)
+	require.NoError(t, err)
+	assert.FileExists(t, filepath.Join(dir, "kustomization.yaml"))
+
+	t.Run("reuses the clone of a commit", func(t *testing.T) {
+		// The tag is resolved once per process, so the new commit isn't seen.
+		writeTestFiles(t, repo, map[string]string{"base/configmap.yaml": "changed"})
+		runGit(t, repo, "commit", "-am", "change")
+		runGit(t, repo, "tag", "-f", "v1.0.0")
+
+		again, err := cache.Fetch(context.Background(), base)
+		require.NoError(t, err)
+		assert.Equal(t, dir, again)
+
+		pinned, ok := ParseRemoteBase("file://" + repo + "//base?ref=" + commit)
+		require.True(t, ok)
+		again, err = cache.Fetch(context.Background(), pinned)
+		require.NoError(t, err)
+		assert.Equal(t, dir, again)
+
+		entries, err := os.ReadDir(cacheDir)
+		require.NoError(t, err)
+		assert.Len(t, entries, 1)
+	})
+
+	t.Run("resolves the ref in a new process", func(t *testing.T) {
+		dir2, err := NewRemoteCache(cacheDir).Fetch(context.Background(), base)
+		require.NoError(t, err)
+		assert.NotEqual(t, dir, dir2)
+		data, err := os.ReadFile(filepath.Join(dir2, "configmap.yaml"))
+		require.NoError(t, err)
+		assert.Equal(t, "changed", string(data))
+	})
+
+	t.Run("path outside of the repository", func(t *testing.T) {
+		outside, ok := ParseRemoteBase("file://" + repo + "//../other?ref=v1.0.0")
+		require.True(t, ok)
+		_, err := cache.Fetch(context.Background(), outside)
+		assert.ErrorContains(t, err, "outside of repository")
+	})
+}
+
+// newTestRepo creates a git repository with a commit of the given files.
+func newTestRepo(t *testing.T, files map[string]string) string {
+	t.Helper()
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed")
+	}
+	dir := t.TempDir()
+	runGit(t, dir, "init", "--initial-branch=main")
+	writeTestFiles(t, dir, files)
+	runGit(t, dir, "add", ".")
+	runGit(t, dir, "commit", "-m", "initial")
+	return dir
+}
+
+func runGit(t *testing.T, dir string, args ...string) string {
+	t.Helper()
+	args = append([]string{"-c", "user.name=test", "-c", "user.email=test@example.com"}, args...)
+	out, err := git(context.Background(), dir, args...)
+	require.NoError(t, err)
+	return out
+}
+
+func writeTestFiles(t *testing.T, dir string, files map[string]string) {
+	t.Helper()
+	for name, content := range files {
+		path := filepath.Join(dir, filepath.FromSlash(name))
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+		require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+	}
+}
@@ -0,0 +1,229 @@
+// Copyright 2016-2026, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package manifest fetches remote Kubernetes manifests, verifying their checksums and caching their content.
+package manifest
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"golang.org/x/sync/singleflight"
+
+	logger "github.com/pulumi/pulumi/sdk/v3/go/common/util/logging"
+)
+
+// checksumFragment is the URL fragment that pins the content of a manifest, e.g. "#sha256=<hex digest>".
+const checksumFragment = "sha256="
+
+var digestPattern = regexp.MustCompile(`^[0-9a-f]{64}$`)
+
+// ErrNotCached is returned by an offline cache for a manifest that isn't cached.
+var ErrNotCached = errors.New("not cached")
+
+// Source is a remote manifest, optionally pinned to a SHA-256 digest of its content.
+type Source struct {
+	// URL is the URL of the manifest, without the checksum fragment.
+	URL string
+	// SHA256 is the hex-encoded SHA-256 digest of the manifest's content, if pinned.
+	SHA256 string
+}
+
+// ParseSource parses the URL of a manifest, with an optional "#sha256=<hex digest>" fragment.
+func ParseSource(rawURL string) (Source, error) {
+	u, fragment, found := strings.Cut(rawURL, "#")
+	if !found || !strings.HasPrefix(fragment, checksumFragment) {
+		return Source{URL: rawURL}, nil
+	}
+	digest := strings.ToLower(strings.TrimPrefix(fragment, checksumFragment))
+	if !digestPattern.MatchString(digest) {
+		return Source{}, fmt.Errorf("invalid checksum %q for %s: expected a hex-encoded SHA-256 digest", digest, u)
+	}
+	return Source{URL: u, SHA256: digest}, nil
+}
+
+// verify checks that the content matches the source's digest, if pinned.
+func (s Source) verify(data []byte) error {
+	if s.SHA256 == "" {
+		return nil
+	}
+	if actual := digest(data); actual != s.SHA256 {
+		return fmt.Errorf("checksum mismatch for %s: expected sha256:%s, got sha256:%s", s.URL, s.SHA256, actual)
+	}
+	return nil
+}
+
+// Fetch downloads the manifest at the given URL, verifying its checksum if pinned. Nothing is cached.
+func Fetch(ctx context.Context, rawURL string) ([]byte, error) {
+	src, err := ParseSource(rawURL)
+	if err != nil {
+		return nil, err
+	}
+	data, err := download(ctx, src.URL)
+	if err != nil {
+		return nil, err
+	}
+	if err := src.verify(data); err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+// Cache is a content-addressed cache of remote manifests. A pinned manifest is downloaded once, and then read
+// from the cache by its digest. An unpinned manifest is downloaded every time, unless the cache is offline, and
+// the cache records the digest of its latest content. An offline cache never downloads anything; it returns the
+// cached content of the manifests, or ErrNotCached.
+type Cache struct {
+	dir     string
+	offline bool
+	group   singleflight.Group
+}
+
+// NewCache returns a cache that keeps the manifests in the given directory.
+func NewCache(dir string, offline bool) *Cache {
+	return &Cache{dir: dir, offline: offline}
+}
+
+// Offline reports whether the cache is offline.
+func (c *Cache) Offline() bool {
+	return c.offline
+}
+
+// Fetch returns the content of the manifest at the given URL, with an optional "#sha256=<hex digest>" fragment.
+func (c *Cache) Fetch(ctx context.Context, rawURL string) ([]byte, error) {
+	src, err := ParseSource(rawURL)
+	if err != nil {
+		return nil, err
+	}
+
+	// A pinned manifest is read from the cache by its digest.
+	if src.SHA256 != "" {
+		if data, ok := c.read(src.SHA256); ok {
+			logger.V(9).Infof("Using the cached manifest %s (sha256:%s)", src.URL, src.SHA256)
+			return data, nil
+		}
+	}
+	if c.offline {
+		if src.SHA256 == "" {
+			if sum, err := os.ReadFile(c.urlPath(src.URL)); err == nil {
+				if data, ok := c.read(string(sum)); ok {
+					return data, nil
+				}
+			}
+		}
+		return nil, fmt.Errorf("fetching %s offline: %w", src.URL, ErrNotCached)
+	}
+
+	v, err, _ := c.group.Do(rawURL, func() (any, error) {
+		data, err := download(ctx, src.URL)
+		if err != nil {
+			return nil, err
+		}
+		if err := src.verify(data); err != nil {
+			return nil, err
+		}
+		if err := c.write(src.URL, data); err != nil {
+			// The cache is an optimization, so a failure to write it isn't fatal.
+			logger.V(3).Infof("Unable to cache the manifest %s: %v", src.URL, err)
+		}
+		return data, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return v.([]byte), nil
+}
+
+// read returns the content with the given digest, if cached and intact.
+func (c *Cache) read(sum string) ([]byte, bool) {
+	if !digestPattern.MatchString(sum) {
+		return nil, false
+	}
+	data, err := os.ReadFile(c.contentPath(sum))
+	if err != nil || digest(data) != sum {
+		return nil, false
+	}
+	return data, true
+}
+
+// write stores the content, and records its digest as the latest content of the URL.
+func (c *Cache) write(url string, data []byte) error {
+	sum := digest(data)
+	if err := writeFile(c.contentPath(sum), data); err != nil {
+		return err
+	}
+	return writeFile(c.urlPath(url), []byte(sum))
+}
+
+func (c *Cache) contentPath(sum string) string {
+	return filepath.Join(c.dir, "sha256", sum)
+}
+
+func (c *Cache) urlPath(url string) string {
+	return filepath.Join(c.dir, "urls", digest([]byte(url)))
+}
+
+// writeFile writes the file atomically, so that concurrent readers never see partial content.
+func writeFile(path string, data []byte) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	f, err := os.CreateTemp(filepath.Dir(path), ".tmp-")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(f.Name())
+	if _, err := f.Write(data); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	return os.Rename(f.Name(), path)
+}
+
+func download(ctx context.Context, url string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("fetching YAML over network: %w", err)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("fetching YAML over network: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return nil, fmt.Errorf("fetching YAML over network: %s: %s", url, resp.Status)
+	}
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("reading YAML over network: %w", err)
+	}
+	return data, nil
+}
+
+func digest(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,113 @@
+// Copyright 2016-2026, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package manifest
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testManifest = "apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: test\n"
+
+func TestParseSource(t *testing.T) {
+	sum := digest([]byte(testManifest))
+
+	src, err := ParseSource("https://example.com/manifest.yaml#sha256=" + sum)
+	require.NoError(t, err)
+	assert.Equal(t, Source{URL: "https://example.com/manifest.yaml", SHA256: sum}, src)
+
+	src, err = ParseSource("https://example.com/manifest.yaml#section")
+	require.NoError(t, err)
+	assert.Equal(t, Source{URL: "https://example.com/manifest.yaml#section"}, src)
+
+	_, err = ParseSource("https://example.com/manifest.yaml#sha256=abc")
+	assert.ErrorContains(t, err, "invalid checksum")
+}
+
+func TestCache(t *testing.T) {
+	var requests atomic.Int32
+	content := testManifest
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		if r.URL.Path != "/manifest.yaml" {
+			http.NotFound(w, r)
+			return
+		}
+		_, _ = w.Write([]byte(content))
+	}))
+	defer server.Close()
+
+	url := server.URL + "/manifest.yaml"
+	pinned := url + "#sha256=" + digest([]byte(testManifest))
+	ctx := context.Background()
+	dir := t.TempDir()
+	cache := NewCache(dir, false)
+
+	t.Run("pinned", func(t *testing.T) {
+		requests.Store(0)
+		for range 2 {
+			data, err := cache.Fetch(ctx, pinned)
+			require.NoError(t, err)
+			assert.Equal(t, testManifest, string(data))
+		}
+		assert.EqualValues(t, 1, requests.Load())
+	})
+
+	t.Run("unpinned", func(t *testing.T) {
+		requests.Store(0)
+		for range 2 {
+			data, err := cache.Fetch(ctx, url)
+			require.NoError(t, err)
+			assert.Equal(t, testManifest, string(data))
+		}
+		assert.EqualValues(t, 2, requests.Load())
+	})
+
+	t.Run("checksum mismatch", func(t *testing.T) {
+		_, err := cache.Fetch(ctx, url+"#sha256="+digest([]byte("other")))
+		assert.ErrorContains(t, err, "checksum mismatch")
+		_, err = Fetch(ctx, url+"#sha256="+digest([]byte("other")))
+		assert.ErrorContains(t, err, "checksum mismatch")
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		_, err := cache.Fetch(ctx, server.URL+"/missing.yaml")
+		assert.ErrorContains(t, err, "404 Not Found")
+	})
+
+	t.Run("offline", func(t *testing.T) {
+		requests.Store(0)
+		content = "changed"
+		offline := NewCache(dir, true)
+
+		data, err := offline.Fetch(ctx, pinned)
+		require.NoError(t, err)
+		assert.Equal(t, testManifest, string(data))
+
+		data, err = offline.Fetch(ctx, url)
+		require.NoError(t, err)
+		assert.Equal(t, testManifest, string(data))
+
+		_, err = offline.Fetch(ctx, server.URL+"/other.yaml")
+		assert.ErrorIs(t, err, ErrNotCached)
+		assert.EqualValues(t, 0, requests.Load())
+	})
+}
@@ -27,4 +27,7 @@ type customResourceProvider interface {
 	Update(ctx context.Context, req *pulumirpc.UpdateRequest) (*pulumirpc.UpdateResponse, error)
 	// Delete tears down an existing resource with the given ID.  If it fails, the resource is assumed to still exist.
 	Delete(context.Context, *pulumirpc.DeleteRequest) (*empty.Empty, error)
+	// List enumerates the existing resources that match the query, skipping the first offset resources and
+	// returning at most limit resources (all of them if limit is zero). It also reports whether more remain.
+	List(ctx context.Context, query listQuery, offset, limit int64) ([]*pulumirpc.ListResponse_Result, bool, error)
 }
@@ -16,9 +16,12 @@ package v4
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"slices"
 	"strings"
 
+	"github.com/mitchellh/mapstructure"
 	"helm.sh/helm/v3/pkg/action"
 	"helm.sh/helm/v3/pkg/chartutil"
 	helmkube "helm.sh/helm/v3/pkg/kube"
@@ -26,8 +29,10 @@ import (
 	"helm.sh/helm/v3/pkg/release"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/client-go/discovery"
+	cliutilsobject "sigs.k8s.io/cli-utils/pkg/object"
 
 	"github.com/pulumi/pulumi/sdk/v3/go/pulumi"
+	pulumierrors "github.com/pulumi/pulumi/sdk/v3/go/pulumi/errors"
 	"github.com/pulumi/pulumi/sdk/v3/go/pulumi/internals"
 	pulumiprovider "github.com/pulumi/pulumi/sdk/v3/go/pulumi/provider"
 
@@ -53,18 +58,28 @@ type ChartArgs struct {
 	Devel            pulumi.BoolInput           `pulumi:"devel,optional"`
 	RepositoryOpts   helmv4.RepositoryOptsInput `pulumi:"repositoryOpts,optional"`
 	DependencyUpdate pulumi.BoolInput           `pulumi:"dependencyUpdate,optional"`
+	DependencyMode   pulumi.StringInput         `pulumi:"dependencyMode,optional"`
 	Verify           pulumi.BoolInput           `pulumi:"verify,optional"`
 	Keyring          pulumi.AssetInput          `pulumi:"keyring,optional"`
 
-	Values       pulumi.MapInput          `pulumi:"values,optional"`
-	ValuesFiles  pulumi.AssetArrayInput   `pulumi:"valueYamlFiles,optional"`
-	SkipCrds     pulumi.BoolInput         `pulumi:"skipCrds,optional"`
-	IncludeHooks pulumi.BoolInput         `pulumi:"includeHooks,optional"`
-	PostRenderer helmv4.PostRendererInput `pulumi:"postRenderer,optional"`
+	Values        pulumi.MapInput          `pulumi:"values,optional"`
+	ValuesFiles   pulumi.AssetArrayInput   `pulumi:"valueYamlFiles,optional"`
+	ValuesFrom    pulumi.ArrayInput        `pulumi:"valuesFrom,optional"`
+	SkipCrds      pulumi.BoolInput         `pulumi:"skipCrds,optional"`
+	CrdPolicy     pulumi.StringInput       `pulumi:"crdPolicy,optional"`
+	IncludeHooks  pulumi.BoolInput         `pulumi:"includeHooks,optional"`
+	ApplyHooks    pulumi.BoolInput         `pulumi:"applyHooks,optional"`
+	PostRenderer  helmv4.PostRendererInput `pulumi:"postRenderer,optional"`
+	PostRenderers pulumi.ArrayInput        `pulumi:"postRenderers,optional"`
 
 	ResourcePrefix pulumi.StringInput `pulumi:"resourcePrefix,optional"`
 	SkipAwait      pulumi.BoolInput   `pulumi:"skipAwait,optional"`
 	PlainHTTP      pulumi.BoolInput   `pulumi:"plainHttp,optional"`
+
+	AllowClusterLookup pulumi.MapInput `pulumi:"allowClusterLookup,optional"`
+
+	Include pulumi.ArrayInput `pulumi:"include,optional"`
+	Exclude pulumi.ArrayInput `pulumi:"exclude,optional"`
 }
 
 type chartArgs struct {
@@ -75,26 +90,39 @@ type chartArgs struct {
 	Devel            bool
 	RepositoryOpts   helmv4.RepositoryOpts
 	DependencyUpdate bool
+	DependencyMode   string
 	Verify           bool
 	Keyring          pulumi.Asset
 
-	Values       map[string]any
-	ValuesFiles  []pulumi.Asset
-	SkipCrds     bool
-	IncludeHooks bool
-	PostRenderer *helmv4.PostRenderer
+	Values        map[string]any
+	ValuesFiles   []pulumi.Asset
+	ValuesFrom    []kubehelm.ValuesReference
+	SkipCrds      bool
+	CrdPolicy     string
+	IncludeHooks  bool
+	ApplyHooks    bool
+	PostRenderer  *helmv4.PostRenderer
+	PostRenderers []kubehelm.BuiltinPostRenderer
 
 	ResourcePrefix *string
 	SkipAwait      bool
 	PlainHTTP      bool
+
+	AllowClusterLookup *kubehelm.ClusterLookup
+
+	Selection *provideryamlv2.Selection
 }
 
 func unwrapChartArgs(ctx context.Context, args *ChartArgs) (*chartArgs, internals.UnsafeAwaitOutputResult, error) {
 	result, err := internals.UnsafeAwaitOutput(ctx, pulumi.All(
 		args.Name, args.Namespace,
-		args.Chart, args.Version, args.Devel, args.RepositoryOpts, args.DependencyUpdate, args.Verify, args.Keyring,
-		args.Values, args.ValuesFiles, args.SkipCrds, args.IncludeHooks, args.PostRenderer,
-		args.ResourcePrefix, args.SkipAwait, args.PlainHTTP))
+		args.Chart, args.Version, args.Devel, args.RepositoryOpts, args.DependencyUpdate, args.DependencyMode,
+		args.Verify, args.Keyring,
+		args.Values, args.ValuesFiles, args.ValuesFrom, args.SkipCrds, args.CrdPolicy,
+		args.IncludeHooks, args.ApplyHooks, args.PostRenderer, args.PostRenderers,
+		args.ResourcePrefix, args.SkipAwait, args.PlainHTTP,
+		args.AllowClusterLookup,
+		args.Include, args.Exclude))
 	if err != nil || !result.Known {
 		return nil, result, err
 	}
@@ -112,16 +140,29 @@ func unwrapChartArgs(ctx context.Context, args *ChartArgs) (*chartArgs, internal
 	r.Devel, _ = pop().(bool)
 	r.RepositoryOpts, _ = pop().(helmv4.RepositoryOpts)
 	r.DependencyUpdate, _ = pop().(bool)
+	r.DependencyMode, _ = pop().(string)
 	r.Verify, _ = pop().(bool)
 	r.Keyring, _ = pop().(pulumi.Asset)
 
 	r.Values, _ = pop().(map[string]any)
 	r.ValuesFiles, _ = pop().([]pulumi.Asset)
+	if v, ok := pop().([]any); ok {
+		if err := mapstructure.Decode(v, &r.ValuesFrom); err != nil {
+			return nil, result, fmt.Errorf("valuesFrom: %w", err)
+		}
+	}
 	r.SkipCrds, _ = pop().(bool)
+	r.CrdPolicy, _ = pop().(string)
 	r.IncludeHooks, _ = pop().(bool)
+	r.ApplyHooks, _ = pop().(bool)
 	if v, ok := pop().(helmv4.PostRenderer); ok {
 		r.PostRenderer = &v
 	}
+	if v, ok := pop().([]any); ok {
+		if err := mapstructure.Decode(v, &r.PostRenderers); err != nil {
+			return nil, result, fmt.Errorf("postRenderers: %w", err)
+		}
+	}
 
 	if v, ok := pop().(string); ok {
 		r.ResourcePrefix = &v
@@ -129,12 +170,27 @@ func unwrapChartArgs(ctx context.Context, args *ChartArgs) (*chartArgs, internal
 	r.SkipAwait, _ = pop().(bool)
 	r.PlainHTTP, _ = pop().(bool)
 
+	if v, ok := pop().(map[string]any); ok {
+		r.AllowClusterLookup = &kubehelm.ClusterLookup{}
+		if err := mapstructure.Decode(v, r.AllowClusterLookup); err != nil {
+			return nil, result, fmt.Errorf("allowClusterLookup: %w", err)
+		}
+	}
+
+	include, _ := pop().([]any)
+	exclude, _ := pop().([]any)
+	if r.Selection, err = provideryamlv2.DecodeSelection(include, exclude); err != nil {
+		return nil, result, err
+	}
+
 	return r, result, nil
 }
 
 type ChartState struct {
 	pulumi.ResourceState
-	Resources pulumi.ArrayOutput `pulumi:"resources"`
+	Resources pulumi.ArrayOutput       `pulumi:"resources"`
+	Notes     pulumi.StringOutput      `pulumi:"notes"`
+	Skipped   pulumi.StringArrayOutput `pulumi:"skipped"`
 }
 
 var _ providerresource.ResourceProvider = &ChartProvider{}
@@ -185,10 +241,16 @@ func (r *ChartProvider) Construct(
 		// across multiple instances of the component resource.
 		chartArgs.ResourcePrefix = &name
 	}
+	ns := chartArgs.Namespace
+	if ns == "" {
+		ns = r.opts.DefaultNamespace
+	}
 
 	// Prepare the `helm template` command
 	tool := r.tool()
 	tool.HelmDriver = r.opts.HelmOptions.HelmDriver
+	tool.IndexCache = r.opts.HelmOptions.IndexCache
+	tool.LookupCache = r.opts.HelmOptions.LookupCache
 	p := tool.AllGetters()
 	cmd := tool.Template()
 
@@ -213,6 +275,10 @@ func (r *ChartProvider) Construct(
 		return nil, fmt.Errorf("repositoryOpts: %w", err)
 	}
 	cmd.DependencyUpdate = chartArgs.DependencyUpdate
+	if cmd.DependencyMode, err = kubehelm.ParseDependencyMode(chartArgs.DependencyMode); err != nil {
+		return nil, fmt.Errorf("dependencyMode: %w", err)
+	}
+	cmd.Preview = ctx.DryRun()
 	cmd.Verify = chartArgs.Verify
 
 	if chartArgs.Keyring != nil {
@@ -226,23 +292,52 @@ func (r *ChartProvider) Construct(
 	// set templating options
 	cmd.Values.Values = chartArgs.Values
 	cmd.Values.ValuesFiles = chartArgs.ValuesFiles
-	cmd.IncludeCRDs = !chartArgs.SkipCrds
+	referencedValues, err := kubehelm.ReadValuesFrom(ctx.Context(), r.opts.ClientSet.GenericClient, ns,
+		chartArgs.ValuesFrom)
+	if err != nil {
+		return nil, err
+	}
+	cmd.Values.ReferencedValues = referencedValues.Values
+	if err := validateCRDPolicy(chartArgs.CrdPolicy, chartArgs.SkipCrds); err != nil {
+		return nil, fmt.Errorf("crdPolicy: %w", err)
+	}
+	// With a CRD policy, the CRDs are registered apart from the rest of the chart (see below).
+	cmd.IncludeCRDs = !chartArgs.SkipCrds && chartArgs.CrdPolicy == ""
 	cmd.DisableHooks = true
 	cmd.ReleaseName = chartArgs.Name
 	cmd.Namespace = chartArgs.Namespace
 	cmd.PlainHTTP = chartArgs.PlainHTTP
+	// Scope the `lookup` function to the allowed kinds and namespaces, rather than giving it the
+	// provider's access to the cluster.
+	cmd.ClusterLookup = chartArgs.AllowClusterLookup
 
+	var postRenderers kubehelm.ChainPostRenderer
 	if chartArgs.PostRenderer != nil {
 		postrenderer, err := postrender.NewExec(chartArgs.PostRenderer.Command, chartArgs.PostRenderer.Args...)
 		if err != nil {
 			return nil, err
 		}
-		cmd.PostRenderer = postrenderer
+		postRenderers = append(postRenderers, postrenderer)
+	}
+	for i, spec := range chartArgs.PostRenderers {
+		postrenderer, err := spec.PostRenderer()
+		if err != nil {
+			return nil, fmt.Errorf("postRenderers[%d]: %w", i, err)
+		}
+		postRenderers = append(postRenderers, postrenderer)
+	}
+	if len(postRenderers) > 0 {
+		cmd.PostRenderer = postRenderers
 	}
 
 	// Execute the Helm command
 	release, err := cmd.Execute(ctx.Context())
 	if err != nil {
+		var schemaErr *kubehelm.ValuesSchemaError
+		if errors.As(err, &schemaErr) {
+			// The offending values mustn't be shown if they may be secret.
+			return nil, valuesSchemaInputError(schemaErr, result.Secret || referencedValues.HasSecrets())
+		}
 		return nil, err
 	}
 
@@ -252,6 +347,17 @@ func (r *ChartProvider) Construct(
 		})
 	}
 
+	// The values read from Secrets (by the `lookup` function or by `valuesFrom`) are sensitive wherever
+	// they appear in the rendered objects. Only the values of `valuesFrom` that make it into the release's
+	// values count, rather than those overridden by other values.
+	secretValues := append(cmd.LookupSecrets(), referencedValues.Secrets(release.Config)...)
+
+	// Expose the rendered NOTES.txt, as a secret if it may contain secret values.
+	comp.Notes = pulumi.String(release.Info.Notes).ToStringOutputWithContext(ctx.Context())
+	if result.Secret || containsSecret(release.Info.Notes, secretValues) {
+		comp.Notes = pulumi.ToSecret(comp.Notes).(pulumi.StringOutput)
+	}
+
 	// Parse the YAML file into an array of Kubernetes objects.
 	//
 	// Helm hook resources (those annotated with `helm.sh/hook`) are separated
@@ -263,17 +369,22 @@ func (r *ChartProvider) Construct(
 	// rendered manifests. Test hooks (`helm.sh/hook: test`) are always excluded
 	// since they are not part of a normal deployment.
 	//
-	// Outside of render mode hooks are not supported (they would be registered as
-	// ordinary Pulumi-managed resources with no hook semantics), so `includeHooks`
-	// is ignored there and a warning is emitted.
+	// Outside of render mode, the install or upgrade hooks are registered with
+	// hook semantics when the user opts in via `applyHooks` (see registerWithHooks).
+	// Otherwise `includeHooks` is ignored there and a warning is emitted.
 	manifest := release.Manifest
-	if chartArgs.IncludeHooks {
-		if r.opts.RenderYAMLToDirectory {
+	withHooks := false
+	switch {
+	case r.opts.RenderYAMLToDirectory:
+		if chartArgs.IncludeHooks {
 			manifest = manifestWithHooks(release)
-		} else {
-			_ = ctx.Log.Warn("includeHooks is only supported when the provider is configured with "+
-				"renderYamlToDirectory; Helm hooks will be ignored.", &pulumi.LogArgs{Resource: comp})
 		}
+	case chartArgs.ApplyHooks:
+		withHooks = true
+	case chartArgs.IncludeHooks:
+		_ = ctx.Log.Warn("includeHooks is only supported when the provider is configured with "+
+			"renderYamlToDirectory; Helm hooks will be ignored. Set applyHooks to apply them.",
+			&pulumi.LogArgs{Resource: comp})
 	}
 	parseOpts := provideryamlv2.ParseOptions{
 		YAML: manifest,
@@ -284,42 +395,76 @@ func (r *ChartProvider) Construct(
 	}
 
 	// Normalize the objects (apply a default namespace, etc.)
-	ns := chartArgs.Namespace
-	if ns == "" {
-		ns = r.opts.DefaultNamespace
-	}
 	objs, unresolvedScope, err := provideryamlv2.Normalize(objs, ns, r.opts.ClientSet)
 	if err != nil {
 		return nil, err
 	}
 	provideryamlv2.WarnUnresolvedNamespaceScope(ctx, unresolvedScope)
 
-	// Register the objects as Pulumi resources.
+	// Select the objects to register. The CRDs and hooks are selected likewise, below.
+	selection := chartArgs.Selection
+	objs = selection.Select(objs)
+
+	// Register the objects as Pulumi resources. The objects of each step (CRDs, hooks and the release) may
+	// depend on those of the previous steps by the depends-on annotation.
 	registerOpts := provideryamlv2.RegisterOptions{
 		Objects:         objs,
 		ResourcePrefix:  *chartArgs.ResourcePrefix,
 		SkipAwait:       chartArgs.SkipAwait,
 		ResourceOptions: []pulumi.ResourceOption{pulumi.Parent(comp)},
+		Registered:      map[cliutilsobject.ObjMetadata]pulumi.Resource{},
 		PreRegisterF: func(
 			ctx *pulumi.Context,
 			_ /* apiVersion */, _ /* kind */, _ /* resourceName */ string,
 			obj *unstructured.Unstructured,
 			resourceOpts []pulumi.ResourceOption,
 		) (*unstructured.Unstructured, []pulumi.ResourceOption) {
-			return preregister(ctx, comp, obj, resourceOpts)
+			return preregister(ctx, comp, obj, resourceOpts, secretValues)
 		},
 	}
-	resources, err := provideryamlv2.Register(ctx, registerOpts)
+
+	// Register the CRDs according to the CRD policy, and make the rest of the chart depend on them, so
+	// that the CRDs are applied first.
+	var crdResources []pulumi.ArrayOutput
+	if chartArgs.CrdPolicy != "" && chartArgs.CrdPolicy != crdPolicySkip {
+		crds, err := parseCRDs(ctx.Context(), release.Chart, ns, r.opts.ClientSet)
+		if err != nil {
+			return nil, err
+		}
+		crds = selection.Select(crds)
+		if len(crds) > 0 {
+			crdOpts := registerOpts
+			crdOpts.Objects = crds
+			resources, err := registerCRDs(ctx, chartArgs.CrdPolicy, r.opts.ClientSet, crdOpts)
+			if err != nil {
+				return nil, err
+			}
+			crdResources = append(crdResources, resources)
+			registerOpts.ResourceOptions = append(registerOpts.ResourceOptions,
+				pulumi.DependsOnInputs(resourceArray(crdResources)))
+		}
+	}
+
+	var resources pulumi.ArrayOutput
+	if withHooks {
+		resources, err = registerWithHooks(ctx, comp, release, ns, r.opts.ClientSet, selection, registerOpts)
+	} else {
+		resources, err = provideryamlv2.Register(ctx, registerOpts)
+	}
 	if err != nil {
 		return nil, err
 	}
+	if len(crdResources) > 0 {
+		resources = flatten(append(crdResources, resources))
+	}
 	comp.Resources = resources
+	comp.Skipped = pulumi.ToStringArray(selection.Skipped()).ToStringArrayOutputWithContext(ctx.Context())
 
 	return pulumiprovider.NewConstructResult(comp)
 }
 
 func preregister(ctx *pulumi.Context, comp *ChartState, obj *unstructured.Unstructured,
-	resourceOpts []pulumi.ResourceOption,
+	resourceOpts []pulumi.ResourceOption, secretValues []string,
 ) (*unstructured.Unstructured, []pulumi.ResourceOption) {
 	// Implement support for Helm resource policies.
 	// https://helm.sh/docs/howto/charts_tips_and_tricks/#tell-helm-not-to-uninstall-a-resource
@@ -340,9 +485,61 @@ func preregister(ctx *pulumi.Context, comp *ChartState, obj *unstructured.Unstru
 		}
 	}
 
+	if len(secretValues) > 0 {
+		for k, v := range obj.Object {
+			switch k {
+			case "apiVersion", "kind", "metadata":
+				// The identity of the object is never secret.
+			default:
+				obj.Object[k] = markSecrets(v, secretValues)
+			}
+		}
+	}
+
 	return obj, resourceOpts
 }
 
+// minEmbeddedSecretLength is the minimum length of a secret value for the strings that merely contain it (e.g. a
+// connection URL that embeds a password) to be considered secret. Shorter values (e.g. "1" or "admin") would
+// otherwise mark unrelated strings as secret.
+const minEmbeddedSecretLength = 8
+
+// containsSecret reports whether the string is one of the given secret values, or embeds one that is at least
+// minEmbeddedSecretLength long.
+func containsSecret(s string, secretValues []string) bool {
+	for _, v := range secretValues {
+		if s == v || (len(v) >= minEmbeddedSecretLength && strings.Contains(s, v)) {
+			return true
+		}
+	}
+	return false
+}
+
+// markSecrets marks the strings that contain a secret value (see containsSecret), and the numbers that equal one,
+// as secret, e.g. a password that a chart reuses from an existing Secret in a ConfigMap or an environment variable.
+func markSecrets(v any, secretValues []string) any {
+	switch v := v.(type) {
+	case map[string]any:
+		for k, e := range v {
+			v[k] = markSecrets(e, secretValues)
+		}
+	case []any:
+		for i, e := range v {
+			v[i] = markSecrets(e, secretValues)
+		}
+	case string:
+		if containsSecret(v, secretValues) {
+			return pulumi.ToSecret(v)
+		}
+	default:
+		// A number is secret if it equals a secret value, e.g. a port read from a Secret.
+		if n, ok := kubehelm.ScalarString(v); ok && slices.Contains(secretValues, n) {
+			return pulumi.ToSecret(v)
+		}
+	}
+	return v
+}
+
 // isTestHook reports whether the given Helm hook fires on the test event.
 func isTestHook(h *release.Hook) bool {
 	for _, e := range h.Events {
@@ -400,3 +597,19 @@ func setKubeVersionAndAPIVersions(clientSet *clients.DynamicClientSet, cmd *kube
 
 	return nil
 }
+
+// valuesSchemaInputError converts a values schema error into an input error, with a property error
+// for each violation. With redact, the errors don't show the offending values.
+func valuesSchemaInputError(err *kubehelm.ValuesSchemaError, redact bool) error {
+	details := make([]pulumierrors.InputPropertyErrorDetails, 0, len(err.Violations))
+	for _, v := range err.Violations {
+		if redact {
+			v = v.Redact()
+		}
+		details = append(details, pulumierrors.InputPropertyErrorDetails{
+			PropertyPath: v.PropertyPath("values"),
+			Reason:       fmt.Sprintf("%s (chart %q)", v.Message, v.Chart),
+		})
+	}
+	return pulumierrors.NewInputPropertiesError("values don't meet the specifications of the chart's schema", details...)
+}
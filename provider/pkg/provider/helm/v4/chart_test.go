@@ -24,9 +24,13 @@ import (
 	gs "github.com/onsi/gomega/gstruct"
 	"helm.sh/helm/v3/pkg/chartutil"
 	"helm.sh/helm/v3/pkg/cli"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 
 	"github.com/pulumi/pulumi/sdk/v3/go/common/resource"
 	"github.com/pulumi/pulumi/sdk/v3/go/common/resource/plugin"
+	"github.com/pulumi/pulumi/sdk/v3/go/pulumi"
 	pulumiprovider "github.com/pulumi/pulumi/sdk/v3/go/pulumi/provider"
 	pulumirpc "github.com/pulumi/pulumi/sdk/v3/proto/go"
 
@@ -124,6 +128,7 @@ var _ = gk.Describe("Construct", func() {
 				outputs := unmarshalProperties(gk.GinkgoTB(), resp.State)
 				gm.Expect(outputs).To(pgm.MatchProps(gs.IgnoreExtras, pgm.Props{
 					"resources": pgm.BeComputed(),
+					"notes":     pgm.BeComputed(),
 				}))
 			})
 		})
@@ -156,6 +161,32 @@ var _ = gk.Describe("Construct", func() {
 				gm.Expect(executor.Action().APIVersions).To(gm.Not(gm.BeEmpty()))
 			})
 		})
+		gk.Describe("Cluster lookup", func() {
+			gk.Context("given allowClusterLookup", func() {
+				gk.BeforeEach(func() {
+					inputs["allowClusterLookup"] = resource.NewObjectProperty(resource.NewPropertyMapFromMap(map[string]any{
+						"kinds":      []any{"Secret", "ConfigMap"},
+						"namespaces": []any{"default"},
+					}))
+				})
+				gk.It("should use server-side dry-run mode", func(ctx context.Context) {
+					_, err := pulumiprovider.Construct(ctx, req, tc.EngineConn(), k.Construct)
+					gm.Expect(err).ShouldNot(gm.HaveOccurred())
+					gm.Expect(executor.Action().DryRunOption).To(gm.Equal("server"))
+				})
+			})
+			gk.Context("given an invalid allowClusterLookup", func() {
+				gk.BeforeEach(func() {
+					inputs["allowClusterLookup"] = resource.NewObjectProperty(resource.NewPropertyMapFromMap(map[string]any{
+						"kinds": 42,
+					}))
+				})
+				gk.It("should fail", func(ctx context.Context) {
+					_, err := pulumiprovider.Construct(ctx, req, tc.EngineConn(), k.Construct)
+					gm.Expect(err).To(gm.MatchError(gm.ContainSubstring("allowClusterLookup")))
+				})
+			})
+		})
 	})
 
 	gk.Describe("Chart Resolution", func() {
@@ -230,6 +261,28 @@ var _ = gk.Describe("Construct", func() {
 				gm.Expect(locator.Action().DependencyUpdate).To(gm.BeTrue())
 			})
 		})
+
+		gk.Describe("DependencyMode", func() {
+			gk.Context("when the mode is offline", func() {
+				gk.BeforeEach(func() {
+					inputs["dependencyMode"] = resource.NewStringProperty("offline")
+				})
+				gk.It("should render the chart", func(ctx context.Context) {
+					_, err := pulumiprovider.Construct(ctx, req, tc.EngineConn(), k.Construct)
+					gm.Expect(err).ShouldNot(gm.HaveOccurred())
+					gm.Expect(executor.Action()).ToNot(gm.BeNil())
+				})
+			})
+			gk.Context("when the mode is unsupported", func() {
+				gk.BeforeEach(func() {
+					inputs["dependencyMode"] = resource.NewStringProperty("online")
+				})
+				gk.It("should fail", func(ctx context.Context) {
+					_, err := pulumiprovider.Construct(ctx, req, tc.EngineConn(), k.Construct)
+					gm.Expect(err).To(gm.MatchError(gm.ContainSubstring(`unsupported dependency mode "online"`)))
+				})
+			})
+		})
 	})
 
 	gk.Describe("Values", func() {
@@ -263,6 +316,76 @@ var _ = gk.Describe("Construct", func() {
 				gm.Expect(executor.Values()).To(gm.HaveKeyWithValue("fullnameOverride", "overridden"))
 			})
 		})
+
+		gk.Describe("Values From", func() {
+			gk.BeforeEach(func() {
+				opts.ClientSet, _, _, _ = fake.NewSimpleDynamicClient(fake.WithObjects(
+					&corev1.ConfigMap{
+						ObjectMeta: metav1.ObjectMeta{Name: "shared", Namespace: "default"},
+						Data: map[string]string{
+							"values.yaml": "fullnameOverride: shared\nnameOverride: shared\n",
+						},
+					},
+				))
+				inputs["valuesFrom"] = resource.NewArrayProperty([]resource.PropertyValue{
+					resource.NewObjectProperty(resource.NewPropertyMapFromMap(map[string]any{
+						"kind": "ConfigMap",
+						"name": "shared",
+					})),
+				})
+				inputs["values"] = resource.NewObjectProperty(resource.NewPropertyMapFromMap(map[string]any{
+					"fullnameOverride": "overridden",
+				}))
+			})
+			gk.It("should merge the referenced values before the literal values", func(ctx context.Context) {
+				_, err := pulumiprovider.Construct(ctx, req, tc.EngineConn(), k.Construct)
+				gm.Expect(err).ShouldNot(gm.HaveOccurred())
+				gm.Expect(executor.Values()).To(gm.HaveKeyWithValue("nameOverride", "shared"))
+				gm.Expect(executor.Values()).To(gm.HaveKeyWithValue("fullnameOverride", "overridden"))
+			})
+			gk.Context("given a missing reference", func() {
+				gk.BeforeEach(func() {
+					inputs["valuesFrom"] = resource.NewArrayProperty([]resource.PropertyValue{
+						resource.NewObjectProperty(resource.NewPropertyMapFromMap(map[string]any{
+							"kind": "Secret",
+							"name": "missing",
+						})),
+					})
+				})
+				gk.It("should fail", func(ctx context.Context) {
+					_, err := pulumiprovider.Construct(ctx, req, tc.EngineConn(), k.Construct)
+					gm.Expect(err).To(gm.MatchError(gm.ContainSubstring(
+						"valuesFrom[0]: unable to read Secret default/missing")))
+				})
+			})
+		})
+	})
+
+	gk.Describe("Notes", func() {
+		gk.It("should provide the rendered notes as a 'notes' output property", func(ctx context.Context) {
+			resp, err := pulumiprovider.Construct(ctx, req, tc.EngineConn(), k.Construct)
+			gm.Expect(err).ShouldNot(gm.HaveOccurred())
+			outputs := unmarshalProperties(gk.GinkgoTB(), resp.State)
+			gm.Expect(outputs).To(pgm.MatchProps(gs.IgnoreExtras, pgm.Props{
+				"notes": pgm.MatchValue(gm.ContainSubstring("capabilities:")),
+			}))
+		})
+
+		gk.Context("when the values are secret", func() {
+			gk.BeforeEach(func() {
+				inputs["values"] = resource.MakeSecret(resource.NewObjectProperty(resource.PropertyMap{
+					"fullnameOverride": resource.NewStringProperty("overridden"),
+				}))
+			})
+			gk.It("should provide the notes as a secret", func(ctx context.Context) {
+				resp, err := pulumiprovider.Construct(ctx, req, tc.EngineConn(), k.Construct)
+				gm.Expect(err).ShouldNot(gm.HaveOccurred())
+				outputs := unmarshalProperties(gk.GinkgoTB(), resp.State)
+				gm.Expect(outputs).To(pgm.MatchProps(gs.IgnoreExtras, pgm.Props{
+					"notes": pgm.BeSecret(pgm.MatchValue(gm.ContainSubstring("overridden"))),
+				}))
+			})
+		})
 	})
 
 	gk.Describe("Templating", func() {
@@ -410,6 +533,155 @@ var _ = gk.Describe("Construct", func() {
 			})
 		})
 
+		gk.Describe("CRD Policy", func() {
+			crdURN := "urn:pulumi:stack::project::kubernetes:helm/v4:Chart$" +
+				"kubernetes:apiextensions.k8s.io/v1:CustomResourceDefinition::test:crontabs.stable.example.com"
+			crdPatchURN := "urn:pulumi:stack::project::kubernetes:helm/v4:Chart$" +
+				"kubernetes:apiextensions.k8s.io/v1:CustomResourceDefinitionPatch::test:crontabs.stable.example.com"
+			serviceAccountURN := "urn:pulumi:stack::project::kubernetes:helm/v4:Chart$kubernetes:core/v1:ServiceAccount::" +
+				"test:default/test-reference"
+			aliasOf := func(typ string) gm.OmegaMatcher {
+				return gm.ContainElement(gm.Satisfy(func(a *pulumirpc.Alias) bool {
+					return a.GetSpec().GetType() == typ
+				}))
+			}
+
+			gk.Context("given createReplace", func() {
+				gk.BeforeEach(func() {
+					inputs["crdPolicy"] = resource.NewStringProperty("createReplace")
+				})
+				gk.It("should register the CRDs first, and never delete them", func(ctx context.Context) {
+					_, err := pulumiprovider.Construct(ctx, req, tc.EngineConn(), k.Construct)
+					gm.Expect(err).ShouldNot(gm.HaveOccurred())
+					gm.Expect(executor.Action().IncludeCRDs).To(gm.BeFalse())
+					gm.Expect(tc.monitor.Registrations()).To(gs.MatchKeys(gs.IgnoreExtras, gs.Keys{
+						crdURN: gs.MatchFields(gs.IgnoreExtras, gs.Fields{
+							"Request": gs.MatchFields(gs.IgnoreExtras, gs.Fields{
+								"RetainOnDelete": gs.PointTo(gm.BeTrue()),
+								"IgnoreChanges":  gm.BeEmpty(),
+								"Aliases":        aliasOf(crdPatchType),
+							}),
+						}),
+						serviceAccountURN: gs.MatchFields(gs.IgnoreExtras, gs.Fields{
+							"Request": gs.MatchFields(gs.IgnoreExtras, gs.Fields{
+								"Dependencies": gm.ContainElement(crdURN),
+							}),
+						}),
+					}))
+				})
+			})
+
+			gk.Context("given create", func() {
+				gk.BeforeEach(func() {
+					inputs["crdPolicy"] = resource.NewStringProperty("create")
+				})
+				gk.It("should not update the CRDs", func(ctx context.Context) {
+					_, err := pulumiprovider.Construct(ctx, req, tc.EngineConn(), k.Construct)
+					gm.Expect(err).ShouldNot(gm.HaveOccurred())
+					gm.Expect(tc.monitor.Registrations()).To(gs.MatchKeys(gs.IgnoreExtras, gs.Keys{
+						crdURN: gs.MatchFields(gs.IgnoreExtras, gs.Fields{
+							"Request": gs.MatchFields(gs.IgnoreExtras, gs.Fields{
+								"RetainOnDelete": gs.PointTo(gm.BeTrue()),
+								"IgnoreChanges":  gm.ConsistOf("metadata", "spec"),
+							}),
+						}),
+					}))
+				})
+
+				existingCRD := func(manager string) *unstructured.Unstructured {
+					crd := &unstructured.Unstructured{}
+					crd.SetAPIVersion("apiextensions.k8s.io/v1")
+					crd.SetKind("CustomResourceDefinition")
+					crd.SetName("crontabs.stable.example.com")
+					crd.SetManagedFields([]metav1.ManagedFieldsEntry{
+						{Manager: manager, Operation: metav1.ManagedFieldsOperationApply},
+					})
+					return crd
+				}
+
+				gk.Context("given a CRD that exists already", func() {
+					gk.BeforeEach(func() {
+						opts.ClientSet, _, _, _ = fake.NewSimpleDynamicClient(fake.WithObjects(existingCRD("kubectl")))
+					})
+					gk.It("should leave the CRD alone", func(ctx context.Context) {
+						_, err := pulumiprovider.Construct(ctx, req, tc.EngineConn(), k.Construct)
+						gm.Expect(err).ShouldNot(gm.HaveOccurred())
+						gm.Expect(tc.monitor.Registrations()).ToNot(gm.HaveKey(crdURN))
+						gm.Expect(tc.monitor.Registrations()).To(gm.HaveKey(serviceAccountURN))
+					})
+				})
+
+				gk.Context("given a CRD that Pulumi manages already", func() {
+					gk.BeforeEach(func() {
+						opts.ClientSet, _, _, _ = fake.NewSimpleDynamicClient(
+							fake.WithObjects(existingCRD("pulumi-kubernetes-a1b2c3d4")))
+					})
+					gk.It("should keep registering the CRD", func(ctx context.Context) {
+						_, err := pulumiprovider.Construct(ctx, req, tc.EngineConn(), k.Construct)
+						gm.Expect(err).ShouldNot(gm.HaveOccurred())
+						gm.Expect(tc.monitor.Registrations()).To(gm.HaveKey(crdURN))
+					})
+				})
+			})
+
+			gk.Context("given patch", func() {
+				gk.BeforeEach(func() {
+					inputs["crdPolicy"] = resource.NewStringProperty("patch")
+				})
+				gk.It("should register the CRDs as patches", func(ctx context.Context) {
+					_, err := pulumiprovider.Construct(ctx, req, tc.EngineConn(), k.Construct)
+					gm.Expect(err).ShouldNot(gm.HaveOccurred())
+					gm.Expect(tc.monitor.Registrations()).To(gs.MatchKeys(gs.IgnoreExtras, gs.Keys{
+						crdPatchURN: gs.MatchFields(gs.IgnoreExtras, gs.Fields{
+							"Request": gs.MatchFields(gs.IgnoreExtras, gs.Fields{
+								"RetainOnDelete": gs.PointTo(gm.BeTrue()),
+								"Aliases":        aliasOf(crdType),
+							}),
+						}),
+						serviceAccountURN: gs.MatchFields(gs.IgnoreExtras, gs.Fields{
+							"Request": gs.MatchFields(gs.IgnoreExtras, gs.Fields{
+								"Dependencies": gm.ContainElement(crdPatchURN),
+							}),
+						}),
+					}))
+					gm.Expect(tc.monitor.Registrations()).ToNot(gm.HaveKey(crdURN))
+				})
+			})
+
+			gk.Context("given skip", func() {
+				gk.BeforeEach(func() {
+					inputs["crdPolicy"] = resource.NewStringProperty("skip")
+				})
+				gk.It("should not register the CRDs", func(ctx context.Context) {
+					_, err := pulumiprovider.Construct(ctx, req, tc.EngineConn(), k.Construct)
+					gm.Expect(err).ShouldNot(gm.HaveOccurred())
+					gm.Expect(tc.monitor.Registrations()).ToNot(gm.HaveKey(crdURN))
+					gm.Expect(tc.monitor.Registrations()).To(gm.HaveKey(serviceAccountURN))
+				})
+			})
+
+			gk.Context("given skipCrds", func() {
+				gk.BeforeEach(func() {
+					inputs["crdPolicy"] = resource.NewStringProperty("create")
+					inputs["skipCrds"] = resource.NewBoolProperty(true)
+				})
+				gk.It("should fail", func(ctx context.Context) {
+					_, err := pulumiprovider.Construct(ctx, req, tc.EngineConn(), k.Construct)
+					gm.Expect(err).To(gm.MatchError(gm.ContainSubstring(`crdPolicy: skipCrds conflicts with crdPolicy "create"`)))
+				})
+			})
+
+			gk.Context("given an unsupported policy", func() {
+				gk.BeforeEach(func() {
+					inputs["crdPolicy"] = resource.NewStringProperty("replace")
+				})
+				gk.It("should fail", func(ctx context.Context) {
+					_, err := pulumiprovider.Construct(ctx, req, tc.EngineConn(), k.Construct)
+					gm.Expect(err).To(gm.MatchError(gm.ContainSubstring(`crdPolicy: unsupported CRD policy "replace"`)))
+				})
+			})
+		})
+
 		gk.Describe("Helm hooks", func() {
 			// The reference chart contains a non-test hook (a ConfigMap annotated
 			// "helm.sh/hook": pre-install) and a test hook (a Pod annotated
@@ -491,6 +763,26 @@ var _ = gk.Describe("Construct", func() {
 						gm.Expect(msgs).To(gm.ContainElement(gm.ContainSubstring("includeHooks")))
 					})
 			})
+
+			gk.Context("given applyHooks", func() {
+				gk.BeforeEach(func() {
+					opts.RenderYAMLToDirectory = false
+					inputs["applyHooks"] = resource.NewBoolProperty(true)
+				})
+				gk.It("should register the install hooks along with the normal resources",
+					func(ctx context.Context) {
+						resp, err := pulumiprovider.Construct(ctx, req, tc.EngineConn(), k.Construct)
+						gm.Expect(err).ShouldNot(gm.HaveOccurred())
+						outputs := unmarshalProperties(gk.GinkgoTB(), resp.State)
+						gm.Expect(outputs).To(pgm.MatchProps(gs.IgnoreExtras, pgm.Props{
+							"resources": pgm.MatchArrayValue(gm.And(
+								gm.ContainElement(normalService),
+								gm.ContainElement(preInstallHook),
+								gm.Not(gm.ContainElement(testHook)),
+							)),
+						}))
+					})
+			})
 		})
 
 		gk.Describe("Post Renderer", func() {
@@ -524,6 +816,50 @@ var _ = gk.Describe("Construct", func() {
 					gm.Expect(err).ShouldNot(gm.HaveOccurred())
 				})
 			})
+
+			gk.Context("given a kustomize post-renderer", func() {
+				gk.BeforeEach(func() {
+					inputs["postRenderers"] = resource.NewArrayProperty([]resource.PropertyValue{
+						resource.NewObjectProperty(resource.PropertyMap{
+							"kustomize": resource.NewObjectProperty(resource.PropertyMap{
+								"patches": resource.NewArrayProperty([]resource.PropertyValue{
+									resource.NewObjectProperty(resource.PropertyMap{
+										"patch": resource.NewStringProperty(
+											"apiVersion: v1\nkind: Service\nmetadata:\n  name: test-reference\n$patch: delete\n"),
+									}),
+								}),
+							}),
+						}),
+					})
+				})
+				gk.It("should apply the patches", func(ctx context.Context) {
+					resp, err := pulumiprovider.Construct(ctx, req, tc.EngineConn(), k.Construct)
+					gm.Expect(err).ShouldNot(gm.HaveOccurred())
+					outputs := unmarshalProperties(gk.GinkgoTB(), resp.State)
+					gm.Expect(outputs).To(pgm.MatchProps(gs.IgnoreExtras, pgm.Props{
+						"resources": pgm.MatchArrayValue(gm.Not(gm.ContainElement(
+							pgm.MatchResourceReferenceValue(
+								"urn:pulumi:stack::project::kubernetes:helm/v4:Chart$kubernetes:core/v1:Service::"+
+									"test:default/test-reference",
+								"test:default/test-reference",
+							),
+						))),
+					}))
+				})
+			})
+
+			gk.Context("given an empty post-renderer specification", func() {
+				gk.BeforeEach(func() {
+					inputs["postRenderers"] = resource.NewArrayProperty([]resource.PropertyValue{
+						resource.NewObjectProperty(resource.PropertyMap{}),
+					})
+				})
+				gk.It("should fail", func(ctx context.Context) {
+					_, err := pulumiprovider.Construct(ctx, req, tc.EngineConn(), k.Construct)
+					gm.Expect(err).To(gm.MatchError(gm.ContainSubstring(
+						"postRenderers[0]: one of kustomize or metadata must be specified")))
+				})
+			})
 		})
 	})
 
@@ -662,3 +998,38 @@ var _ = gk.Describe("Construct", func() {
 		})
 	})
 })
+
+var _ = gk.Describe("markSecrets", func() {
+	isSecret := gm.Satisfy(func(v any) bool {
+		_, ok := v.(pulumi.Output)
+		return ok
+	})
+
+	gk.It("should mark the strings that contain a secret value", func() {
+		obj := map[string]any{
+			"data": map[string]any{
+				"password": "hunter2hunter2",
+				"url":      "postgres://admin:hunter2hunter2@db:5432",
+				"user":     "admin",
+				"greeting": "hello, administrator",
+			},
+			"env":      []any{"hunter2hunter2", "other"},
+			"replicas": int64(1),
+			"port":     int64(5432),
+		}
+		for k, v := range obj {
+			obj[k] = markSecrets(v, []string{"hunter2hunter2", "admin", "5432"})
+		}
+		data := obj["data"].(map[string]any)
+		gm.Expect(data["password"]).To(isSecret)
+		gm.Expect(data["url"]).To(isSecret)
+		gm.Expect(data["user"]).To(isSecret)
+		// Short secret values are only matched as a whole.
+		gm.Expect(data["greeting"]).To(gm.Equal("hello, administrator"))
+		env := obj["env"].([]any)
+		gm.Expect(env[0]).To(isSecret)
+		gm.Expect(env[1]).To(gm.Equal("other"))
+		gm.Expect(obj["replicas"]).To(gm.Equal(int64(1)))
+		gm.Expect(obj["port"]).To(isSecret)
+	})
+})
@@ -0,0 +1,182 @@
+// Copyright 2016-2026, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v4
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	helmchart "helm.sh/helm/v3/pkg/chart"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	cliutilsobject "sigs.k8s.io/cli-utils/pkg/object"
+
+	"github.com/pulumi/pulumi/sdk/v3/go/pulumi"
+
+	"github.com/pulumi/pulumi-kubernetes/provider/v4/pkg/clients"
+	provideryamlv2 "github.com/pulumi/pulumi-kubernetes/provider/v4/pkg/provider/yaml/v2"
+	"github.com/pulumi/pulumi-kubernetes/sdk/v4/go/kubernetes"
+	apiextensionsv1 "github.com/pulumi/pulumi-kubernetes/sdk/v4/go/kubernetes/apiextensions/v1"
+)
+
+// The CRD policies govern the CustomResourceDefinitions in the `crds/` directory of a chart, modelled on
+// the CRD policies of a Flux HelmRelease. Without a policy, the CRDs are registered like any other object.
+const (
+	// crdPolicyCreate creates the CRDs, and leaves them unchanged when the chart is upgraded. CRDs that
+	// exist already, and that Pulumi doesn't manage, are left alone.
+	crdPolicyCreate = "create"
+	// crdPolicyCreateReplace creates the CRDs, and updates them when the chart is upgraded.
+	crdPolicyCreateReplace = "createReplace"
+	// crdPolicySkip neither creates nor updates the CRDs.
+	crdPolicySkip = "skip"
+	// crdPolicyPatch applies the CRDs as server-side apply patches, so that only the fields set by the
+	// chart are managed, e.g. for CRDs shared with other tools.
+	crdPolicyPatch = "patch"
+)
+
+// validateCRDPolicy checks the CRD policy of the chart, which must agree with skipCrds.
+func validateCRDPolicy(policy string, skipCrds bool) error {
+	switch policy {
+	case "", crdPolicySkip:
+		return nil
+	case crdPolicyCreate, crdPolicyCreateReplace, crdPolicyPatch:
+		if skipCrds {
+			return fmt.Errorf("skipCrds conflicts with crdPolicy %q", policy)
+		}
+		return nil
+	default:
+		return fmt.Errorf("unsupported CRD policy %q (expected one of %q, %q, %q or %q)",
+			policy, crdPolicyCreate, crdPolicyCreateReplace, crdPolicySkip, crdPolicyPatch)
+	}
+}
+
+// parseCRDs parses the CRDs in the `crds/` directories of the chart and its dependencies.
+func parseCRDs(
+	ctx context.Context, chart *helmchart.Chart, ns string, clientSet *clients.DynamicClientSet,
+) ([]unstructured.Unstructured, error) {
+	var crds []unstructured.Unstructured
+	for _, crd := range chart.CRDObjects() {
+		objs, err := provideryamlv2.Parse(ctx, provideryamlv2.ParseOptions{YAML: string(crd.File.Data)})
+		if err != nil {
+			return nil, fmt.Errorf("parsing CRDs from %q: %w", crd.Filename, err)
+		}
+		crds = append(crds, objs...)
+	}
+	crds, _, err := provideryamlv2.Normalize(crds, ns, clientSet)
+	return crds, err
+}
+
+const (
+	crdType      = "kubernetes:apiextensions.k8s.io/v1:CustomResourceDefinition"
+	crdPatchType = "kubernetes:apiextensions.k8s.io/v1:CustomResourceDefinitionPatch"
+)
+
+// registerCRDs registers the CRDs according to the CRD policy. The CRDs are never deleted, neither when
+// a new version of the chart drops them nor when the chart is deleted, since that would delete all of
+// their custom resources too. Helm doesn't delete CRDs either. The CRDs are aliased to the resources of
+// the other policies (i.e. CustomResourceDefinition or CustomResourceDefinitionPatch), so that they are
+// carried over rather than replaced when the policy changes.
+func registerCRDs(
+	ctx *pulumi.Context, policy string, clientSet *clients.DynamicClientSet, opts provideryamlv2.RegisterOptions,
+) (pulumi.ArrayOutput, error) {
+	opts.ResourceOptions = append(slices.Clone(opts.ResourceOptions), pulumi.RetainOnDelete(true))
+	switch policy {
+	case crdPolicyCreate:
+		opts.Objects = slices.DeleteFunc(slices.Clone(opts.Objects), func(crd unstructured.Unstructured) bool {
+			if existsUnmanaged(ctx.Context(), clientSet, &crd) {
+				_ = ctx.Log.Info(fmt.Sprintf("CustomResourceDefinition %q exists already and will be left as is",
+					crd.GetName()), nil)
+				return true
+			}
+			return false
+		})
+		if len(opts.Objects) == 0 {
+			return pulumi.Array{}.ToArrayOutputWithContext(ctx.Context()), nil
+		}
+		opts.ResourceOptions = append(opts.ResourceOptions,
+			pulumi.IgnoreChanges([]string{"metadata", "spec"}),
+			pulumi.Aliases([]pulumi.Alias{{Type: pulumi.String(crdPatchType)}}))
+		return provideryamlv2.Register(ctx, opts)
+	case crdPolicyCreateReplace:
+		opts.ResourceOptions = append(opts.ResourceOptions,
+			pulumi.Aliases([]pulumi.Alias{{Type: pulumi.String(crdPatchType)}}))
+		return provideryamlv2.Register(ctx, opts)
+	case crdPolicyPatch:
+		opts.ResourceOptions = append(opts.ResourceOptions,
+			pulumi.Aliases([]pulumi.Alias{{Type: pulumi.String(crdType)}}))
+		return registerCRDPatches(ctx, opts)
+	default:
+		return pulumi.ArrayOutput{}, fmt.Errorf("unsupported CRD policy %q", policy)
+	}
+}
+
+// existsUnmanaged reports whether the object exists in the cluster, and isn't managed by Pulumi (judging by its
+// field managers), e.g. a CRD installed by another tool. The create policy leaves such CRDs alone, rather than
+// adopting them, as Flux does. The object is assumed not to exist if the cluster is unreachable.
+func existsUnmanaged(ctx context.Context, clientSet *clients.DynamicClientSet, obj *unstructured.Unstructured) bool {
+	if clientSet == nil || clientSet.GenericClient == nil || clientSet.RESTMapper == nil {
+		return false
+	}
+	client, err := clientSet.ResourceClientForObject(obj)
+	if err != nil {
+		return false
+	}
+	live, err := client.Get(ctx, obj.GetName(), metav1.GetOptions{})
+	if err != nil {
+		return false
+	}
+	for _, f := range live.GetManagedFields() {
+		if f.Manager == "pulumi-kubernetes" || strings.HasPrefix(f.Manager, "pulumi-kubernetes-") {
+			return false
+		}
+	}
+	return true
+}
+
+// registerCRDPatches registers the CRDs as CustomResourceDefinitionPatch resources, subject to the
+// PreRegisterF of the options, as Register does.
+func registerCRDPatches(ctx *pulumi.Context, opts provideryamlv2.RegisterOptions) (pulumi.ArrayOutput, error) {
+	resources := pulumi.Array{}
+	for i := range opts.Objects {
+		obj := &opts.Objects[i]
+		if obj.GetAPIVersion() != "apiextensions.k8s.io/v1" || obj.GetKind() != "CustomResourceDefinition" {
+			return pulumi.ArrayOutput{}, fmt.Errorf(
+				"the patch CRD policy only supports apiextensions.k8s.io/v1 CustomResourceDefinitions, got %s %q",
+				obj.GetAPIVersion(), obj.GetName())
+		}
+		resourceName := obj.GetName()
+		if opts.ResourcePrefix != "" {
+			resourceName = fmt.Sprintf("%s:%s", opts.ResourcePrefix, resourceName)
+		}
+		resourceOpts := slices.Clone(opts.ResourceOptions)
+		if opts.PreRegisterF != nil {
+			obj, resourceOpts = opts.PreRegisterF(ctx, obj.GetAPIVersion(), obj.GetKind(), resourceName, obj,
+				resourceOpts)
+		}
+		var res apiextensionsv1.CustomResourceDefinitionPatch
+		err := ctx.RegisterResource(crdPatchType, resourceName, kubernetes.UntypedArgs(obj.Object), &res,
+			resourceOpts...)
+		if err != nil {
+			return pulumi.ArrayOutput{}, err
+		}
+		resources = append(resources, pulumi.NewResourceOutput(&res))
+		if opts.Registered != nil {
+			opts.Registered[cliutilsobject.UnstructuredToObjMetadata(obj)] = &res
+		}
+	}
+	return resources.ToArrayOutputWithContext(ctx.Context()), nil
+}
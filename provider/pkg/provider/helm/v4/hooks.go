@@ -0,0 +1,275 @@
+// Copyright 2016-2026, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v4
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"slices"
+	"sort"
+
+	"helm.sh/helm/v3/pkg/release"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"github.com/pulumi/pulumi/sdk/v3/go/pulumi"
+
+	"github.com/pulumi/pulumi-kubernetes/provider/v4/pkg/clients"
+	provideryamlv2 "github.com/pulumi/pulumi-kubernetes/provider/v4/pkg/provider/yaml/v2"
+)
+
+// hookPhases groups the hooks that apply to an install (or to an upgrade) into a pre-phase and a post-phase.
+// Each phase is a list of weight groups, ordered by weight (and then by name, as Helm does). Hooks that fire on
+// neither install nor upgrade (i.e. delete and rollback hooks) are returned separately since they can't be
+// honored. Test hooks are dropped.
+func hookPhases(hooks []*release.Hook, upgrade bool) (pre, post [][]*release.Hook, unsupported []*release.Hook) {
+	sorted := slices.Clone(hooks)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		if sorted[i].Weight == sorted[j].Weight {
+			return sorted[i].Name < sorted[j].Name
+		}
+		return sorted[i].Weight < sorted[j].Weight
+	})
+
+	group := func(groups [][]*release.Hook, h *release.Hook) [][]*release.Hook {
+		if n := len(groups); n > 0 && groups[n-1][0].Weight == h.Weight {
+			groups[n-1] = append(groups[n-1], h)
+			return groups
+		}
+		return append(groups, []*release.Hook{h})
+	}
+
+	preEvent, postEvent := release.HookPreInstall, release.HookPostInstall
+	if upgrade {
+		preEvent, postEvent = release.HookPreUpgrade, release.HookPostUpgrade
+	}
+	for _, h := range sorted {
+		switch {
+		case isTestHook(h):
+		case hasHookEvent(h, preEvent):
+			pre = group(pre, h)
+		case hasHookEvent(h, postEvent):
+			post = group(post, h)
+		case !hasHookEvent(h, release.HookPreInstall, release.HookPostInstall,
+			release.HookPreUpgrade, release.HookPostUpgrade):
+			unsupported = append(unsupported, h)
+		}
+	}
+	return pre, post, unsupported
+}
+
+// hasHookEvent reports whether the hook fires on any of the given events.
+func hasHookEvent(h *release.Hook, events ...release.HookEvent) bool {
+	for _, e := range h.Events {
+		if slices.Contains(events, e) {
+			return true
+		}
+	}
+	return false
+}
+
+// hookResourceOptions returns the resource options that implement the semantics of the hook:
+//   - a hook that fires on upgrade is replaced whenever the release changes, so that it runs again
+//     (e.g. a migration Job). The previous hook resource is deleted before it is replaced, as with the
+//     default delete policy (before-hook-creation), since the new one has the same name.
+//   - a hook that fires only on install runs once, when it is first created. Its resources are retained
+//     when the hook is no longer registered, once the release is upgraded, as Helm leaves them.
+func hookResourceOptions(h *release.Hook, revision string) []pulumi.ResourceOption {
+	if hasHookEvent(h, release.HookPreUpgrade, release.HookPostUpgrade) {
+		return []pulumi.ResourceOption{
+			pulumi.ReplacementTrigger(pulumi.String(revision)),
+			pulumi.DeleteBeforeReplace(true),
+		}
+	}
+	return []pulumi.ResourceOption{pulumi.RetainOnDelete(true)}
+}
+
+// unsupportedDeletePolicies returns the delete policies of the hook that can't be honored: those that delete the
+// hook's resources once it has run.
+func unsupportedDeletePolicies(h *release.Hook) []release.HookDeletePolicy {
+	var policies []release.HookDeletePolicy
+	for _, p := range h.DeletePolicies {
+		if p != release.HookBeforeHookCreation {
+			policies = append(policies, p)
+		}
+	}
+	return policies
+}
+
+// isUpgrade reports whether the release is upgraded rather than installed, i.e. whether it is deployed already.
+// Helm decides by whether the release exists; lacking a release record, a single object of the release is probed,
+// namely the first one of a known kind, so that a large chart costs one request. The release is installed if the
+// cluster is unreachable.
+func isUpgrade(ctx context.Context, clientSet *clients.DynamicClientSet, objs []unstructured.Unstructured) bool {
+	if clientSet == nil || clientSet.GenericClient == nil || clientSet.RESTMapper == nil {
+		return false
+	}
+	for i := range objs {
+		client, err := clientSet.ResourceClientForObject(&objs[i])
+		if err != nil {
+			// e.g. a custom resource whose CRD is yet to be installed.
+			continue
+		}
+		_, err = client.Get(ctx, objs[i].GetName(), metav1.GetOptions{})
+		return err == nil
+	}
+	return false
+}
+
+// releaseRevision returns a digest of the rendered release, which changes whenever an upgrade would.
+func releaseRevision(rel *release.Release) string {
+	h := sha256.New()
+	h.Write([]byte(rel.Manifest))
+	for _, hook := range rel.Hooks {
+		h.Write([]byte(hook.Manifest))
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// registerWithHooks registers the objects of the release along with its install hooks, or its upgrade hooks if
+// the release is upgraded (see isUpgrade). The pre-hooks are registered first, one weight group after another,
+// then the release's objects, and finally the post-hooks. Each step depends on the previous one, so that the
+// engine waits for the hooks (e.g. Jobs) to become ready before proceeding. The objects of the hooks are subject
+// to the selection.
+func registerWithHooks(
+	ctx *pulumi.Context, comp *ChartState, rel *release.Release,
+	ns string, clientSet *clients.DynamicClientSet, selection *provideryamlv2.Selection,
+	opts provideryamlv2.RegisterOptions,
+) (pulumi.ArrayOutput, error) {
+	pre, post, unsupported := hookPhases(rel.Hooks, isUpgrade(ctx.Context(), clientSet, opts.Objects))
+	for _, h := range unsupported {
+		_ = ctx.Log.Warn(fmt.Sprintf("Helm hook %q (%s) is not supported and will be ignored.", h.Name, h.Path),
+			&pulumi.LogArgs{Resource: comp})
+	}
+	for _, phase := range [][][]*release.Hook{pre, post} {
+		for _, group := range phase {
+			for _, h := range group {
+				for _, p := range unsupportedDeletePolicies(h) {
+					_ = ctx.Log.Warn(fmt.Sprintf("The %q delete policy of Helm hook %q (%s) is not supported "+
+						"and will be ignored.", p, h.Name, h.Path), &pulumi.LogArgs{Resource: comp})
+				}
+			}
+		}
+	}
+	revision := releaseRevision(rel)
+
+	var all []pulumi.ArrayOutput
+	var previous []pulumi.ArrayOutput
+	register := func(objs [][]unstructured.Unstructured, extra ...[]pulumi.ResourceOption) error {
+		var current []pulumi.ArrayOutput
+		for i, o := range objs {
+			if len(o) == 0 {
+				// e.g. a hook whose objects are all excluded by the selection.
+				continue
+			}
+			stepOpts := opts
+			stepOpts.Objects = o
+			stepOpts.ResourceOptions = slices.Clone(opts.ResourceOptions)
+			if len(previous) > 0 {
+				stepOpts.ResourceOptions = append(stepOpts.ResourceOptions,
+					pulumi.DependsOnInputs(resourceArray(previous)))
+			}
+			if len(extra) > i {
+				stepOpts.ResourceOptions = append(stepOpts.ResourceOptions, extra[i]...)
+			}
+			resources, err := provideryamlv2.Register(ctx, stepOpts)
+			if err != nil {
+				return err
+			}
+			current = append(current, resources)
+		}
+		all = append(all, current...)
+		// An empty step (e.g. a weight group of excluded hooks) doesn't order the next one.
+		if len(current) > 0 {
+			previous = current
+		}
+		return nil
+	}
+	registerHooks := func(groups [][]*release.Hook) error {
+		for _, group := range groups {
+			objs := make([][]unstructured.Unstructured, 0, len(group))
+			extra := make([][]pulumi.ResourceOption, 0, len(group))
+			for _, h := range group {
+				o, err := parseHook(ctx, h, ns, clientSet)
+				if err != nil {
+					return err
+				}
+				objs = append(objs, selection.Select(o))
+				extra = append(extra, hookResourceOptions(h, revision))
+			}
+			if err := register(objs, extra...); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	if err := registerHooks(pre); err != nil {
+		return pulumi.ArrayOutput{}, err
+	}
+	if err := register([][]unstructured.Unstructured{opts.Objects}); err != nil {
+		return pulumi.ArrayOutput{}, err
+	}
+	if err := registerHooks(post); err != nil {
+		return pulumi.ArrayOutput{}, err
+	}
+	return flatten(all), nil
+}
+
+// parseHook parses and normalizes the objects of the hook.
+func parseHook(
+	ctx *pulumi.Context, h *release.Hook, ns string, clientSet *clients.DynamicClientSet,
+) ([]unstructured.Unstructured, error) {
+	objs, err := provideryamlv2.Parse(ctx.Context(), provideryamlv2.ParseOptions{YAML: h.Manifest})
+	if err != nil {
+		return nil, fmt.Errorf("parsing Helm hook %q: %w", h.Name, err)
+	}
+	objs, unresolvedScope, err := provideryamlv2.Normalize(objs, ns, clientSet)
+	if err != nil {
+		return nil, err
+	}
+	provideryamlv2.WarnUnresolvedNamespaceScope(ctx, unresolvedScope)
+	return objs, nil
+}
+
+// resourceArray combines the given resource arrays into a single array, e.g. for use with DependsOnInputs.
+func resourceArray(arrays []pulumi.ArrayOutput) pulumi.ResourceArrayOutput {
+	return flatten(arrays).ApplyT(func(xs []any) []pulumi.Resource {
+		resources := make([]pulumi.Resource, 0, len(xs))
+		for _, x := range xs {
+			if r, ok := x.(pulumi.Resource); ok {
+				resources = append(resources, r)
+			}
+		}
+		return resources
+	}).(pulumi.ResourceArrayOutput)
+}
+
+// flatten concatenates the given arrays.
+func flatten(arrays []pulumi.ArrayOutput) pulumi.ArrayOutput {
+	args := make([]any, 0, len(arrays))
+	for _, a := range arrays {
+		args = append(args, a)
+	}
+	return pulumi.All(args...).ApplyT(func(xs []any) []any {
+		var result []any
+		for _, x := range xs {
+			result = append(result, x.([]any)...)
+		}
+		return result
+	}).(pulumi.ArrayOutput)
+}
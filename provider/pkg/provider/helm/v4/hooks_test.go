@@ -0,0 +1,134 @@
+// Copyright 2016-2026, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package v4
+
+import (
+	"context"
+
+	gk "github.com/onsi/ginkgo/v2"
+	gm "github.com/onsi/gomega"
+	"helm.sh/helm/v3/pkg/release"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"github.com/pulumi/pulumi-kubernetes/provider/v4/pkg/clients/fake"
+)
+
+var _ = gk.Describe("hookPhases", func() {
+	hook := func(name string, weight int, events ...release.HookEvent) *release.Hook {
+		return &release.Hook{Name: name, Weight: weight, Events: events}
+	}
+	names := func(groups [][]*release.Hook) [][]string {
+		var result [][]string
+		for _, g := range groups {
+			var n []string
+			for _, h := range g {
+				n = append(n, h.Name)
+			}
+			result = append(result, n)
+		}
+		return result
+	}
+
+	hooks := []*release.Hook{
+		hook("migrate", 5, release.HookPreInstall, release.HookPreUpgrade),
+		hook("secret", -5, release.HookPreInstall),
+		hook("config-b", 0, release.HookPreUpgrade),
+		hook("config-a", 0, release.HookPreInstall),
+		hook("notify", 0, release.HookPostInstall, release.HookPostUpgrade),
+		hook("cleanup", 0, release.HookPreDelete),
+		hook("test-connection", 0, release.HookTest),
+		hook("rollback", 0, release.HookPreRollback),
+	}
+
+	gk.It("should group the install hooks by phase and weight", func() {
+		pre, post, unsupported := hookPhases(hooks, false)
+		gm.Expect(names(pre)).To(gm.Equal([][]string{{"secret"}, {"config-a"}, {"migrate"}}))
+		gm.Expect(names(post)).To(gm.Equal([][]string{{"notify"}}))
+		gm.Expect(unsupported).To(gm.ConsistOf(gm.HaveField("Name", "cleanup"), gm.HaveField("Name", "rollback")))
+	})
+
+	gk.It("should group the upgrade hooks by phase and weight", func() {
+		pre, post, unsupported := hookPhases(hooks, true)
+		gm.Expect(names(pre)).To(gm.Equal([][]string{{"config-b"}, {"migrate"}}))
+		gm.Expect(names(post)).To(gm.Equal([][]string{{"notify"}}))
+		gm.Expect(unsupported).To(gm.ConsistOf(gm.HaveField("Name", "cleanup"), gm.HaveField("Name", "rollback")))
+	})
+})
+
+var _ = gk.Describe("hookResourceOptions", func() {
+	gk.It("should re-run upgrade hooks and delete them before re-creation", func() {
+		opts := hookResourceOptions(&release.Hook{
+			Events:         []release.HookEvent{release.HookPreInstall, release.HookPreUpgrade},
+			DeletePolicies: []release.HookDeletePolicy{release.HookSucceeded},
+		}, "rev")
+		gm.Expect(opts).To(gm.HaveLen(2))
+	})
+	gk.It("should run install-only hooks once, and retain them", func() {
+		opts := hookResourceOptions(&release.Hook{
+			Events: []release.HookEvent{release.HookPostInstall},
+		}, "rev")
+		gm.Expect(opts).To(gm.HaveLen(1))
+	})
+})
+
+var _ = gk.Describe("unsupportedDeletePolicies", func() {
+	gk.It("should report the policies other than before-hook-creation", func() {
+		gm.Expect(unsupportedDeletePolicies(&release.Hook{
+			DeletePolicies: []release.HookDeletePolicy{release.HookBeforeHookCreation, release.HookSucceeded},
+		})).To(gm.Equal([]release.HookDeletePolicy{release.HookSucceeded}))
+		gm.Expect(unsupportedDeletePolicies(&release.Hook{})).To(gm.BeEmpty())
+	})
+})
+
+var _ = gk.Describe("isUpgrade", func() {
+	objs := []unstructured.Unstructured{{Object: map[string]any{
+		"apiVersion": "v1",
+		"kind":       "ConfigMap",
+		"metadata":   map[string]any{"name": "settings", "namespace": "default"},
+	}}}
+
+	gk.It("should report an install if none of the objects exist", func(ctx context.Context) {
+		clientSet, _, _, _ := fake.NewSimpleDynamicClient()
+		gm.Expect(isUpgrade(ctx, clientSet, objs)).To(gm.BeFalse())
+		gm.Expect(isUpgrade(ctx, nil, objs)).To(gm.BeFalse())
+	})
+	gk.It("should report an upgrade if the objects exist", func(ctx context.Context) {
+		clientSet, _, _, _ := fake.NewSimpleDynamicClient(fake.WithObjects(&corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: "settings", Namespace: "default"},
+		}))
+		gm.Expect(isUpgrade(ctx, clientSet, objs)).To(gm.BeTrue())
+	})
+	gk.It("should probe a single object", func(ctx context.Context) {
+		clientSet, _, _, client := fake.NewSimpleDynamicClient(fake.WithObjects(&corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: "settings", Namespace: "default"},
+		}))
+		many := []unstructured.Unstructured{
+			{Object: map[string]any{
+				"apiVersion": "example.com/v1",
+				"kind":       "Unknown",
+				"metadata":   map[string]any{"name": "unknown", "namespace": "default"},
+			}},
+			objs[0],
+			{Object: map[string]any{
+				"apiVersion": "v1",
+				"kind":       "ConfigMap",
+				"metadata":   map[string]any{"name": "other", "namespace": "default"},
+			}},
+		}
+		gm.Expect(isUpgrade(ctx, clientSet, many)).To(gm.BeTrue())
+		gm.Expect(client.Actions()).To(gm.HaveLen(1))
+	})
+})
@@ -15,15 +15,18 @@
 package provider
 
 import (
+	"cmp"
 	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"io/fs"
+	"maps"
 	"net/url"
 	"os"
 	"path/filepath"
 	"reflect"
+	"slices"
 	"strings"
 	"time"
 
@@ -40,7 +43,9 @@ import (
 	"helm.sh/helm/v3/pkg/registry"
 	"helm.sh/helm/v3/pkg/release"
 	"helm.sh/helm/v3/pkg/repo"
+	"helm.sh/helm/v3/pkg/storage"
 	"helm.sh/helm/v3/pkg/storage/driver"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/util/strategicpatch"
 	"k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/clientcmd"
@@ -81,6 +86,8 @@ type Release struct {
 	CreateNamespace bool `json:"createNamespace,omitempty"`
 	// Run helm dependency update before installing the chart
 	DependencyUpdate bool `json:"dependencyUpdate,omitempty"`
+	// How to resolve the chart's dependencies. Values are: locked, vendor, offline.
+	DependencyMode string `json:"dependencyMode,omitempty"`
 	// Add a custom description
 	Description string `json:"description,omitempty"`
 	// Use chart development versions, too. Equivalent to version '>0.0.0-0'. If `version` is set, this is ignored
@@ -140,6 +147,8 @@ type Release struct {
 	ResourceNames map[string][]string `json:"resourceNames,omitempty"`
 	// Status of the deployed release.
 	Status *ReleaseStatus `json:"status,omitempty"`
+	// The rendered notes (NOTES.txt) of the deployed release.
+	Notes string `json:"notes,omitempty"`
 }
 
 type ReleaseSpec struct{}
@@ -182,6 +191,7 @@ type helmReleaseProvider struct {
 	host                     host.HostClient
 	canceler                 *cancellationContext
 	helmDriver               string
+	sqlStorage               *helmSQLStorage
 	apiConfig                *api.Config
 	defaultOverrides         *clientcmd.ConfigOverrides
 	restConfig               *rest.Config
@@ -192,6 +202,12 @@ type helmReleaseProvider struct {
 	clusterUnreachableReason string
 	name                     string
 	settings                 *cli.EnvSettings
+	indexCache               *helm.IndexCache
+	// skipManifestDiff disables the server-side dry-run upgrade by which Diff reports the changes to the manifest.
+	skipManifestDiff bool
+	// renderer writes the manifests of the releases in render mode (`renderYamlToDirectory`), in place of
+	// installing them. It's nil otherwise.
+	renderer *yamlRenderer
 }
 
 func newHelmReleaseProvider(
@@ -202,12 +218,16 @@ func newHelmReleaseProvider(
 	restConfig *rest.Config,
 	clientSet *clients.DynamicClientSet,
 	helmDriver,
+	helmSQLConnectionString,
 	namespace string,
 	enableSecrets bool,
 	pluginsDirectory,
 	registryConfigPath,
 	repositoryConfigPath,
 	repositoryCache string,
+	indexCache *helm.IndexCache,
+	skipManifestDiff bool,
+	renderer *yamlRenderer,
 	clusterUnreachable bool,
 	clusterUnreachableReason string,
 ) (customResourceProvider, error) {
@@ -218,6 +238,11 @@ func newHelmReleaseProvider(
 	settings.RepositoryCache = repositoryCache
 	settings.Debug = true
 
+	var sqlStorage *helmSQLStorage
+	if helmDriver == helmSQLDriver {
+		sqlStorage = newHelmSQLStorage(helmSQLConnectionString)
+	}
+
 	return &helmReleaseProvider{
 		host:                     host,
 		canceler:                 canceler,
@@ -226,12 +251,16 @@ func newHelmReleaseProvider(
 		restConfig:               restConfig,
 		clientSet:                clientSet,
 		helmDriver:               helmDriver,
+		sqlStorage:               sqlStorage,
 		defaultNamespace:         namespace,
 		enableSecrets:            enableSecrets,
 		clusterUnreachable:       clusterUnreachable,
 		clusterUnreachableReason: clusterUnreachableReason,
 		name:                     "kubernetes:helmrelease",
 		settings:                 settings,
+		indexCache:               indexCache,
+		skipManifestDiff:         skipManifestDiff,
+		renderer:                 renderer,
 	}, nil
 }
 
@@ -266,7 +295,18 @@ func (r *helmReleaseProvider) getActionConfig(namespace string) (*action.Configu
 	}
 	kc := NewKubeConfig(r.restConfig, clientConfig)
 
-	if err := conf.Init(kc, namespace, r.helmDriver, debug); err != nil {
+	if r.sqlStorage != nil {
+		// Helm reads the SQL driver's connection string from the environment, so initialize the
+		// configuration with an in-memory driver and then swap in the configured SQL storage.
+		if err := conf.Init(kc, namespace, "memory", debug); err != nil {
+			return nil, err
+		}
+		d, err := r.sqlStorage.driver(namespace)
+		if err != nil {
+			return nil, err
+		}
+		conf.Releases = storage.Init(d)
+	} else if err := conf.Init(kc, namespace, r.helmDriver, debug); err != nil {
 		return nil, err
 	}
 	logger.V(9).Infof("Setting registry client with config file: %q and debug: %v", r.settings.RegistryConfig,
@@ -283,6 +323,17 @@ func (r *helmReleaseProvider) getActionConfig(namespace string) (*action.Configu
 	return conf, nil
 }
 
+// lockRelease serializes the operations on the release, if the storage driver requires it. It waits up
+// to the release's timeout for the lock.
+func (r *helmReleaseProvider) lockRelease(ctx context.Context, rel *Release) (func(), error) {
+	if r.sqlStorage == nil {
+		return func() {}, nil
+	}
+	ctx, cancel := context.WithTimeout(ctx, getTimeoutOrDefault(rel.Timeout))
+	defer cancel()
+	return r.sqlStorage.lockRelease(ctx, rel.Namespace, rel.Name)
+}
+
 // mapReplExtractValues extracts pure values from the property map.
 var mapReplExtractValues = combineMapReplv(mapReplStripSecrets, mapReplStripComputed)
 
@@ -374,9 +425,16 @@ func (r *helmReleaseProvider) Check(
 			"`allowNullValues` is deprecated and has no effect; null values in Helm chart values are preserved by default.")
 	}
 
-	if !news.ContainsUnknowns() {
+	if _, err := helm.ParseDependencyMode(newRelease.DependencyMode); err != nil {
+		failures = append(failures, &pulumirpc.CheckFailure{
+			Property: "dependencyMode",
+			Reason:   err.Error(),
+		})
+	}
+
+	if !news.ContainsUnknowns() && len(failures) == 0 {
 		logger.V(9).Infof("Loading Helm chart.")
-		chart, err := r.helmLoad(ctx, urn, newRelease)
+		chart, err := r.helmLoad(ctx, urn, newRelease, true)
 		if err != nil {
 			failures = append(failures, &pulumirpc.CheckFailure{
 				Property: "chart",
@@ -388,6 +446,10 @@ func (r *helmReleaseProvider) Check(
 			// with this we may determine whether the Helm release needs to be upgraded.
 			newRelease.Version = chart.Metadata.Version
 
+			// validate the values against the chart's schema(s), so that each violation
+			// may be reported against the offending value.
+			failures = append(failures, checkValuesSchema(chart, newRelease, news["values"].ContainsSecrets())...)
+
 			r.cacheReleaseCRDs(ctx, chart, newRelease)
 		}
 	}
@@ -454,13 +516,14 @@ func (r *helmReleaseProvider) helmLoad(
 	_ /* ctx */ context.Context,
 	_ /* urn */ resource.URN,
 	newRelease *Release,
+	preview bool,
 ) (*helmchart.Chart, error) {
 	conf, err := r.getActionConfig(newRelease.Namespace)
 	if err != nil {
 		return nil, err
 	}
 	client := action.NewInstall(conf)
-	c, path, err := getChart(&client.ChartPathOptions, conf.RegistryClient, r.settings, newRelease)
+	c, path, err := getChart(&client.ChartPathOptions, conf.RegistryClient, r.settings, r.indexCache, newRelease)
 	if err != nil {
 		logger.V(9).Infof("getChart failed: %v", err)
 		logger.V(9).Infof("Settings: %#v", r.settings)
@@ -476,7 +539,9 @@ func (r *helmReleaseProvider) helmLoad(
 		newRelease.Keyring,
 		r.settings,
 		conf.RegistryClient,
-		newRelease.DependencyUpdate)
+		newRelease.DependencyUpdate,
+		newRelease.DependencyMode,
+		preview)
 	if err != nil {
 		return nil, err
 	} else if updated {
@@ -538,6 +603,174 @@ func (r *helmReleaseProvider) renderChartManifest(chart *helmchart.Chart, rel *R
 	return rendered.Manifest, nil
 }
 
+// helmRender renders the release client-side, as `helm template` does, and writes its objects to the render
+// directory in place of installing it. The hooks of the release are written apart from its objects, to the
+// release's hook file. The objects that the old release rendered, and that the new one doesn't, are removed.
+func (r *helmReleaseProvider) helmRender(ctx context.Context, urn resource.URN, newRelease, oldRelease *Release) error {
+	c, err := r.helmLoad(ctx, urn, newRelease, false)
+	if err != nil {
+		return err
+	}
+	if err := isChartInstallable(c); err != nil {
+		return err
+	}
+	values, err := getValues(newRelease)
+	if err != nil {
+		return err
+	}
+	conf, err := r.getActionConfig(newRelease.Namespace)
+	if err != nil {
+		return err
+	}
+
+	client := action.NewInstall(conf)
+	client.ClientOnly = true
+	client.DryRun = true
+	client.IncludeCRDs = !newRelease.SkipCrds
+	client.Devel = newRelease.Devel
+	client.Namespace = newRelease.Namespace
+	client.ReleaseName = newRelease.Name
+	client.SubNotes = newRelease.RenderSubchartNotes
+	client.DisableOpenAPIValidation = newRelease.DisableOpenapiValidation
+	client.Description = newRelease.Description
+	if cmd := newRelease.Postrender; cmd != "" {
+		pr, err := postrender.NewExec(cmd)
+		if err != nil {
+			return err
+		}
+		client.PostRenderer = pr
+	}
+	rel, err := client.RunWithContext(r.canceler.context, c, values)
+	if err != nil {
+		return err
+	}
+
+	objs, err := parseRenderedObjects(rel.Manifest)
+	if err != nil {
+		return fmt.Errorf("failed to parse the manifest of Helm release %s/%s: %w",
+			newRelease.Namespace, newRelease.Name, err)
+	}
+	var hooks []*unstructured.Unstructured
+	if !newRelease.DisableWebhooks {
+		slices.SortStableFunc(rel.Hooks, func(a, b *release.Hook) int {
+			return cmp.Or(cmp.Compare(a.Weight, b.Weight), cmp.Compare(a.Name, b.Name))
+		})
+		for _, hook := range rel.Hooks {
+			hookObjs, err := parseRenderedObjects(hook.Manifest)
+			if err != nil {
+				return fmt.Errorf("failed to parse the hook %q of Helm release %s/%s: %w",
+					hook.Path, newRelease.Namespace, newRelease.Name, err)
+			}
+			hooks = append(hooks, hookObjs...)
+		}
+	}
+	// Helm leaves the namespace of the objects to the cluster's default, which is the release's namespace.
+	scopes := clients.NewNamespaceScopeResolver(r.clientSet, crdObjects(objs)...)
+	for _, obj := range slices.Concat(objs, hooks) {
+		if obj.GetNamespace() != "" {
+			continue
+		}
+		namespaced, err := scopes.IsNamespaced(obj.GroupVersionKind())
+		if err != nil && !clients.IsNoNamespaceInfoErr(err) {
+			return err
+		}
+		// A kind that can't be found is presumed to be namespaced, as in Check.
+		if err != nil || namespaced {
+			obj.SetNamespace(newRelease.Namespace)
+		}
+	}
+
+	// The objects are identified by their file, and their kind, namespace and name within it.
+	type renderedObject struct{ path, key string }
+	rendered := map[renderedObject]bool{}
+	var manifest strings.Builder
+	for _, obj := range objs {
+		if _, err := r.renderer.render(urn, obj, nil); err != nil {
+			return err
+		}
+		rendered[renderedObject{r.renderer.path(obj), renderedObjectKey(obj)}] = true
+		yamlBytes, err := marshalRenderedObject(obj)
+		if err != nil {
+			return err
+		}
+		manifest.WriteString("---\n")
+		manifest.Write(yamlBytes)
+	}
+	if err := r.renderer.renderHooks(newRelease.Namespace, newRelease.Name, hooks); err != nil {
+		return err
+	}
+	if oldRelease != nil {
+		for _, obj := range releaseResourceObjects(oldRelease.ResourceNames) {
+			if rendered[renderedObject{r.renderer.path(obj), renderedObjectKey(obj)}] {
+				continue
+			}
+			if err := r.renderer.remove(urn, obj); err != nil && !errors.Is(err, fs.ErrNotExist) {
+				return err
+			}
+		}
+		if oldRelease.Namespace != newRelease.Namespace || oldRelease.Name != newRelease.Name {
+			if err := r.renderer.renderHooks(oldRelease.Namespace, oldRelease.Name, nil); err != nil {
+				return err
+			}
+		}
+	}
+
+	// Record the objects as rendered, with their namespaces, so that they may be removed later on.
+	rel.Manifest = manifest.String()
+	return setReleaseAttributes(newRelease, rel, false)
+}
+
+// helmRenderDelete removes the objects and the hooks of the release from the render directory.
+func (r *helmReleaseProvider) helmRenderDelete(urn resource.URN, release *Release) error {
+	for _, obj := range releaseResourceObjects(release.ResourceNames) {
+		if err := r.renderer.remove(urn, obj); err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return err
+		}
+	}
+	return r.renderer.renderHooks(release.Namespace, release.Name, nil)
+}
+
+// releaseResourceObjects returns the objects that the resource names of a release identify, e.g.
+// `{"Deployment.apps/apps/v1": ["my-namespace/my-app"]}`, with their apiVersion, kind, namespace and name only.
+func releaseResourceObjects(resourceNames map[string][]string) []*unstructured.Unstructured {
+	var objs []*unstructured.Unstructured
+	for key, names := range resourceNames {
+		groupKind, apiVersion, ok := strings.Cut(key, "/")
+		if !ok {
+			continue
+		}
+		kind, _, _ := strings.Cut(groupKind, ".")
+		for _, name := range names {
+			obj := &unstructured.Unstructured{}
+			obj.SetAPIVersion(apiVersion)
+			obj.SetKind(kind)
+			if namespace, n, ok := strings.Cut(name, "/"); ok {
+				obj.SetNamespace(namespace)
+				name = n
+			}
+			obj.SetName(name)
+			objs = append(objs, obj)
+		}
+	}
+	return objs
+}
+
+// renderedObjectKey identifies a rendered object within its file.
+func renderedObjectKey(obj *unstructured.Unstructured) string {
+	return fmt.Sprintf("%s/%s/%s", obj.GroupVersionKind().GroupKind(), obj.GetNamespace(), obj.GetName())
+}
+
+// crdObjects returns the CRDs of the objects.
+func crdObjects(objs []*unstructured.Unstructured) []unstructured.Unstructured {
+	var crds []unstructured.Unstructured
+	for _, obj := range objs {
+		if clients.IsCRD(obj) {
+			crds = append(crds, *obj)
+		}
+	}
+	return crds
+}
+
 func cacheCRDsFromManifest(ctx context.Context, manifest string, cache *clients.CRDCache) error {
 	if cache == nil {
 		return nil
@@ -563,8 +796,13 @@ func (r *helmReleaseProvider) helmCreate(ctx context.Context, urn resource.URN,
 	if err != nil {
 		return err
 	}
+	unlock, err := r.lockRelease(ctx, newRelease)
+	if err != nil {
+		return err
+	}
+	defer unlock()
 	client := action.NewInstall(conf)
-	c, path, err := getChart(&client.ChartPathOptions, conf.RegistryClient, r.settings, newRelease)
+	c, path, err := getChart(&client.ChartPathOptions, conf.RegistryClient, r.settings, r.indexCache, newRelease)
 	if err != nil {
 		logger.V(9).Infof("getChart failed: %+v", err)
 		logger.V(9).Infof("Settings: %#v", r.settings)
@@ -579,7 +817,9 @@ func (r *helmReleaseProvider) helmCreate(ctx context.Context, urn resource.URN,
 		newRelease.Keyring,
 		r.settings,
 		conf.RegistryClient,
-		newRelease.DependencyUpdate)
+		newRelease.DependencyUpdate,
+		newRelease.DependencyMode,
+		false)
 	if err != nil {
 		return err
 	} else if updated {
@@ -691,10 +931,15 @@ func (r *helmReleaseProvider) helmUpdate(newRelease, oldRelease *Release) error
 	if err != nil {
 		return err
 	}
+	unlock, err := r.lockRelease(r.canceler.context, oldRelease)
+	if err != nil {
+		return err
+	}
+	defer unlock()
 	client := action.NewUpgrade(actionConfig)
 	cpo := &client.ChartPathOptions
 	// Get Chart metadata, if we fail - we're done
-	chart, path, err := getChart(cpo, actionConfig.RegistryClient, r.settings, newRelease)
+	chart, path, err := getChart(cpo, actionConfig.RegistryClient, r.settings, r.indexCache, newRelease)
 	if err != nil {
 		return err
 	}
@@ -706,7 +951,9 @@ func (r *helmReleaseProvider) helmUpdate(newRelease, oldRelease *Release) error
 		newRelease.Keyring,
 		r.settings,
 		actionConfig.RegistryClient,
-		newRelease.DependencyUpdate)
+		newRelease.DependencyUpdate,
+		newRelease.DependencyMode,
+		false)
 	if err != nil {
 		return err
 	} else if updated {
@@ -793,7 +1040,7 @@ func assignNameIfAutonameable(pm resource.PropertyMap, urn resource.URN) {
 }
 
 func (r *helmReleaseProvider) Diff(
-	_ /* ctx */ context.Context, req *pulumirpc.DiffRequest,
+	ctx context.Context, req *pulumirpc.DiffRequest,
 ) (*pulumirpc.DiffResponse, error) {
 	urn := resource.URN(req.GetUrn())
 	label := fmt.Sprintf("Provider[%s].Diff(%s)", r.name, urn)
@@ -923,6 +1170,11 @@ func (r *helmReleaseProvider) Diff(
 				replaces = append(replaces, k)
 			}
 		}
+
+		// Show what the upgrade would change in the cluster, object by object.
+		if len(detailedDiff) > 0 && len(replaces) == 0 && !news.ContainsUnknowns() {
+			maps.Copy(detailedDiff, r.manifestDiff(newRelease, oldRelease))
+		}
 	}
 
 	return &pulumirpc.DiffResponse{
@@ -986,7 +1238,13 @@ func (r *helmReleaseProvider) Create(
 	id := ""
 
 	var creationError error
-	if !req.GetPreview() {
+	if !req.GetPreview() && r.renderer != nil {
+		id = fqName(newRelease.Namespace, newRelease.Name)
+		if err := r.helmRender(ctx, urn, newRelease, nil); err != nil {
+			return nil, err
+		}
+		_ = r.host.LogStatus(ctx, diag.Info, urn, fmt.Sprintf("rendered Helm release %s", id))
+	} else if !req.GetPreview() {
 		if r.clusterUnreachable {
 			return nil, fmt.Errorf("can't create Helm Release with unreachable cluster: %s", r.clusterUnreachableReason)
 		}
@@ -1051,6 +1309,15 @@ func (r *helmReleaseProvider) Read(ctx context.Context, req *pulumirpc.ReadReque
 	}
 	logger.V(9).Infof("%s decoded release: %#v", label, existingRelease)
 
+	// In render mode, the release isn't installed, so its state is as it was rendered.
+	if r.renderer != nil && len(oldState) > 0 {
+		return &pulumirpc.ReadResponse{
+			Id:         req.GetId(),
+			Properties: req.GetProperties(),
+			Inputs:     req.GetInputs(),
+		}, nil
+	}
+
 	var namespace, name string
 	if len(oldState) == 0 {
 		namespace, name = parseFqName(req.GetId())
@@ -1125,11 +1392,12 @@ func (r *helmReleaseProvider) serializeImportInputs(release *Release) resource.P
 	inputs := resource.NewPropertyMap(release)
 	delete(inputs, "resourceNames")
 	delete(inputs, "status")
+	delete(inputs, "notes")
 	return inputs
 }
 
 func (r *helmReleaseProvider) Update(
-	_ /* ctx */ context.Context,
+	ctx context.Context,
 	req *pulumirpc.UpdateRequest,
 ) (*pulumirpc.UpdateResponse, error) {
 	urn := resource.URN(req.GetUrn())
@@ -1166,7 +1434,13 @@ func (r *helmReleaseProvider) Update(
 	}
 
 	var updateError error
-	if !req.GetPreview() {
+	if !req.GetPreview() && r.renderer != nil {
+		if err = r.helmRender(ctx, urn, newRelease, oldRelease); err != nil {
+			return nil, err
+		}
+		_ = r.host.LogStatus(ctx, diag.Info, urn, fmt.Sprintf(
+			"rendered Helm release %s", fqName(newRelease.Namespace, newRelease.Name)))
+	} else if !req.GetPreview() {
 		if r.clusterUnreachable {
 			return nil, fmt.Errorf("can't update Helm Release with unreachable cluster: %s", r.clusterUnreachableReason)
 		}
@@ -1207,7 +1481,7 @@ func (r *helmReleaseProvider) Update(
 }
 
 func (r *helmReleaseProvider) Delete(
-	_ /* ctx */ context.Context, req *pulumirpc.DeleteRequest,
+	ctx context.Context, req *pulumirpc.DeleteRequest,
 ) (*pbempty.Empty, error) {
 	urn := resource.URN(req.GetUrn())
 	label := fmt.Sprintf("Provider[%s].Delete(%s)", r.name, urn)
@@ -1225,11 +1499,25 @@ func (r *helmReleaseProvider) Delete(
 		return nil, err
 	}
 
+	if r.renderer != nil {
+		if err := r.helmRenderDelete(urn, release); err != nil {
+			return nil, err
+		}
+		_ = r.host.LogStatus(ctx, diag.Info, urn, fmt.Sprintf(
+			"deleted rendered Helm release %s", fqName(release.Namespace, release.Name)))
+		return &pbempty.Empty{}, nil
+	}
+
 	namespace := release.Namespace
 	actionConfig, err := r.getActionConfig(namespace)
 	if err != nil {
 		return nil, err
 	}
+	unlock, err := r.lockRelease(ctx, release)
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
 
 	name := release.Name
 
@@ -1275,6 +1563,10 @@ func checkpointRelease(
 	if isPreview {
 		object["resourceNames"] = resource.MakeComputed(resource.NewStringProperty(""))
 		object["status"] = resource.MakeComputed(resource.NewStringProperty(""))
+		object["notes"] = resource.MakeComputed(resource.NewStringProperty(""))
+	} else if notes, ok := object["notes"]; ok && inputs.ContainsSecrets() {
+		// The notes are rendered with the values, so they may contain secret values.
+		object["notes"] = resource.MakeSecret(notes)
 	}
 
 	return object
@@ -1332,6 +1624,7 @@ func setReleaseAttributes(release *Release, r *release.Release, isPreview bool)
 	release.Status.Chart = r.Chart.Metadata.Name
 	release.Status.Version = r.Chart.Metadata.Version
 	release.Status.AppVersion = r.Chart.Metadata.AppVersion
+	release.Notes = r.Info.Notes
 	return nil
 }
 
@@ -1388,7 +1681,7 @@ func (r *helmReleaseProvider) importRelease(
 	if name, _, found := searchProgramDirectory(hr.Chart.Metadata.Name, hr.Chart.Metadata.Version); found {
 		release.Chart = name
 	} else if repo, chart, found := searchHelmRepositories(
-		r.settings, hr.Chart.Metadata.Name, hr.Chart.Metadata.Version,
+		r.settings, r.indexCache, hr.Chart.Metadata.Name, hr.Chart.Metadata.Version,
 	); found {
 		// use a local repository reference, rather than reconstructing all the repository opts
 		release.Chart = fmt.Sprintf("%s/%s", repo.Name, chart.Name)
@@ -1397,7 +1690,7 @@ func (r *helmReleaseProvider) importRelease(
 		release.Chart = hr.Chart.Metadata.Name
 	}
 
-	chart, err := r.helmLoad(ctx, urn, release)
+	chart, err := r.helmLoad(ctx, urn, release, true)
 	if err != nil {
 		// Likely because the chart is not readily available (e.g. import of chart where no repo info is stored).
 		// Eat the error to allow import to succeed, assuming that Check will report the failure later.
@@ -1469,7 +1762,9 @@ func searchProgramDirectory(name, version string) (string, *helmchart.Metadata,
 }
 
 // searchHelmRepositories implements a best-effort search for a chart in the locally-configured repositories.
-func searchHelmRepositories(settings *cli.EnvSettings, name, version string) (*repo.Entry, *repo.ChartVersion, bool) {
+func searchHelmRepositories(
+	settings *cli.EnvSettings, indexCache *helm.IndexCache, name, version string,
+) (*repo.Entry, *repo.ChartVersion, bool) {
 	repoFile := settings.RepositoryConfig
 	repoCacheDir := settings.RepositoryCache
 
@@ -1484,7 +1779,7 @@ func searchHelmRepositories(settings *cli.EnvSettings, name, version string) (*r
 	for _, re := range rf.Repositories {
 		n := re.Name
 		f := filepath.Join(repoCacheDir, helmpath.CacheIndexFile(n))
-		ind, err := repo.LoadIndexFile(f)
+		ind, err := indexCache.LoadIndexFile(f)
 		if err != nil {
 			logger.V(9).Infof("Repo %q is corrupt or missing. Try 'helm repo update'.", n)
 			continue
@@ -1500,7 +1795,7 @@ func searchHelmRepositories(settings *cli.EnvSettings, name, version string) (*r
 }
 
 func getChart(cpo *action.ChartPathOptions, registryClient *registry.Client, settings *cli.EnvSettings,
-	newRelease *Release) (*helmchart.Chart, string,
+	indexCache *helm.IndexCache, newRelease *Release) (*helmchart.Chart, string,
 	error,
 ) {
 	logger.V(9).Infof("Looking up chart path options for release: %q", newRelease.Name)
@@ -1526,7 +1821,7 @@ func getChart(cpo *action.ChartPathOptions, registryClient *registry.Client, set
 	}
 
 	logger.V(9).Infof("Chart name: %q", chartName)
-	path, err := locateChart(cpo, registryClient, chartName, settings)
+	path, err := locateChart(cpo, registryClient, chartName, settings, indexCache)
 	if err != nil {
 		return nil, "", err
 	}
@@ -1579,7 +1874,7 @@ func localChart(name string, verify bool, keyring string) (string, bool, error)
 // local charts. Helm prefers a local chart over a remote chart with the same name, even if the local chart
 // directory is not well-formed. Pulumi adds additional checks of the local chart directory.
 func locateChart(cpo *action.ChartPathOptions, registryClient *registry.Client, name string,
-	settings *cli.EnvSettings,
+	settings *cli.EnvSettings, indexCache *helm.IndexCache,
 ) (string, error) {
 	name = strings.TrimSpace(name)
 	version := strings.TrimSpace(cpo.Version)
@@ -1620,10 +1915,7 @@ func locateChart(cpo *action.ChartPathOptions, registryClient *registry.Client,
 		dl.Verify = downloader.VerifyAlways
 	}
 	if cpo.RepoURL != "" {
-		chartURL, err := repo.FindChartInAuthAndTLSAndPassRepoURL(
-			cpo.RepoURL, cpo.Username, cpo.Password, name, version, cpo.CertFile,
-			cpo.KeyFile, cpo.CaFile, cpo.InsecureSkipTLSverify, cpo.PassCredentialsAll,
-			getter.All(settings))
+		chartURL, err := indexCache.FindChart(helm.RepositoryEntry(*cpo), name, version, getter.All(settings))
 		if err != nil {
 			return "", err
 		}
@@ -1675,9 +1967,51 @@ func locateChart(cpo *action.ChartPathOptions, registryClient *registry.Client,
 	return filename, fmt.Errorf("failed to download %q%s", name, atVersion)
 }
 
+// checkValuesSchema validates the release values against the chart's schema(s), returning a check
+// failure for each violation. With redact, the failures don't show the offending values, which may be secret.
+func checkValuesSchema(c *helmchart.Chart, release *Release, redact bool) []*pulumirpc.CheckFailure {
+	values, err := getValues(release)
+	if err != nil {
+		logger.V(9).Infof("Unable to validate values: %v", err)
+		return nil
+	}
+	violations, err := helm.ValidateValues(c, values)
+	if err != nil {
+		logger.V(9).Infof("Unable to validate values: %v", err)
+		return nil
+	}
+	failures := make([]*pulumirpc.CheckFailure, 0, len(violations))
+	for _, v := range violations {
+		if redact {
+			v = v.Redact()
+		}
+		failures = append(failures, &pulumirpc.CheckFailure{
+			Property: v.PropertyPath("values"),
+			Reason:   fmt.Sprintf("%s (chart %q)", v.Message, v.Chart),
+		})
+	}
+	return failures
+}
+
 func checkChartDependencies(c *helmchart.Chart, path, keyring string, settings *cli.EnvSettings,
-	registryClient *registry.Client, dependencyUpdate bool,
+	registryClient *registry.Client, dependencyUpdate bool, dependencyMode string, preview bool,
 ) (bool, error) {
+	mode, err := helm.ParseDependencyMode(dependencyMode)
+	if err != nil {
+		return false, err
+	}
+	if mode != helm.DependencyModeDefault {
+		return helm.ResolveDependencies(c, path, helm.DependencyOptions{
+			Mode:           mode,
+			Update:         dependencyUpdate,
+			Preview:        preview,
+			Keyring:        keyring,
+			Settings:       settings,
+			RegistryClient: registryClient,
+			Out:            os.Stdout,
+		})
+	}
+
 	p := getter.All(settings)
 
 	if req := c.Metadata.Dependencies; req != nil {
@@ -1759,8 +2093,10 @@ func resolveChartName(repository, name string) (string, string, error) {
 	return "", name, nil
 }
 
+const helmReleaseType = "kubernetes:helm.sh/v3:Release"
+
 func isHelmRelease(urn resource.URN) bool {
-	return urn.Type() == "kubernetes:helm.sh/v3:Release"
+	return urn.Type() == helmReleaseType
 }
 
 func getTimeoutOrDefault(timeout int) time.Duration {
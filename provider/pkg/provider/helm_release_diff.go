@@ -0,0 +1,257 @@
+// Copyright 2016-2026, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package provider
+
+import (
+	"fmt"
+	"maps"
+	"reflect"
+	"slices"
+	"strings"
+
+	"helm.sh/helm/v3/pkg/action"
+	"helm.sh/helm/v3/pkg/chart/loader"
+	"helm.sh/helm/v3/pkg/postrender"
+
+	"github.com/pulumi/pulumi/sdk/v3/go/common/resource"
+	logger "github.com/pulumi/pulumi/sdk/v3/go/common/util/logging"
+	pulumirpc "github.com/pulumi/pulumi/sdk/v3/proto/go"
+)
+
+// helmDiffManifests renders the manifest that upgrading the old release to the new release would apply,
+// using a server-side dry-run upgrade, and returns it along with the manifest of the deployed release.
+func (r *helmReleaseProvider) helmDiffManifests(newRelease, oldRelease *Release) (string, string, error) {
+	actionConfig, err := r.getActionConfig(oldRelease.Namespace)
+	if err != nil {
+		return "", "", err
+	}
+	deployed, err := getRelease(actionConfig, oldRelease.Name)
+	if err != nil {
+		return "", "", err
+	}
+
+	client := action.NewUpgrade(actionConfig)
+	chart, path, err := getChart(
+		&client.ChartPathOptions, actionConfig.RegistryClient, r.settings, r.indexCache, newRelease)
+	if err != nil {
+		return "", "", err
+	}
+	updated, err := checkChartDependencies(
+		chart,
+		path,
+		newRelease.Keyring,
+		r.settings,
+		actionConfig.RegistryClient,
+		newRelease.DependencyUpdate,
+		newRelease.DependencyMode,
+		true)
+	if err != nil {
+		return "", "", err
+	} else if updated {
+		// load the chart again if its dependencies have been updated
+		if chart, err = loader.Load(path); err != nil {
+			return "", "", err
+		}
+	}
+	values, err := getValues(newRelease)
+	if err != nil {
+		return "", "", err
+	}
+
+	client.DryRun = true
+	client.DryRunOption = "server"
+	client.Devel = newRelease.Devel
+	client.Namespace = newRelease.Namespace
+	client.DisableHooks = newRelease.DisableCRDHooks
+	client.SubNotes = newRelease.RenderSubchartNotes
+	client.ResetValues = newRelease.ResetValues
+	client.ReuseValues = newRelease.ReuseValues
+	client.DisableOpenAPIValidation = newRelease.DisableOpenapiValidation
+	if cmd := newRelease.Postrender; cmd != "" {
+		pr, err := postrender.NewExec(cmd)
+		if err != nil {
+			return "", "", err
+		}
+		client.PostRenderer = pr
+	}
+
+	rel, err := client.RunWithContext(r.canceler.context, newRelease.Name, chart, values)
+	if err != nil {
+		return "", "", err
+	}
+	return deployed.Manifest, rel.Manifest, nil
+}
+
+// manifestObjectDiff is the change that an upgrade makes to an object of the release's manifest.
+type manifestObjectDiff struct {
+	// Key identifies the object, e.g. `Deployment.apps default/nginx`.
+	Key string
+	// Kind is whether the object is added, deleted or updated.
+	Kind pulumirpc.PropertyDiff_Kind
+	// Paths are the paths of the changed fields of an updated object, e.g. `spec.replicas`.
+	Paths []string
+}
+
+// manifestDetailedDiff returns the detailed diff entries of the changes to the objects of the release's manifest,
+// under the `manifest` property and keyed by object (e.g. `manifest["Deployment.apps default/nginx"]`), with an
+// entry per changed field of an updated object (e.g. `manifest["Deployment.apps default/nginx"].spec.replicas`).
+func manifestDetailedDiff(diffs []manifestObjectDiff) map[string]*pulumirpc.PropertyDiff {
+	detailedDiff := map[string]*pulumirpc.PropertyDiff{}
+	for _, d := range diffs {
+		key := resource.PropertyPath{"manifest", d.Key}.String()
+		if d.Kind != pulumirpc.PropertyDiff_UPDATE {
+			detailedDiff[key] = &pulumirpc.PropertyDiff{Kind: d.Kind}
+			continue
+		}
+		for _, p := range d.Paths {
+			if !strings.HasPrefix(p, "[") {
+				p = "." + p
+			}
+			detailedDiff[key+p] = &pulumirpc.PropertyDiff{Kind: d.Kind}
+		}
+	}
+	return detailedDiff
+}
+
+// diffManifests computes the changes between two rendered manifests, object by object, in the order of their keys.
+// Objects are keyed by kind and API group, namespace and name, e.g. `Deployment.apps default/nginx`. Only the
+// paths of the changed fields are reported, never their values, such that Secret data isn't disclosed.
+func diffManifests(oldManifest, newManifest string) ([]manifestObjectDiff, error) {
+	oldObjects, err := manifestObjects(oldManifest)
+	if err != nil {
+		return nil, fmt.Errorf("parsing the deployed manifest: %w", err)
+	}
+	newObjects, err := manifestObjects(newManifest)
+	if err != nil {
+		return nil, fmt.Errorf("parsing the rendered manifest: %w", err)
+	}
+
+	var diffs []manifestObjectDiff
+	for key, oldObj := range oldObjects {
+		newObj, ok := newObjects[key]
+		if !ok {
+			diffs = append(diffs, manifestObjectDiff{Key: key, Kind: pulumirpc.PropertyDiff_DELETE})
+			continue
+		}
+		changed := map[string]bool{}
+		diffManifestValues(nil, oldObj, newObj, changed)
+		if len(changed) > 0 {
+			diffs = append(diffs, manifestObjectDiff{
+				Key: key, Kind: pulumirpc.PropertyDiff_UPDATE, Paths: slices.Sorted(maps.Keys(changed)),
+			})
+		}
+	}
+	for key := range newObjects {
+		if _, ok := oldObjects[key]; !ok {
+			diffs = append(diffs, manifestObjectDiff{Key: key, Kind: pulumirpc.PropertyDiff_ADD})
+		}
+	}
+	slices.SortFunc(diffs, func(a, b manifestObjectDiff) int { return strings.Compare(a.Key, b.Key) })
+	return diffs, nil
+}
+
+// manifestObjects parses the manifest into a map of objects keyed by "Kind.group namespace/name" (or
+// "Kind.group name" for objects without a namespace, and without the group for the core API group).
+func manifestObjects(manifest string) (map[string]any, error) {
+	objects, _, err := convertYAMLManifestToJSON(manifest)
+	if err != nil {
+		return nil, err
+	}
+	result := make(map[string]any, len(objects))
+	for _, o := range objects {
+		obj, ok := o.(map[string]any)
+		if !ok {
+			continue
+		}
+		apiVersion, _ := obj["apiVersion"].(string)
+		kind, _ := obj["kind"].(string)
+		metadata, _ := obj["metadata"].(map[string]any)
+		name, _ := metadata["name"].(string)
+		key := kind
+		if group, _, ok := strings.Cut(apiVersion, "/"); ok {
+			key += "." + group
+		}
+		if namespace, _ := metadata["namespace"].(string); namespace != "" {
+			name = namespace + "/" + name
+		}
+		result[key+" "+name] = obj
+	}
+	return result, nil
+}
+
+// diffManifestValues recursively compares the values, adding the path of each leaf that was added, deleted or
+// updated to the changed paths.
+func diffManifestValues(path resource.PropertyPath, oldValue, newValue any, changed map[string]bool) {
+	switch {
+	case reflect.DeepEqual(oldValue, newValue) || equalNumbers(oldValue, newValue):
+		return
+	case oldValue == nil || newValue == nil:
+		changed[path.String()] = true
+		return
+	}
+
+	switch oldValue := oldValue.(type) {
+	case map[string]any:
+		if newValue, ok := newValue.(map[string]any); ok {
+			for k, v := range oldValue {
+				diffManifestValues(append(path[:len(path):len(path)], k), v, newValue[k], changed)
+			}
+			for k, v := range newValue {
+				if _, ok := oldValue[k]; !ok {
+					diffManifestValues(append(path[:len(path):len(path)], k), nil, v, changed)
+				}
+			}
+			return
+		}
+	case []any:
+		if newValue, ok := newValue.([]any); ok {
+			for i := 0; i < len(oldValue) || i < len(newValue); i++ {
+				var o, n any
+				if i < len(oldValue) {
+					o = oldValue[i]
+				}
+				if i < len(newValue) {
+					n = newValue[i]
+				}
+				diffManifestValues(append(path[:len(path):len(path)], i), o, n, changed)
+			}
+			return
+		}
+	}
+	changed[path.String()] = true
+}
+
+// manifestDiff returns the changes that upgrading the release would make in the cluster, object by object, as
+// detailed diff entries (see manifestDetailedDiff). This is best-effort: no changes are returned if the upgrade
+// can't be rendered (e.g. because the cluster is unreachable, or in render mode, where the release isn't
+// installed), and if it's disabled by `helmReleaseSettings.skipManifestDiff`.
+func (r *helmReleaseProvider) manifestDiff(newRelease, oldRelease *Release) map[string]*pulumirpc.PropertyDiff {
+	if r.clusterUnreachable || r.renderer != nil || r.skipManifestDiff {
+		return nil
+	}
+	oldManifest, newManifest, err := r.helmDiffManifests(newRelease, oldRelease)
+	if err != nil {
+		logger.V(3).Infof("unable to render the manifest diff of Helm release %s/%s: %v",
+			oldRelease.Namespace, oldRelease.Name, err)
+		return nil
+	}
+	diffs, err := diffManifests(oldManifest, newManifest)
+	if err != nil {
+		logger.V(3).Infof("unable to diff the manifest of Helm release %s/%s: %v",
+			oldRelease.Namespace, oldRelease.Name, err)
+		return nil
+	}
+	return manifestDetailedDiff(diffs)
+}
@@ -0,0 +1,130 @@
+// Copyright 2016-2026, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package provider
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	pulumirpc "github.com/pulumi/pulumi/sdk/v3/proto/go"
+)
+
+func TestDiffManifests(t *testing.T) {
+	oldManifest := `
+---
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: nginx
+  namespace: default
+  labels:
+    app.kubernetes.io/name: nginx
+spec:
+  replicas: 1
+  template:
+    spec:
+      containers:
+      - name: nginx
+        image: nginx:1.25
+---
+apiVersion: v1
+kind: Secret
+metadata:
+  name: credentials
+  namespace: default
+data:
+  password: c2VjcmV0
+---
+apiVersion: rbac.authorization.k8s.io/v1
+kind: ClusterRole
+metadata:
+  name: reader
+---
+apiVersion: extensions/v1beta1
+kind: Deployment
+metadata:
+  name: nginx
+  namespace: default
+`
+	newManifest := `
+---
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: nginx
+  namespace: default
+  labels:
+    app.kubernetes.io/name: nginx
+    app.kubernetes.io/version: "1.26"
+spec:
+  replicas: 1
+  template:
+    spec:
+      containers:
+      - name: nginx
+        image: nginx:1.26
+      - name: sidecar
+        image: busybox
+---
+apiVersion: v1
+kind: Secret
+metadata:
+  name: credentials
+  namespace: default
+data:
+  password: c2VjcmV0Mg==
+---
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: settings
+  namespace: default
+`
+
+	diffs, err := diffManifests(oldManifest, newManifest)
+	require.NoError(t, err)
+	assert.Equal(t, []manifestObjectDiff{
+		{Key: "ClusterRole.rbac.authorization.k8s.io reader", Kind: pulumirpc.PropertyDiff_DELETE},
+		{Key: "ConfigMap default/settings", Kind: pulumirpc.PropertyDiff_ADD},
+		{Key: "Deployment.apps default/nginx", Kind: pulumirpc.PropertyDiff_UPDATE, Paths: []string{
+			`metadata.labels["app.kubernetes.io/version"]`,
+			"spec.template.spec.containers[0].image",
+			"spec.template.spec.containers[1]",
+		}},
+		{Key: "Deployment.extensions default/nginx", Kind: pulumirpc.PropertyDiff_DELETE},
+		{Key: "Secret default/credentials", Kind: pulumirpc.PropertyDiff_UPDATE, Paths: []string{"data.password"}},
+	}, diffs)
+	assert.Equal(t, map[string]*pulumirpc.PropertyDiff{
+		`manifest["ClusterRole.rbac.authorization.k8s.io reader"]`: {Kind: pulumirpc.PropertyDiff_DELETE},
+		`manifest["ConfigMap default/settings"]`:                   {Kind: pulumirpc.PropertyDiff_ADD},
+		`manifest["Deployment.apps default/nginx"].metadata.labels["app.kubernetes.io/version"]`: {
+			Kind: pulumirpc.PropertyDiff_UPDATE,
+		},
+		`manifest["Deployment.apps default/nginx"].spec.template.spec.containers[0].image`: {
+			Kind: pulumirpc.PropertyDiff_UPDATE,
+		},
+		`manifest["Deployment.apps default/nginx"].spec.template.spec.containers[1]`: {
+			Kind: pulumirpc.PropertyDiff_UPDATE,
+		},
+		`manifest["Deployment.extensions default/nginx"]`:      {Kind: pulumirpc.PropertyDiff_DELETE},
+		`manifest["Secret default/credentials"].data.password`: {Kind: pulumirpc.PropertyDiff_UPDATE},
+	}, manifestDetailedDiff(diffs), "only the paths of the changes are reported, not their values")
+
+	diffs, err = diffManifests(oldManifest, oldManifest)
+	require.NoError(t, err)
+	assert.Empty(t, diffs)
+}
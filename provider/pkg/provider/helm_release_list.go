@@ -0,0 +1,121 @@
+// Copyright 2016-2026, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"helm.sh/helm/v3/pkg/action"
+
+	pulumirpc "github.com/pulumi/pulumi/sdk/v3/proto/go"
+)
+
+// listHelmReleases streams the identifiers of the Helm releases in the cluster, as recorded by the
+// configured Helm storage driver. The IDs are of the form `namespace/name`, as expected by Read when
+// importing a release.
+func (k *kubeProvider) listHelmReleases(
+	stream grpc.ServerStreamingServer[pulumirpc.ListResponse],
+	query listQuery,
+	contState continuationState,
+	pageSize int64,
+	remaining *int64,
+) error {
+	if query.fieldSelector != "" {
+		return status.Error(codes.InvalidArgument, "query.fieldSelector is not supported for Helm releases")
+	}
+	if contState.K8sContinue != "" {
+		return status.Error(codes.InvalidArgument, "continuation_token was not issued for Helm releases")
+	}
+
+	results, more, err := k.helmReleaseProvider.List(
+		stream.Context(), query, contState.HelmOffset, effectiveLimit(pageSize, remaining))
+	if err != nil {
+		return err
+	}
+	for _, result := range results {
+		if err := stream.Send(&pulumirpc.ListResponse{
+			Response: &pulumirpc.ListResponse_Result_{Result: result},
+		}); err != nil {
+			return err
+		}
+	}
+
+	sent := int64(len(results))
+	nextState := continuationState{}
+	if more {
+		nextState.HelmOffset = contState.HelmOffset + sent
+	}
+	if remaining != nil {
+		newRem := max(*remaining-sent, 0)
+		nextState.Remaining = &newRem
+	}
+	return sendContinuation(stream, nextState)
+}
+
+func (r *helmReleaseProvider) List(
+	ctx context.Context, query listQuery, offset, limit int64,
+) ([]*pulumirpc.ListResponse_Result, bool, error) {
+	if r.clusterUnreachable {
+		return nil, false, fmt.Errorf("configured Kubernetes cluster is unreachable: %s", r.clusterUnreachableReason)
+	}
+	// An empty namespace configures the storage driver to look across all namespaces.
+	actionConfig, err := r.getActionConfig(query.namespace)
+	if err != nil {
+		return nil, false, err
+	}
+	return listReleases(actionConfig, query, offset, limit)
+}
+
+// listReleases lists the latest revision of each release, ordered by name. Uninstalled releases, whose history is
+// kept, and releases being uninstalled are omitted, since they can't be imported.
+func listReleases(
+	actionConfig *action.Configuration, query listQuery, offset, limit int64,
+) ([]*pulumirpc.ListResponse_Result, bool, error) {
+	client := action.NewList(actionConfig)
+	client.AllNamespaces = query.namespace == ""
+	client.StateMask = action.ListAll &^ (action.ListUninstalled | action.ListUninstalling)
+	client.Selector = query.labelSelector
+	if query.name != "" {
+		client.Filter = "^" + regexp.QuoteMeta(query.name) + "$"
+	}
+	client.Offset = int(offset)
+	if limit > 0 {
+		// Ask for one more release than needed to learn whether another page exists.
+		client.Limit = int(limit) + 1
+	}
+
+	releases, err := client.Run()
+	if err != nil {
+		return nil, false, fmt.Errorf("listing Helm releases: %w", err)
+	}
+	more := limit > 0 && int64(len(releases)) > limit
+	if more {
+		releases = releases[:limit]
+	}
+
+	results := make([]*pulumirpc.ListResponse_Result, 0, len(releases))
+	for _, rel := range releases {
+		results = append(results, &pulumirpc.ListResponse_Result{
+			Id:   fqName(rel.Namespace, rel.Name),
+			Name: rel.Name,
+		})
+	}
+	return results, more, nil
+}
@@ -0,0 +1,92 @@
+// Copyright 2016-2026, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package provider
+
+import (
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"helm.sh/helm/v3/pkg/action"
+	kubefake "helm.sh/helm/v3/pkg/kube/fake"
+	"helm.sh/helm/v3/pkg/release"
+	"helm.sh/helm/v3/pkg/storage"
+	"helm.sh/helm/v3/pkg/storage/driver"
+)
+
+func TestListReleases(t *testing.T) {
+	mem := driver.NewMemory()
+	actionConfig := &action.Configuration{
+		Releases:   storage.Init(mem),
+		KubeClient: &kubefake.PrintingKubeClient{Out: io.Discard},
+		Log:        func(string, ...any) {},
+	}
+	for _, rel := range []*release.Release{
+		{Name: "nginx", Namespace: "web", Version: 1, Info: &release.Info{Status: release.StatusSuperseded}},
+		{Name: "nginx", Namespace: "web", Version: 2, Info: &release.Info{Status: release.StatusDeployed}},
+		{Name: "redis", Namespace: "cache", Version: 1, Info: &release.Info{Status: release.StatusFailed}},
+		{
+			Name: "postgres", Namespace: "db", Version: 1, Info: &release.Info{Status: release.StatusDeployed},
+			Labels: map[string]string{"team": "data"},
+		},
+		// Uninstalled with --keep-history, and being uninstalled.
+		{Name: "kafka", Namespace: "queue", Version: 1, Info: &release.Info{Status: release.StatusUninstalled}},
+		{Name: "mongo", Namespace: "db", Version: 1, Info: &release.Info{Status: release.StatusUninstalling}},
+	} {
+		require.NoError(t, actionConfig.Releases.Create(rel))
+	}
+	// Look across all namespaces, as an unscoped action configuration would.
+	mem.SetNamespace("")
+
+	ids := func(t *testing.T, query listQuery, offset, limit int64) ([]string, bool) {
+		t.Helper()
+		results, more, err := listReleases(actionConfig, query, offset, limit)
+		require.NoError(t, err)
+		var ids []string
+		for _, r := range results {
+			ids = append(ids, r.GetId())
+		}
+		return ids, more
+	}
+
+	t.Run("all", func(t *testing.T) {
+		got, more := ids(t, listQuery{}, 0, 0)
+		assert.Equal(t, []string{"web/nginx", "db/postgres", "cache/redis"}, got)
+		assert.False(t, more)
+	})
+	t.Run("paginated", func(t *testing.T) {
+		got, more := ids(t, listQuery{}, 0, 2)
+		assert.Equal(t, []string{"web/nginx", "db/postgres"}, got)
+		assert.True(t, more)
+
+		got, more = ids(t, listQuery{}, 2, 2)
+		assert.Equal(t, []string{"cache/redis"}, got)
+		assert.False(t, more)
+	})
+	t.Run("exact page", func(t *testing.T) {
+		got, more := ids(t, listQuery{}, 0, 3)
+		assert.Len(t, got, 3)
+		assert.False(t, more)
+	})
+	t.Run("by name", func(t *testing.T) {
+		got, _ := ids(t, listQuery{name: "nginx"}, 0, 0)
+		assert.Equal(t, []string{"web/nginx"}, got)
+	})
+	t.Run("by label", func(t *testing.T) {
+		got, _ := ids(t, listQuery{labelSelector: "team=data"}, 0, 0)
+		assert.Equal(t, []string{"db/postgres"}, got)
+	})
+}
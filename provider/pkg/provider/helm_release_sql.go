@@ -0,0 +1,140 @@
+// Copyright 2016-2026, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package provider
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"sync"
+
+	_ "github.com/lib/pq" // Register the PostgreSQL driver used by Helm's SQL storage driver.
+	"helm.sh/helm/v3/pkg/storage/driver"
+
+	logger "github.com/pulumi/pulumi/sdk/v3/go/common/util/logging"
+)
+
+// helmSQLDriver is the name of Helm's SQL storage driver, which stores releases in PostgreSQL.
+const helmSQLDriver = "sql"
+
+var errNoHelmSQLConnectionString = errors.New(
+	"the sql Helm driver requires a connection string (set helmReleaseSettings.sqlConnectionString)")
+
+// helmSQLStorage manages the connections to the database that backs Helm's SQL storage driver.
+type helmSQLStorage struct {
+	connectionString string
+	// newDriver creates the storage driver for a namespace, and openDB opens the database that holds the locks
+	// of the releases. They're Helm's SQL driver and PostgreSQL, but for tests.
+	newDriver func(connectionString, namespace string) (driver.Driver, error)
+	openDB    func(connectionString string) (*sql.DB, error)
+
+	mu      sync.Mutex
+	drivers map[string]driver.Driver
+	lockDB  *sql.DB
+}
+
+func newHelmSQLStorage(connectionString string) *helmSQLStorage {
+	return &helmSQLStorage{
+		connectionString: connectionString,
+		newDriver: func(connectionString, namespace string) (driver.Driver, error) {
+			return driver.NewSQL(connectionString, debug, namespace)
+		},
+		openDB: func(connectionString string) (*sql.DB, error) {
+			return sql.Open("postgres", connectionString)
+		},
+		drivers: map[string]driver.Driver{},
+	}
+}
+
+// driver returns the storage driver for the given namespace. Helm's SQL driver is bound to a namespace
+// (all namespaces if empty) and applies the schema migrations when it is created, so the drivers are
+// created once and reused, along with their connection pools.
+func (s *helmSQLStorage) driver(namespace string) (driver.Driver, error) {
+	if s.connectionString == "" {
+		return nil, errNoHelmSQLConnectionString
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if d, ok := s.drivers[namespace]; ok {
+		return d, nil
+	}
+	d, err := s.newDriver(s.connectionString, namespace)
+	if err != nil {
+		return nil, fmt.Errorf("unable to connect to the Helm SQL storage: %w", err)
+	}
+	s.drivers[namespace] = d
+	return d, nil
+}
+
+// lockRelease acquires an exclusive lock on the release, held until the returned function is called.
+// It serializes the operations on a release across all the programs that share the storage, e.g. stacks
+// that manage the same release from different pipelines. Helm's Kubernetes-backed drivers rely on the
+// release's pending status for this, which the SQL driver doesn't record atomically.
+//
+// The lock is a PostgreSQL session-level advisory lock, so it is released if the provider goes away
+// mid-operation. Acquiring the lock waits until it is available or the context is done.
+func (s *helmSQLStorage) lockRelease(ctx context.Context, namespace, name string) (func(), error) {
+	db, err := s.db()
+	if err != nil {
+		return nil, err
+	}
+	conn, err := db.Conn(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("unable to connect to the Helm SQL storage: %w", err)
+	}
+	key := releaseLockKey(namespace, name)
+	if _, err := conn.ExecContext(ctx, "SELECT pg_advisory_lock($1)", key); err != nil {
+		_ = conn.Close()
+		return nil, fmt.Errorf("unable to lock Helm release %q: %w", fqName(namespace, name), err)
+	}
+	logger.V(9).Infof("Locked Helm release %q", fqName(namespace, name))
+
+	return func() {
+		if _, err := conn.ExecContext(context.Background(), "SELECT pg_advisory_unlock($1)", key); err != nil {
+			// Closing the connection ends the session, which releases the lock anyway.
+			logger.V(3).Infof("Unable to unlock Helm release %q: %v", fqName(namespace, name), err)
+		}
+		_ = conn.Close()
+	}, nil
+}
+
+func (s *helmSQLStorage) db() (*sql.DB, error) {
+	if s.connectionString == "" {
+		return nil, errNoHelmSQLConnectionString
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.lockDB == nil {
+		db, err := s.openDB(s.connectionString)
+		if err != nil {
+			return nil, fmt.Errorf("unable to connect to the Helm SQL storage: %w", err)
+		}
+		// Don't keep idle connections, so that a session (and any lock it holds) ends with its operation.
+		db.SetMaxIdleConns(0)
+		s.lockDB = db
+	}
+	return s.lockDB, nil
+}
+
+// releaseLockKey returns the advisory lock key for the given release.
+func releaseLockKey(namespace, name string) int64 {
+	h := fnv.New64a()
+	_, _ = h.Write([]byte("helm.sh/release/" + namespace + "/" + name))
+	return int64(h.Sum64()) //nolint:gosec // The key is opaque, so the sign doesn't matter.
+}
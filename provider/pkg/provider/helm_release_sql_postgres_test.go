@@ -0,0 +1,176 @@
+// Copyright 2016-2026, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build postgres
+
+// The tests of the SQL storage against PostgreSQL, which embedded-postgres downloads and runs (as a non-root user):
+//
+//	go test -tags postgres -run TestHelmSQLStoragePostgres ./pkg/provider/
+
+package provider
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"io"
+	"net"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	embeddedpostgres "github.com/fergusstrange/embedded-postgres"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"helm.sh/helm/v3/pkg/action"
+	kubefake "helm.sh/helm/v3/pkg/kube/fake"
+	"helm.sh/helm/v3/pkg/release"
+	"helm.sh/helm/v3/pkg/storage"
+)
+
+// startPostgres starts a PostgreSQL server for the duration of the test, and returns its connection string.
+func startPostgres(t *testing.T) string {
+	t.Helper()
+	l, err := net.Listen("tcp", "localhost:0")
+	require.NoError(t, err)
+	port := l.Addr().(*net.TCPAddr).Port
+	require.NoError(t, l.Close())
+
+	config := embeddedpostgres.DefaultConfig().
+		Port(uint32(port)). //nolint:gosec // A TCP port fits.
+		RuntimePath(t.TempDir()).
+		StartTimeout(time.Minute).
+		Logger(io.Discard)
+	db := embeddedpostgres.NewDatabase(config)
+	require.NoError(t, db.Start())
+	t.Cleanup(func() { assert.NoError(t, db.Stop()) })
+	return config.GetConnectionURL() + "?sslmode=disable"
+}
+
+// tryLock reports whether another session can take the advisory lock, i.e. whether the lock is free.
+func tryLock(t *testing.T, db *sql.DB, key int64) bool {
+	t.Helper()
+	conn, err := db.Conn(context.Background())
+	require.NoError(t, err)
+	defer func() { _ = conn.Close() }()
+	var locked bool
+	require.NoError(t, conn.QueryRowContext(context.Background(), "SELECT pg_try_advisory_lock($1)", key).Scan(&locked))
+	if locked {
+		_, err := conn.ExecContext(context.Background(), "SELECT pg_advisory_unlock($1)", key)
+		require.NoError(t, err)
+	}
+	return locked
+}
+
+func TestHelmSQLStoragePostgres(t *testing.T) {
+	connectionString := startPostgres(t)
+	db, err := sql.Open("postgres", connectionString)
+	require.NoError(t, err)
+	defer func() { _ = db.Close() }()
+	const ns = "test"
+
+	t.Run("storage", func(t *testing.T) {
+		s := newHelmSQLStorage(connectionString)
+		d, err := s.driver(ns)
+		require.NoError(t, err)
+
+		// The migrations are applied once, and again (as a no-op) by another provider.
+		var tables int
+		require.NoError(t, db.QueryRow(
+			"SELECT count(*) FROM information_schema.tables WHERE table_name = 'releases_v1'").Scan(&tables))
+		assert.Equal(t, 1, tables)
+		_, err = newHelmSQLStorage(connectionString).driver(ns)
+		require.NoError(t, err)
+
+		actionConfig := &action.Configuration{
+			Releases:   storage.Init(d),
+			KubeClient: &kubefake.PrintingKubeClient{Out: io.Discard},
+			Log:        func(string, ...any) {},
+		}
+		for _, name := range []string{"nginx", "redis"} {
+			require.NoError(t, actionConfig.Releases.Create(&release.Release{
+				Name: name, Namespace: ns, Version: 1, Info: &release.Info{Status: release.StatusDeployed},
+			}))
+		}
+		other, err := s.driver("other")
+		require.NoError(t, err)
+		require.NoError(t, storage.Init(other).Create(&release.Release{
+			Name: "nginx", Namespace: "other", Version: 1, Info: &release.Info{Status: release.StatusDeployed},
+		}))
+
+		results, more, err := listReleases(actionConfig, listQuery{namespace: ns}, 0, 0)
+		require.NoError(t, err)
+		assert.False(t, more)
+		var ids []string
+		for _, r := range results {
+			ids = append(ids, r.GetId())
+		}
+		assert.Equal(t, []string{ns + "/nginx", ns + "/redis"}, ids)
+	})
+
+	t.Run("lock", func(t *testing.T) {
+		s := newHelmSQLStorage(connectionString)
+		key := releaseLockKey(ns, "nginx")
+		unlock, err := s.lockRelease(context.Background(), ns, "nginx")
+		require.NoError(t, err)
+		assert.False(t, tryLock(t, db, key))
+
+		// Another release isn't affected.
+		assert.True(t, tryLock(t, db, releaseLockKey(ns, "redis")))
+
+		// The lock isn't reentrant across the operations of a provider, since each has its own session.
+		ctx, cancel := context.WithTimeout(context.Background(), 500*time.Millisecond)
+		defer cancel()
+		_, err = s.lockRelease(ctx, ns, "nginx")
+		assert.ErrorContains(t, err, `unable to lock Helm release "test/nginx"`)
+
+		// Only the session that holds the lock can release it.
+		var unlocked bool
+		require.NoError(t, db.QueryRow("SELECT pg_advisory_unlock($1)", key).Scan(&unlocked))
+		assert.False(t, unlocked)
+		assert.False(t, tryLock(t, db, key))
+
+		unlock()
+		assert.True(t, tryLock(t, db, key))
+	})
+
+	t.Run("concurrent lockers", func(t *testing.T) {
+		// Two providers (e.g. two stacks) operate on the same release; their operations never overlap.
+		storages := []*helmSQLStorage{newHelmSQLStorage(connectionString), newHelmSQLStorage(connectionString)}
+		var holders, overlaps atomic.Int32
+		var wg sync.WaitGroup
+		for i, s := range storages {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				for range 10 {
+					unlock, err := s.lockRelease(context.Background(), ns, "nginx")
+					if !assert.NoError(t, err, fmt.Sprintf("locker %d", i)) {
+						return
+					}
+					if holders.Add(1) > 1 {
+						overlaps.Add(1)
+					}
+					time.Sleep(5 * time.Millisecond)
+					holders.Add(-1)
+					unlock()
+				}
+			}()
+		}
+		wg.Wait()
+		assert.Zero(t, overlaps.Load())
+		assert.True(t, tryLock(t, db, releaseLockKey(ns, "nginx")))
+	})
+}
@@ -0,0 +1,212 @@
+// Copyright 2016-2026, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package provider
+
+import (
+	"context"
+	"database/sql"
+	sqldriver "database/sql/driver"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"helm.sh/helm/v3/pkg/action"
+	kubefake "helm.sh/helm/v3/pkg/kube/fake"
+	"helm.sh/helm/v3/pkg/release"
+	"helm.sh/helm/v3/pkg/storage"
+	"helm.sh/helm/v3/pkg/storage/driver"
+)
+
+func TestReleaseLockKey(t *testing.T) {
+	assert.Equal(t, releaseLockKey("default", "nginx"), releaseLockKey("default", "nginx"))
+	assert.NotEqual(t, releaseLockKey("default", "nginx"), releaseLockKey("web", "nginx"))
+	assert.NotEqual(t, releaseLockKey("default", "nginx"), releaseLockKey("default", "redis"))
+}
+
+func TestHelmSQLStorage_NoConnectionString(t *testing.T) {
+	s := newHelmSQLStorage("")
+	_, err := s.driver("default")
+	assert.ErrorIs(t, err, errNoHelmSQLConnectionString)
+	_, err = s.lockRelease(context.Background(), "default", "nginx")
+	assert.ErrorIs(t, err, errNoHelmSQLConnectionString)
+}
+
+func TestHelmSQLStorage(t *testing.T) {
+	const connectionString = "postgresql://helm@db:5432/helm"
+	locks := &fakeAdvisoryLocks{held: map[int64]*fakeAdvisoryLockConn{}, changed: make(chan struct{})}
+	newStorage := func() *helmSQLStorage {
+		s := newHelmSQLStorage(connectionString)
+		s.newDriver = func(cs, namespace string) (driver.Driver, error) {
+			assert.Equal(t, connectionString, cs)
+			d := driver.NewMemory()
+			d.SetNamespace(namespace)
+			return d, nil
+		}
+		s.openDB = func(cs string) (*sql.DB, error) {
+			assert.Equal(t, connectionString, cs)
+			return sql.OpenDB(locks), nil
+		}
+		return s
+	}
+	s := newStorage()
+	const ns = "test"
+
+	t.Run("storage", func(t *testing.T) {
+		d, err := s.driver(ns)
+		require.NoError(t, err)
+		again, err := s.driver(ns)
+		require.NoError(t, err)
+		assert.Same(t, d, again, "expected the driver to be reused")
+		other, err := s.driver("other")
+		require.NoError(t, err)
+		assert.NotSame(t, d, other)
+
+		actionConfig := &action.Configuration{
+			Releases:   storage.Init(d),
+			KubeClient: &kubefake.PrintingKubeClient{Out: io.Discard},
+			Log:        func(string, ...any) {},
+		}
+		for _, name := range []string{"nginx", "redis"} {
+			require.NoError(t, actionConfig.Releases.Create(&release.Release{
+				Name: name, Namespace: ns, Version: 1, Info: &release.Info{Status: release.StatusDeployed},
+			}))
+		}
+
+		results, more, err := listReleases(actionConfig, listQuery{namespace: ns}, 0, 0)
+		require.NoError(t, err)
+		assert.False(t, more)
+		var ids []string
+		for _, r := range results {
+			ids = append(ids, r.GetId())
+		}
+		assert.Equal(t, []string{ns + "/nginx", ns + "/redis"}, ids)
+	})
+
+	t.Run("lock", func(t *testing.T) {
+		unlock, err := s.lockRelease(context.Background(), ns, "nginx")
+		require.NoError(t, err)
+
+		// Another release isn't affected.
+		unlockOther, err := s.lockRelease(context.Background(), ns, "redis")
+		require.NoError(t, err)
+		unlockOther()
+
+		// The same release waits for the lock, from another provider too.
+		ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+		defer cancel()
+		_, err = newStorage().lockRelease(ctx, ns, "nginx")
+		assert.ErrorContains(t, err, `unable to lock Helm release "test/nginx"`)
+
+		acquired := make(chan func())
+		go func() {
+			unlock, err := newStorage().lockRelease(context.Background(), ns, "nginx")
+			assert.NoError(t, err)
+			acquired <- unlock
+		}()
+		select {
+		case <-acquired:
+			t.Fatal("expected the lock to be held")
+		case <-time.After(50 * time.Millisecond):
+		}
+		unlock()
+		(<-acquired)()
+	})
+}
+
+// fakeAdvisoryLocks is a database/sql connector whose connections implement the session-level advisory locks
+// of PostgreSQL in memory.
+type fakeAdvisoryLocks struct {
+	mu   sync.Mutex
+	held map[int64]*fakeAdvisoryLockConn
+	// changed is closed (and replaced) whenever a lock is released.
+	changed chan struct{}
+}
+
+func (l *fakeAdvisoryLocks) Connect(context.Context) (sqldriver.Conn, error) {
+	return &fakeAdvisoryLockConn{locks: l}, nil
+}
+
+func (l *fakeAdvisoryLocks) Driver() sqldriver.Driver { return nil }
+
+// release releases the locks held by the connection that match.
+func (l *fakeAdvisoryLocks) release(c *fakeAdvisoryLockConn, match func(key int64) bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	for key, holder := range l.held {
+		if holder == c && match(key) {
+			delete(l.held, key)
+		}
+	}
+	close(l.changed)
+	l.changed = make(chan struct{})
+}
+
+type fakeAdvisoryLockConn struct {
+	locks *fakeAdvisoryLocks
+}
+
+func (c *fakeAdvisoryLockConn) ExecContext(
+	ctx context.Context, query string, args []sqldriver.NamedValue,
+) (sqldriver.Result, error) {
+	if len(args) != 1 {
+		return nil, fmt.Errorf("unexpected arguments: %v", args)
+	}
+	key, ok := args[0].Value.(int64)
+	if !ok {
+		return nil, fmt.Errorf("unexpected argument: %v", args[0].Value)
+	}
+	switch query {
+	case "SELECT pg_advisory_lock($1)":
+		for {
+			c.locks.mu.Lock()
+			if holder, ok := c.locks.held[key]; !ok || holder == c {
+				c.locks.held[key] = c
+				c.locks.mu.Unlock()
+				return sqldriver.RowsAffected(0), nil
+			}
+			changed := c.locks.changed
+			c.locks.mu.Unlock()
+			select {
+			case <-ctx.Done():
+				return nil, ctx.Err()
+			case <-changed:
+			}
+		}
+	case "SELECT pg_advisory_unlock($1)":
+		c.locks.release(c, func(k int64) bool { return k == key })
+		return sqldriver.RowsAffected(0), nil
+	default:
+		return nil, fmt.Errorf("unexpected query: %s", query)
+	}
+}
+
+func (c *fakeAdvisoryLockConn) Prepare(string) (sqldriver.Stmt, error) {
+	return nil, errors.New("not supported")
+}
+
+// Close ends the session, which releases its locks.
+func (c *fakeAdvisoryLockConn) Close() error {
+	c.locks.release(c, func(int64) bool { return true })
+	return nil
+}
+
+func (c *fakeAdvisoryLockConn) Begin() (sqldriver.Tx, error) {
+	return nil, errors.New("not supported")
+}
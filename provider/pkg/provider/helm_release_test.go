@@ -16,10 +16,14 @@ package provider
 
 import (
 	"context"
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	helmchart "helm.sh/helm/v3/pkg/chart"
+	"helm.sh/helm/v3/pkg/cli"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 
 	"github.com/pulumi/pulumi/sdk/v3/go/common/resource"
@@ -266,3 +270,135 @@ spec:
 	require.NotNil(t, cache.GetCRD(schema.GroupKind{Group: "example.com", Kind: "Widget"}))
 	require.Nil(t, cache.GetCRD(schema.GroupKind{Group: "", Kind: "ConfigMap"}))
 }
+
+func TestCheckValuesSchema(t *testing.T) {
+	c := &helmchart.Chart{
+		Metadata: &helmchart.Metadata{APIVersion: helmchart.APIVersionV2, Name: "app", Version: "1.0.0"},
+		Schema: []byte(`{
+			"type": "object",
+			"properties": {
+				"replicas": {"type": "integer"},
+				"ports": {"type": "array", "items": {"type": "integer"}},
+				"password": {"type": "string", "pattern": "^[a-z]+$"}
+			}
+		}`),
+	}
+
+	failures := checkValuesSchema(c, &Release{Values: map[string]any{"replicas": 2}}, false)
+	assert.Empty(t, failures)
+
+	failures = checkValuesSchema(c, &Release{Values: map[string]any{
+		"replicas": "two",
+		"ports":    []any{80, "http"},
+	}}, false)
+	require.Len(t, failures, 2)
+	props := []string{failures[0].Property, failures[1].Property}
+	assert.ElementsMatch(t, []string{"values.replicas", "values.ports[1]"}, props)
+	for _, f := range failures {
+		assert.Contains(t, f.Reason, `(chart "app")`)
+	}
+
+	// Secret values aren't shown.
+	values := &Release{Values: map[string]any{"password": "hunter2"}}
+	failures = checkValuesSchema(c, values, false)
+	require.Len(t, failures, 1)
+	assert.Contains(t, failures[0].Reason, "hunter2")
+	failures = checkValuesSchema(c, values, true)
+	require.Len(t, failures, 1)
+	assert.Equal(t, "values.password", failures[0].Property)
+	assert.Equal(t, `value doesn't satisfy the "pattern" keyword of the schema (chart "app")`, failures[0].Reason)
+}
+
+func TestCheckpointReleaseNotes(t *testing.T) {
+	release := &Release{Name: "test", Notes: "The password is hunter2."}
+
+	plain := checkpointRelease(resource.PropertyMap{"name": resource.NewStringProperty("test")}, release, "test", false)
+	assert.Equal(t, resource.NewStringProperty("The password is hunter2."), plain["notes"])
+
+	secret := checkpointRelease(resource.PropertyMap{
+		"values": resource.NewObjectProperty(resource.PropertyMap{
+			"password": resource.MakeSecret(resource.NewStringProperty("hunter2")),
+		}),
+	}, release, "test", false)
+	assert.True(t, secret["notes"].IsSecret())
+
+	preview := checkpointRelease(resource.PropertyMap{}, release, "test", true)
+	assert.True(t, preview["notes"].IsComputed())
+}
+
+func TestHelmRender(t *testing.T) {
+	dir := t.TempDir()
+	chart := filepath.Join(dir, "app")
+	files := map[string]string{
+		"Chart.yaml": "apiVersion: v2\nname: app\nversion: 1.0.0\n",
+		"templates/configmap.yaml": `{{- if .Values.config }}
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: config
+{{- end }}
+`,
+		"templates/namespace.yaml": `apiVersion: v1
+kind: Namespace
+metadata:
+  name: {{ .Release.Namespace }}
+`,
+		"templates/migrate.yaml": `apiVersion: batch/v1
+kind: Job
+metadata:
+  name: migrate
+  annotations:
+    helm.sh/hook: pre-upgrade
+spec:
+  template:
+    spec:
+      restartPolicy: Never
+      containers:
+      - name: migrate
+        image: migrate
+`,
+	}
+	for name, content := range files {
+		require.NoError(t, os.MkdirAll(filepath.Dir(filepath.Join(chart, name)), 0o700))
+		require.NoError(t, os.WriteFile(filepath.Join(chart, name), []byte(content), 0o600))
+	}
+
+	out := filepath.Join(dir, "out")
+	r := &helmReleaseProvider{
+		canceler:   makeCancellationContext(),
+		clientSet:  &clients.DynamicClientSet{},
+		helmDriver: "memory",
+		settings:   cli.New(),
+		renderer:   newYamlRenderer(out, renderYamlFormatNamespaces, nil, nil),
+	}
+	urn := resource.NewURN("stack", "project", "", "kubernetes:helm.sh/v3:Release", "app")
+	release := &Release{Name: "app", Namespace: "web", Chart: chart, Values: map[string]any{"config": true}}
+	require.NoError(t, r.helmRender(context.Background(), urn, release, nil))
+
+	objs, err := readRenderedObjects(filepath.Join(out, "web.yaml"))
+	require.NoError(t, err)
+	require.Len(t, objs, 1)
+	assert.Equal(t, "ConfigMap", objs[0].GetKind())
+	assert.Equal(t, "web", objs[0].GetNamespace(), "the namespaced objects are in the release's namespace")
+	objs, err = readRenderedObjects(filepath.Join(out, "_cluster.yaml"))
+	require.NoError(t, err)
+	require.Len(t, objs, 1)
+	assert.Equal(t, "Namespace", objs[0].GetKind())
+	hooks, err := readRenderedObjects(filepath.Join(out, "hooks", "web-app.yaml"))
+	require.NoError(t, err)
+	require.Len(t, hooks, 1)
+	assert.Equal(t, "Job", hooks[0].GetKind())
+	assert.Equal(t, "web", hooks[0].GetNamespace())
+	assert.Equal(t, map[string][]string{"ConfigMap/v1": {"web/config"}, "Namespace/v1": {"web"}}, release.ResourceNames)
+
+	// The objects that the chart no longer renders are removed.
+	oldRelease := release
+	release = &Release{Name: "app", Namespace: "web", Chart: chart, Values: map[string]any{"config": false}}
+	require.NoError(t, r.helmRender(context.Background(), urn, release, oldRelease))
+	assert.NoFileExists(t, filepath.Join(out, "web.yaml"))
+	assert.FileExists(t, filepath.Join(out, "_cluster.yaml"))
+
+	require.NoError(t, r.helmRenderDelete(urn, release))
+	assert.NoFileExists(t, filepath.Join(out, "_cluster.yaml"))
+	assert.NoFileExists(t, filepath.Join(out, "hooks", "web-app.yaml"))
+}
@@ -16,17 +16,26 @@ package v2
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"maps"
+	"path"
+	"path/filepath"
 
+	"github.com/mitchellh/mapstructure"
+	"sigs.k8s.io/kustomize/api/konfig"
 	"sigs.k8s.io/kustomize/api/krusty"
 	kresmap "sigs.k8s.io/kustomize/api/resmap"
 	ktypes "sigs.k8s.io/kustomize/api/types"
 	kfilesys "sigs.k8s.io/kustomize/kyaml/filesys"
+	"sigs.k8s.io/yaml"
 
 	"github.com/pulumi/pulumi/sdk/v3/go/pulumi"
 	"github.com/pulumi/pulumi/sdk/v3/go/pulumi/internals"
 	pulumiprovider "github.com/pulumi/pulumi/sdk/v3/go/pulumi/provider"
 
+	"github.com/pulumi/pulumi-kubernetes/provider/v4/pkg/helm"
+	"github.com/pulumi/pulumi-kubernetes/provider/v4/pkg/kustomize"
 	providerresource "github.com/pulumi/pulumi-kubernetes/provider/v4/pkg/provider/resource"
 	provideryamlv2 "github.com/pulumi/pulumi-kubernetes/provider/v4/pkg/provider/yaml/v2"
 )
@@ -41,17 +50,44 @@ type DirectoryProvider struct {
 }
 
 type DirectoryArgs struct {
-	Directory      pulumi.StringInput `pulumi:"directory"`
-	Namespace      pulumi.StringInput `pulumi:"namespace,optional"`
-	ResourcePrefix pulumi.StringInput `pulumi:"resourcePrefix,optional"`
-	SkipAwait      pulumi.BoolInput   `pulumi:"skipAwait,optional"`
+	Directory          pulumi.StringInput    `pulumi:"directory,optional"`
+	Kustomization      pulumi.MapInput       `pulumi:"kustomization,optional"`
+	Files              pulumi.StringMapInput `pulumi:"files,optional"`
+	Namespace          pulumi.StringInput    `pulumi:"namespace,optional"`
+	ResourcePrefix     pulumi.StringInput    `pulumi:"resourcePrefix,optional"`
+	SkipAwait          pulumi.BoolInput      `pulumi:"skipAwait,optional"`
+	LoadRestrictor     pulumi.StringInput    `pulumi:"loadRestrictor,optional"`
+	EnableHelm         pulumi.BoolInput      `pulumi:"enableHelm,optional"`
+	HelmCommand        pulumi.StringInput    `pulumi:"helmCommand,optional"`
+	EnableAlphaPlugins pulumi.BoolInput      `pulumi:"enableAlphaPlugins,optional"`
+	RemoteBases        pulumi.MapInput       `pulumi:"remoteBases,optional"`
+	Patches            pulumi.ArrayInput     `pulumi:"patches,optional"`
+	Include            pulumi.ArrayInput     `pulumi:"include,optional"`
+	Exclude            pulumi.ArrayInput     `pulumi:"exclude,optional"`
 }
 
 type directoryArgs struct {
-	Directory      string
-	Namespace      string
-	ResourcePrefix *string
-	SkipAwait      bool
+	Directory          string
+	Kustomization      map[string]any
+	Files              map[string]string
+	Namespace          string
+	ResourcePrefix     *string
+	SkipAwait          bool
+	LoadRestrictions   ktypes.LoadRestrictions
+	EnableHelm         bool
+	HelmCommand        string
+	EnableAlphaPlugins bool
+	RemoteBases        RemoteBasesOpts
+	Patches            []provideryamlv2.Patch
+	Selection          *provideryamlv2.Selection
+}
+
+// RemoteBasesOpts configures the fetching of remote git bases.
+type RemoteBasesOpts struct {
+	// RequirePinnedRefs requires the remote bases to be pinned to a ref (e.g. `?ref=v1.0.0`).
+	RequirePinnedRefs bool `mapstructure:"requirePinnedRefs"`
+	// DisableCache disables the caching of remote bases, such that kustomize clones them for every build.
+	DisableCache bool `mapstructure:"disableCache"`
 }
 
 func unwrapDirectoryArgs(
@@ -59,7 +95,9 @@ func unwrapDirectoryArgs(
 	args *DirectoryArgs,
 ) (*directoryArgs, internals.UnsafeAwaitOutputResult, error) {
 	result, err := internals.UnsafeAwaitOutput(ctx, pulumi.All(
-		args.Directory, args.Namespace, args.ResourcePrefix, args.SkipAwait))
+		args.Directory, args.Kustomization, args.Files, args.Namespace, args.ResourcePrefix, args.SkipAwait,
+		args.LoadRestrictor, args.EnableHelm, args.HelmCommand, args.EnableAlphaPlugins, args.RemoteBases,
+		args.Patches, args.Include, args.Exclude))
 	if err != nil || !result.Known {
 		return nil, result, err
 	}
@@ -71,18 +109,54 @@ func unwrapDirectoryArgs(
 
 	r := &directoryArgs{}
 	r.Directory, _ = pop().(string)
+	r.Kustomization, _ = pop().(map[string]any)
+	r.Files, _ = pop().(map[string]string)
 	r.Namespace, _ = pop().(string)
 	if v, ok := pop().(string); ok {
 		r.ResourcePrefix = &v
 	}
 	r.SkipAwait, _ = pop().(bool)
+	switch v, _ := pop().(string); v {
+	case "", ktypes.LoadRestrictionsNone.String():
+		r.LoadRestrictions = ktypes.LoadRestrictionsNone
+	case ktypes.LoadRestrictionsRootOnly.String():
+		r.LoadRestrictions = ktypes.LoadRestrictionsRootOnly
+	default:
+		return nil, result, fmt.Errorf("loadRestrictor must be %q or %q",
+			ktypes.LoadRestrictionsNone, ktypes.LoadRestrictionsRootOnly)
+	}
+	r.EnableHelm = true
+	if v, ok := pop().(bool); ok {
+		r.EnableHelm = v
+	}
+	r.HelmCommand, _ = pop().(string)
+	r.EnableAlphaPlugins = true
+	if v, ok := pop().(bool); ok {
+		r.EnableAlphaPlugins = v
+	}
+	if v, ok := pop().(map[string]any); ok {
+		if err := mapstructure.Decode(v, &r.RemoteBases); err != nil {
+			return nil, result, fmt.Errorf("remoteBases: %w", err)
+		}
+	}
+	if v, ok := pop().([]any); ok {
+		if r.Patches, err = provideryamlv2.DecodePatches(v); err != nil {
+			return nil, result, err
+		}
+	}
+	include, _ := pop().([]any)
+	exclude, _ := pop().([]any)
+	if r.Selection, err = provideryamlv2.DecodeSelection(include, exclude); err != nil {
+		return nil, result, err
+	}
 
 	return r, result, nil
 }
 
 type DirectoryState struct {
 	pulumi.ResourceState
-	Resources pulumi.ArrayOutput `pulumi:"resources"`
+	Resources pulumi.ArrayOutput       `pulumi:"resources"`
+	Skipped   pulumi.StringArrayOutput `pulumi:"skipped"`
 }
 
 var _ providerresource.ResourceProvider = &DirectoryProvider{}
@@ -129,11 +203,40 @@ func (r *DirectoryProvider) Construct(
 		directoryArgs.ResourcePrefix = &name
 	}
 
+	// Prepare the file system, which fetches the remote bases and inflates the Helm charts in-process.
+	fsOpts := kustomize.Options{
+		LoadRestrictions:  directoryArgs.LoadRestrictions,
+		RequirePinnedRefs: directoryArgs.RemoteBases.RequirePinnedRefs,
+	}
+	if directoryArgs.EnableHelm && directoryArgs.HelmCommand == "" {
+		fsOpts.HelmTool = r.helmTool
+	}
+	if !directoryArgs.RemoteBases.DisableCache && r.opts.KustomizeOptions != nil {
+		fsOpts.RemoteCache = r.opts.KustomizeOptions.RemoteCache
+	}
+	var baseFs kfilesys.FileSystem = kfilesys.MakeFsOnDisk()
+	dir := directoryArgs.Directory
+	if directoryArgs.Kustomization != nil || len(directoryArgs.Files) > 0 {
+		baseFs, dir, err = makeInMemoryFileSystem(directoryArgs)
+		if err != nil {
+			return nil, err
+		}
+	} else if dir == "" {
+		return nil, errors.New("either directory or kustomization must be set")
+	}
+	fSys := kustomize.NewFileSystem(ctx.Context(), baseFs, fsOpts)
+	dir, err = fSys.LocateDirectory(dir)
+	if err != nil {
+		return nil, fmt.Errorf("kustomize build error: %w", err)
+	}
+
 	// Execute the kustomize command to generate the Kubernetes manifest.
 	k := r.makeKustomizer(directoryArgs)
-	fSys := kfilesys.MakeFsOnDisk()
-	rm, err := k.Run(fSys, directoryArgs.Directory)
+	rm, err := k.Run(fSys, dir)
 	if err != nil {
+		if fsErr := fSys.Err(); fsErr != nil {
+			err = fsErr
+		}
 		return nil, fmt.Errorf("kustomize build error: %w", err)
 	}
 	manifest, err := rm.AsYaml()
@@ -150,6 +253,12 @@ func (r *DirectoryProvider) Construct(
 		return nil, err
 	}
 
+	// Patch the objects, if any patches are given.
+	objs, err = provideryamlv2.ApplyPatches(objs, directoryArgs.Patches)
+	if err != nil {
+		return nil, err
+	}
+
 	// Normalize the objects (apply a default namespace, etc.)
 	ns := r.opts.DefaultNamespace
 	if directoryArgs.Namespace != "" {
@@ -161,6 +270,10 @@ func (r *DirectoryProvider) Construct(
 	}
 	provideryamlv2.WarnUnresolvedNamespaceScope(ctx, unresolvedScope)
 
+	// Select the objects to register, and report the skipped objects.
+	objs = directoryArgs.Selection.Select(objs)
+	comp.Skipped = pulumi.ToStringArray(directoryArgs.Selection.Skipped()).ToStringArrayOutputWithContext(ctx.Context())
+
 	// Register the objects as Pulumi resources.
 	registerOpts := provideryamlv2.RegisterOptions{
 		Objects:         objs,
@@ -177,16 +290,59 @@ func (r *DirectoryProvider) Construct(
 	return pulumiprovider.NewConstructResult(comp)
 }
 
-// makeKustomizer prepares the kustomize tool with helm support, full permission to use plugins, and no load
-// restrictions
-func makeKustomizer(_ /* args */ *directoryArgs) kustomizer {
+// makeInMemoryFileSystem materialises the inline kustomization and files of the component into an in-memory
+// file system, returning the file system and the directory of the kustomization within it.
+func makeInMemoryFileSystem(args *directoryArgs) (kfilesys.FileSystem, string, error) {
+	dir := args.Directory
+	if _, ok := kustomize.ParseRemoteBase(dir); ok || path.IsAbs(dir) {
+		return nil, "", fmt.Errorf("directory %q must be a relative path within the inline files", dir)
+	}
+	files := maps.Clone(args.Files)
+	if args.Kustomization != nil {
+		if files == nil {
+			files = map[string]string{}
+		}
+		for _, name := range konfig.RecognizedKustomizationFileNames() {
+			if _, ok := files[path.Join(dir, name)]; ok {
+				return nil, "", fmt.Errorf("files must not contain %q when kustomization is set", path.Join(dir, name))
+			}
+		}
+		data, err := yaml.Marshal(args.Kustomization)
+		if err != nil {
+			return nil, "", fmt.Errorf("marshaling kustomization: %w", err)
+		}
+		files[path.Join(dir, konfig.DefaultKustomizationFileName())] = string(data)
+	}
+	fSys, err := kustomize.NewInMemoryFileSystem(files)
+	if err != nil {
+		return nil, "", err
+	}
+	return fSys, filepath.Join(kustomize.InMemoryRoot, filepath.FromSlash(path.Clean(dir))), nil
+}
+
+// helmTool returns the Helm tool with which to inflate the charts of the kustomizations in-process.
+func (r *DirectoryProvider) helmTool() *helm.Tool {
+	tool := helm.NewTool(r.opts.HelmOptions.EnvSettings)
+	tool.HelmDriver = r.opts.HelmOptions.HelmDriver
+	tool.IndexCache = r.opts.HelmOptions.IndexCache
+	return tool
+}
+
+// makeKustomizer prepares the kustomize tool with the load restrictions and plugin configuration of the
+// component. The Helm charts are inflated by kustomize only when a helm command is given; otherwise they're
+// inflated in-process by the file system.
+func makeKustomizer(args *directoryArgs) kustomizer {
 	opts := krusty.MakeDefaultOptions()
 	opts.Reorder = krusty.ReorderOptionNone
 	opts.AddManagedbyLabel = false
-	opts.LoadRestrictions = ktypes.LoadRestrictionsNone
-	opts.PluginConfig = ktypes.EnabledPluginConfig(ktypes.BploUseStaticallyLinked)
-	opts.PluginConfig.HelmConfig.Enabled = true
-	opts.PluginConfig.HelmConfig.Command = "helm"
+	opts.LoadRestrictions = args.LoadRestrictions
+	if args.EnableAlphaPlugins {
+		opts.PluginConfig = ktypes.EnabledPluginConfig(ktypes.BploUseStaticallyLinked)
+	} else {
+		opts.PluginConfig = ktypes.DisabledPluginConfig()
+	}
+	opts.PluginConfig.HelmConfig.Enabled = args.EnableHelm && args.HelmCommand != ""
+	opts.PluginConfig.HelmConfig.Command = args.HelmCommand
 	k := krusty.MakeKustomizer(opts)
 	return k
 }
@@ -16,6 +16,7 @@ package v2
 import (
 	"context"
 	"fmt"
+	"path/filepath"
 
 	gk "github.com/onsi/ginkgo/v2"
 	gm "github.com/onsi/gomega"
@@ -23,6 +24,7 @@ import (
 	kprovider "sigs.k8s.io/kustomize/api/provider"
 	kresmap "sigs.k8s.io/kustomize/api/resmap"
 	kresource "sigs.k8s.io/kustomize/api/resource"
+	ktypes "sigs.k8s.io/kustomize/api/types"
 	kfilesys "sigs.k8s.io/kustomize/kyaml/filesys"
 
 	"github.com/pulumi/pulumi/sdk/v3/go/common/resource"
@@ -32,6 +34,7 @@ import (
 
 	"github.com/pulumi/pulumi-kubernetes/provider/v4/pkg/clients/fake"
 	pgm "github.com/pulumi/pulumi-kubernetes/provider/v4/pkg/gomega"
+	"github.com/pulumi/pulumi-kubernetes/provider/v4/pkg/kustomize"
 	providerresource "github.com/pulumi/pulumi-kubernetes/provider/v4/pkg/provider/resource"
 )
 
@@ -43,11 +46,16 @@ var (
 type fakeKustomizer struct {
 	resmap kresmap.ResMap
 	err    error
+
+	// the file system and path of the last run
+	fSys kfilesys.FileSystem
+	path string
 }
 
 var _ kustomizer = &fakeKustomizer{}
 
-func (k *fakeKustomizer) Run(_ kfilesys.FileSystem, _ /* path */ string) (kresmap.ResMap, error) {
+func (k *fakeKustomizer) Run(fSys kfilesys.FileSystem, path string) (kresmap.ResMap, error) {
+	k.fSys, k.path = fSys, path
 	return k.resmap, k.err
 }
 
@@ -72,6 +80,7 @@ var _ = gk.Describe("Construct", func() {
 	var req *pulumirpc.ConstructRequest
 	var inputs resource.PropertyMap
 	var tool *fakeKustomizer
+	var kustomizerArgs *directoryArgs
 	var k *DirectoryProvider
 
 	gk.BeforeEach(func() {
@@ -101,7 +110,8 @@ var _ = gk.Describe("Construct", func() {
 		var err error
 		k = &DirectoryProvider{
 			opts: opts,
-			makeKustomizer: func(_ *directoryArgs) kustomizer {
+			makeKustomizer: func(args *directoryArgs) kustomizer {
+				kustomizerArgs = args
 				return tool
 			},
 		}
@@ -261,4 +271,204 @@ var _ = gk.Describe("Construct", func() {
 			})
 		})
 	})
+
+	gk.Describe("Patches", func() {
+		gk.BeforeEach(func() {
+			inputs["patches"] = resource.NewArrayProperty([]resource.PropertyValue{
+				resource.NewObjectProperty(resource.PropertyMap{
+					"patch": resource.NewStringProperty(`[{"op": "add", "path": "/data", "value": {"patched": "true"}}]`),
+					"target": resource.NewObjectProperty(resource.PropertyMap{
+						"kind": resource.NewStringProperty("ConfigMap"),
+						"name": resource.NewStringProperty("cm001"),
+					}),
+				}),
+			})
+		})
+		gk.It("should patch the objects before registering them", func(ctx context.Context) {
+			_, err := pulumiprovider.Construct(ctx, req, tc.EngineConn(), k.Construct)
+			gm.Expect(err).ShouldNot(gm.HaveOccurred())
+			gm.Expect(tc.monitor.Registrations()).To(gs.MatchKeys(gs.IgnoreExtras, gs.Keys{
+				"urn:pulumi:stack::project::kubernetes:kustomize/v2:Directory$kubernetes:core/v1:ConfigMap::" +
+					"test:default/cm001": gs.MatchFields(
+					gs.IgnoreExtras,
+					gs.Fields{
+						"State": pgm.MatchProps(gs.IgnoreExtras, pgm.Props{
+							"data": pgm.MatchObject(gs.IgnoreExtras, pgm.Props{
+								"patched": pgm.MatchValue("true"),
+							}),
+						}),
+					},
+				),
+			}))
+		})
+
+		gk.Context("given a patch without content", func() {
+			gk.BeforeEach(func() {
+				inputs["patches"] = resource.NewArrayProperty([]resource.PropertyValue{
+					resource.NewObjectProperty(resource.PropertyMap{}),
+				})
+			})
+			gk.It("should fail", func(ctx context.Context) {
+				_, err := pulumiprovider.Construct(ctx, req, tc.EngineConn(), k.Construct)
+				gm.Expect(err).Should(gm.MatchError(gm.ContainSubstring("patch is required")))
+			})
+		})
+	})
+
+	gk.Describe("Selection", func() {
+		gk.BeforeEach(func() {
+			_ = tool.resmap.Append(makeCm(2))
+			inputs["exclude"] = resource.NewArrayProperty([]resource.PropertyValue{
+				resource.NewObjectProperty(resource.PropertyMap{
+					"name": resource.NewStringProperty("cm00[2-9]"),
+				}),
+			})
+		})
+		gk.It("should skip the excluded objects and report them", func(ctx context.Context) {
+			resp, err := pulumiprovider.Construct(ctx, req, tc.EngineConn(), k.Construct)
+			gm.Expect(err).ShouldNot(gm.HaveOccurred())
+			outputs := unmarshalProperties(gk.GinkgoTB(), resp.State)
+			gm.Expect(outputs).To(pgm.MatchProps(gs.IgnoreExtras, pgm.Props{
+				"resources": pgm.MatchArrayValue(gm.ConsistOf(
+					pgm.MatchResourceReferenceValue(
+						"urn:pulumi:stack::project::kubernetes:kustomize/v2:Directory$kubernetes:core/v1:ConfigMap::test:default/cm001",
+						"test:default/cm001",
+					),
+				)),
+				"skipped": pgm.MatchArrayValue(gm.ConsistOf(pgm.MatchValue("v1/ConfigMap:default/cm002"))),
+			}))
+		})
+	})
+
+	gk.Describe("Kustomize Options", func() {
+		gk.Context("by default", func() {
+			gk.It("should enable Helm and plugins without load restrictions", func(ctx context.Context) {
+				_, err := pulumiprovider.Construct(ctx, req, tc.EngineConn(), k.Construct)
+				gm.Expect(err).ShouldNot(gm.HaveOccurred())
+				gm.Expect(*kustomizerArgs).To(gs.MatchFields(gs.IgnoreExtras, gs.Fields{
+					"LoadRestrictions":   gm.Equal(ktypes.LoadRestrictionsNone),
+					"EnableHelm":         gm.BeTrue(),
+					"HelmCommand":        gm.BeEmpty(),
+					"EnableAlphaPlugins": gm.BeTrue(),
+					"RemoteBases":        gm.BeZero(),
+				}))
+			})
+		})
+
+		gk.Context("given options", func() {
+			gk.BeforeEach(func() {
+				inputs["loadRestrictor"] = resource.NewStringProperty("LoadRestrictionsRootOnly")
+				inputs["enableHelm"] = resource.NewBoolProperty(false)
+				inputs["helmCommand"] = resource.NewStringProperty("/usr/local/bin/helm")
+				inputs["enableAlphaPlugins"] = resource.NewBoolProperty(false)
+				inputs["remoteBases"] = resource.NewObjectProperty(resource.PropertyMap{
+					"requirePinnedRefs": resource.NewBoolProperty(true),
+					"disableCache":      resource.NewBoolProperty(true),
+				})
+			})
+			gk.It("should use the options", func(ctx context.Context) {
+				_, err := pulumiprovider.Construct(ctx, req, tc.EngineConn(), k.Construct)
+				gm.Expect(err).ShouldNot(gm.HaveOccurred())
+				gm.Expect(*kustomizerArgs).To(gs.MatchFields(gs.IgnoreExtras, gs.Fields{
+					"LoadRestrictions":   gm.Equal(ktypes.LoadRestrictionsRootOnly),
+					"EnableHelm":         gm.BeFalse(),
+					"HelmCommand":        gm.Equal("/usr/local/bin/helm"),
+					"EnableAlphaPlugins": gm.BeFalse(),
+					"RemoteBases": gm.Equal(RemoteBasesOpts{
+						RequirePinnedRefs: true,
+						DisableCache:      true,
+					}),
+				}))
+			})
+		})
+
+		gk.Context("given an invalid load restrictor", func() {
+			gk.BeforeEach(func() {
+				inputs["loadRestrictor"] = resource.NewStringProperty("RootOnly")
+			})
+			gk.It("should fail", func(ctx context.Context) {
+				_, err := pulumiprovider.Construct(ctx, req, tc.EngineConn(), k.Construct)
+				gm.Expect(err).Should(gm.MatchError(gm.ContainSubstring("loadRestrictor must be")))
+			})
+		})
+	})
+
+	gk.Describe("Inline Kustomization", func() {
+		readFile := func(name string) string {
+			data, err := tool.fSys.ReadFile(filepath.Join(tool.path, name))
+			gm.Expect(err).ShouldNot(gm.HaveOccurred())
+			return string(data)
+		}
+
+		gk.BeforeEach(func() {
+			delete(inputs, "directory")
+			inputs["kustomization"] = resource.NewObjectProperty(resource.PropertyMap{
+				"resources": resource.NewArrayProperty([]resource.PropertyValue{
+					resource.NewStringProperty("base"),
+				}),
+				"patches": resource.NewArrayProperty([]resource.PropertyValue{
+					resource.NewObjectProperty(resource.PropertyMap{
+						"path": resource.NewStringProperty("patch.yaml"),
+					}),
+				}),
+			})
+			inputs["files"] = resource.NewObjectProperty(resource.PropertyMap{
+				"patch.yaml":              resource.NewStringProperty("kind: ConfigMap"),
+				"base/kustomization.yaml": resource.NewStringProperty("resources: []"),
+			})
+		})
+
+		gk.It("should materialise the kustomization and files in memory", func(ctx context.Context) {
+			_, err := pulumiprovider.Construct(ctx, req, tc.EngineConn(), k.Construct)
+			gm.Expect(err).ShouldNot(gm.HaveOccurred())
+			gm.Expect(tool.path).To(gm.Equal(kustomize.InMemoryRoot))
+			gm.Expect(readFile("kustomization.yaml")).To(gm.MatchYAML("patches: [{path: patch.yaml}]\nresources: [base]"))
+			gm.Expect(readFile("patch.yaml")).To(gm.Equal("kind: ConfigMap"))
+			gm.Expect(readFile("base/kustomization.yaml")).To(gm.Equal("resources: []"))
+			gm.Expect(tool.fSys.Exists("reference")).To(gm.BeFalse())
+		})
+
+		gk.Context("given a directory", func() {
+			gk.BeforeEach(func() {
+				inputs["directory"] = resource.NewStringProperty("overlays/dev")
+			})
+			gk.It("should use the directory within the files", func(ctx context.Context) {
+				_, err := pulumiprovider.Construct(ctx, req, tc.EngineConn(), k.Construct)
+				gm.Expect(err).ShouldNot(gm.HaveOccurred())
+				gm.Expect(tool.path).To(gm.Equal(filepath.Join(kustomize.InMemoryRoot, "overlays", "dev")))
+				gm.Expect(readFile("kustomization.yaml")).To(gm.ContainSubstring("patch.yaml"))
+			})
+		})
+
+		gk.Context("given a kustomization file", func() {
+			gk.BeforeEach(func() {
+				inputs["files"].ObjectValue()["kustomization.yaml"] = resource.NewStringProperty("resources: []")
+			})
+			gk.It("should fail", func(ctx context.Context) {
+				_, err := pulumiprovider.Construct(ctx, req, tc.EngineConn(), k.Construct)
+				gm.Expect(err).Should(gm.MatchError(gm.ContainSubstring("files must not contain")))
+			})
+		})
+
+		gk.Context("given a file outside of the kustomization", func() {
+			gk.BeforeEach(func() {
+				inputs["files"].ObjectValue()["../patch.yaml"] = resource.NewStringProperty("kind: ConfigMap")
+			})
+			gk.It("should fail", func(ctx context.Context) {
+				_, err := pulumiprovider.Construct(ctx, req, tc.EngineConn(), k.Construct)
+				gm.Expect(err).Should(gm.MatchError(gm.ContainSubstring("invalid file name")))
+			})
+		})
+
+		gk.Context("given neither a directory nor a kustomization", func() {
+			gk.BeforeEach(func() {
+				delete(inputs, "kustomization")
+				delete(inputs, "files")
+			})
+			gk.It("should fail", func(ctx context.Context) {
+				_, err := pulumiprovider.Construct(ctx, req, tc.EngineConn(), k.Construct)
+				gm.Expect(err).Should(gm.MatchError(gm.ContainSubstring("either directory or kustomization must be set")))
+			})
+		})
+	})
 })
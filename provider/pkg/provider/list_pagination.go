@@ -24,6 +24,9 @@ import (
 type continuationState struct {
 	// K8sContinue is K8s's own pagination cursor.
 	K8sContinue string `json:"k8sContinue,omitempty"`
+	// HelmOffset is the index of the next Helm release to list, for resource types that are
+	// enumerated through the Helm storage driver rather than the K8s API.
+	HelmOffset int64 `json:"helmOffset,omitempty"`
 	// Remaining tracks items still allowed under the request's limit.
 	// nil = no cap; *0 = cap exhausted; *N>0 = N items allowed.
 	Remaining *int64 `json:"remaining,omitempty"`
@@ -31,7 +34,7 @@ type continuationState struct {
 
 // isZero reports whether the pagination state carries nothing worth emitting.
 func (c continuationState) isZero() bool {
-	if c.K8sContinue == "" {
+	if c.K8sContinue == "" && c.HelmOffset == 0 {
 		return true
 	}
 	if c.Remaining != nil && *c.Remaining == 0 {
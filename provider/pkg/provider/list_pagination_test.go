@@ -96,6 +96,10 @@ func TestIsZero(t *testing.T) {
 			"no more K8s pages, session budget unspent",
 			continuationState{Remaining: ptr.To(int64(5))}, true,
 		},
+		{
+			"more Helm releases, no session cap",
+			continuationState{HelmOffset: 10}, false,
+		},
 		{
 			"no more K8s pages and cap exhausted",
 			continuationState{Remaining: ptr.To(int64(0))}, true,
@@ -52,7 +52,6 @@ import (
 	clientapi "k8s.io/client-go/tools/clientcmd/api"
 	k8sopenapi "k8s.io/kubectl/pkg/util/openapi"
 	"k8s.io/utils/ptr"
-	"sigs.k8s.io/yaml"
 
 	pulumischema "github.com/pulumi/pulumi/pkg/v3/codegen/schema"
 	"github.com/pulumi/pulumi/pkg/v3/resource/provider"
@@ -71,12 +70,16 @@ import (
 	"github.com/pulumi/pulumi-kubernetes/provider/v4/pkg/clients"
 	"github.com/pulumi/pulumi-kubernetes/provider/v4/pkg/cluster"
 	"github.com/pulumi/pulumi-kubernetes/provider/v4/pkg/gen"
+	"github.com/pulumi/pulumi-kubernetes/provider/v4/pkg/helm"
 	"github.com/pulumi/pulumi-kubernetes/provider/v4/pkg/host"
 	"github.com/pulumi/pulumi-kubernetes/provider/v4/pkg/kinds"
+	"github.com/pulumi/pulumi-kubernetes/provider/v4/pkg/kustomize"
 	"github.com/pulumi/pulumi-kubernetes/provider/v4/pkg/logging"
+	"github.com/pulumi/pulumi-kubernetes/provider/v4/pkg/manifest"
 	"github.com/pulumi/pulumi-kubernetes/provider/v4/pkg/metadata"
 	"github.com/pulumi/pulumi-kubernetes/provider/v4/pkg/openapi"
 	providerresource "github.com/pulumi/pulumi-kubernetes/provider/v4/pkg/provider/resource"
+	"github.com/pulumi/pulumi-kubernetes/provider/v4/pkg/sops"
 	"github.com/pulumi/pulumi-kubernetes/provider/v4/pkg/ssa"
 	"github.com/pulumi/pulumi-kubernetes/provider/v4/pkg/version"
 )
@@ -154,15 +157,23 @@ type kubeProvider struct {
 	upsertExistingObjects       bool
 
 	helmDriver               string
+	helmSQLConnectionString  string
 	helmPluginsPath          string
 	helmRegistryConfigPath   string
 	helmRepositoryConfigPath string
 	helmRepositoryCache      string
+	helmIndexCache           *helm.IndexCache
+	helmSkipManifestDiff     bool
+	helmLookupCache          *helm.LookupCache
 	helmSettings             *helmcli.EnvSettings
 	helmReleaseProvider      customResourceProvider
 
+	kustomizeRemoteCache *kustomize.RemoteCache
+	manifestCache        *manifest.Cache
+
 	yamlRenderMode bool
 	yamlDirectory  string
+	yamlRenderer   *yamlRenderer
 	alwaysRender   bool
 
 	clusterUnreachable       bool   // Kubernetes cluster is unreachable.
@@ -439,6 +450,55 @@ func (k *kubeProvider) CheckConfig(
 			}}}, nil
 		}
 	}
+
+	// renderYamlFormat is only valid when we're in YAML render mode
+	if v, ok := news["renderYamlFormat"]; ok && v.IsString() && v.StringValue() != "" {
+		var reason string
+		switch format := v.StringValue(); {
+		case !renderYamlEnabled:
+			reason = `"renderYamlFormat" requires "renderYamlToDirectory" to be set`
+		case format != renderYamlFormatFiles && format != renderYamlFormatNamespaces &&
+			format != renderYamlFormatKustomization:
+			reason = fmt.Sprintf(`"renderYamlFormat" must be %q, %q or %q`,
+				renderYamlFormatFiles, renderYamlFormatNamespaces, renderYamlFormatKustomization)
+		}
+		if reason != "" {
+			return &pulumirpc.CheckResponse{Inputs: req.GetNews(), Failures: []*pulumirpc.CheckFailure{{
+				Property: "renderYamlFormat",
+				Reason:   reason,
+			}}}, nil
+		}
+	}
+
+	// renderYamlSnapshot is only valid when we're in YAML render mode
+	if truthyValue("renderYamlSnapshot", news) && !renderYamlEnabled {
+		return &pulumirpc.CheckResponse{Inputs: req.GetNews(), Failures: []*pulumirpc.CheckFailure{{
+			Property: "renderYamlSnapshot",
+			Reason:   `"renderYamlSnapshot" requires "renderYamlToDirectory" to be set`,
+		}}}, nil
+	}
+
+	// renderYamlAgeRecipients is only valid when we're in YAML render mode
+	if v, ok := news["renderYamlAgeRecipients"]; ok && v.IsString() && v.StringValue() != "" {
+		reason := `"renderYamlAgeRecipients" requires "renderYamlToDirectory" to be set`
+		if renderYamlEnabled {
+			reason = ""
+			if _, err := sops.ParseAgeRecipients(v.StringValue()); err != nil {
+				reason = err.Error()
+			} else if format := news["renderYamlFormat"]; format.IsString() &&
+				format.StringValue() == renderYamlFormatNamespaces {
+				// Each Secret is a SOPS document of its own, which `sops -d` can't decrypt in a multi-document file.
+				reason = fmt.Sprintf(`"renderYamlAgeRecipients" is not supported by the %q "renderYamlFormat"`,
+					renderYamlFormatNamespaces)
+			}
+		}
+		if reason != "" {
+			return &pulumirpc.CheckResponse{Inputs: req.GetNews(), Failures: []*pulumirpc.CheckFailure{{
+				Property: "renderYamlAgeRecipients",
+				Reason:   reason,
+			}}}, nil
+		}
+	}
 	return &pulumirpc.CheckResponse{Inputs: req.GetNews()}, nil
 }
 
@@ -533,6 +593,9 @@ func (k *kubeProvider) DiffConfig(
 		case "renderYamlToDirectory":
 			// If the render directory changes, all the manifests will be replaced.
 			replaces = append(replaces, "renderYamlToDirectory")
+		case "renderYamlFormat":
+			// If the layout of the rendered manifests changes, all the manifests will be replaced.
+			replaces = append(replaces, "renderYamlFormat")
 		}
 	}
 
@@ -766,6 +829,22 @@ func (k *kubeProvider) Configure(
 	}
 	k.yamlDirectory = renderYamlToDirectory()
 	k.yamlRenderMode = len(k.yamlDirectory) > 0
+	var renderSnapshot *renderSnapshot
+	if path := vars["kubernetes:config:renderYamlSnapshot"]; path != "" && k.yamlRenderMode {
+		var err error
+		if renderSnapshot, err = loadRenderSnapshot(path); err != nil {
+			return nil, err
+		}
+	}
+	var renderRecipients []*sops.AgeRecipient
+	if recipients := vars["kubernetes:config:renderYamlAgeRecipients"]; recipients != "" && k.yamlRenderMode {
+		var err error
+		if renderRecipients, err = sops.ParseAgeRecipients(recipients); err != nil {
+			return nil, err
+		}
+	}
+	k.yamlRenderer = newYamlRenderer(
+		k.yamlDirectory, vars["kubernetes:config:renderYamlFormat"], renderSnapshot, renderRecipients)
 
 	k.alwaysRender = vars["kubernetes:config:alwaysRender"] == trueStr
 
@@ -792,6 +871,19 @@ func (k *kubeProvider) Configure(
 	}
 	k.helmDriver = helmDriver() // TODO: Make sure this is in provider state
 
+	helmSQLConnectionString := func() string {
+		if helmReleaseSettings.SQLConnectionString != nil {
+			return *helmReleaseSettings.SQLConnectionString
+		}
+
+		// If the provider flag is not set, fall back to the ENV vars, including Helm's own.
+		if connectionString, exists := os.LookupEnv("PULUMI_K8S_HELM_SQL_CONNECTION_STRING"); exists {
+			return connectionString
+		}
+		return os.Getenv("HELM_DRIVER_SQL_CONNECTION_STRING")
+	}
+	k.helmSQLConnectionString = helmSQLConnectionString()
+
 	helmPluginsPath := func() string {
 		if helmReleaseSettings.PluginsPath != nil {
 			return *helmReleaseSettings.PluginsPath
@@ -858,6 +950,61 @@ func (k *kubeProvider) Configure(
 		helmSettings.RepositoryCache = k.helmRepositoryCache
 	}
 
+	helmRepositoryIndexTTL := func() (time.Duration, error) {
+		if helmReleaseSettings.RepositoryIndexTTL != nil {
+			return time.Duration(*helmReleaseSettings.RepositoryIndexTTL) * time.Second, nil
+		}
+
+		if ttl, exists := os.LookupEnv("PULUMI_K8S_HELM_REPOSITORY_INDEX_TTL"); exists {
+			seconds, err := strconv.Atoi(ttl)
+			if err != nil {
+				return 0, fmt.Errorf("invalid PULUMI_K8S_HELM_REPOSITORY_INDEX_TTL: %w", err)
+			}
+			return time.Duration(seconds) * time.Second, nil
+		}
+		return helm.DefaultIndexTTL, nil
+	}
+	indexTTL, err := helmRepositoryIndexTTL()
+	if err != nil {
+		return nil, err
+	}
+	k.helmIndexCache = helm.NewIndexCache(k.helmRepositoryCache, indexTTL)
+
+	helmSkipManifestDiff := func() bool {
+		if helmReleaseSettings.SkipManifestDiff != nil {
+			return *helmReleaseSettings.SkipManifestDiff
+		}
+
+		// If the provider flag is not set, fall back to the ENV var.
+		if skip, exists := os.LookupEnv("PULUMI_K8S_HELM_SKIP_MANIFEST_DIFF"); exists {
+			return skip == trueStr
+		}
+		return false
+	}
+	k.helmSkipManifestDiff = helmSkipManifestDiff()
+	k.helmLookupCache = helm.NewLookupCache()
+
+	// The remote bases of kustomizations and the remote manifests are cached in the user's cache directory, to be
+	// shared by deployments.
+	cacheDir, err := os.UserCacheDir()
+	if err != nil {
+		cacheDir = os.TempDir()
+	}
+	k.kustomizeRemoteCache = kustomize.NewRemoteCache(filepath.Join(cacheDir, "pulumi-kubernetes", "kustomize"))
+
+	manifestCacheOffline := func() bool {
+		// If the provider flag is set, use that value to determine behavior. This will override the ENV var.
+		if enabled, exists := vars["kubernetes:config:manifestCacheOffline"]; exists {
+			return enabled == trueStr
+		}
+		// If the provider flag is not set, fall back to the ENV var.
+		if enabled, exists := os.LookupEnv("PULUMI_K8S_MANIFEST_CACHE_OFFLINE"); exists {
+			return enabled == trueStr
+		}
+		return false
+	}
+	k.manifestCache = manifest.NewCache(filepath.Join(cacheDir, "pulumi-kubernetes", "manifests"), manifestCacheOffline())
+
 	// Rather than erroring out on an invalid k8s config, mark the cluster as unreachable and conditionally bail out on
 	// operations that require a valid cluster. This will allow us to perform invoke operations using the default
 	// provider.
@@ -978,7 +1125,6 @@ func (k *kubeProvider) Configure(
 		}
 	}
 
-	var err error
 	k.clientSet, k.logClient, err = k.makeClient(k.canceler.context, config)
 	if err != nil {
 		return nil, err
@@ -1004,6 +1150,10 @@ func (k *kubeProvider) Configure(
 		k.helmSettings = helmSettings
 	}
 
+	var releaseRenderer *yamlRenderer
+	if k.yamlRenderMode {
+		releaseRenderer = k.yamlRenderer
+	}
 	k.helmReleaseProvider, err = newHelmReleaseProvider(
 		k.host,
 		k.canceler,
@@ -1012,12 +1162,16 @@ func (k *kubeProvider) Configure(
 		config,
 		k.clientSet,
 		k.helmDriver,
+		k.helmSQLConnectionString,
 		k.defaultNamespace,
 		k.enableSecrets,
 		k.helmPluginsPath,
 		k.helmRegistryConfigPath,
 		k.helmRepositoryConfigPath,
 		k.helmRepositoryCache,
+		k.helmIndexCache,
+		k.helmSkipManifestDiff,
+		releaseRenderer,
 		k.clusterUnreachable,
 		k.clusterUnreachableReason)
 	if err != nil {
@@ -1388,7 +1542,7 @@ func (k *kubeProvider) Check(ctx context.Context, req *pulumirpc.CheckRequest) (
 	}
 
 	if k.yamlRenderMode {
-		if checkedInputs.ContainsSecrets() {
+		if checkedInputs.ContainsSecrets() && !k.yamlRenderer.encrypts(newInputs) {
 			_ = k.host.Log(ctx, diag.Warning, urn, "rendered YAML will contain a secret value in plaintext")
 		}
 	}
@@ -1666,6 +1820,18 @@ func (k *kubeProvider) Diff(ctx context.Context, req *pulumirpc.DiffRequest) (*p
 		hasChanges = pulumirpc.DiffResponse_DIFF_SOME
 	}
 
+	// In YAML render mode, a Secret that was rendered for other age recipients (see renderYamlAgeRecipients) is
+	// rendered again, so that it's encrypted to the current ones.
+	if k.yamlRenderMode && hasChanges != pulumirpc.DiffResponse_DIFF_SOME {
+		reencrypt, err := k.yamlRenderer.reencrypts(newInputs)
+		if err != nil {
+			return nil, err
+		}
+		if reencrypt {
+			hasChanges = pulumirpc.DiffResponse_DIFF_SOME
+		}
+	}
+
 	return &pulumirpc.DiffResponse{
 		Changes:             hasChanges,
 		Replaces:            replaces,
@@ -1729,8 +1895,9 @@ func (k *kubeProvider) Create(
 		kinds.IsPatchResource(urn, newInputs.GetKind()) ||
 		!k.serverSideApplyMode
 	// If this is a preview and the input meets one of the skip criteria, then return them as-is. This is compatible
-	// with prior behavior implemented by the Pulumi engine.
-	if req.GetPreview() && skipPreview {
+	// with prior behavior implemented by the Pulumi engine. In render mode, the resource is previewed nonetheless if
+	// its unknown values can be resolved from a snapshot.
+	if req.GetPreview() && skipPreview && !(k.yamlRenderMode && k.yamlRenderer.resolvesUnknowns()) {
 		logger.V(9).Infof("cannot preview Create(%v)", urn)
 		return &pulumirpc.CreateResponse{Id: "", Properties: req.GetProperties()}, nil
 	}
@@ -1739,17 +1906,27 @@ func (k *kubeProvider) Create(
 	fieldManager := k.fieldManagerName(nil, newResInputs, newInputs)
 
 	if k.yamlRenderMode {
-		if newResInputs.ContainsSecrets() {
+		if newResInputs.ContainsSecrets() && !k.yamlRenderer.encrypts(newInputs) {
 			_ = k.host.Log(ctx, diag.Warning, urn, fmt.Sprintf(
 				"rendered file %s contains a secret value in plaintext",
-				renderPathForResource(newInputs, k.yamlDirectory)))
+				k.yamlRenderer.path(newInputs)))
 		}
-		err := renderYaml(newInputs, k.yamlDirectory)
-		if err != nil {
+		// A preview leaves the rendered files as they are: the unknown values are resolved from the snapshot in
+		// its result only.
+		rendered := newInputs
+		var unresolved []string
+		if req.GetPreview() {
+			rendered, unresolved = k.yamlRenderer.resolve(urn, newInputs)
+		} else if unresolved, err = k.yamlRenderer.render(urn, newInputs, nil); err != nil {
 			return nil, err
 		}
+		if len(unresolved) > 0 {
+			_ = k.host.Log(ctx, diag.Warning, urn, fmt.Sprintf(
+				"rendered file %s contains unresolved values: %s", k.yamlRenderer.path(newInputs),
+				strings.Join(unresolved, ", ")))
+		}
 
-		obj := checkpointObject(newInputs, newInputs, newResInputs, initialAPIVersion, fieldManager)
+		obj := checkpointObject(newInputs, rendered, newResInputs, initialAPIVersion, fieldManager)
 		inputsAndComputed, err := plugin.MarshalProperties(
 			obj, plugin.MarshalOptions{
 				Label:        fmt.Sprintf("%s.inputsAndComputed", label),
@@ -1761,8 +1938,10 @@ func (k *kubeProvider) Create(
 			return nil, err
 		}
 
-		_ = k.host.LogStatus(ctx, diag.Info, urn, fmt.Sprintf(
-			"rendered %s", renderPathForResource(newInputs, k.yamlDirectory)))
+		if !req.GetPreview() {
+			_ = k.host.LogStatus(ctx, diag.Info, urn, fmt.Sprintf(
+				"rendered %s", k.yamlRenderer.path(newInputs)))
+		}
 
 		return &pulumirpc.CreateResponse{
 			Id: fqObjName(newInputs), Properties: inputsAndComputed,
@@ -2201,6 +2380,10 @@ func (k *kubeProvider) List(
 		remaining = &sessionLimit
 	}
 
+	if req.GetToken() == helmReleaseType {
+		return k.listHelmReleases(stream, query, contState, req.GetPageSize(), remaining)
+	}
+
 	gvk, err := k.gvkFromTypeToken(req.GetToken())
 	if err != nil {
 		return status.Errorf(codes.InvalidArgument, "invalid token %q: %v", req.GetToken(), err)
@@ -2273,23 +2456,26 @@ func (k *kubeProvider) List(
 		}
 		nextState.Remaining = &newRem
 	}
-	token, err := encodeContinuation(nextState)
+	return sendContinuation(stream, nextState)
+}
+
+// sendContinuation emits the continuation token for the given pagination state, unless there is
+// nothing left to list.
+func sendContinuation(stream grpc.ServerStreamingServer[pulumirpc.ListResponse], state continuationState) error {
+	token, err := encodeContinuation(state)
 	if err != nil {
 		return fmt.Errorf("encode continuation: %w", err)
 	}
-	if token != "" {
-		if err := stream.Send(&pulumirpc.ListResponse{
-			Response: &pulumirpc.ListResponse_Continuation_{
-				Continuation: &pulumirpc.ListResponse_Continuation{
-					ContinuationToken: token,
-				},
-			},
-		}); err != nil {
-			return err
-		}
+	if token == "" {
+		return nil
 	}
-
-	return nil
+	return stream.Send(&pulumirpc.ListResponse{
+		Response: &pulumirpc.ListResponse_Continuation_{
+			Continuation: &pulumirpc.ListResponse_Continuation{
+				ContinuationToken: token,
+			},
+		},
+	})
 }
 
 // Update updates an existing resource with new values. This client uses a Server-side Apply (SSA) patch by default, but
@@ -2365,8 +2551,10 @@ func (k *kubeProvider) Update(
 	}
 
 	// If this is a preview and the input values contain unknowns, or an unregistered GVK, return them as-is. This is
-	// compatible with prior behavior implemented by the Pulumi engine.
-	if req.GetPreview() && (hasComputedValue(newInputs) || !k.gvkExists(newInputs)) {
+	// compatible with prior behavior implemented by the Pulumi engine. In render mode, the resource is previewed
+	// nonetheless if its unknown values can be resolved from a snapshot.
+	if req.GetPreview() && (hasComputedValue(newInputs) || !k.gvkExists(newInputs)) &&
+		!(k.yamlRenderMode && k.yamlRenderer.resolvesUnknowns()) {
 		logger.V(9).Infof("cannot preview Update(%v)", urn)
 		return &pulumirpc.UpdateResponse{Properties: req.News}, nil
 	}
@@ -2393,17 +2581,27 @@ func (k *kubeProvider) Update(
 	fieldManager := k.fieldManagerName(nil, oldState, newInputs)
 
 	if k.yamlRenderMode {
-		if newResInputs.ContainsSecrets() {
+		if newResInputs.ContainsSecrets() && !k.yamlRenderer.encrypts(newInputs) {
 			_ = k.host.LogStatus(ctx, diag.Warning, urn, fmt.Sprintf(
 				"rendered file %s contains a secret value in plaintext",
-				renderPathForResource(newInputs, k.yamlDirectory)))
+				k.yamlRenderer.path(newInputs)))
 		}
-		err := renderYaml(newInputs, k.yamlDirectory)
-		if err != nil {
+		// A preview leaves the rendered files as they are: the unknown values are resolved from the snapshot in
+		// its result only.
+		rendered := newInputs
+		var unresolved []string
+		if req.GetPreview() {
+			rendered, unresolved = k.yamlRenderer.resolve(urn, newInputs)
+		} else if unresolved, err = k.yamlRenderer.render(urn, newInputs, oldInputs); err != nil {
 			return nil, err
 		}
+		if len(unresolved) > 0 {
+			_ = k.host.Log(ctx, diag.Warning, urn, fmt.Sprintf(
+				"rendered file %s contains unresolved values: %s", k.yamlRenderer.path(newInputs),
+				strings.Join(unresolved, ", ")))
+		}
 
-		obj := checkpointObject(newInputs, newInputs, newResInputs, initialAPIVersion, fieldManager)
+		obj := checkpointObject(newInputs, rendered, newResInputs, initialAPIVersion, fieldManager)
 		inputsAndComputed, err := plugin.MarshalProperties(
 			obj, plugin.MarshalOptions{
 				Label:        fmt.Sprintf("%s.inputsAndComputed", label),
@@ -2415,8 +2613,10 @@ func (k *kubeProvider) Update(
 			return nil, err
 		}
 
-		_ = k.host.LogStatus(ctx, diag.Info, urn, fmt.Sprintf(
-			"rendered %s", renderPathForResource(newInputs, k.yamlDirectory)))
+		if !req.GetPreview() {
+			_ = k.host.LogStatus(ctx, diag.Info, urn, fmt.Sprintf(
+				"rendered %s", k.yamlRenderer.path(newInputs)))
+		}
 
 		return &pulumirpc.UpdateResponse{Properties: inputsAndComputed}, nil
 	}
@@ -2544,7 +2744,7 @@ func (k *kubeProvider) Delete(ctx context.Context, req *pulumirpc.DeleteRequest)
 	oldInputs, _ := parseCheckpointObject(oldState)
 
 	if isHelmRelease(urn) {
-		if k.clusterUnreachable {
+		if k.clusterUnreachable && !k.yamlRenderMode {
 			return nil, fmt.Errorf(
 				"can't delete Helm Release with unreachable cluster. Reason: %q",
 				k.clusterUnreachableReason,
@@ -2557,8 +2757,8 @@ func (k *kubeProvider) Delete(ctx context.Context, req *pulumirpc.DeleteRequest)
 	_, name := parseFqName(req.GetId())
 
 	if k.yamlRenderMode {
-		file := renderPathForResource(current, k.yamlDirectory)
-		err := os.Remove(file)
+		file := k.yamlRenderer.path(current)
+		err := k.yamlRenderer.remove(urn, current)
 		if err != nil {
 			// Most of the time, errors will be because the file was already deleted. In this case,
 			// the operation succeeds. It's also possible that deletion fails due to file permission if
@@ -3497,75 +3697,3 @@ func annotateSecrets(outs, ins resource.PropertyMap) {
 		}
 	}
 }
-
-// renderYaml marshals an Unstructured resource to YAML and writes it to the specified path on disk or returns an error.
-func renderYaml(resource *unstructured.Unstructured, yamlDirectory string) error {
-	jsonBytes, err := resource.MarshalJSON()
-	if err != nil {
-		return fmt.Errorf("failed to render YAML file: %q: %w", yamlDirectory, err)
-	}
-	yamlBytes, err := yaml.JSONToYAML(jsonBytes)
-	if err != nil {
-		return fmt.Errorf("failed to render YAML file: %q: %w", yamlDirectory, err)
-	}
-
-	crdDirectory := filepath.Join(yamlDirectory, "0-crd")
-	manifestDirectory := filepath.Join(yamlDirectory, "1-manifest")
-
-	if _, err := os.Stat(crdDirectory); os.IsNotExist(err) {
-		err = os.MkdirAll(crdDirectory, 0o700)
-		if err != nil {
-			return fmt.Errorf("failed to create directory for rendered YAML: %q: %w", crdDirectory, err)
-		}
-	}
-	if _, err := os.Stat(manifestDirectory); os.IsNotExist(err) {
-		err = os.MkdirAll(manifestDirectory, 0o700)
-		if err != nil {
-			return fmt.Errorf("failed to create directory for rendered YAML: %q: %w", manifestDirectory, err)
-		}
-	}
-
-	path := renderPathForResource(resource, yamlDirectory)
-	err = os.WriteFile(path, yamlBytes, 0o600)
-	if err != nil {
-		return fmt.Errorf("failed to write YAML file: %q: %w", path, err)
-	}
-
-	return nil
-}
-
-// renderPathForResource determines the appropriate YAML render path depending on the resource kind.
-func renderPathForResource(resource *unstructured.Unstructured, yamlDirectory string) string {
-	contract.Assertf(resource.GetName() != "", "expected object name to be nonempty: %v", resource)
-	crdDirectory := filepath.Join(yamlDirectory, "0-crd")
-	manifestDirectory := filepath.Join(yamlDirectory, "1-manifest")
-
-	namespace := "default"
-	if resource.GetNamespace() != "" {
-		namespace = resource.GetNamespace()
-	}
-
-	sanitise := func(name string) string {
-		name = strings.NewReplacer("/", "_", ":", "_").Replace(name)
-		return name
-	}
-
-	fileName := fmt.Sprintf(
-		"%s-%s-%s-%s.yaml",
-		sanitise(resource.GetAPIVersion()),
-		strings.ToLower(resource.GetKind()),
-		namespace,
-		resource.GetName(),
-	)
-	filepath.Join(yamlDirectory, fileName)
-
-	var path string
-	if kinds.KnownGroupVersions.Has(resource.GetAPIVersion()) &&
-		kinds.Kind(resource.GetKind()) == kinds.CustomResourceDefinition {
-		path = filepath.Join(crdDirectory, fileName)
-	} else {
-		path = filepath.Join(manifestDirectory, fileName)
-	}
-
-	return path
-}
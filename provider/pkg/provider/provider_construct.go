@@ -58,6 +58,14 @@ func (k *kubeProvider) getResourceProvider(typ string) (providerresource.Resourc
 			SuppressHelmHookWarnings: k.suppressHelmHookWarnings,
 			HelmDriver:               k.helmDriver,
 			EnvSettings:              k.helmSettings,
+			IndexCache:               k.helmIndexCache,
+			LookupCache:              k.helmLookupCache,
+		},
+		KustomizeOptions: &providerresource.KustomizeOptions{
+			RemoteCache: k.kustomizeRemoteCache,
+		},
+		YAMLOptions: &providerresource.YAMLOptions{
+			ManifestCache: k.manifestCache,
 		},
 	}
 	return providerF(options), true
@@ -389,6 +389,105 @@ func TestCheckConfig_AlwaysRenderRequiresRenderYamlToDirectory(t *testing.T) {
 	}
 }
 
+func TestCheckConfig_RenderYamlOptions(t *testing.T) {
+	tests := []struct {
+		name         string
+		news         resource.PropertyMap
+		wantProperty string
+		wantReason   string
+	}{
+		{
+			name: "renderYamlFormat without renderYamlToDirectory should fail",
+			news: resource.PropertyMap{
+				"renderYamlFormat": resource.NewStringProperty("namespaces"),
+			},
+			wantProperty: "renderYamlFormat",
+			wantReason:   `"renderYamlFormat" requires "renderYamlToDirectory" to be set`,
+		},
+		{
+			name: "an unknown renderYamlFormat should fail",
+			news: resource.PropertyMap{
+				"renderYamlFormat":      resource.NewStringProperty("helm"),
+				"renderYamlToDirectory": resource.NewStringProperty("/tmp/yaml"),
+			},
+			wantProperty: "renderYamlFormat",
+			wantReason:   `"renderYamlFormat" must be "files", "namespaces" or "kustomization"`,
+		},
+		{
+			name: "renderYamlSnapshot without renderYamlToDirectory should fail",
+			news: resource.PropertyMap{
+				"renderYamlSnapshot": resource.NewStringProperty("snapshot.json"),
+			},
+			wantProperty: "renderYamlSnapshot",
+			wantReason:   `"renderYamlSnapshot" requires "renderYamlToDirectory" to be set`,
+		},
+		{
+			name: "renderYamlAgeRecipients without renderYamlToDirectory should fail",
+			news: resource.PropertyMap{
+				"renderYamlAgeRecipients": resource.NewStringProperty(
+					"age1ql3z7hjy54pw3hyww5ayyfg7zqgvc7w3j2elw8zmrj2kg5sfn9aqmcac8p"),
+			},
+			wantProperty: "renderYamlAgeRecipients",
+			wantReason:   `"renderYamlAgeRecipients" requires "renderYamlToDirectory" to be set`,
+		},
+		{
+			name: "a malformed age recipient should fail",
+			news: resource.PropertyMap{
+				"renderYamlAgeRecipients": resource.NewStringProperty("age1invalid"),
+				"renderYamlToDirectory":   resource.NewStringProperty("/tmp/yaml"),
+			},
+			wantProperty: "renderYamlAgeRecipients",
+			wantReason:   `malformed recipient "age1invalid": invalid character data part: s[0]=105`,
+		},
+		{
+			name: "renderYamlAgeRecipients with the namespaces renderYamlFormat should fail",
+			news: resource.PropertyMap{
+				"renderYamlFormat":      resource.NewStringProperty("namespaces"),
+				"renderYamlToDirectory": resource.NewStringProperty("/tmp/yaml"),
+				"renderYamlAgeRecipients": resource.NewStringProperty(
+					"age1ql3z7hjy54pw3hyww5ayyfg7zqgvc7w3j2elw8zmrj2kg5sfn9aqmcac8p"),
+			},
+			wantProperty: "renderYamlAgeRecipients",
+			wantReason:   `"renderYamlAgeRecipients" is not supported by the "namespaces" "renderYamlFormat"`,
+		},
+		{
+			name: "the render options with renderYamlToDirectory should succeed",
+			news: resource.PropertyMap{
+				"renderYamlFormat":      resource.NewStringProperty("kustomization"),
+				"renderYamlSnapshot":    resource.NewStringProperty("snapshot.json"),
+				"renderYamlToDirectory": resource.NewStringProperty("/tmp/yaml"),
+				"renderYamlAgeRecipients": resource.NewStringProperty(
+					"age1ql3z7hjy54pw3hyww5ayyfg7zqgvc7w3j2elw8zmrj2kg5sfn9aqmcac8p"),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			k := &kubeProvider{}
+
+			newspb, err := plugin.MarshalProperties(tt.news, plugin.MarshalOptions{})
+			require.NoError(t, err)
+
+			req := &pulumirpc.CheckRequest{
+				Urn:  "urn:pulumi:test::test::pulumi:providers:kubernetes::k8s",
+				News: newspb,
+			}
+
+			resp, err := k.CheckConfig(context.Background(), req)
+			require.NoError(t, err)
+
+			if tt.wantReason != "" {
+				require.Len(t, resp.Failures, 1)
+				assert.Equal(t, tt.wantProperty, resp.Failures[0].Property)
+				assert.Equal(t, tt.wantReason, resp.Failures[0].Reason)
+			} else {
+				assert.Empty(t, resp.Failures)
+			}
+		})
+	}
+}
+
 // TestPropMapToUnstructuredPreservesNulls covers pulumi/pulumi-kubernetes#3746,
 // where the apiserver requires an explicit null to clear a server-defaulted
 // field (see kubernetes/kubernetes#100151).
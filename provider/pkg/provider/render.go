@@ -0,0 +1,604 @@
+// Copyright 2016-2026, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package provider
+
+import (
+	"bytes"
+	"cmp"
+	"errors"
+	"fmt"
+	"io/fs"
+	"maps"
+	"os"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"helm.sh/helm/v3/pkg/releaseutil"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/yaml"
+
+	"github.com/pulumi/pulumi/sdk/v3/go/common/resource"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/util/contract"
+
+	"github.com/pulumi/pulumi-kubernetes/provider/v4/pkg/kinds"
+	"github.com/pulumi/pulumi-kubernetes/provider/v4/pkg/sops"
+)
+
+// The layouts of the manifests that render mode writes, by the `renderYamlFormat` provider config.
+const (
+	// renderYamlFormatFiles writes each object to its own file, in the `0-crd` and `1-manifest` directories.
+	renderYamlFormatFiles = "files"
+	// renderYamlFormatNamespaces writes the objects of each namespace to a single multi-document file, e.g.
+	// `my-namespace.yaml`, and the objects without a namespace (e.g. cluster-scoped objects) to `_cluster.yaml`.
+	renderYamlFormatNamespaces = "namespaces"
+	// renderYamlFormatKustomization writes each object to its own file, as renderYamlFormatFiles does, along with
+	// a `kustomization.yaml` that lists the files.
+	renderYamlFormatKustomization = "kustomization"
+)
+
+const (
+	renderCRDDirectory      = "0-crd"
+	renderManifestDirectory = "1-manifest"
+	renderClusterFile       = "_cluster.yaml"
+	renderKustomizationFile = "kustomization.yaml"
+	// renderHookDirectory holds the hooks of the rendered Helm releases, one file per release. The hooks run around
+	// the installation of a release rather than being part of it, so they're kept apart from its objects (and out
+	// of the kustomization).
+	renderHookDirectory = "hooks"
+	// renderReportFile lists the unknown values that render mode couldn't resolve. It isn't a YAML file, so that
+	// the tools that read the manifests of the directory don't mistake it for one.
+	renderReportFile = "render-report.txt"
+)
+
+// serverOnlyMetadataFields are the fields of an object's metadata that the API server sets, and that mustn't be
+// rendered (e.g. when an object is copied from a cluster).
+var serverOnlyMetadataFields = []string{
+	"uid", "resourceVersion", "generation", "creationTimestamp", "deletionTimestamp", "deletionGracePeriodSeconds",
+	"managedFields", "selfLink",
+}
+
+// yamlRenderer writes the manifests of the resources in render mode (`renderYamlToDirectory`), in one of the
+// renderYamlFormat layouts. The objects of a multi-document file, and the entries of a kustomization, are in
+// a deterministic order: the CRDs first, then the order in which Helm installs the kinds, then by apiVersion,
+// namespace and name.
+//
+// The unknown values of the objects are resolved from the snapshot, if any. Those that remain unresolved are
+// rendered as placeholders, and listed by resource in a report file.
+//
+// If there are age recipients, the data of the Secrets is encrypted to them with SOPS, as Flux decrypts it.
+type yamlRenderer struct {
+	directory  string
+	format     string
+	snapshot   *renderSnapshot
+	recipients []*sops.AgeRecipient
+
+	// mu serializes the writes, since the resources are rendered concurrently, and some layouts share files
+	// between the resources.
+	mu sync.Mutex
+	// unresolved are the paths of the unresolved values by resource, as listed by the report file.
+	unresolved map[resource.URN][]string
+}
+
+func newYamlRenderer(
+	directory, format string, snapshot *renderSnapshot, recipients []*sops.AgeRecipient,
+) *yamlRenderer {
+	if format == "" {
+		format = renderYamlFormatFiles
+	}
+	return &yamlRenderer{directory: directory, format: format, snapshot: snapshot, recipients: recipients}
+}
+
+// resolvesUnknowns reports whether the renderer resolves unknown values from a snapshot. If so, the resources are
+// previewed with their unknown values resolved, although previews don't render them.
+func (r *yamlRenderer) resolvesUnknowns() bool {
+	return r.snapshot != nil
+}
+
+// path returns the path of the file to which the object is rendered.
+func (r *yamlRenderer) path(obj *unstructured.Unstructured) string {
+	if r.format == renderYamlFormatNamespaces {
+		if obj.GetNamespace() == "" {
+			return filepath.Join(r.directory, renderClusterFile)
+		}
+		return filepath.Join(r.directory, obj.GetNamespace()+".yaml")
+	}
+	return renderPathForResource(obj, r.directory)
+}
+
+// resolve returns a copy of the object of the given resource with its unknown values resolved from the snapshot,
+// along with the paths of those that remain unknown. Nothing is written, e.g. for a preview.
+func (r *yamlRenderer) resolve(
+	urn resource.URN, obj *unstructured.Unstructured,
+) (*unstructured.Unstructured, []string) {
+	var unresolved []string
+	resolved, _ := r.snapshot.resolve(urn, obj.Object, nil, &unresolved).(map[string]any)
+	return &unstructured.Unstructured{Object: resolved}, unresolved
+}
+
+// render writes the object of the given resource to its file. It returns the paths of the unknown values that it
+// couldn't resolve, which are rendered as placeholders, e.g. `${unresolved:spec.loadBalancerIP}`. The server-only
+// fields of the object are left out, except in the `files` layout, which writes the objects as they are, as it
+// always has.
+//
+// The previous object is the one that was last rendered for the resource, if any. An encrypted Secret that is
+// unchanged since keeps its ciphertext, so that rendering it again leaves its file as it is.
+func (r *yamlRenderer) render(urn resource.URN, obj, previous *unstructured.Unstructured) ([]string, error) {
+	obj, unresolved := r.resolve(urn, obj)
+	unchanged := previous != nil && len(unresolved) == 0 && reflect.DeepEqual(obj.Object, previous.Object)
+	obj.Object, _ = unresolvedPlaceholders(obj.Object, nil).(map[string]any)
+	if r.format != renderYamlFormatFiles {
+		obj = stripServerOnlyFields(obj)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	obj, err := r.encrypt(obj, unchanged)
+	if err != nil {
+		return nil, err
+	}
+
+	switch r.format {
+	case renderYamlFormatNamespaces:
+		err = r.updateMultiDocumentFile(obj, false)
+	case renderYamlFormatKustomization:
+		if err = renderYaml(obj, r.directory); err == nil {
+			err = r.writeKustomization()
+		}
+	default:
+		err = renderYaml(obj, r.directory)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return unresolved, r.updateReport(urn, unresolved)
+}
+
+// unresolvedPlaceholders returns a copy of the value with its unknown values replaced by placeholders.
+func unresolvedPlaceholders(v any, path resource.PropertyPath) any {
+	switch v := v.(type) {
+	case map[string]any:
+		replaced := make(map[string]any, len(v))
+		for k, e := range v {
+			replaced[k] = unresolvedPlaceholders(e, append(slices.Clone(path), k))
+		}
+		return replaced
+	case []any:
+		replaced := make([]any, len(v))
+		for i, e := range v {
+			replaced[i] = unresolvedPlaceholders(e, append(slices.Clone(path), i))
+		}
+		return replaced
+	case resource.Computed:
+		return fmt.Sprintf("${unresolved:%s}", path)
+	default:
+		return v
+	}
+}
+
+// encrypts reports whether the data of the object is encrypted when it's rendered.
+func (r *yamlRenderer) encrypts(obj *unstructured.Unstructured) bool {
+	return len(r.recipients) > 0 && obj.GetAPIVersion() == "v1" && obj.GetKind() == string(kinds.Secret)
+}
+
+// encrypt returns the object with its data encrypted, if the renderer encrypts it. If the object is unchanged since
+// it was last rendered, and its rendered Secret is encrypted to the renderer's recipients, that Secret is returned
+// as it is, since encrypting it again would change its ciphertext (and its file) all the same.
+func (r *yamlRenderer) encrypt(obj *unstructured.Unstructured, unchanged bool) (*unstructured.Unstructured, error) {
+	if !r.encrypts(obj) {
+		return obj, nil
+	}
+	if unchanged {
+		rendered, err := r.renderedObject(obj)
+		if err != nil {
+			return nil, err
+		}
+		if rendered != nil && slices.Equal(sops.AgeRecipients(rendered.Object), r.recipientStrings()) {
+			return rendered, nil
+		}
+	}
+	encrypted, err := sops.EncryptSecret(obj.Object, r.recipients, time.Now())
+	if err != nil {
+		return nil, fmt.Errorf("failed to encrypt Secret %q: %w", fqObjName(obj), err)
+	}
+	return &unstructured.Unstructured{Object: encrypted}, nil
+}
+
+// recipientStrings returns the age recipients of the renderer, as SOPS lists them.
+func (r *yamlRenderer) recipientStrings() []string {
+	var result []string
+	for _, recipient := range r.recipients {
+		result = append(result, recipient.String())
+	}
+	return result
+}
+
+// renderedObject returns the object as it was last rendered to its file, or nil if it wasn't.
+func (r *yamlRenderer) renderedObject(obj *unstructured.Unstructured) (*unstructured.Unstructured, error) {
+	objs, err := readRenderedObjects(r.path(obj))
+	if err != nil {
+		return nil, err
+	}
+	i := slices.IndexFunc(objs, func(o *unstructured.Unstructured) bool {
+		return o.GetKind() == obj.GetKind() && o.GetName() == obj.GetName() &&
+			canonicalNamespace(o.GetNamespace()) == canonicalNamespace(obj.GetNamespace())
+	})
+	if i < 0 {
+		return nil, nil
+	}
+	return objs[i], nil
+}
+
+// reencrypts reports whether the rendered Secret of the object is encrypted to other recipients than the renderer's
+// (or is encrypted, or not, when it mustn't be), such that it must be rendered again.
+func (r *yamlRenderer) reencrypts(obj *unstructured.Unstructured) (bool, error) {
+	if obj.GetAPIVersion() != "v1" || obj.GetKind() != string(kinds.Secret) {
+		return false, nil
+	}
+	rendered, err := r.renderedObject(obj)
+	if err != nil || rendered == nil {
+		return false, err
+	}
+	var want []string
+	_, hasData := obj.Object["data"]
+	_, hasStringData := obj.Object["stringData"]
+	if hasData || hasStringData {
+		want = r.recipientStrings()
+	}
+	return !slices.Equal(sops.AgeRecipients(rendered.Object), want), nil
+}
+
+// remove removes the object of the given resource from its file.
+func (r *yamlRenderer) remove(urn resource.URN, obj *unstructured.Unstructured) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var err error
+	switch r.format {
+	case renderYamlFormatNamespaces:
+		err = r.updateMultiDocumentFile(obj, true)
+	case renderYamlFormatKustomization:
+		err = os.Remove(r.path(obj))
+		if kerr := r.writeKustomization(); kerr != nil {
+			return kerr
+		}
+	default:
+		err = os.Remove(r.path(obj))
+	}
+	if rerr := r.updateReport(urn, nil); rerr != nil {
+		return rerr
+	}
+	return err
+}
+
+// updateReport records the unresolved values of the given resource in the report file, which lists the
+// unresolved values of all the resources, one per line, by URN and property path. The file is removed when
+// there are none.
+func (r *yamlRenderer) updateReport(urn resource.URN, unresolved []string) error {
+	path := filepath.Join(r.directory, renderReportFile)
+	if r.unresolved == nil {
+		// Keep the unresolved values of the resources that were rendered by previous updates.
+		r.unresolved = map[resource.URN][]string{}
+		data, err := os.ReadFile(path)
+		if err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return fmt.Errorf("failed to read render report: %q: %w", path, err)
+		}
+		for _, line := range strings.Split(string(data), "\n") {
+			if u, p, ok := strings.Cut(line, "\t"); ok && !strings.HasPrefix(line, "#") {
+				r.unresolved[resource.URN(u)] = append(r.unresolved[resource.URN(u)], p)
+			}
+		}
+	}
+	if len(unresolved) == 0 && len(r.unresolved[urn]) == 0 {
+		return nil
+	}
+	if len(unresolved) == 0 {
+		delete(r.unresolved, urn)
+	} else {
+		r.unresolved[urn] = unresolved
+	}
+
+	if len(r.unresolved) == 0 {
+		if err := os.Remove(path); err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return err
+		}
+		return nil
+	}
+	var lines []string
+	for u, paths := range r.unresolved {
+		for _, p := range paths {
+			lines = append(lines, fmt.Sprintf("%s\t%s", u, p))
+		}
+	}
+	slices.Sort(lines)
+	report := "# The unknown values that render mode couldn't resolve, by resource URN and property path.\n" +
+		strings.Join(lines, "\n") + "\n"
+	if err := os.MkdirAll(r.directory, 0o700); err != nil {
+		return fmt.Errorf("failed to create directory for rendered YAML: %q: %w", r.directory, err)
+	}
+	if err := os.WriteFile(path, []byte(report), 0o600); err != nil {
+		return fmt.Errorf("failed to write render report: %q: %w", path, err)
+	}
+	return nil
+}
+
+// updateMultiDocumentFile adds (or replaces) or removes the object in its multi-document file. The file is
+// removed when it has no objects left.
+func (r *yamlRenderer) updateMultiDocumentFile(obj *unstructured.Unstructured, remove bool) error {
+	path := r.path(obj)
+	objs, err := readRenderedObjects(path)
+	if err != nil {
+		return err
+	}
+	objs = slices.DeleteFunc(objs, func(o *unstructured.Unstructured) bool {
+		return o.GroupVersionKind().GroupKind() == obj.GroupVersionKind().GroupKind() &&
+			o.GetNamespace() == obj.GetNamespace() && o.GetName() == obj.GetName()
+	})
+	if !remove {
+		objs = append(objs, obj)
+	}
+	if len(objs) == 0 {
+		if err := os.Remove(path); err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return err
+		}
+		return nil
+	}
+	sortRenderedObjects(objs)
+	return writeMultiDocumentFile(path, objs)
+}
+
+// hookPath returns the path of the file to which the hooks of the Helm release are rendered.
+func (r *yamlRenderer) hookPath(namespace, name string) string {
+	return filepath.Join(r.directory, renderHookDirectory, fmt.Sprintf("%s-%s.yaml", namespace, name))
+}
+
+// renderHooks writes the hooks of the Helm release, in the given order, to the release's hook file. The file is
+// removed when the release has no hooks.
+func (r *yamlRenderer) renderHooks(namespace, name string, hooks []*unstructured.Unstructured) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	path := r.hookPath(namespace, name)
+	if len(hooks) == 0 {
+		if err := os.Remove(path); err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return err
+		}
+		return nil
+	}
+	objs := make([]*unstructured.Unstructured, 0, len(hooks))
+	for _, hook := range hooks {
+		obj, err := r.encrypt(stripServerOnlyFields(hook), false)
+		if err != nil {
+			return err
+		}
+		objs = append(objs, obj)
+	}
+	return writeMultiDocumentFile(path, objs)
+}
+
+// writeMultiDocumentFile writes the objects, in order, to a multi-document file.
+func writeMultiDocumentFile(path string, objs []*unstructured.Unstructured) error {
+	var buf bytes.Buffer
+	for _, o := range objs {
+		yamlBytes, err := marshalRenderedObject(o)
+		if err != nil {
+			return fmt.Errorf("failed to render YAML file: %q: %w", path, err)
+		}
+		buf.WriteString("---\n")
+		buf.Write(yamlBytes)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return fmt.Errorf("failed to create directory for rendered YAML: %q: %w", filepath.Dir(path), err)
+	}
+	if err := os.WriteFile(path, buf.Bytes(), 0o600); err != nil {
+		return fmt.Errorf("failed to write YAML file: %q: %w", path, err)
+	}
+	return nil
+}
+
+// writeKustomization writes a `kustomization.yaml` that lists the rendered files, in the order of their objects.
+func (r *yamlRenderer) writeKustomization() error {
+	var objs []*unstructured.Unstructured
+	files := map[*unstructured.Unstructured]string{}
+	for _, dir := range []string{renderCRDDirectory, renderManifestDirectory} {
+		paths, err := filepath.Glob(filepath.Join(r.directory, dir, "*.yaml"))
+		if err != nil {
+			return err
+		}
+		for _, path := range paths {
+			rendered, err := readRenderedObjects(path)
+			if err != nil {
+				return err
+			}
+			for _, obj := range rendered {
+				objs = append(objs, obj)
+				files[obj] = filepath.ToSlash(filepath.Join(dir, filepath.Base(path)))
+			}
+		}
+	}
+	sortRenderedObjects(objs)
+
+	resources := make([]string, 0, len(objs))
+	for _, obj := range objs {
+		resources = append(resources, files[obj])
+	}
+	kustomization, err := yaml.Marshal(map[string]any{
+		"apiVersion": "kustomize.config.k8s.io/v1beta1",
+		"kind":       "Kustomization",
+		"resources":  resources,
+	})
+	if err != nil {
+		return err
+	}
+	path := filepath.Join(r.directory, renderKustomizationFile)
+	if err := os.WriteFile(path, kustomization, 0o600); err != nil {
+		return fmt.Errorf("failed to write YAML file: %q: %w", path, err)
+	}
+	return nil
+}
+
+// readRenderedObjects reads the objects of a rendered file, if it exists.
+func readRenderedObjects(path string) ([]*unstructured.Unstructured, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read YAML file: %q: %w", path, err)
+	}
+	objs, err := parseRenderedObjects(string(data))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read YAML file: %q: %w", path, err)
+	}
+	return objs, nil
+}
+
+// parseRenderedObjects parses the objects of a multi-document manifest, in order, skipping the empty documents.
+func parseRenderedObjects(manifest string) ([]*unstructured.Unstructured, error) {
+	docs := releaseutil.SplitManifests(manifest)
+	keys := slices.Collect(maps.Keys(docs))
+	sort.Sort(releaseutil.BySplitManifestsOrder(keys))
+
+	var objs []*unstructured.Unstructured
+	for _, key := range keys {
+		jsonBytes, err := yaml.YAMLToJSON([]byte(docs[key]))
+		if err != nil {
+			return nil, err
+		}
+		if strings.TrimSpace(string(jsonBytes)) == "null" {
+			continue
+		}
+		obj := &unstructured.Unstructured{}
+		if err := obj.UnmarshalJSON(jsonBytes); err != nil {
+			return nil, err
+		}
+		objs = append(objs, obj)
+	}
+	return objs, nil
+}
+
+// sortRenderedObjects sorts the objects with the CRDs first, and then in the order in which Helm installs their
+// kinds (with the unknown kinds last), then by apiVersion, namespace and name.
+func sortRenderedObjects(objs []*unstructured.Unstructured) {
+	rank := func(obj *unstructured.Unstructured) int {
+		if kinds.Kind(obj.GetKind()) == kinds.CustomResourceDefinition {
+			return -1
+		}
+		if i := slices.Index(releaseutil.InstallOrder, obj.GetKind()); i >= 0 {
+			return i
+		}
+		return len(releaseutil.InstallOrder)
+	}
+	slices.SortStableFunc(objs, func(a, b *unstructured.Unstructured) int {
+		return cmp.Or(
+			cmp.Compare(rank(a), rank(b)),
+			cmp.Compare(a.GetKind(), b.GetKind()),
+			cmp.Compare(a.GetAPIVersion(), b.GetAPIVersion()),
+			cmp.Compare(a.GetNamespace(), b.GetNamespace()),
+			cmp.Compare(a.GetName(), b.GetName()),
+		)
+	})
+}
+
+// stripServerOnlyFields returns a copy of the object without the fields that the API server sets.
+func stripServerOnlyFields(obj *unstructured.Unstructured) *unstructured.Unstructured {
+	obj = obj.DeepCopy()
+	unstructured.RemoveNestedField(obj.Object, "status")
+	for _, field := range serverOnlyMetadataFields {
+		unstructured.RemoveNestedField(obj.Object, "metadata", field)
+	}
+	return obj
+}
+
+func marshalRenderedObject(obj *unstructured.Unstructured) ([]byte, error) {
+	jsonBytes, err := obj.MarshalJSON()
+	if err != nil {
+		return nil, err
+	}
+	return yaml.JSONToYAML(jsonBytes)
+}
+
+// renderYaml marshals an Unstructured resource to YAML and writes it to the specified path on disk or returns an error.
+func renderYaml(resource *unstructured.Unstructured, yamlDirectory string) error {
+	yamlBytes, err := marshalRenderedObject(resource)
+	if err != nil {
+		return fmt.Errorf("failed to render YAML file: %q: %w", yamlDirectory, err)
+	}
+
+	crdDirectory := filepath.Join(yamlDirectory, renderCRDDirectory)
+	manifestDirectory := filepath.Join(yamlDirectory, renderManifestDirectory)
+
+	if _, err := os.Stat(crdDirectory); os.IsNotExist(err) {
+		err = os.MkdirAll(crdDirectory, 0o700)
+		if err != nil {
+			return fmt.Errorf("failed to create directory for rendered YAML: %q: %w", crdDirectory, err)
+		}
+	}
+	if _, err := os.Stat(manifestDirectory); os.IsNotExist(err) {
+		err = os.MkdirAll(manifestDirectory, 0o700)
+		if err != nil {
+			return fmt.Errorf("failed to create directory for rendered YAML: %q: %w", manifestDirectory, err)
+		}
+	}
+
+	path := renderPathForResource(resource, yamlDirectory)
+	err = os.WriteFile(path, yamlBytes, 0o600)
+	if err != nil {
+		return fmt.Errorf("failed to write YAML file: %q: %w", path, err)
+	}
+
+	return nil
+}
+
+// renderPathForResource determines the appropriate YAML render path depending on the resource kind.
+func renderPathForResource(resource *unstructured.Unstructured, yamlDirectory string) string {
+	contract.Assertf(resource.GetName() != "", "expected object name to be nonempty: %v", resource)
+	crdDirectory := filepath.Join(yamlDirectory, renderCRDDirectory)
+	manifestDirectory := filepath.Join(yamlDirectory, renderManifestDirectory)
+
+	namespace := "default"
+	if resource.GetNamespace() != "" {
+		namespace = resource.GetNamespace()
+	}
+
+	sanitise := func(name string) string {
+		name = strings.NewReplacer("/", "_", ":", "_").Replace(name)
+		return name
+	}
+
+	fileName := fmt.Sprintf(
+		"%s-%s-%s-%s.yaml",
+		sanitise(resource.GetAPIVersion()),
+		strings.ToLower(resource.GetKind()),
+		namespace,
+		resource.GetName(),
+	)
+	filepath.Join(yamlDirectory, fileName)
+
+	var path string
+	if kinds.KnownGroupVersions.Has(resource.GetAPIVersion()) &&
+		kinds.Kind(resource.GetKind()) == kinds.CustomResourceDefinition {
+		path = filepath.Join(crdDirectory, fileName)
+	} else {
+		path = filepath.Join(manifestDirectory, fileName)
+	}
+
+	return path
+}
@@ -0,0 +1,175 @@
+// Copyright 2016-2026, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package provider
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"slices"
+
+	"github.com/pulumi/pulumi/sdk/v3/go/common/apitype"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/resource"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/resource/sig"
+)
+
+// renderSnapshot resolves the unknown values of the objects in render mode, from the `renderYamlSnapshot`
+// provider config. The snapshot is a JSON file, either:
+//
+//   - a deployment, as exported by `pulumi stack export --show-secrets`, in which case an unknown value is
+//     resolved from the same property of the inputs (or else the outputs) of the resource with the same URN;
+//   - an object of resolved values keyed by resource URN and then by property path (e.g.
+//     `{"urn:pulumi:...": {"spec.loadBalancerIP": "10.0.0.1"}}`), e.g. as written by a script.
+//
+// Since the unknown values of a resource are only sent to the provider during previews, the resources are rendered
+// during previews if there is a snapshot (see yamlRenderer.resolvesUnknowns).
+type renderSnapshot struct {
+	// resources are the inputs and outputs of the resources of a deployment, by URN.
+	resources map[resource.URN][]map[string]any
+	// values are the resolved values, by URN and property path.
+	values map[resource.URN]map[string]any
+}
+
+// loadRenderSnapshot reads the snapshot at the given path.
+func loadRenderSnapshot(path string) (*renderSnapshot, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read render snapshot: %w", err)
+	}
+
+	var untyped apitype.UntypedDeployment
+	if err := json.Unmarshal(data, &untyped); err == nil && len(untyped.Deployment) > 0 {
+		var deployment apitype.DeploymentV3
+		if err := json.Unmarshal(untyped.Deployment, &deployment); err != nil {
+			return nil, fmt.Errorf("failed to read render snapshot: %q: %w", path, err)
+		}
+		s := &renderSnapshot{resources: map[resource.URN][]map[string]any{}}
+		for _, res := range deployment.Resources {
+			s.resources[res.URN] = []map[string]any{res.Inputs, res.Outputs}
+		}
+		return s, nil
+	}
+
+	var values map[resource.URN]map[string]any
+	if err := json.Unmarshal(data, &values); err != nil {
+		return nil, fmt.Errorf("failed to read render snapshot: %q: expected a deployment or resolved values: %w",
+			path, err)
+	}
+	s := &renderSnapshot{values: map[resource.URN]map[string]any{}}
+	for urn, byPath := range values {
+		s.values[urn] = map[string]any{}
+		for p, v := range byPath {
+			propertyPath, err := resource.ParsePropertyPath(p)
+			if err != nil {
+				return nil, fmt.Errorf("failed to read render snapshot: %q: %s: %w", path, urn, err)
+			}
+			s.values[urn][propertyPath.String()] = v
+		}
+	}
+	return s, nil
+}
+
+// resolve returns a copy of the value of the resource with the given URN, with its unknown values resolved from
+// the snapshot. The unknown values that the snapshot doesn't resolve are left unknown, and their paths are added
+// to unresolved. A nil snapshot resolves nothing.
+func (s *renderSnapshot) resolve(urn resource.URN, v any, path resource.PropertyPath, unresolved *[]string) any {
+	switch v := v.(type) {
+	case map[string]any:
+		resolved := make(map[string]any, len(v))
+		for k, e := range v {
+			resolved[k] = s.resolve(urn, e, append(slices.Clone(path), k), unresolved)
+		}
+		return resolved
+	case []any:
+		resolved := make([]any, len(v))
+		for i, e := range v {
+			resolved[i] = s.resolve(urn, e, append(slices.Clone(path), i), unresolved)
+		}
+		return resolved
+	case resource.Computed:
+		if value, ok := s.lookup(urn, path); ok {
+			return value
+		}
+		*unresolved = append(*unresolved, path.String())
+		return v
+	default:
+		return v
+	}
+}
+
+// lookup returns the value of the resource with the given URN at the given path.
+func (s *renderSnapshot) lookup(urn resource.URN, path resource.PropertyPath) (any, bool) {
+	if s == nil {
+		return nil, false
+	}
+	if values, ok := s.values[urn]; ok {
+		v, ok := values[path.String()]
+		return v, ok
+	}
+	for _, props := range s.resources[urn] {
+		if v, ok := lookupSnapshotValue(props, path); ok {
+			return v, true
+		}
+	}
+	return nil, false
+}
+
+// lookupSnapshotValue returns the value at the given path of a deployment's properties, decoding the secrets that
+// were exported in plaintext.
+func lookupSnapshotValue(props map[string]any, path resource.PropertyPath) (any, bool) {
+	var v any = props
+	for _, key := range path {
+		var ok bool
+		if v, ok = unwrapSnapshotSecret(v); !ok {
+			return nil, false
+		}
+		switch key := key.(type) {
+		case string:
+			m, isMap := v.(map[string]any)
+			if !isMap {
+				return nil, false
+			}
+			if v, ok = m[key]; !ok {
+				return nil, false
+			}
+		case int:
+			a, isArray := v.([]any)
+			if !isArray || key < 0 || key >= len(a) {
+				return nil, false
+			}
+			v = a[key]
+		}
+	}
+	v, ok := unwrapSnapshotSecret(v)
+	return v, ok && v != nil
+}
+
+// unwrapSnapshotSecret returns the plaintext of a secret value of a deployment, or the value itself if it isn't
+// a secret. A secret that was exported as ciphertext can't be resolved.
+func unwrapSnapshotSecret(v any) (any, bool) {
+	m, ok := v.(map[string]any)
+	if !ok || m[sig.Key] != sig.Secret {
+		return v, true
+	}
+	plaintext, ok := m["plaintext"].(string)
+	if !ok {
+		return nil, false
+	}
+	var value any
+	if err := json.Unmarshal([]byte(plaintext), &value); err != nil {
+		return nil, false
+	}
+	return value, true
+}
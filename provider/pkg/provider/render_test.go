@@ -0,0 +1,401 @@
+// Copyright 2016-2026, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package provider
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/structpb"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"github.com/pulumi/pulumi/sdk/v3/go/common/resource"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/resource/plugin"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/tokens"
+	pulumirpc "github.com/pulumi/pulumi/sdk/v3/proto/go"
+
+	fakehost "github.com/pulumi/pulumi-kubernetes/provider/v4/pkg/host/fake"
+	"github.com/pulumi/pulumi-kubernetes/provider/v4/pkg/sops"
+)
+
+func renderTestObject(apiVersion, kind, namespace, name string) *unstructured.Unstructured {
+	obj := &unstructured.Unstructured{}
+	obj.SetAPIVersion(apiVersion)
+	obj.SetKind(kind)
+	obj.SetNamespace(namespace)
+	obj.SetName(name)
+	return obj
+}
+
+func renderTestURN(obj *unstructured.Unstructured) resource.URN {
+	return resource.NewURN("stack", "project", "", tokens.Type("kubernetes:"+obj.GetKind()), obj.GetName())
+}
+
+func TestYamlRendererFiles(t *testing.T) {
+	dir := t.TempDir()
+	r := newYamlRenderer(dir, "", nil, nil)
+
+	service := renderTestObject("v1", "Service", "app", "web")
+	service.SetUID("0b5a2f0e")
+	service.Object["status"] = map[string]any{"loadBalancer": map[string]any{}}
+	_, err := r.render(renderTestURN(service), service, nil)
+	require.NoError(t, err)
+
+	// The files layout writes the objects as they are.
+	objs, err := readRenderedObjects(r.path(service))
+	require.NoError(t, err)
+	require.Len(t, objs, 1)
+	assert.Equal(t, "0b5a2f0e", string(objs[0].GetUID()))
+	assert.Contains(t, objs[0].Object, "status")
+}
+
+func TestYamlRendererNamespaces(t *testing.T) {
+	dir := t.TempDir()
+	r := newYamlRenderer(dir, renderYamlFormatNamespaces, nil, nil)
+
+	deployment := renderTestObject("apps/v1", "Deployment", "app", "web")
+	service := renderTestObject("v1", "Service", "app", "web")
+	service.SetUID("0b5a2f0e")
+	service.SetResourceVersion("42")
+	service.Object["status"] = map[string]any{"loadBalancer": map[string]any{}}
+	namespace := renderTestObject("v1", "Namespace", "", "app")
+	for _, obj := range []*unstructured.Unstructured{deployment, service, namespace} {
+		_, err := r.render(renderTestURN(obj), obj, nil)
+		require.NoError(t, err)
+	}
+
+	assert.Equal(t, filepath.Join(dir, "app.yaml"), r.path(deployment))
+	assert.Equal(t, filepath.Join(dir, "_cluster.yaml"), r.path(namespace))
+	data, err := os.ReadFile(r.path(deployment))
+	require.NoError(t, err)
+	assert.Equal(t, `---
+apiVersion: v1
+kind: Service
+metadata:
+  name: web
+  namespace: app
+---
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: web
+  namespace: app
+`, string(data))
+	assert.Equal(t, "0b5a2f0e", string(service.GetUID()), "the rendered object mustn't be modified")
+
+	// Rendering an object again replaces it.
+	deployment.SetLabels(map[string]string{"app": "web"})
+	_, err = r.render(renderTestURN(deployment), deployment, nil)
+	require.NoError(t, err)
+	objs, err := readRenderedObjects(r.path(deployment))
+	require.NoError(t, err)
+	require.Len(t, objs, 2)
+	assert.Equal(t, map[string]string{"app": "web"}, objs[1].GetLabels())
+
+	require.NoError(t, r.remove(renderTestURN(service), service))
+	objs, err = readRenderedObjects(r.path(deployment))
+	require.NoError(t, err)
+	require.Len(t, objs, 1)
+	assert.Equal(t, "Deployment", objs[0].GetKind())
+
+	require.NoError(t, r.remove(renderTestURN(deployment), deployment))
+	assert.NoFileExists(t, r.path(deployment))
+	assert.FileExists(t, r.path(namespace))
+}
+
+func TestYamlRendererKustomization(t *testing.T) {
+	dir := t.TempDir()
+	r := newYamlRenderer(dir, renderYamlFormatKustomization, nil, nil)
+
+	configMap := renderTestObject("v1", "ConfigMap", "app", "config")
+	crd := renderTestObject("apiextensions.k8s.io/v1", "CustomResourceDefinition", "", "crontabs.stable.example.com")
+	cronTab := renderTestObject("stable.example.com/v1", "CronTab", "app", "backup")
+	for _, obj := range []*unstructured.Unstructured{cronTab, configMap, crd} {
+		_, err := r.render(renderTestURN(obj), obj, nil)
+		require.NoError(t, err)
+	}
+
+	data, err := os.ReadFile(filepath.Join(dir, "kustomization.yaml"))
+	require.NoError(t, err)
+	assert.Equal(t, `apiVersion: kustomize.config.k8s.io/v1beta1
+kind: Kustomization
+resources:
+- 0-crd/apiextensions.k8s.io_v1-customresourcedefinition-default-crontabs.stable.example.com.yaml
+- 1-manifest/v1-configmap-app-config.yaml
+- 1-manifest/stable.example.com_v1-crontab-app-backup.yaml
+`, string(data))
+
+	require.NoError(t, r.remove(renderTestURN(cronTab), cronTab))
+	assert.NoFileExists(t, r.path(cronTab))
+	data, err = os.ReadFile(filepath.Join(dir, "kustomization.yaml"))
+	require.NoError(t, err)
+	assert.NotContains(t, string(data), "crontab-app-backup")
+}
+
+func TestYamlRendererSnapshot(t *testing.T) {
+	dir := t.TempDir()
+	service := renderTestObject("v1", "Service", "app", "web")
+	service.Object["spec"] = map[string]any{
+		"loadBalancerIP": resource.Computed{Element: resource.NewStringProperty("")},
+		"externalName":   resource.Computed{Element: resource.NewStringProperty("")},
+	}
+	urn := renderTestURN(service)
+
+	snapshot := filepath.Join(dir, "snapshot.json")
+	require.NoError(t, os.WriteFile(snapshot, []byte(`{
+		"version": 3,
+		"deployment": {
+			"resources": [{
+				"urn": "`+string(urn)+`",
+				"custom": true,
+				"type": "kubernetes:core/v1:Service",
+				"inputs": {
+					"spec": {
+						"loadBalancerIP": {
+							"4dabf18193072939515e22adb298388d": "1b47061264138c4ac30d75fd1eb44270",
+							"plaintext": "\"10.0.0.1\""
+						}
+					}
+				}
+			}]
+		}
+	}`), 0o600))
+	s, err := loadRenderSnapshot(snapshot)
+	require.NoError(t, err)
+
+	r := newYamlRenderer(filepath.Join(dir, "out"), renderYamlFormatNamespaces, s, nil)
+	unresolved, err := r.render(urn, service, nil)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"spec.externalName"}, unresolved)
+
+	objs, err := readRenderedObjects(r.path(service))
+	require.NoError(t, err)
+	require.Len(t, objs, 1)
+	assert.Equal(t, map[string]any{
+		"loadBalancerIP": "10.0.0.1",
+		"externalName":   "${unresolved:spec.externalName}",
+	}, objs[0].Object["spec"])
+
+	report, err := os.ReadFile(filepath.Join(dir, "out", "render-report.txt"))
+	require.NoError(t, err)
+	assert.Contains(t, string(report), string(urn)+"\tspec.externalName\n")
+
+	// The report is removed once the values are resolved.
+	r = newYamlRenderer(filepath.Join(dir, "out"), renderYamlFormatNamespaces, &renderSnapshot{
+		values: map[resource.URN]map[string]any{urn: {
+			"spec.loadBalancerIP": "10.0.0.1",
+			"spec.externalName":   "web.example.com",
+		}},
+	}, nil)
+	unresolved, err = r.render(urn, service, nil)
+	require.NoError(t, err)
+	assert.Empty(t, unresolved)
+	assert.NoFileExists(t, filepath.Join(dir, "out", "render-report.txt"))
+}
+
+func TestRenderPreviewWithSnapshot(t *testing.T) {
+	service := renderTestObject("v1", "Service", "app", "web")
+	service.Object["spec"] = map[string]any{
+		"loadBalancerIP": resource.Computed{Element: resource.NewStringProperty("")},
+	}
+	urn := resource.NewURN("stack", "project", "", "kubernetes:core/v1:Service", "web")
+	props, err := plugin.MarshalProperties(resource.NewPropertyMapFromMap(service.Object),
+		plugin.MarshalOptions{KeepUnknowns: true})
+	require.NoError(t, err)
+	olds, err := plugin.MarshalProperties(
+		checkpointObject(service, service, resource.NewPropertyMapFromMap(service.Object), "v1", ""),
+		plugin.MarshalOptions{KeepUnknowns: true})
+	require.NoError(t, err)
+
+	newProvider := func(dir string, snapshot *renderSnapshot) *kubeProvider {
+		return &kubeProvider{
+			host:               &fakehost.HostClient{},
+			clusterUnreachable: true,
+			yamlRenderMode:     true,
+			yamlDirectory:      dir,
+			yamlRenderer:       newYamlRenderer(dir, renderYamlFormatNamespaces, snapshot, nil),
+		}
+	}
+	snapshot := &renderSnapshot{values: map[resource.URN]map[string]any{urn: {"spec.loadBalancerIP": "10.0.0.1"}}}
+
+	for _, tt := range []struct {
+		name string
+		op   func(k *kubeProvider) (*structpb.Struct, error)
+	}{
+		{
+			name: "create",
+			op: func(k *kubeProvider) (*structpb.Struct, error) {
+				resp, err := k.Create(context.Background(), &pulumirpc.CreateRequest{
+					Urn: string(urn), Properties: props, Preview: true,
+				})
+				return resp.GetProperties(), err
+			},
+		},
+		{
+			name: "update",
+			op: func(k *kubeProvider) (*structpb.Struct, error) {
+				resp, err := k.Update(context.Background(), &pulumirpc.UpdateRequest{
+					Urn: string(urn), Olds: olds, News: props, Preview: true,
+				})
+				return resp.GetProperties(), err
+			},
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			loadBalancerIP := func(result *structpb.Struct) resource.PropertyValue {
+				outputs, err := plugin.UnmarshalProperties(result, plugin.MarshalOptions{KeepUnknowns: true})
+				require.NoError(t, err)
+				return outputs["spec"].ObjectValue()["loadBalancerIP"]
+			}
+
+			// Without a snapshot, the unknown values can't be resolved.
+			k := newProvider(t.TempDir(), nil)
+			result, err := tt.op(k)
+			require.NoError(t, err)
+			assert.True(t, loadBalancerIP(result).IsComputed())
+
+			// With a snapshot, the result of the preview has the resolved values.
+			k = newProvider(t.TempDir(), snapshot)
+			result, err = tt.op(k)
+			require.NoError(t, err)
+			assert.Equal(t, resource.NewStringProperty("10.0.0.1"), loadBalancerIP(result))
+
+			// A preview never writes to the directory.
+			entries, err := os.ReadDir(k.yamlDirectory)
+			require.NoError(t, err)
+			assert.Empty(t, entries)
+		})
+	}
+}
+
+func TestYamlRendererEncryption(t *testing.T) {
+	dir := t.TempDir()
+	recipients, err := sops.ParseAgeRecipients("age1ql3z7hjy54pw3hyww5ayyfg7zqgvc7w3j2elw8zmrj2kg5sfn9aqmcac8p")
+	require.NoError(t, err)
+	r := newYamlRenderer(dir, renderYamlFormatKustomization, nil, recipients)
+
+	secret := renderTestObject("v1", "Secret", "app", "db")
+	secret.Object["data"] = map[string]any{"password": "aHVudGVyMg=="}
+	configMap := renderTestObject("v1", "ConfigMap", "app", "config")
+	configMap.Object["data"] = map[string]any{"user": "admin"}
+	assert.True(t, r.encrypts(secret))
+	assert.False(t, r.encrypts(configMap))
+	for _, obj := range []*unstructured.Unstructured{secret, configMap} {
+		_, err := r.render(renderTestURN(obj), obj, nil)
+		require.NoError(t, err)
+	}
+
+	objs, err := readRenderedObjects(r.path(secret))
+	require.NoError(t, err)
+	require.Len(t, objs, 1)
+	password, _, _ := unstructured.NestedString(objs[0].Object, "data", "password")
+	assert.Regexp(t, `^ENC\[AES256_GCM,`, password)
+	metadata, ok := objs[0].Object[sops.MetadataKey].(map[string]any)
+	require.True(t, ok)
+	assert.Equal(t, sops.EncryptedRegex, metadata["encrypted_regex"])
+	assert.Equal(t, []string{recipients[0].String()}, sops.AgeRecipients(objs[0].Object))
+	assert.Equal(t, "aHVudGVyMg==", secret.Object["data"].(map[string]any)["password"],
+		"the rendered object mustn't be modified")
+
+	objs, err = readRenderedObjects(r.path(configMap))
+	require.NoError(t, err)
+	require.Len(t, objs, 1)
+	assert.Equal(t, map[string]any{"user": "admin"}, objs[0].Object["data"], "only Secrets are encrypted")
+	assert.NotContains(t, objs[0].Object, sops.MetadataKey)
+
+	// The Secret is rendered again when the recipients change.
+	for _, obj := range []*unstructured.Unstructured{secret, configMap} {
+		reencrypt, err := r.reencrypts(obj)
+		require.NoError(t, err)
+		assert.False(t, reencrypt)
+	}
+	others, err := sops.ParseAgeRecipients("age1ql3z7hjy54pw3hyww5ayyfg7zqgvc7w3j2elw8zmrj2kg5sfn9aqmcac8p, " +
+		"age1zvkyg2lqzraa2lnjvqej32nkuu0ues2s82hzrye869xeexvn73equnujwj")
+	require.NoError(t, err)
+	for _, rr := range []*yamlRenderer{
+		newYamlRenderer(dir, renderYamlFormatKustomization, nil, others),
+		newYamlRenderer(dir, renderYamlFormatKustomization, nil, nil),
+	} {
+		reencrypt, err := rr.reencrypts(secret)
+		require.NoError(t, err)
+		assert.True(t, reencrypt)
+		reencrypt, err = rr.reencrypts(configMap)
+		require.NoError(t, err)
+		assert.False(t, reencrypt)
+	}
+	// An unchanged Secret keeps its ciphertext when it's rendered again, e.g. with alwaysRender; a changed one is
+	// encrypted again.
+	rendered, err := os.ReadFile(r.path(secret))
+	require.NoError(t, err)
+	_, err = r.render(renderTestURN(secret), secret, secret.DeepCopy())
+	require.NoError(t, err)
+	again, err := os.ReadFile(r.path(secret))
+	require.NoError(t, err)
+	assert.Equal(t, string(rendered), string(again))
+
+	changed := secret.DeepCopy()
+	changed.Object["data"] = map[string]any{"password": "aHVudGVyMw=="}
+	_, err = r.render(renderTestURN(changed), changed, secret)
+	require.NoError(t, err)
+	again, err = os.ReadFile(r.path(secret))
+	require.NoError(t, err)
+	assert.NotEqual(t, string(rendered), string(again))
+
+	// An unchanged Secret is encrypted again to other recipients.
+	rr := newYamlRenderer(dir, renderYamlFormatKustomization, nil, others)
+	_, err = rr.render(renderTestURN(changed), changed, changed.DeepCopy())
+	require.NoError(t, err)
+	objs, err = readRenderedObjects(r.path(secret))
+	require.NoError(t, err)
+	require.Len(t, objs, 1)
+	assert.Equal(t, []string{others[0].String(), others[1].String()}, sops.AgeRecipients(objs[0].Object))
+}
+
+func TestRenderUpdateKeepsCiphertext(t *testing.T) {
+	dir := t.TempDir()
+	recipients, err := sops.ParseAgeRecipients("age1ql3z7hjy54pw3hyww5ayyfg7zqgvc7w3j2elw8zmrj2kg5sfn9aqmcac8p")
+	require.NoError(t, err)
+	k := &kubeProvider{
+		host:               &fakehost.HostClient{},
+		clusterUnreachable: true,
+		yamlRenderMode:     true,
+		yamlDirectory:      dir,
+		yamlRenderer:       newYamlRenderer(dir, renderYamlFormatNamespaces, nil, recipients),
+	}
+	secret := renderTestObject("v1", "Secret", "app", "db")
+	secret.Object["data"] = map[string]any{"password": "aHVudGVyMg==", "port": "NTQzMg=="}
+	urn := resource.NewURN("stack", "project", "", "kubernetes:core/v1:Secret", "db")
+	props, err := plugin.MarshalProperties(resource.NewPropertyMapFromMap(secret.Object), plugin.MarshalOptions{})
+	require.NoError(t, err)
+
+	created, err := k.Create(context.Background(), &pulumirpc.CreateRequest{Urn: string(urn), Properties: props})
+	require.NoError(t, err)
+	rendered, err := os.ReadFile(k.yamlRenderer.path(secret))
+	require.NoError(t, err)
+	assert.Contains(t, string(rendered), "ENC[AES256_GCM,")
+
+	// An update of the unchanged Secret, as alwaysRender makes, leaves its file as it is.
+	_, err = k.Update(context.Background(), &pulumirpc.UpdateRequest{
+		Urn: string(urn), Olds: created.GetProperties(), News: props,
+	})
+	require.NoError(t, err)
+	again, err := os.ReadFile(k.yamlRenderer.path(secret))
+	require.NoError(t, err)
+	assert.Equal(t, string(rendered), string(again))
+}
@@ -21,6 +21,9 @@ import (
 	pulumiprovider "github.com/pulumi/pulumi/sdk/v3/go/pulumi/provider"
 
 	"github.com/pulumi/pulumi-kubernetes/provider/v4/pkg/clients"
+	"github.com/pulumi/pulumi-kubernetes/provider/v4/pkg/helm"
+	"github.com/pulumi/pulumi-kubernetes/provider/v4/pkg/kustomize"
+	"github.com/pulumi/pulumi-kubernetes/provider/v4/pkg/manifest"
 )
 
 type ResourceProvider interface { // nolint:revive // stutters
@@ -32,6 +35,8 @@ type ResourceProviderOptions struct { // nolint:revive // stutters
 	ClientSet        *clients.DynamicClientSet
 	DefaultNamespace string
 	HelmOptions      *HelmOptions
+	KustomizeOptions *KustomizeOptions
+	YAMLOptions      *YAMLOptions
 
 	// RenderYAMLToDirectory indicates that the provider is in render-only mode
 	// (the `renderYamlToDirectory` provider config is set). In this mode the
@@ -43,6 +48,16 @@ type HelmOptions struct {
 	SuppressHelmHookWarnings bool
 	HelmDriver               string
 	EnvSettings              *cli.EnvSettings
+	IndexCache               *helm.IndexCache
+	LookupCache              *helm.LookupCache
+}
+
+type KustomizeOptions struct {
+	RemoteCache *kustomize.RemoteCache
+}
+
+type YAMLOptions struct {
+	ManifestCache *manifest.Cache
 }
 
 type ResourceProviderFactory func(*ResourceProviderOptions) ResourceProvider // nolint:revive // stutters
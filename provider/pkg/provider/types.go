@@ -21,6 +21,8 @@ package provider
 type HelmReleaseSettings struct {
 	// The backend storage driver for Helm. Values are: configmap, secret, memory, sql.
 	Driver *string `json:"driver"`
+	// The connection string of the PostgreSQL database used by the sql driver.
+	SQLConnectionString *string `json:"sqlConnectionString"`
 	// The path to the helm plugins directory.
 	PluginsPath *string `json:"pluginsPath"`
 	// The path to the registry config file.
@@ -29,6 +31,10 @@ type HelmReleaseSettings struct {
 	RepositoryCache *string `json:"repositoryCache"`
 	// The path to the file containing repository names and URLs.
 	RepositoryConfigPath *string `json:"repositoryConfigPath"`
+	// The time in seconds for which a downloaded repository index is used before it is downloaded again.
+	RepositoryIndexTTL *int `json:"repositoryIndexTtl"`
+	// Whether to skip the server-side dry-run upgrade by which a preview reports the changes to a release's objects.
+	SkipManifestDiff *bool `json:"skipManifestDiff"`
 }
 
 // Options for tuning the Kubernetes client used by a Provider.
@@ -23,32 +23,44 @@ import (
 	pulumiprovider "github.com/pulumi/pulumi/sdk/v3/go/pulumi/provider"
 
 	"github.com/pulumi/pulumi-kubernetes/provider/v4/pkg/clients"
+	manifestcache "github.com/pulumi/pulumi-kubernetes/provider/v4/pkg/manifest"
 	providerresource "github.com/pulumi/pulumi-kubernetes/provider/v4/pkg/provider/resource"
 )
 
 type ConfigFileProvider struct {
 	clientSet        *clients.DynamicClientSet
 	defaultNamespace string
+	manifestCache    *manifestcache.Cache
 }
 
 type ConfigFileArgs struct {
 	File           pulumi.StringInput `pulumi:"file"`
 	ResourcePrefix pulumi.StringInput `pulumi:"resourcePrefix,optional"`
 	SkipAwait      pulumi.BoolInput   `pulumi:"skipAwait,optional"`
+	Values         pulumi.MapInput    `pulumi:"values,optional"`
+	TemplateEngine pulumi.StringInput `pulumi:"templateEngine,optional"`
+	Patches        pulumi.ArrayInput  `pulumi:"patches,optional"`
+	Include        pulumi.ArrayInput  `pulumi:"include,optional"`
+	Exclude        pulumi.ArrayInput  `pulumi:"exclude,optional"`
 }
 
 type ConfigFileState struct {
 	pulumi.ResourceState
-	Resources pulumi.ArrayOutput `pulumi:"resources"`
+	Resources pulumi.ArrayOutput       `pulumi:"resources"`
+	Skipped   pulumi.StringArrayOutput `pulumi:"skipped"`
 }
 
 var _ providerresource.ResourceProvider = &ConfigFileProvider{}
 
 func NewConfigFileProvider(opts *providerresource.ResourceProviderOptions) providerresource.ResourceProvider {
-	return &ConfigFileProvider{
+	p := &ConfigFileProvider{
 		clientSet:        opts.ClientSet,
 		defaultNamespace: opts.DefaultNamespace,
 	}
+	if opts.YAMLOptions != nil {
+		p.manifestCache = opts.YAMLOptions.ManifestCache
+	}
+	return p
 }
 
 func (k *ConfigFileProvider) Construct(
@@ -70,7 +82,8 @@ func (k *ConfigFileProvider) Construct(
 
 	// Check if all the required args are known, and print a warning if not.
 	result, err := internals.UnsafeAwaitOutput(ctx.Context(), pulumi.All(
-		args.File, args.ResourcePrefix, args.SkipAwait))
+		args.File, args.ResourcePrefix, args.SkipAwait, args.Values, args.TemplateEngine, args.Patches,
+		args.Include, args.Exclude))
 	if err != nil {
 		return nil, err
 	}
@@ -83,14 +96,22 @@ func (k *ConfigFileProvider) Construct(
 		_ = ctx.Log.Warn(msg, nil)
 	}
 
-	// Parse the manifest(s) and register the resources.
-
-	comp.Resources = pulumi.All(args.File, args.ResourcePrefix, args.SkipAwait).ApplyTWithContext(
+	// Parse the manifest(s) and register the resources, recording the objects that aren't selected.
+	var skipped []string
+	comp.Resources = pulumi.All(
+		args.File, args.ResourcePrefix, args.SkipAwait, args.Values, args.TemplateEngine, args.Patches,
+		args.Include, args.Exclude,
+	).ApplyTWithContext(
 		ctx.Context(), func(_ context.Context, args []any) (pulumi.ArrayOutput, error) {
 			// make type assertions to get each value (or the zero value)
 			file, _ := args[0].(string)
 			resourcePrefix, hasResourcePrefix := args[1].(string)
 			skipAwait, _ := args[2].(bool)
+			values, _ := args[3].(map[string]any)
+			templateEngine, _ := args[4].(string)
+			rawPatches, _ := args[5].([]any)
+			include, _ := args[6].([]any)
+			exclude, _ := args[7].([]any)
 
 			if !hasResourcePrefix {
 				// use the name of the ConfigFile as the resource prefix to ensure uniqueness
@@ -98,16 +119,37 @@ func (k *ConfigFileProvider) Construct(
 				resourcePrefix = name
 			}
 
-			// Parse the YAML file into an array of Kubernetes objects.
+			patches, err := DecodePatches(rawPatches)
+			if err != nil {
+				return pulumi.ArrayOutput{}, err
+			}
+			selection, err := DecodeSelection(include, exclude)
+			if err != nil {
+				return pulumi.ArrayOutput{}, err
+			}
+
+			// Parse the YAML file into an array of Kubernetes objects, templating it with the values, if any.
+			templateOpts, err := NewTemplateOptions(templateEngine, values)
+			if err != nil {
+				return pulumi.ArrayOutput{}, err
+			}
 			parseOpts := ParseOptions{
-				Files: []string{file},
-				Glob:  false,
+				Files:    []string{file},
+				Glob:     false,
+				Template: templateOpts,
+				Cache:    k.manifestCache,
 			}
 			objs, err := Parse(ctx.Context(), parseOpts)
 			if err != nil {
 				return pulumi.ArrayOutput{}, err
 			}
 
+			// Patch the objects, if any patches are given.
+			objs, err = ApplyPatches(objs, patches)
+			if err != nil {
+				return pulumi.ArrayOutput{}, err
+			}
+
 			// Normalize the objects (apply a default namespace, etc.)
 			objs, unresolvedScope, err := Normalize(objs, k.defaultNamespace, k.clientSet)
 			if err != nil {
@@ -115,6 +157,10 @@ func (k *ConfigFileProvider) Construct(
 			}
 			WarnUnresolvedNamespaceScope(ctx, unresolvedScope)
 
+			// Select the objects to register, recording the skipped objects.
+			objs = selection.Select(objs)
+			skipped = selection.Skipped()
+
 			// Register the objects as Pulumi resources.
 			registerOpts := RegisterOptions{
 				Objects:         objs,
@@ -128,5 +174,8 @@ func (k *ConfigFileProvider) Construct(
 	// issue: https://github.com/pulumi/pulumi/issues/15527
 	_, _ = internals.UnsafeAwaitOutput(ctx.Context(), comp.Resources)
 
+	// Report the skipped objects once the resources are known.
+	comp.Skipped = comp.Resources.ApplyT(func([]any) []string { return skipped }).(pulumi.StringArrayOutput)
+
 	return pulumiprovider.NewConstructResult(comp)
 }
@@ -25,12 +25,14 @@ import (
 	pulumiprovider "github.com/pulumi/pulumi/sdk/v3/go/pulumi/provider"
 
 	"github.com/pulumi/pulumi-kubernetes/provider/v4/pkg/clients"
+	manifestcache "github.com/pulumi/pulumi-kubernetes/provider/v4/pkg/manifest"
 	providerresource "github.com/pulumi/pulumi-kubernetes/provider/v4/pkg/provider/resource"
 )
 
 type ConfigGroupProvider struct {
 	clientSet        *clients.DynamicClientSet
 	defaultNamespace string
+	manifestCache    *manifestcache.Cache
 }
 
 type ConfigGroupArgs struct {
@@ -39,20 +41,30 @@ type ConfigGroupArgs struct {
 	Objects        pulumi.MapArrayInput    `pulumi:"objs,optional"`
 	ResourcePrefix pulumi.StringInput      `pulumi:"resourcePrefix,optional"`
 	SkipAwait      pulumi.BoolInput        `pulumi:"skipAwait,optional"`
+	Values         pulumi.MapInput         `pulumi:"values,optional"`
+	TemplateEngine pulumi.StringInput      `pulumi:"templateEngine,optional"`
+	Patches        pulumi.ArrayInput       `pulumi:"patches,optional"`
+	Include        pulumi.ArrayInput       `pulumi:"include,optional"`
+	Exclude        pulumi.ArrayInput       `pulumi:"exclude,optional"`
 }
 
 type ConfigGroupState struct {
 	pulumi.ResourceState
-	Resources pulumi.ArrayOutput `pulumi:"resources"`
+	Resources pulumi.ArrayOutput       `pulumi:"resources"`
+	Skipped   pulumi.StringArrayOutput `pulumi:"skipped"`
 }
 
 var _ providerresource.ResourceProvider = &ConfigGroupProvider{}
 
 func NewConfigGroupProvider(opts *providerresource.ResourceProviderOptions) providerresource.ResourceProvider {
-	return &ConfigGroupProvider{
+	p := &ConfigGroupProvider{
 		clientSet:        opts.ClientSet,
 		defaultNamespace: opts.DefaultNamespace,
 	}
+	if opts.YAMLOptions != nil {
+		p.manifestCache = opts.YAMLOptions.ManifestCache
+	}
+	return p
 }
 
 func (k *ConfigGroupProvider) Construct(
@@ -74,7 +86,8 @@ func (k *ConfigGroupProvider) Construct(
 
 	// Check if all the required args are known, and print a warning if not.
 	result, err := internals.UnsafeAwaitOutput(ctx.Context(), pulumi.All(
-		args.Files, args.YAML, args.Objects, args.ResourcePrefix, args.SkipAwait))
+		args.Files, args.YAML, args.Objects, args.ResourcePrefix, args.SkipAwait, args.Values, args.TemplateEngine,
+		args.Patches, args.Include, args.Exclude))
 	if err != nil {
 		return nil, err
 	}
@@ -87,9 +100,11 @@ func (k *ConfigGroupProvider) Construct(
 		_ = ctx.Log.Warn(msg, nil)
 	}
 
-	// Parse the manifest(s) and register the resources.
-
-	comp.Resources = pulumi.All(args.Files, args.YAML, args.Objects, args.ResourcePrefix, args.SkipAwait).
+	// Parse the manifest(s) and register the resources, recording the objects that aren't selected.
+	var skipped []string
+	comp.Resources = pulumi.All(
+		args.Files, args.YAML, args.Objects, args.ResourcePrefix, args.SkipAwait, args.Values, args.TemplateEngine,
+		args.Patches, args.Include, args.Exclude).
 		ApplyTWithContext(ctx.Context(), func(_ context.Context, args []any) (pulumi.ArrayOutput, error) {
 			// make type assertions to get each value (or the zero value)
 			// note: "objects" contains unwrapped values at this point
@@ -98,6 +113,11 @@ func (k *ConfigGroupProvider) Construct(
 			objects, _ := args[2].([]map[string]any)
 			resourcePrefix, hasResourcePrefix := args[3].(string)
 			skipAwait, _ := args[4].(bool)
+			values, _ := args[5].(map[string]any)
+			templateEngine, _ := args[6].(string)
+			rawPatches, _ := args[7].([]any)
+			include, _ := args[8].([]any)
+			exclude, _ := args[9].([]any)
 
 			if !hasResourcePrefix {
 				// use the name of the ConfigGroup as the resource prefix to ensure uniqueness
@@ -105,11 +125,27 @@ func (k *ConfigGroupProvider) Construct(
 				resourcePrefix = name
 			}
 
+			patches, err := DecodePatches(rawPatches)
+			if err != nil {
+				return pulumi.ArrayOutput{}, err
+			}
+			selection, err := DecodeSelection(include, exclude)
+			if err != nil {
+				return pulumi.ArrayOutput{}, err
+			}
+
 			// Parse the YAML files and literals into an array of Kubernetes objects, plus the provided objects.
+			// The files and literals are templated with the values, if any.
+			templateOpts, err := NewTemplateOptions(templateEngine, values)
+			if err != nil {
+				return pulumi.ArrayOutput{}, err
+			}
 			parseOpts := ParseOptions{
-				Files: files,
-				Glob:  true,
-				YAML:  yaml,
+				Files:    files,
+				Glob:     true,
+				YAML:     yaml,
+				Template: templateOpts,
+				Cache:    k.manifestCache,
 			}
 			objs, err := Parse(ctx.Context(), parseOpts)
 			if err != nil {
@@ -119,6 +155,12 @@ func (k *ConfigGroupProvider) Construct(
 				objs = append(objs, unstructured.Unstructured{Object: obj})
 			}
 
+			// Patch the objects, if any patches are given.
+			objs, err = ApplyPatches(objs, patches)
+			if err != nil {
+				return pulumi.ArrayOutput{}, err
+			}
+
 			// Normalize the objects (apply a default namespace, etc.)
 			objs, unresolvedScope, err := Normalize(objs, k.defaultNamespace, k.clientSet)
 			if err != nil {
@@ -126,6 +168,10 @@ func (k *ConfigGroupProvider) Construct(
 			}
 			WarnUnresolvedNamespaceScope(ctx, unresolvedScope)
 
+			// Select the objects to register, recording the skipped objects.
+			objs = selection.Select(objs)
+			skipped = selection.Skipped()
+
 			// Register the objects as Pulumi resources.
 			registerOpts := RegisterOptions{
 				Objects:         objs,
@@ -140,5 +186,8 @@ func (k *ConfigGroupProvider) Construct(
 	// issue: https://github.com/pulumi/pulumi/issues/15527
 	_, _ = internals.UnsafeAwaitOutput(ctx.Context(), comp.Resources)
 
+	// Report the skipped objects once the resources are known.
+	comp.Skipped = comp.Resources.ApplyT(func([]any) []string { return skipped }).(pulumi.StringArrayOutput)
+
 	return pulumiprovider.NewConstructResult(comp)
 }
@@ -247,6 +247,27 @@ var _ = gk.Describe("Construct", func() {
 		})
 	})
 
+	gk.Describe("selection", func() {
+		gk.BeforeEach(func() {
+			inputs["yaml"] = resource.NewStringProperty(manifest)
+			inputs["exclude"] = resource.NewArrayProperty([]resource.PropertyValue{
+				resource.NewObjectProperty(resource.PropertyMap{
+					"kind": resource.NewStringProperty("Namespace"),
+				}),
+			})
+		})
+
+		gk.It("should skip the excluded objects and report them", func(ctx context.Context) {
+			resp, err := pulumiprovider.Construct(ctx, req, tc.EngineConn(), k.Construct)
+			gm.Expect(err).ShouldNot(gm.HaveOccurred())
+			outputs := unmarshalProperties(gk.GinkgoTB(), resp.State)
+			gm.Expect(outputs).To(pgm.MatchProps(gs.IgnoreExtras, pgm.Props{
+				"resources": pgm.MatchArrayValue(gm.HaveLen(3)),
+				"skipped":   pgm.MatchArrayValue(gm.ConsistOf(pgm.MatchValue("v1/Namespace:my-namespace"))),
+			}))
+		})
+	})
+
 	gk.Describe("preview", func() {
 		gk.Context("when the input value(s) are unknown", func() {
 			gk.BeforeEach(func() {
@@ -266,6 +287,7 @@ var _ = gk.Describe("Construct", func() {
 				outputs := unmarshalProperties(gk.GinkgoTB(), resp.State)
 				gm.Expect(outputs).To(pgm.MatchProps(gs.IgnoreExtras, pgm.Props{
 					"resources": pgm.BeComputed(),
+					"skipped":   pgm.BeComputed(),
 				}))
 			})
 		})
@@ -0,0 +1,66 @@
+// Copyright 2016-2026, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v2
+
+import (
+	cliutilsobject "sigs.k8s.io/cli-utils/pkg/object"
+	"sigs.k8s.io/cli-utils/pkg/object/dependson"
+)
+
+// splitExternalDependencies splits the dependencies that the objects declare by the
+// `config.kubernetes.io/depends-on` annotation into those on objects of the set and those on objects outside of it
+// (e.g. objects registered by another step of a component, or objects that are expected to exist in the cluster).
+// It returns the objects with the external dependencies removed from their annotations, for cli-utils (which
+// rejects external dependencies), and the external dependencies of each object.
+// The objs and ids must match in order and length. The given objects are not modified.
+func splitExternalDependencies(
+	objs cliutilsobject.UnstructuredSet, ids cliutilsobject.ObjMetadataSet,
+) (cliutilsobject.UnstructuredSet, map[cliutilsobject.ObjMetadata]cliutilsobject.ObjMetadataSet) {
+	result := make(cliutilsobject.UnstructuredSet, len(objs))
+	copy(result, objs)
+	external := map[cliutilsobject.ObjMetadata]cliutilsobject.ObjMetadataSet{}
+	for i, obj := range objs {
+		if !dependson.HasAnnotation(obj) {
+			continue
+		}
+		deps, err := dependson.ReadAnnotation(obj)
+		if err != nil {
+			// cli-utils reports the invalid annotation.
+			continue
+		}
+		var internal dependson.DependencySet
+		for _, dep := range deps {
+			if ids.Contains(dep) {
+				internal = append(internal, dep)
+			} else {
+				external[ids[i]] = append(external[ids[i]], dep)
+			}
+		}
+		if len(external[ids[i]]) == 0 {
+			continue
+		}
+
+		obj = obj.DeepCopy()
+		if len(internal) == 0 {
+			annotations := obj.GetAnnotations()
+			delete(annotations, dependson.Annotation)
+			obj.SetAnnotations(annotations)
+		} else if err := dependson.WriteAnnotation(obj, internal); err != nil {
+			continue
+		}
+		result[i] = obj
+	}
+	return result, external
+}
@@ -0,0 +1,60 @@
+// Copyright 2016-2026, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v2
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/mitchellh/mapstructure"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/yaml"
+
+	"github.com/pulumi/pulumi-kubernetes/provider/v4/pkg/helm"
+)
+
+// Patch is a strategic merge patch or a JSON 6902 patch, applied to the objects that its target selects.
+type Patch = helm.KustomizePatch
+
+// DecodePatches decodes the `patches` input of a component.
+func DecodePatches(v []any) ([]Patch, error) {
+	var patches []Patch
+	if err := mapstructure.Decode(v, &patches); err != nil {
+		return nil, fmt.Errorf("patches: %w", err)
+	}
+	return patches, nil
+}
+
+// ApplyPatches applies the patches to the objects, in-process with kustomize, returning the patched objects.
+// The objects are returned as is if there are no patches.
+func ApplyPatches(objs []unstructured.Unstructured, patches []Patch) ([]unstructured.Unstructured, error) {
+	if len(patches) == 0 || len(objs) == 0 {
+		return objs, nil
+	}
+	var manifests bytes.Buffer
+	for _, obj := range objs {
+		data, err := yaml.Marshal(obj.Object)
+		if err != nil {
+			return nil, err
+		}
+		manifests.WriteString("---\n")
+		manifests.Write(data)
+	}
+	out, err := helm.ApplyKustomizePatches(manifests.Bytes(), patches)
+	if err != nil {
+		return nil, fmt.Errorf("applying patches: %w", err)
+	}
+	return yamlDecode(string(out))
+}
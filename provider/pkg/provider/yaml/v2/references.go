@@ -0,0 +1,138 @@
+// Copyright 2016-2026, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v2
+
+import (
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	cliutilsobject "sigs.k8s.io/cli-utils/pkg/object"
+	cliutilsgraph "sigs.k8s.io/cli-utils/pkg/object/graph"
+
+	logger "github.com/pulumi/pulumi/sdk/v3/go/common/util/logging"
+)
+
+var (
+	configMapGK = schema.GroupKind{Kind: "ConfigMap"}
+	secretGK    = schema.GroupKind{Kind: "Secret"}
+)
+
+// podSpecPaths are the paths of the pod specs of the built-in workload kinds.
+var podSpecPaths = map[schema.GroupKind][]string{
+	{Kind: "Pod"}:                             {"spec"},
+	{Kind: "PodTemplate"}:                     {"template", "spec"},
+	{Kind: "ReplicationController"}:           {"spec", "template", "spec"},
+	{Group: "apps", Kind: "Deployment"}:       {"spec", "template", "spec"},
+	{Group: "apps", Kind: "ReplicaSet"}:       {"spec", "template", "spec"},
+	{Group: "apps", Kind: "StatefulSet"}:      {"spec", "template", "spec"},
+	{Group: "apps", Kind: "DaemonSet"}:        {"spec", "template", "spec"},
+	{Group: "batch", Kind: "Job"}:             {"spec", "template", "spec"},
+	{Group: "batch", Kind: "CronJob"}:         {"spec", "jobTemplate", "spec", "template", "spec"},
+	{Group: "extensions", Kind: "Deployment"}: {"spec", "template", "spec"},
+	{Group: "extensions", Kind: "DaemonSet"}:  {"spec", "template", "spec"},
+	{Group: "extensions", Kind: "ReplicaSet"}: {"spec", "template", "spec"},
+}
+
+// addReferenceEdges adds edges to the dependency graph from workloads to the ConfigMaps and Secrets that their
+// pods reference (by volumes, envFrom, env and imagePullSecrets), such that the referenced config is created
+// first. This matters for the config generated by kustomize, whose names change with the content: a workload
+// that rolls out with a new name mustn't race the creation of the config.
+// The objs and ids must match in order and length.
+func addReferenceEdges(
+	g *cliutilsgraph.Graph, objs cliutilsobject.UnstructuredSet, ids cliutilsobject.ObjMetadataSet,
+) {
+	known := map[cliutilsobject.ObjMetadata]bool{}
+	for _, id := range ids {
+		if id.GroupKind == configMapGK || id.GroupKind == secretGK {
+			known[id] = true
+		}
+	}
+	if len(known) == 0 {
+		return
+	}
+	for i, obj := range objs {
+		path, ok := podSpecPaths[obj.GroupVersionKind().GroupKind()]
+		if !ok {
+			continue
+		}
+		podSpec, _ := nestedField(obj.Object, path...).(map[string]any)
+		for _, ref := range podSpecReferences(podSpec) {
+			to := cliutilsobject.ObjMetadata{Namespace: obj.GetNamespace(), Name: ref.Name, GroupKind: ref.GroupKind}
+			if known[to] {
+				from := ids[i]
+				logger.V(9).Infof("adding edge from: %s, to referenced object: %s", from, to)
+				g.AddEdge(from, to)
+			}
+		}
+	}
+}
+
+// podReference is a reference of a pod spec to an object in the pod's namespace.
+type podReference struct {
+	GroupKind schema.GroupKind
+	Name      string
+}
+
+// podSpecReferences returns the ConfigMaps and Secrets that the given pod spec references.
+func podSpecReferences(podSpec map[string]any) []podReference {
+	var refs []podReference
+	add := func(gk schema.GroupKind, obj map[string]any, fields ...string) {
+		if name, _ := nestedField(obj, fields...).(string); name != "" {
+			refs = append(refs, podReference{GroupKind: gk, Name: name})
+		}
+	}
+
+	for _, volume := range nestedMaps(podSpec, "volumes") {
+		add(configMapGK, volume, "configMap", "name")
+		add(secretGK, volume, "secret", "secretName")
+		for _, source := range nestedMaps(volume, "projected", "sources") {
+			add(configMapGK, source, "configMap", "name")
+			add(secretGK, source, "secret", "name")
+		}
+	}
+	for _, field := range []string{"initContainers", "containers", "ephemeralContainers"} {
+		for _, container := range nestedMaps(podSpec, field) {
+			for _, envFrom := range nestedMaps(container, "envFrom") {
+				add(configMapGK, envFrom, "configMapRef", "name")
+				add(secretGK, envFrom, "secretRef", "name")
+			}
+			for _, env := range nestedMaps(container, "env") {
+				add(configMapGK, env, "valueFrom", "configMapKeyRef", "name")
+				add(secretGK, env, "valueFrom", "secretKeyRef", "name")
+			}
+		}
+	}
+	for _, secret := range nestedMaps(podSpec, "imagePullSecrets") {
+		add(secretGK, secret, "name")
+	}
+	return refs
+}
+
+// nestedMaps returns the objects of the list at the given path, ignoring any elements that aren't objects.
+func nestedMaps(obj map[string]any, fields ...string) []map[string]any {
+	items, _ := nestedField(obj, fields...).([]any)
+	result := make([]map[string]any, 0, len(items))
+	for _, item := range items {
+		if m, ok := item.(map[string]any); ok {
+			result = append(result, m)
+		}
+	}
+	return result
+}
+
+// nestedField returns the value at the given path without copying it, or nil if there's none.
+func nestedField(obj map[string]any, fields ...string) any {
+	v, _, _ := unstructured.NestedFieldNoCopy(obj, fields...)
+	return v
+}
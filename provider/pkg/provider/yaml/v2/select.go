@@ -0,0 +1,155 @@
+// Copyright 2016-2026, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v2
+
+import (
+	"fmt"
+	"path"
+	"sort"
+
+	"github.com/mitchellh/mapstructure"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+// Selector selects objects by group, version, kind, name, namespace and labels. The empty fields match any object.
+type Selector struct {
+	Group   string `mapstructure:"group"`
+	Version string `mapstructure:"version"`
+	Kind    string `mapstructure:"kind"`
+	// Name is a glob pattern (e.g. `*-psp`) that the name of the objects must match.
+	Name      string `mapstructure:"name"`
+	Namespace string `mapstructure:"namespace"`
+	// LabelSelector is a label selector (e.g. `app=nginx,tier!=cache`) that the objects must match.
+	LabelSelector string `mapstructure:"labelSelector"`
+
+	labels labels.Selector
+}
+
+// matches reports whether the selector selects the given object.
+func (s *Selector) matches(obj *unstructured.Unstructured) bool {
+	gvk := obj.GroupVersionKind()
+	switch {
+	case s.Group != "" && s.Group != gvk.Group,
+		s.Version != "" && s.Version != gvk.Version,
+		s.Kind != "" && s.Kind != gvk.Kind,
+		s.Namespace != "" && s.Namespace != obj.GetNamespace():
+		return false
+	}
+	if s.Name != "" {
+		if ok, _ := path.Match(s.Name, obj.GetName()); !ok {
+			return false
+		}
+	}
+	return s.labels == nil || s.labels.Matches(labels.Set(obj.GetLabels()))
+}
+
+// Selection selects the objects of a component to register: those that match any of the included selectors (or
+// all objects, if there are none), less those that match any of the excluded selectors. The selection records the
+// objects that it skips, such that the component may report them.
+type Selection struct {
+	include []Selector
+	exclude []Selector
+	skipped []string
+}
+
+// DecodeSelection decodes the `include` and `exclude` inputs of a component. It returns nil if neither is set.
+func DecodeSelection(include, exclude []any) (*Selection, error) {
+	if len(include) == 0 && len(exclude) == 0 {
+		return nil, nil
+	}
+	s := &Selection{}
+	if err := decodeSelectors(include, &s.include); err != nil {
+		return nil, fmt.Errorf("include: %w", err)
+	}
+	if err := decodeSelectors(exclude, &s.exclude); err != nil {
+		return nil, fmt.Errorf("exclude: %w", err)
+	}
+	return s, nil
+}
+
+func decodeSelectors(v []any, selectors *[]Selector) error {
+	if err := mapstructure.Decode(v, selectors); err != nil {
+		return err
+	}
+	for i := range *selectors {
+		s := &(*selectors)[i]
+		if _, err := path.Match(s.Name, ""); err != nil {
+			return fmt.Errorf("invalid name pattern %q: %w", s.Name, err)
+		}
+		if s.LabelSelector != "" {
+			selector, err := labels.Parse(s.LabelSelector)
+			if err != nil {
+				return fmt.Errorf("invalid label selector %q: %w", s.LabelSelector, err)
+			}
+			s.labels = selector
+		}
+	}
+	return nil
+}
+
+// Select returns the selected objects, recording the objects that it skips. A nil selection selects all objects.
+func (s *Selection) Select(objs []unstructured.Unstructured) []unstructured.Unstructured {
+	if s == nil {
+		return objs
+	}
+	selected := make([]unstructured.Unstructured, 0, len(objs))
+	for i := range objs {
+		if s.selects(&objs[i]) {
+			selected = append(selected, objs[i])
+		} else {
+			s.skipped = append(s.skipped, objectID(&objs[i]))
+		}
+	}
+	return selected
+}
+
+func (s *Selection) selects(obj *unstructured.Unstructured) bool {
+	included := len(s.include) == 0
+	for i := range s.include {
+		if s.include[i].matches(obj) {
+			included = true
+			break
+		}
+	}
+	if !included {
+		return false
+	}
+	for i := range s.exclude {
+		if s.exclude[i].matches(obj) {
+			return false
+		}
+	}
+	return true
+}
+
+// Skipped returns the identities of the skipped objects (e.g. `v1/Namespace:my-namespace`), in sorted order.
+func (s *Selection) Skipped() []string {
+	if s == nil {
+		return nil
+	}
+	skipped := append([]string(nil), s.skipped...)
+	sort.Strings(skipped)
+	return skipped
+}
+
+// objectID returns the identity of an object, e.g. `apps/v1/Deployment:my-namespace/my-deployment`.
+func objectID(obj *unstructured.Unstructured) string {
+	name := obj.GetName()
+	if obj.GetNamespace() != "" {
+		name = obj.GetNamespace() + "/" + name
+	}
+	return fmt.Sprintf("%s/%s:%s", obj.GetAPIVersion(), obj.GetKind(), name)
+}
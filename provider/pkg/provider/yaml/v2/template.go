@@ -0,0 +1,140 @@
+// Copyright 2016-2026, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v2
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"text/template"
+
+	"github.com/Masterminds/sprig/v3"
+)
+
+// The engines with which manifests may be templated.
+const (
+	// TemplateEngineGoTemplate renders the manifests as Go templates, with the Sprig functions and the
+	// values as `.Values`.
+	TemplateEngineGoTemplate = "goTemplate"
+	// TemplateEngineEnvsubst substitutes `${VAR}` and `${VAR:-default}` references to the values, as envsubst
+	// does. Nested values are referred to by dotted paths, e.g. `${image.tag}`.
+	TemplateEngineEnvsubst = "envsubst"
+)
+
+// TemplateOptions configures the templating of manifests before they're decoded.
+type TemplateOptions struct {
+	// Engine is the template engine, defaulting to TemplateEngineGoTemplate.
+	Engine string
+	// Values are the values with which to render the templates.
+	Values map[string]any
+}
+
+// NewTemplateOptions returns the template options for the given engine and values, or nil if neither is set.
+func NewTemplateOptions(engine string, values map[string]any) (*TemplateOptions, error) {
+	switch engine {
+	case "":
+		if values == nil {
+			return nil, nil
+		}
+		engine = TemplateEngineGoTemplate
+	case TemplateEngineGoTemplate, TemplateEngineEnvsubst:
+	default:
+		return nil, fmt.Errorf("templateEngine must be %q or %q", TemplateEngineGoTemplate, TemplateEngineEnvsubst)
+	}
+	return &TemplateOptions{Engine: engine, Values: values}, nil
+}
+
+// render renders the manifest with the given name.
+func (o *TemplateOptions) render(name, text string) (string, error) {
+	switch o.Engine {
+	case TemplateEngineEnvsubst:
+		return envsubst(text, o.Values)
+	default:
+		return goTemplate(name, text, o.Values)
+	}
+}
+
+// templateFuncs are the Sprig functions, less those that read the environment of the provider, as in Helm.
+var templateFuncs = func() template.FuncMap {
+	funcs := sprig.TxtFuncMap()
+	delete(funcs, "env")
+	delete(funcs, "expandenv")
+	return funcs
+}()
+
+func goTemplate(name, text string, values map[string]any) (string, error) {
+	t, err := template.New(name).Funcs(templateFuncs).Option("missingkey=error").Parse(text)
+	if err != nil {
+		return "", err
+	}
+	if values == nil {
+		values = map[string]any{}
+	}
+	var out strings.Builder
+	if err := t.Execute(&out, map[string]any{"Values": values}); err != nil {
+		return "", err
+	}
+	return out.String(), nil
+}
+
+// envsubstPattern matches the `${VAR}` and `${VAR:-default}` references, and the `$${VAR}` escapes.
+var envsubstPattern = regexp.MustCompile(`\$?\$\{([A-Za-z_][A-Za-z0-9_.-]*)(?::-([^}]*))?\}`)
+
+// envsubst substitutes the references to the values in the text. A reference to a missing value without a
+// default is left as is, so that manifests may contain references that aren't meant to be substituted (e.g. in
+// scripts). A reference is escaped with another `$`, e.g. `$${VAR}`.
+func envsubst(text string, values map[string]any) (string, error) {
+	var err error
+	out := envsubstPattern.ReplaceAllStringFunc(text, func(ref string) string {
+		if strings.HasPrefix(ref, "$$") {
+			return ref[1:]
+		}
+		m := envsubstPattern.FindStringSubmatch(ref)
+		v, ok := lookupValue(values, m[1])
+		switch {
+		case !ok && strings.Contains(ref, ":-"):
+			return m[2]
+		case !ok:
+			return ref
+		}
+		switch v := v.(type) {
+		case map[string]any, []any:
+			if err == nil {
+				err = fmt.Errorf("value %q is not a scalar", m[1])
+			}
+			return ref
+		case nil:
+			return ""
+		default:
+			return fmt.Sprint(v)
+		}
+	})
+	return out, err
+}
+
+// lookupValue returns the value at the given dotted path.
+func lookupValue(values map[string]any, path string) (any, bool) {
+	var v any = values
+	for _, key := range strings.Split(path, ".") {
+		m, ok := v.(map[string]any)
+		if !ok {
+			return nil, false
+		}
+		if v, ok = m[key]; !ok {
+			return nil, false
+		}
+	}
+	return v, true
+}
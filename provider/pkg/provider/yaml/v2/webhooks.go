@@ -0,0 +1,189 @@
+// Copyright 2016-2026, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v2
+
+import (
+	"slices"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	cliutilsobject "sigs.k8s.io/cli-utils/pkg/object"
+	cliutilsgraph "sigs.k8s.io/cli-utils/pkg/object/graph"
+
+	logger "github.com/pulumi/pulumi/sdk/v3/go/common/util/logging"
+
+	"github.com/pulumi/pulumi-kubernetes/provider/v4/pkg/kinds"
+)
+
+const admissionRegistrationGroup = "admissionregistration.k8s.io"
+
+var (
+	serviceGK                 = schema.GroupKind{Kind: "Service"}
+	mutatingWebhookConfigGK   = schema.GroupKind{Group: admissionRegistrationGroup, Kind: "MutatingWebhookConfiguration"}
+	validatingWebhookConfigGK = schema.GroupKind{Group: admissionRegistrationGroup, Kind: "ValidatingWebhookConfiguration"}
+)
+
+// addWebhookEdges adds edges to the dependency graph for the admission webhooks of the set, such that a webhook
+// is ready before the objects that it intercepts are created:
+//
+//   - from a webhook configuration to the Services that back its webhooks, and to the workloads that those
+//     Services select, such that the webhook configuration is created once its backend is ready;
+//   - from the objects that a webhook intercepts on creation or update (by the apiGroups, apiVersions and
+//     resources of its rules) to the webhook configuration.
+//
+// Webhooks whose failure policy is Ignore don't block the objects, and so don't order them. The objects in the
+// namespace of a webhook's Service, and the cluster-scoped built-in objects (e.g. Namespaces and RBAC), are
+// presumed to be part of the webhook's installation, and so aren't ordered after it. Neither is any object that
+// the webhook configuration depends on, directly or not, since the edge would be a cycle.
+// The objs and ids must match in order and length.
+func addWebhookEdges(g *cliutilsgraph.Graph, objs cliutilsobject.UnstructuredSet, ids cliutilsobject.ObjMetadataSet) {
+	var configs []int
+	for i := range objs {
+		if gk := ids[i].GroupKind; gk == mutatingWebhookConfigGK || gk == validatingWebhookConfigGK {
+			configs = append(configs, i)
+		}
+	}
+
+	// Order the webhook configurations after their backends first, such that the edges to the webhook
+	// configurations may then be checked for cycles.
+	for _, i := range configs {
+		for _, webhook := range nestedMaps(objs[i].Object, "webhooks") {
+			namespace, name := webhookService(webhook)
+			if name == "" {
+				continue
+			}
+			for _, to := range webhookBackends(objs, ids, namespace, name) {
+				logger.V(9).Infof("adding edge from: %s, to webhook backend: %s", ids[i], to)
+				g.AddEdge(ids[i], to)
+			}
+		}
+	}
+
+	for _, i := range configs {
+		to := ids[i]
+		for _, webhook := range nestedMaps(objs[i].Object, "webhooks") {
+			if failurePolicy, _ := webhook["failurePolicy"].(string); failurePolicy == "Ignore" {
+				continue
+			}
+			namespace, name := webhookService(webhook)
+			for j, obj := range objs {
+				from := ids[j]
+				switch {
+				case from.GroupKind == mutatingWebhookConfigGK, from.GroupKind == validatingWebhookConfigGK,
+					name != "" && from.Namespace == namespace,
+					from.Namespace == "" && kinds.KnownGroupVersions.Has(obj.GetAPIVersion()),
+					!webhookIntercepts(webhook, obj),
+					dependsOn(g, to, from):
+					continue
+				}
+				logger.V(9).Infof("adding edge from: %s, to intercepting webhook: %s", from, to)
+				g.AddEdge(from, to)
+			}
+		}
+	}
+}
+
+// webhookService returns the namespace and name of the Service that backs the webhook, if any.
+func webhookService(webhook map[string]any) (namespace, name string) {
+	namespace, _ = nestedField(webhook, "clientConfig", "service", "namespace").(string)
+	name, _ = nestedField(webhook, "clientConfig", "service", "name").(string)
+	return namespace, name
+}
+
+// webhookBackends returns the Service of the given namespace and name, if it's in the set, and the workloads
+// of the namespace whose pods the Service selects.
+func webhookBackends(
+	objs cliutilsobject.UnstructuredSet, ids cliutilsobject.ObjMetadataSet, namespace, name string,
+) cliutilsobject.ObjMetadataSet {
+	svcID := cliutilsobject.ObjMetadata{Namespace: namespace, Name: name, GroupKind: serviceGK}
+	i := slices.Index(ids, svcID)
+	if i < 0 {
+		return nil
+	}
+	backends := cliutilsobject.ObjMetadataSet{svcID}
+
+	selector, _ := nestedField(objs[i].Object, "spec", "selector").(map[string]any)
+	if len(selector) == 0 {
+		return backends
+	}
+	set := labels.Set{}
+	for k, v := range selector {
+		if s, ok := v.(string); ok {
+			set[k] = s
+		}
+	}
+	for j, obj := range objs {
+		path, ok := podSpecPaths[ids[j].GroupKind]
+		if !ok || ids[j].Namespace != namespace {
+			continue
+		}
+		// The pod's labels are alongside its spec, e.g. at .spec.template.metadata.labels of a Deployment.
+		labelsPath := append(slices.Clone(path[:len(path)-1]), "metadata", "labels")
+		podLabels, _, _ := unstructured.NestedStringMap(obj.Object, labelsPath...)
+		if labels.SelectorFromSet(set).Matches(labels.Set(podLabels)) {
+			backends = append(backends, ids[j])
+		}
+	}
+	return backends
+}
+
+// webhookIntercepts reports whether any of the webhook's rules intercepts the creation or update of the object.
+func webhookIntercepts(webhook map[string]any, obj *unstructured.Unstructured) bool {
+	gvk := obj.GroupVersionKind()
+	resource, _ := meta.UnsafeGuessKindToResource(gvk)
+	for _, rule := range nestedMaps(webhook, "rules") {
+		switch {
+		case !matchesAny(rule, "operations", "CREATE", "UPDATE"),
+			!matchesAny(rule, "apiGroups", gvk.Group),
+			!matchesAny(rule, "apiVersions", gvk.Version),
+			!matchesAny(rule, "resources", resource.Resource, "*/*"):
+			continue
+		}
+		return true
+	}
+	return false
+}
+
+// matchesAny reports whether the list at the given field of the rule contains a wildcard or any of the values.
+func matchesAny(rule map[string]any, field string, values ...string) bool {
+	items, _ := rule[field].([]any)
+	for _, item := range items {
+		if s, _ := item.(string); s == "*" || slices.Contains(values, s) {
+			return true
+		}
+	}
+	return false
+}
+
+// dependsOn reports whether the from object depends on the to object, directly or not.
+func dependsOn(g *cliutilsgraph.Graph, from, to cliutilsobject.ObjMetadata) bool {
+	visited := map[cliutilsobject.ObjMetadata]bool{}
+	pending := []cliutilsobject.ObjMetadata{from}
+	for len(pending) > 0 {
+		v := pending[len(pending)-1]
+		pending = pending[:len(pending)-1]
+		if v == to {
+			return true
+		}
+		if visited[v] {
+			continue
+		}
+		visited[v] = true
+		pending = append(pending, g.Dependencies(v)...)
+	}
+	return false
+}
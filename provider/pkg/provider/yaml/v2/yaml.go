@@ -21,7 +21,6 @@ import (
 	"context"
 	"fmt"
 	"io"
-	"net/http"
 	"net/url"
 	"os"
 	"path/filepath"
@@ -30,9 +29,11 @@ import (
 	"strings"
 
 	"github.com/pulumi/pulumi-kubernetes/provider/v4/pkg/clients"
+	manifestcache "github.com/pulumi/pulumi-kubernetes/provider/v4/pkg/manifest"
 	"github.com/pulumi/pulumi-kubernetes/sdk/v4/go/kubernetes"
 	yamlv2 "github.com/pulumi/pulumi-kubernetes/sdk/v4/go/kubernetes/yaml/v2"
 	"github.com/pulumi/pulumi/sdk/v3/go/common/util/contract"
+	logger "github.com/pulumi/pulumi/sdk/v3/go/common/util/logging"
 	"github.com/pulumi/pulumi/sdk/v3/go/pulumi"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime/schema"
@@ -45,6 +46,10 @@ type ParseOptions struct {
 	Files []string
 	Glob  bool
 	YAML  string
+	// Template, if set, templates the manifests before they're decoded.
+	Template *TemplateOptions
+	// Cache, if set, caches the manifests fetched from URLs.
+	Cache *manifestcache.Cache
 }
 
 // Parse parses a set of Kubernetes manifests into Unstructured objects.
@@ -52,24 +57,26 @@ type ParseOptions struct {
 func Parse(ctx context.Context, opts ParseOptions) ([]unstructured.Unstructured, error) {
 	var objs []unstructured.Unstructured
 
-	// Load the manifest files.
+	// Load the manifest files, keeping the name of each for templating.
 	yamls := []string{}
+	names := []string{}
 	for _, file := range opts.Files {
 		// Read the raw YAML file(s) specified in the input file parameter. It might be a URL or a file path.
 		var yaml []byte
 		u, err := url.Parse(file)
 		if err == nil && u.IsAbs() {
-			// If the string looks like a URL, in that it begins with a scheme, fetch it over the network.
-			resp, err := http.Get(file)
-			if err != nil {
-				return nil, fmt.Errorf("fetching YAML over network: %w", err)
+			// If the string looks like a URL, in that it begins with a scheme, fetch it over the network,
+			// verifying its checksum if pinned (e.g. "https://example.com/manifest.yaml#sha256=...").
+			if opts.Cache != nil {
+				yaml, err = opts.Cache.Fetch(ctx, file)
+			} else {
+				yaml, err = manifestcache.Fetch(ctx, file)
 			}
-			defer resp.Body.Close()
-			yaml, err = io.ReadAll(resp.Body)
 			if err != nil {
-				return nil, fmt.Errorf("reading YAML over network: %w", err)
+				return nil, err
 			}
 			yamls = append(yamls, string(yaml))
+			names = append(names, file)
 		} else {
 			// Otherwise, assume this is a path to a file on disk. If globbing
 			// is enabled and a pattern is provided, we might have multiple
@@ -91,14 +98,25 @@ func Parse(ctx context.Context, opts ParseOptions) ([]unstructured.Unstructured,
 					return nil, fmt.Errorf("reading YAML file from disk: %w", err)
 				}
 				yamls = append(yamls, string(yaml))
+				names = append(names, f)
 			}
 		}
 	}
 
 	// Include the manifest string literals.
 	yamls = append(yamls, opts.YAML)
+	names = append(names, "yaml")
+
+	for i, yaml := range yamls {
+		// Render the templated manifests.
+		if opts.Template != nil {
+			var err error
+			yaml, err = opts.Template.render(names[i], yaml)
+			if err != nil {
+				return nil, fmt.Errorf("rendering template: %w", err)
+			}
+		}
 
-	for _, yaml := range yamls {
 		// Parse the resulting YAML bytes and turn them into raw Kubernetes objects.
 		dec, err := yamlDecode(yaml)
 		if err != nil {
@@ -140,6 +158,10 @@ func yamlDecode(text string) ([]unstructured.Unstructured, error) {
 // - expands any list types into their individual resources
 // - applies the default namespace to namespaced resources that do not have a namespace
 //
+// The scope of a kind is determined by the built-in kinds, the CustomResourceDefinitions among the objects and
+// those that the program intends to create (see clients.CRDCache), and the provider's cached discovery
+// information, which is refreshed if a kind isn't found, such that CRDs installed in the cluster are found.
+//
 // The second return value lists the GroupVersionKinds whose namespace scope could not be
 // determined (no reachable cluster and no matching CustomResourceDefinition). Those objects are
 // left without a namespace rather than failing; callers should surface them with
@@ -157,6 +179,10 @@ func Normalize(
 		return nil, nil, err
 	}
 
+	// Determine the scope of the kinds by the CRDs among the objects, the CRDs that the program intends to create, and
+	// the cached discovery information, which is refreshed once if need be (e.g. for CRDs installed by another tool).
+	scopes := clients.NewNamespaceScopeResolver(clientSet, objs...)
+
 	var unresolved []schema.GroupVersionKind
 	for _, obj := range objs {
 		gvk := obj.GroupVersionKind()
@@ -184,7 +210,7 @@ func Normalize(
 
 		// Determine whether the kind is namespaced, which may involve a call to the API server.
 		// The object list is searched for a matching CRD before resorting to a discovery call.
-		isNamespaced, err := clients.IsNamespacedKind(gvk, clientSet, objs...)
+		isNamespaced, err := scopes.IsNamespaced(gvk)
 		switch {
 		case clients.IsNoNamespaceInfoErr(err):
 			// Scope can't be determined offline (no reachable cluster, and no matching CRD bundled or
@@ -268,6 +294,10 @@ type RegisterOptions struct {
 	SkipAwait       bool
 	ResourceOptions []pulumi.ResourceOption
 	PreRegisterF    PreRegisterFunc
+	// Registered, if set, are the resources that the component registered by previous calls to Register, on which
+	// the objects may depend by the `config.kubernetes.io/depends-on` annotation. Register adds the resources that
+	// it registers.
+	Registered map[cliutilsobject.ObjMetadata]pulumi.Resource
 }
 
 // Register registers the given Kubernetes objects as resources with the Pulumi engine.
@@ -280,10 +310,15 @@ func Register(ctx *pulumi.Context, opts RegisterOptions) (pulumi.ArrayOutput, er
 	}
 
 	// sort the objects using heuristics about Kubernetes object kinds and their dependencies.
-	g, err := cliutilsgraph.DependencyGraph(objs)
+	// The dependencies on objects outside the set are resolved to the resources registered previously.
+	ids := cliutilsobject.UnstructuredSetToObjMetadataSet(objs)
+	graphObjs, external := splitExternalDependencies(objs, ids)
+	g, err := cliutilsgraph.DependencyGraph(graphObjs)
 	if err != nil {
 		return pulumi.ArrayOutput{}, err
 	}
+	addReferenceEdges(g, objs, ids)
+	addWebhookEdges(g, objs, ids)
 
 	// process the resources in topological order, meaning that we first process the resources that have no
 	// dependencies,
@@ -304,12 +339,21 @@ func Register(ctx *pulumi.Context, opts RegisterOptions) (pulumi.ArrayOutput, er
 			// Depend on the explicit dependencies.
 			// The subsets are ordered such that the dependency is guaranteed to be registered before the dependent.
 			dependsOn := []pulumi.Resource{}
-			dependents := g.Dependencies(cliutilsobject.UnstructuredToObjMetadata(obj))
+			id := cliutilsobject.UnstructuredToObjMetadata(obj)
+			dependents := g.Dependencies(id)
 			for _, dep := range dependents {
 				if r, ok := objToResource[dep]; ok {
 					dependsOn = append(dependsOn, r)
 				}
 			}
+			for _, dep := range external[id] {
+				if r, ok := opts.Registered[dep]; ok {
+					dependsOn = append(dependsOn, r)
+				} else {
+					logger.V(3).Infof("%s depends on %s, which is not part of the component; "+
+						"the object is expected to exist in the cluster", id, dep)
+				}
+			}
 			if len(dependsOn) > 0 {
 				resourceOptions = append(resourceOptions, pulumi.DependsOn(dependsOn))
 			}
@@ -321,7 +365,10 @@ func Register(ctx *pulumi.Context, opts RegisterOptions) (pulumi.ArrayOutput, er
 				return pulumi.ArrayOutput{}, err
 			}
 			resources = append(resources, pulumi.NewResourceOutput(r))
-			objToResource[cliutilsobject.UnstructuredToObjMetadata(obj)] = r
+			objToResource[id] = r
+			if opts.Registered != nil {
+				opts.Registered[id] = r
+			}
 		}
 	}
 	return resources.ToArrayOutputWithContext(ctx.Context()), nil
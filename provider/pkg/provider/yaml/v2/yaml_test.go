@@ -16,9 +16,14 @@ package v2
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 
 	gk "github.com/onsi/ginkgo/v2"
@@ -28,6 +33,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	cliutilsobject "sigs.k8s.io/cli-utils/pkg/object"
 
 	"github.com/pulumi/pulumi/sdk/v3/go/pulumi"
 	"github.com/pulumi/pulumi/sdk/v3/go/pulumi/internals"
@@ -35,6 +41,8 @@ import (
 	"github.com/pulumi/pulumi-kubernetes/provider/v4/pkg/clients"
 	"github.com/pulumi/pulumi-kubernetes/provider/v4/pkg/clients/fake"
 	pgm "github.com/pulumi/pulumi-kubernetes/provider/v4/pkg/gomega"
+	"github.com/pulumi/pulumi-kubernetes/provider/v4/pkg/helm"
+	manifestcache "github.com/pulumi/pulumi-kubernetes/provider/v4/pkg/manifest"
 )
 
 const (
@@ -369,6 +377,243 @@ var _ = gk.Describe("Register", func() {
 					}))
 				})
 			})
+
+			gk.Context("explicit dependencies on objects outside of the set", func() {
+				gk.BeforeEach(func() {
+					registerOpts.Objects = append(registerOpts.Objects, unstructured.Unstructured{
+						Object: map[string]any{
+							"apiVersion": "v1",
+							"kind":       "Pod",
+							"metadata": map[string]any{
+								"name":      "my-pod",
+								"namespace": "my-namespace",
+								"annotations": map[string]any{
+									"config.kubernetes.io/depends-on": "/Namespace/my-namespace," +
+										"/namespaces/my-namespace/ConfigMap/other-map," +
+										"/namespaces/my-namespace/ConfigMap/existing-map",
+								},
+							},
+						},
+					})
+				})
+				gk.It("should depend on the previously registered objects, and ignore the others", func(ctx context.Context) {
+					err := pulumi.RunWithContext(tc.NewContext(ctx), func(ctx *pulumi.Context) error {
+						registerOpts.Registered = map[cliutilsobject.ObjMetadata]pulumi.Resource{}
+						previousOpts := *registerOpts
+						previousOpts.Objects = []unstructured.Unstructured{{
+							Object: map[string]any{
+								"apiVersion": "v1",
+								"kind":       "ConfigMap",
+								"metadata": map[string]any{
+									"name":      "other-map",
+									"namespace": "my-namespace",
+								},
+							},
+						}}
+						if _, err := Register(ctx, previousOpts); err != nil {
+							return err
+						}
+						_, err := Register(ctx, *registerOpts)
+						return err
+					})
+					gm.Expect(err).ShouldNot(gm.HaveOccurred())
+
+					gm.Expect(tc.monitor.Registrations()).To(gs.MatchKeys(gs.IgnoreExtras, gs.Keys{
+						"urn:pulumi:stack::project::kubernetes:core/v1:Pod::my-namespace/my-pod": gs.MatchFields(
+							gs.IgnoreExtras,
+							gs.Fields{
+								"Request": gs.MatchFields(gs.IgnoreExtras, gs.Fields{
+									"Dependencies": gm.ConsistOf(
+										"urn:pulumi:stack::project::kubernetes:core/v1:Namespace::my-namespace",
+										"urn:pulumi:stack::project::kubernetes:core/v1:ConfigMap::my-namespace/other-map",
+									),
+								}),
+							},
+						),
+					}))
+				})
+			})
+
+			gk.Context("admission webhooks", func() {
+				gk.BeforeEach(func() {
+					registerOpts.Objects = append(registerOpts.Objects,
+						unstructured.Unstructured{
+							Object: map[string]any{
+								"apiVersion": "v1",
+								"kind":       "Service",
+								"metadata": map[string]any{
+									"name":      "webhook",
+									"namespace": "webhook-system",
+								},
+								"spec": map[string]any{
+									"selector": map[string]any{"app": "webhook"},
+								},
+							},
+						},
+						unstructured.Unstructured{
+							Object: map[string]any{
+								"apiVersion": "apps/v1",
+								"kind":       "Deployment",
+								"metadata": map[string]any{
+									"name":      "webhook",
+									"namespace": "webhook-system",
+								},
+								"spec": map[string]any{
+									"template": map[string]any{
+										"metadata": map[string]any{
+											"labels": map[string]any{"app": "webhook", "tier": "backend"},
+										},
+									},
+								},
+							},
+						},
+						unstructured.Unstructured{
+							Object: map[string]any{
+								"apiVersion": "admissionregistration.k8s.io/v1",
+								"kind":       "ValidatingWebhookConfiguration",
+								"metadata": map[string]any{
+									"name": "crontabs",
+								},
+								"webhooks": []any{
+									map[string]any{
+										"name": "crontabs.stable.example.com",
+										"clientConfig": map[string]any{
+											"service": map[string]any{"namespace": "webhook-system", "name": "webhook"},
+										},
+										"rules": []any{
+											map[string]any{
+												"operations":  []any{"CREATE", "UPDATE"},
+												"apiGroups":   []any{"stable.example.com"},
+												"apiVersions": []any{"*"},
+												"resources":   []any{"crontabs"},
+											},
+										},
+									},
+								},
+							},
+						},
+					)
+				})
+				gk.It("should order the webhook after its backend, and the intercepted objects after the webhook",
+					func(ctx context.Context) {
+						_, err := register(ctx)
+						gm.Expect(err).ShouldNot(gm.HaveOccurred())
+
+						gm.Expect(tc.monitor.Registrations()).To(gs.MatchKeys(gs.IgnoreExtras, gs.Keys{
+							"urn:pulumi:stack::project::kubernetes:admissionregistration.k8s.io/v1:" +
+								"ValidatingWebhookConfiguration::crontabs": gs.MatchFields(
+								gs.IgnoreExtras,
+								gs.Fields{
+									"Request": gs.MatchFields(gs.IgnoreExtras, gs.Fields{
+										"Dependencies": gm.ConsistOf(
+											"urn:pulumi:stack::project::kubernetes:core/v1:Service::webhook-system/webhook",
+											"urn:pulumi:stack::project::kubernetes:apps/v1:Deployment::webhook-system/webhook",
+										),
+									}),
+								},
+							),
+							"urn:pulumi:stack::project::kubernetes:stable.example.com/v1:CronTab::" +
+								"my-namespace/my-new-cron-object": gs.MatchFields(
+								gs.IgnoreExtras,
+								gs.Fields{
+									"Request": gs.MatchFields(gs.IgnoreExtras, gs.Fields{
+										"Dependencies": gm.ConsistOf(
+											"urn:pulumi:stack::project::kubernetes:apiextensions.k8s.io/v1:"+
+												"CustomResourceDefinition::crontabs.stable.example.com",
+											"urn:pulumi:stack::project::kubernetes:core/v1:Namespace::my-namespace",
+											"urn:pulumi:stack::project::kubernetes:admissionregistration.k8s.io/v1:"+
+												"ValidatingWebhookConfiguration::crontabs",
+										),
+									}),
+								},
+							),
+							"urn:pulumi:stack::project::kubernetes:core/v1:ConfigMap::my-namespace/my-map": gs.MatchFields(
+								gs.IgnoreExtras,
+								gs.Fields{
+									"Request": gs.MatchFields(gs.IgnoreExtras, gs.Fields{
+										"Dependencies": gm.ConsistOf(
+											"urn:pulumi:stack::project::kubernetes:core/v1:Namespace::my-namespace",
+										),
+									}),
+								},
+							),
+						}))
+					})
+			})
+
+			gk.Context("referenced config (ConfigMaps and Secrets)", func() {
+				gk.BeforeEach(func() {
+					registerOpts.Objects = append(registerOpts.Objects,
+						unstructured.Unstructured{
+							Object: map[string]any{
+								"apiVersion": "v1",
+								"kind":       "Secret",
+								"metadata": map[string]any{
+									"name":      "my-secret-5f7h9k",
+									"namespace": "my-namespace",
+								},
+							},
+						},
+						unstructured.Unstructured{
+							Object: map[string]any{
+								"apiVersion": "apps/v1",
+								"kind":       "Deployment",
+								"metadata": map[string]any{
+									"name":      "my-deployment",
+									"namespace": "my-namespace",
+								},
+								"spec": map[string]any{
+									"template": map[string]any{
+										"spec": map[string]any{
+											"containers": []any{
+												map[string]any{
+													"name": "app",
+													"envFrom": []any{
+														map[string]any{"configMapRef": map[string]any{"name": "my-map"}},
+													},
+													"env": []any{
+														map[string]any{
+															"name": "OTHER",
+															"valueFrom": map[string]any{
+																"secretKeyRef": map[string]any{"name": "other-secret", "key": "key"},
+															},
+														},
+													},
+												},
+											},
+											"volumes": []any{
+												map[string]any{
+													"name":   "secret",
+													"secret": map[string]any{"secretName": "my-secret-5f7h9k"},
+												},
+											},
+										},
+									},
+								},
+							},
+						},
+					)
+				})
+				gk.It("should apply a DependsOn option on the referencing workloads", func(ctx context.Context) {
+					_, err := register(ctx)
+					gm.Expect(err).ShouldNot(gm.HaveOccurred())
+
+					gm.Expect(tc.monitor.Registrations()).To(gs.MatchKeys(gs.IgnoreExtras, gs.Keys{
+						"urn:pulumi:stack::project::kubernetes:apps/v1:Deployment::my-namespace/my-deployment": gs.MatchFields(
+							gs.IgnoreExtras,
+							gs.Fields{
+								"Request": gs.MatchFields(gs.IgnoreExtras, gs.Fields{
+									"Dependencies": gm.ConsistOf(
+										"urn:pulumi:stack::project::kubernetes:core/v1:Namespace::my-namespace",
+										"urn:pulumi:stack::project::kubernetes:core/v1:ConfigMap::my-namespace/my-map",
+										"urn:pulumi:stack::project::kubernetes:core/v1:Secret::my-namespace/my-secret-5f7h9k",
+									),
+								}),
+							},
+						),
+					}))
+				})
+			})
 		})
 	})
 
@@ -515,6 +760,280 @@ var _ = gk.Describe("Parse", func() {
 				gm.Expect(objs).To(gm.HaveLen(6))
 			})
 		})
+
+		gk.Context("when the input is a URL pinned to a checksum", func() {
+			var server *httptest.Server
+			var requests int
+			var pin string
+
+			gk.BeforeEach(func() {
+				requests = 0
+				server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+					requests++
+					_, _ = w.Write([]byte(manifest))
+				}))
+				gk.DeferCleanup(server.Close)
+				sum := sha256.Sum256([]byte(manifest))
+				pin = hex.EncodeToString(sum[:])
+				args.Files = []string{server.URL + "/manifest.yaml#sha256=" + pin}
+			})
+			manifestAssertions()
+
+			gk.Context("with a manifest cache", func() {
+				gk.BeforeEach(func() {
+					args.Cache = manifestcache.NewCache(gk.GinkgoT().TempDir(), false)
+				})
+				gk.It("should download the document once", func(ctx context.Context) {
+					for range 2 {
+						_, err := Parse(ctx, args)
+						gm.Expect(err).ShouldNot(gm.HaveOccurred())
+					}
+					gm.Expect(requests).To(gm.Equal(1))
+				})
+			})
+
+			gk.Context("when the checksum doesn't match", func() {
+				gk.BeforeEach(func() {
+					args.Files = []string{server.URL + "/manifest.yaml#sha256=" + strings.Repeat("0", 64)}
+				})
+				gk.It("should fail", func(ctx context.Context) {
+					_, err := Parse(ctx, args)
+					gm.Expect(err).Should(gm.MatchError(gm.ContainSubstring("checksum mismatch")))
+				})
+			})
+		})
+	})
+
+	gk.Describe("templating", func() {
+		templated := `apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: %s
+data:
+  image: %s
+  script: echo ${HOME}
+`
+		values := map[string]any{
+			"name":  "my-map",
+			"image": map[string]any{"repository": "nginx", "tag": "1.27"},
+		}
+		matchData := func(name string, data gs.Keys) gm.OmegaMatcher {
+			return gm.ConsistOf(matchUnstructured(gs.Keys{
+				"metadata": gs.MatchKeys(gs.IgnoreExtras, gs.Keys{"name": gm.Equal(name)}),
+				"data":     gs.MatchAllKeys(data),
+			}))
+		}
+
+		gk.Context("with Go templates", func() {
+			gk.BeforeEach(func() {
+				args.Template = &TemplateOptions{Engine: TemplateEngineGoTemplate, Values: values}
+				args.YAML = fmt.Sprintf(templated,
+					"{{ .Values.name | upper | lower }}", `{{ printf "%s:%s" .Values.image.repository .Values.image.tag }}`)
+			})
+			gk.It("should render the templates with the values", func(ctx context.Context) {
+				objs, err := Parse(ctx, args)
+				gm.Expect(err).ShouldNot(gm.HaveOccurred())
+				gm.Expect(objs).To(matchData("my-map", gs.Keys{
+					"image":  gm.Equal("nginx:1.27"),
+					"script": gm.Equal("echo ${HOME}"),
+				}))
+			})
+
+			gk.Context("when a value is missing", func() {
+				gk.BeforeEach(func() {
+					args.YAML = fmt.Sprintf(templated, "{{ .Values.missing }}", "nginx")
+				})
+				gk.It("should fail", func(ctx context.Context) {
+					_, err := Parse(ctx, args)
+					gm.Expect(err).Should(gm.MatchError(gm.ContainSubstring("rendering template")))
+				})
+			})
+		})
+
+		gk.Context("with envsubst", func() {
+			gk.BeforeEach(func() {
+				args.Template = &TemplateOptions{Engine: TemplateEngineEnvsubst, Values: values}
+				tempDir := tempFiles(fmt.Sprintf(templated, "${name}", "${image.repository}:${image.digest:-latest}"))
+				args.Files = []string{filepath.Join(tempDir, "manifest-01.yaml")}
+			})
+			gk.It("should substitute the values, leaving unknown variables as is", func(ctx context.Context) {
+				objs, err := Parse(ctx, args)
+				gm.Expect(err).ShouldNot(gm.HaveOccurred())
+				gm.Expect(objs).To(matchData("my-map", gs.Keys{
+					"image":  gm.Equal("nginx:latest"),
+					"script": gm.Equal("echo ${HOME}"),
+				}))
+			})
+		})
+	})
+})
+
+var _ = gk.Describe("ApplyPatches", func() {
+	var objs []unstructured.Unstructured
+	var patches []Patch
+
+	gk.BeforeEach(func() {
+		var err error
+		objs, err = yamlDecode(manifest)
+		gm.Expect(err).ShouldNot(gm.HaveOccurred())
+		patches = nil
+	})
+
+	gk.Context("when there are no patches", func() {
+		gk.It("should return the objects as is", func(_ /* ctx */ context.Context) {
+			patched, err := ApplyPatches(objs, patches)
+			gm.Expect(err).ShouldNot(gm.HaveOccurred())
+			gm.Expect(patched).To(gm.Equal(objs))
+		})
+	})
+
+	gk.Context("given a strategic merge patch", func() {
+		gk.BeforeEach(func() {
+			patches = []Patch{{Patch: `
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: my-map
+  namespace: my-namespace
+data:
+  greeting: Hello
+`}}
+		})
+		gk.It("should patch the object that the patch identifies", func(_ /* ctx */ context.Context) {
+			patched, err := ApplyPatches(objs, patches)
+			gm.Expect(err).ShouldNot(gm.HaveOccurred())
+			gm.Expect(patched).To(gm.HaveLen(len(objs)))
+			gm.Expect(patched).To(gm.ContainElement(matchUnstructured(gs.Keys{
+				"kind": gm.Equal("ConfigMap"),
+				"data": gs.MatchAllKeys(gs.Keys{
+					"altGreeting": gm.Equal("Good Morning!"),
+					"greeting":    gm.Equal("Hello"),
+				}),
+			})))
+		})
+	})
+
+	gk.Context("given a JSON 6902 patch with a target", func() {
+		gk.BeforeEach(func() {
+			patches = []Patch{{
+				Patch:  `[{"op": "replace", "path": "/spec/image", "value": "my-other-cron-image"}]`,
+				Target: &helm.KustomizePatchTarget{Kind: "CronTab", Name: "my-.*"},
+			}}
+		})
+		gk.It("should patch the objects that the target selects", func(_ /* ctx */ context.Context) {
+			patched, err := ApplyPatches(objs, patches)
+			gm.Expect(err).ShouldNot(gm.HaveOccurred())
+			gm.Expect(patched).To(gm.ContainElement(matchUnstructured(gs.Keys{
+				"kind": gm.Equal("CronTab"),
+				"spec": gs.MatchKeys(gs.IgnoreExtras, gs.Keys{"image": gm.Equal("my-other-cron-image")}),
+			})))
+		})
+	})
+
+	gk.Context("when a patch is empty", func() {
+		gk.BeforeEach(func() {
+			patches = []Patch{{Target: &helm.KustomizePatchTarget{Kind: "ConfigMap"}}}
+		})
+		gk.It("should fail", func(_ /* ctx */ context.Context) {
+			_, err := ApplyPatches(objs, patches)
+			gm.Expect(err).Should(gm.MatchError(gm.ContainSubstring("patch is required")))
+		})
+	})
+})
+
+var _ = gk.Describe("Selection", func() {
+	var objs []unstructured.Unstructured
+	var include, exclude []any
+
+	gk.BeforeEach(func() {
+		var err error
+		objs, err = yamlDecode(manifest)
+		gm.Expect(err).ShouldNot(gm.HaveOccurred())
+		objs[2].SetLabels(map[string]string{"app": "greeter"})
+		include, exclude = nil, nil
+	})
+
+	selectNames := func() ([]string, []string) {
+		gk.GinkgoHelper()
+		selection, err := DecodeSelection(include, exclude)
+		gm.Expect(err).ShouldNot(gm.HaveOccurred())
+		var names []string
+		for _, obj := range selection.Select(objs) {
+			names = append(names, obj.GetName())
+		}
+		return names, selection.Skipped()
+	}
+
+	gk.Context("when there are no selectors", func() {
+		gk.It("should select all objects", func(_ /* ctx */ context.Context) {
+			selection, err := DecodeSelection(include, exclude)
+			gm.Expect(err).ShouldNot(gm.HaveOccurred())
+			gm.Expect(selection).To(gm.BeNil())
+			gm.Expect(selection.Select(objs)).To(gm.Equal(objs))
+			gm.Expect(selection.Skipped()).To(gm.BeEmpty())
+		})
+	})
+
+	gk.Context("given an excluded kind", func() {
+		gk.BeforeEach(func() {
+			exclude = []any{map[string]any{"kind": "Namespace"}}
+		})
+		gk.It("should skip the objects of that kind", func(_ /* ctx */ context.Context) {
+			selected, skipped := selectNames()
+			gm.Expect(selected).To(gm.ConsistOf("crontabs.stable.example.com", "my-map", "my-new-cron-object"))
+			gm.Expect(skipped).To(gm.ConsistOf("v1/Namespace:my-namespace"))
+		})
+	})
+
+	gk.Context("given included namespaces and name patterns", func() {
+		gk.BeforeEach(func() {
+			include = []any{map[string]any{"namespace": "my-namespace", "name": "my-new-*"}}
+		})
+		gk.It("should skip the other objects", func(_ /* ctx */ context.Context) {
+			selected, skipped := selectNames()
+			gm.Expect(selected).To(gm.ConsistOf("my-new-cron-object"))
+			gm.Expect(skipped).To(gm.HaveExactElements(
+				"apiextensions.k8s.io/v1/CustomResourceDefinition:crontabs.stable.example.com",
+				"v1/ConfigMap:my-namespace/my-map",
+				"v1/Namespace:my-namespace",
+			))
+		})
+	})
+
+	gk.Context("given both included and excluded selectors", func() {
+		gk.BeforeEach(func() {
+			include = []any{map[string]any{"group": "stable.example.com"}, map[string]any{"kind": "ConfigMap"}}
+			exclude = []any{map[string]any{"labelSelector": "app in (greeter)"}}
+		})
+		gk.It("should skip the excluded objects among the included objects", func(_ /* ctx */ context.Context) {
+			selected, skipped := selectNames()
+			gm.Expect(selected).To(gm.ConsistOf("my-new-cron-object"))
+			gm.Expect(skipped).To(gm.ConsistOf(
+				"apiextensions.k8s.io/v1/CustomResourceDefinition:crontabs.stable.example.com",
+				"v1/ConfigMap:my-namespace/my-map",
+				"v1/Namespace:my-namespace",
+			))
+		})
+	})
+
+	gk.Context("given an invalid label selector", func() {
+		gk.BeforeEach(func() {
+			include = []any{map[string]any{"labelSelector": "app in greeter"}}
+		})
+		gk.It("should fail", func(_ /* ctx */ context.Context) {
+			_, err := DecodeSelection(include, exclude)
+			gm.Expect(err).Should(gm.MatchError(gm.ContainSubstring("include: invalid label selector")))
+		})
+	})
+
+	gk.Context("given an invalid name pattern", func() {
+		gk.BeforeEach(func() {
+			exclude = []any{map[string]any{"name": "my-["}}
+		})
+		gk.It("should fail", func(_ /* ctx */ context.Context) {
+			_, err := DecodeSelection(include, exclude)
+			gm.Expect(err).Should(gm.MatchError(gm.ContainSubstring("exclude: invalid name pattern")))
+		})
 	})
 })
 
@@ -619,6 +1138,48 @@ var _ = gk.Describe("Normalize", func() {
 				))
 			})
 		})
+
+		gk.Context("when the object's kind is served by another version of its group", func() {
+			gk.BeforeEach(func() {
+				objs = []unstructured.Unstructured{{
+					Object: map[string]any{
+						"apiVersion": "stable.example.com/v2",
+						"kind":       "Issuer",
+						"metadata": map[string]any{
+							"name": "my-issuer",
+						},
+					},
+				}}
+			})
+
+			gk.It("should use the scope of the served kind", func(_ /* ctx */ context.Context) {
+				objs, unresolved, err := Normalize(objs, defaultNamespace, clientSet)
+				gm.Expect(err).ShouldNot(gm.HaveOccurred())
+				gm.Expect(unresolved).To(gm.BeEmpty())
+				gm.Expect(objs).To(gm.HaveExactElements(
+					matchUnstructured(gs.Keys{"metadata": gs.MatchKeys(gs.IgnoreExtras, gs.Keys{"namespace": gm.Equal("default")})}),
+				))
+			})
+		})
+
+		gk.Context("when the object's kind is installed by a CRD that the program intends to create", func() {
+			gk.BeforeEach(func() {
+				crds, err := yamlDecode(manifest)
+				gm.Expect(err).ShouldNot(gm.HaveOccurred())
+				gm.Expect(clientSet.CRDCache.AddCRD(&crds[0])).To(gm.Succeed())
+				objs = []unstructured.Unstructured{crds[3]}
+				unstructured.RemoveNestedField(objs[0].Object, "metadata", "namespace")
+			})
+
+			gk.It("should use the scope of the CRD", func(_ /* ctx */ context.Context) {
+				objs, unresolved, err := Normalize(objs, defaultNamespace, clientSet)
+				gm.Expect(err).ShouldNot(gm.HaveOccurred())
+				gm.Expect(unresolved).To(gm.BeEmpty())
+				gm.Expect(objs).To(gm.HaveExactElements(
+					matchUnstructured(gs.Keys{"metadata": gs.MatchKeys(gs.IgnoreExtras, gs.Keys{"namespace": gm.Equal("default")})}),
+				))
+			})
+		})
 	})
 
 	gk.Describe("special-case kinds", func() {
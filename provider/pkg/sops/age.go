@@ -0,0 +1,56 @@
+// Copyright 2016-2026, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sops
+
+import (
+	"strings"
+
+	"filippo.io/age"
+)
+
+// AgeRecipient is the X25519 public key of an age identity, to which the data keys are encrypted.
+type AgeRecipient struct {
+	recipient *age.X25519Recipient
+}
+
+// ParseAgeRecipient parses an age recipient, e.g. `age1ql3z7hjy54pw3hyww5ayyfg7zqgvc7w3j2elw8zmrj2kg5sfn9aqmcac8p`.
+func ParseAgeRecipient(s string) (*AgeRecipient, error) {
+	recipient, err := age.ParseX25519Recipient(s)
+	if err != nil {
+		return nil, err
+	}
+	return &AgeRecipient{recipient: recipient}, nil
+}
+
+// ParseAgeRecipients parses a comma-separated list of age recipients, as accepted by the `--age` flag of SOPS.
+func ParseAgeRecipients(s string) ([]*AgeRecipient, error) {
+	var recipients []*AgeRecipient
+	for _, r := range strings.Split(s, ",") {
+		if r = strings.TrimSpace(r); r == "" {
+			continue
+		}
+		recipient, err := ParseAgeRecipient(r)
+		if err != nil {
+			return nil, err
+		}
+		recipients = append(recipients, recipient)
+	}
+	return recipients, nil
+}
+
+// String returns the recipient in its `age1...` form.
+func (r *AgeRecipient) String() string {
+	return r.recipient.String()
+}
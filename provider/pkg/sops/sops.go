@@ -0,0 +1,130 @@
+// Copyright 2016-2026, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package sops encrypts the data of Kubernetes Secrets in the SOPS format, with age recipients, such that the
+// Secrets may be committed to git, and decrypted by SOPS or by Flux's kustomize-controller on their way to a
+// cluster.
+package sops
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	sopsv3 "github.com/getsops/sops/v3"
+	sopsaes "github.com/getsops/sops/v3/aes"
+	sopsage "github.com/getsops/sops/v3/age"
+	sopsconfig "github.com/getsops/sops/v3/config"
+	"github.com/getsops/sops/v3/keyservice"
+	sopsyaml "github.com/getsops/sops/v3/stores/yaml"
+	sopsversion "github.com/getsops/sops/v3/version"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	// MetadataKey is the key of the SOPS metadata of an encrypted document.
+	MetadataKey = "sops"
+	// EncryptedRegex matches the keys whose values are encrypted: the `data` and `stringData` of a Secret, as with
+	// the `--encrypted-regex` that Flux recommends.
+	EncryptedRegex = "^(data|stringData)$"
+)
+
+// EncryptSecret returns a copy of the Secret with the values of its `data` and `stringData` encrypted with a new
+// data key, and the SOPS metadata by which the recipients may decrypt them, as `sops --encrypt` does. The Secret is
+// returned as is if it has neither, or if it's encrypted already.
+//
+// The document is authenticated by a MAC over all of its values, in the order of its keys. The document must be
+// written with its keys sorted (as the YAML and JSON of an unstructured object are), so that SOPS reads the values
+// in that order.
+func EncryptSecret(obj map[string]any, recipients []*AgeRecipient, now time.Time) (map[string]any, error) {
+	if _, ok := obj[MetadataKey]; ok {
+		return obj, nil
+	}
+	if _, ok := obj["data"]; !ok {
+		if _, ok := obj["stringData"]; !ok {
+			return obj, nil
+		}
+	}
+	if len(recipients) == 0 {
+		return nil, errors.New("no age recipients to encrypt to")
+	}
+
+	plaintext, err := yaml.Marshal(obj)
+	if err != nil {
+		return nil, err
+	}
+	store := sopsyaml.NewStore(&sopsconfig.YAMLStoreConfig{})
+	branches, err := store.LoadPlainFile(plaintext)
+	if err != nil {
+		return nil, err
+	}
+	var keyGroup sopsv3.KeyGroup
+	for _, r := range recipients {
+		key, err := sopsage.MasterKeyFromRecipient(r.String())
+		if err != nil {
+			return nil, err
+		}
+		keyGroup = append(keyGroup, key)
+	}
+	tree := sopsv3.Tree{
+		Branches: branches,
+		Metadata: sopsv3.Metadata{
+			KeyGroups:      []sopsv3.KeyGroup{keyGroup},
+			EncryptedRegex: EncryptedRegex,
+			Version:        sopsversion.Version,
+		},
+	}
+	dataKey, errs := tree.GenerateDataKeyWithKeyServices(
+		[]keyservice.KeyServiceClient{keyservice.NewLocalClient()})
+	if len(errs) > 0 {
+		return nil, fmt.Errorf("failed to encrypt the data key: %w", errors.Join(errs...))
+	}
+
+	cipher := sopsaes.NewCipher()
+	mac, err := tree.Encrypt(dataKey, cipher)
+	if err != nil {
+		return nil, err
+	}
+	tree.Metadata.LastModified = now.UTC().Truncate(time.Second)
+	tree.Metadata.MessageAuthenticationCode, err = cipher.Encrypt(
+		mac, dataKey, tree.Metadata.LastModified.Format(time.RFC3339))
+	if err != nil {
+		return nil, err
+	}
+
+	encrypted, err := store.EmitEncryptedFile(tree)
+	if err != nil {
+		return nil, err
+	}
+	var result map[string]any
+	if err := yaml.Unmarshal(encrypted, &result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// AgeRecipients returns the age recipients to which a SOPS document is encrypted, in order, or nil if the
+// document isn't encrypted.
+func AgeRecipients(obj map[string]any) []string {
+	metadata, _ := obj[MetadataKey].(map[string]any)
+	ageKeys, _ := metadata["age"].([]any)
+	var recipients []string
+	for _, k := range ageKeys {
+		ageKey, _ := k.(map[string]any)
+		if recipient, ok := ageKey["recipient"].(string); ok {
+			recipients = append(recipients, recipient)
+		}
+	}
+	return recipients
+}
@@ -0,0 +1,140 @@
+// Copyright 2016-2026, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sops
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"filippo.io/age"
+	"github.com/getsops/sops/v3/decrypt"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"sigs.k8s.io/yaml"
+)
+
+func TestParseAgeRecipients(t *testing.T) {
+	recipients, err := ParseAgeRecipients(
+		"age1ql3z7hjy54pw3hyww5ayyfg7zqgvc7w3j2elw8zmrj2kg5sfn9aqmcac8p, " + testIdentity(t).recipient.String())
+	require.NoError(t, err)
+	require.Len(t, recipients, 2)
+	assert.Equal(t, "age1ql3z7hjy54pw3hyww5ayyfg7zqgvc7w3j2elw8zmrj2kg5sfn9aqmcac8p", recipients[0].String())
+
+	_, err = ParseAgeRecipients("age1ql3z7hjy54pw3hyww5ayyfg7zqgvc7w3j2elw8zmrj2kg5sfn9aqmcac8q")
+	assert.ErrorContains(t, err, "malformed recipient")
+	_, err = ParseAgeRecipients("AGE-SECRET-KEY-1QQQQQQQQQQQQQQQQQQQQQQQQQQQQQQQQQQQQQQQQQQQQQQQQQQQQQQQQHGVSQ3")
+	assert.Error(t, err)
+}
+
+func TestEncryptSecret(t *testing.T) {
+	alice, bob := testIdentity(t), testIdentity(t)
+	secret := map[string]any{
+		"apiVersion": "v1",
+		"kind":       "Secret",
+		"metadata":   map[string]any{"name": "db", "labels": map[string]any{"app": "db"}},
+		"type":       "Opaque",
+		"immutable":  true,
+		"data":       map[string]any{"password": "aHVudGVyMg==", "empty": ""},
+		"stringData": map[string]any{"config.yaml": "user: admin\n"},
+	}
+	now := time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)
+
+	encrypted, err := EncryptSecret(secret, []*AgeRecipient{alice.recipient, bob.recipient}, now)
+	require.NoError(t, err)
+	assert.Equal(t, "aHVudGVyMg==", secret["data"].(map[string]any)["password"], "the Secret mustn't be modified")
+	assert.Equal(t, "db", encrypted["metadata"].(map[string]any)["name"], "the metadata isn't encrypted")
+	assert.Equal(t, "", encrypted["data"].(map[string]any)["empty"])
+	assert.Regexp(t, `^ENC\[AES256_GCM,data:.+,iv:.+,tag:.+,type:str\]$`, encrypted["data"].(map[string]any)["password"])
+
+	assert.Equal(t, []string{alice.recipient.String(), bob.recipient.String()}, AgeRecipients(encrypted))
+	assert.Nil(t, AgeRecipients(secret))
+	metadata := encrypted[MetadataKey].(map[string]any)
+	assert.Equal(t, "2026-10-19T12:00:00Z", metadata["lastmodified"])
+	assert.Equal(t, EncryptedRegex, metadata["encrypted_regex"])
+
+	// Each recipient decrypts the Secret, as written, with SOPS.
+	manifest, err := yaml.Marshal(encrypted)
+	require.NoError(t, err)
+	for _, id := range []*testAgeIdentity{alice, bob} {
+		assert.Equal(t, secret, id.decrypt(t, manifest))
+	}
+
+	// Secrets without data, and encrypted Secrets, are left as they are.
+	empty := map[string]any{"apiVersion": "v1", "kind": "Secret", "metadata": map[string]any{"name": "empty"}}
+	result, err := EncryptSecret(empty, []*AgeRecipient{alice.recipient}, now)
+	require.NoError(t, err)
+	assert.Equal(t, empty, result)
+	result, err = EncryptSecret(encrypted, []*AgeRecipient{alice.recipient}, now)
+	require.NoError(t, err)
+	assert.Equal(t, encrypted, result)
+}
+
+// TestDecryptFixture decrypts a Secret encrypted by the sops CLI, as the provider renders them:
+//
+//	sops --encrypt --age <recipient> --encrypted-regex '^(data|stringData)$' secret.yaml > secret.enc.yaml
+func TestDecryptFixture(t *testing.T) {
+	key, err := os.ReadFile(filepath.Join("testdata", "age.key"))
+	require.NoError(t, err)
+	identities, err := age.ParseIdentities(bytes.NewReader(key))
+	require.NoError(t, err)
+	require.Len(t, identities, 1)
+	id := &testAgeIdentity{identity: identities[0].(*age.X25519Identity)}
+
+	plaintext, err := os.ReadFile(filepath.Join("testdata", "secret.yaml"))
+	require.NoError(t, err)
+	var secret map[string]any
+	require.NoError(t, yaml.Unmarshal(plaintext, &secret))
+	manifest, err := os.ReadFile(filepath.Join("testdata", "secret.enc.yaml"))
+	require.NoError(t, err)
+	var fixture map[string]any
+	require.NoError(t, yaml.Unmarshal(manifest, &fixture))
+
+	assert.Equal(t, []string{id.identity.Recipient().String()}, AgeRecipients(fixture))
+	assert.Equal(t, EncryptedRegex, fixture[MetadataKey].(map[string]any)["encrypted_regex"])
+	assert.Equal(t, secret, id.decrypt(t, manifest))
+
+	// The fixture, as read and written again by the renderer, still decrypts.
+	rewritten, err := yaml.Marshal(fixture)
+	require.NoError(t, err)
+	assert.Equal(t, secret, id.decrypt(t, rewritten))
+}
+
+// testAgeIdentity is an age identity, by which SOPS decrypts the documents.
+type testAgeIdentity struct {
+	identity  *age.X25519Identity
+	recipient *AgeRecipient
+}
+
+func testIdentity(t *testing.T) *testAgeIdentity {
+	identity, err := age.GenerateX25519Identity()
+	require.NoError(t, err)
+	recipient, err := ParseAgeRecipient(identity.Recipient().String())
+	require.NoError(t, err)
+	return &testAgeIdentity{identity: identity, recipient: recipient}
+}
+
+// decrypt decrypts the SOPS document with the identity, and checks its MAC, as `sops --decrypt` does.
+func (id *testAgeIdentity) decrypt(t *testing.T, manifest []byte) map[string]any {
+	t.Helper()
+	t.Setenv("SOPS_AGE_KEY", id.identity.String())
+	plaintext, err := decrypt.Data(manifest, "yaml")
+	require.NoError(t, err)
+	var result map[string]any
+	require.NoError(t, yaml.Unmarshal(plaintext, &result))
+	return result
+}
@@ -17,6 +17,11 @@ namespace Pulumi.Kubernetes.Helm.V3
     /// a library to perform the orchestration of the resources. As a result, the full spectrum of Helm features are supported
     /// natively.
     /// 
+    /// When the provider renders manifests (`renderYamlToDirectory`), a `Release` isn't installed. Instead, its chart is
+    /// rendered client-side, as with `helm template`, and its objects are written to the render directory alongside the other
+    /// resources, in the layout of `renderYamlFormat`. The chart's hooks are written apart from its objects, to
+    /// `hooks/&lt;namespace&gt;-&lt;name&gt;.yaml`, in the order of their weights.
+    /// 
     /// You may also want to consider the `Chart` resource as an alternative method for managing helm charts. For more information about the trade-offs between these options see: [Choosing the right Helm resource for your use case](https://www.pulumi.com/registry/packages/kubernetes/how-to-guides/choosing-the-right-helm-resource-for-your-use-case)
     /// 
     /// ## Example Usage
@@ -285,6 +290,12 @@ namespace Pulumi.Kubernetes.Helm.V3
         [Output("createNamespace")]
         public Output<bool> CreateNamespace { get; private set; } = null!;
 
+        /// <summary>
+        /// How to resolve the chart's dependencies. By default, missing dependencies are fetched when `dependencyUpdate` is set. Values are: `locked` (require a `Chart.lock` that matches `Chart.yaml`, and fetch only the locked versions), `vendor` (resolve the dependencies into the `charts/` directory of a local chart, to be committed alongside the program; a preview resolves them into a temporary copy of the chart instead), and `offline` (never fetch dependencies; they must already be present in the chart).
+        /// </summary>
+        [Output("dependencyMode")]
+        public Output<string> DependencyMode { get; private set; } = null!;
+
         /// <summary>
         /// Run helm dependency update before installing the chart.
         /// </summary>
@@ -363,6 +374,12 @@ namespace Pulumi.Kubernetes.Helm.V3
         [Output("namespace")]
         public Output<string> Namespace { get; private set; } = null!;
 
+        /// <summary>
+        /// The rendered notes (NOTES.txt) of the deployed release.
+        /// </summary>
+        [Output("notes")]
+        public Output<string> Notes { get; private set; } = null!;
+
         /// <summary>
         /// Postrender command to run.
         /// </summary>
@@ -557,6 +574,12 @@ namespace Pulumi.Kubernetes.Types.Inputs.Helm.V3
         [Input("createNamespace")]
         public Input<bool>? CreateNamespace { get; set; }
 
+        /// <summary>
+        /// How to resolve the chart's dependencies. By default, missing dependencies are fetched when `dependencyUpdate` is set. Values are: `locked` (require a `Chart.lock` that matches `Chart.yaml`, and fetch only the locked versions), `vendor` (resolve the dependencies into the `charts/` directory of a local chart, to be committed alongside the program; a preview resolves them into a temporary copy of the chart instead), and `offline` (never fetch dependencies; they must already be present in the chart).
+        /// </summary>
+        [Input("dependencyMode")]
+        public Input<string>? DependencyMode { get; set; }
+
         /// <summary>
         /// Run helm dependency update before installing the chart.
         /// </summary>
@@ -75,6 +75,14 @@ namespace Pulumi.Kubernetes.Helm.V4
     /// 
     /// Use the `postRenderer` input to pipe the rendered manifest through a [post-rendering command](https://helm.sh/docs/topics/advanced/#post-rendering).
     /// 
+    /// ### Selecting Objects
+    /// 
+    /// Use the `include` and `exclude` inputs to select the objects of the chart to register, e.g. to skip a Namespace
+    /// that's managed separately. Each selector matches objects by `group`, `version`, `kind`, `name` (a glob pattern),
+    /// `namespace` and `labelSelector`. An object is registered if it matches any of the included selectors (or if there are
+    /// none), and none of the excluded selectors. The selectors apply to the CRDs and hooks, too. The skipped objects are
+    /// reported in the `skipped` output.
+    /// 
     /// ### Resource Ordering
     /// 
     /// Sometimes resources must be applied in a specific order. For example, a namespace resource must be
@@ -83,10 +91,20 @@ namespace Pulumi.Kubernetes.Helm.V4
     /// Pulumi uses heuristics to determine which order to apply and delete objects within the Chart.  Pulumi also
     /// waits for each object to be fully reconciled, unless `skipAwait` is enabled.
     /// 
+    /// Workloads depend on the ConfigMaps and Secrets in the same namespace that their pods reference (by volumes, `envFrom`,
+    /// `env` or `imagePullSecrets`), so that the referenced configuration is created first.
+    /// 
+    /// Admission webhook configurations depend on the Services that back them and on the workloads that those Services
+    /// select, and the objects that a webhook intercepts depend on the webhook configuration, so that the webhook is ready
+    /// before the objects are created. Webhooks whose `failurePolicy` is `Ignore` don't affect the ordering, and the objects
+    /// in the namespace of the webhook's Service are assumed to be part of the webhook's installation.
+    /// 
     /// Pulumi supports the `config.kubernetes.io/depends-on` annotation to declare an explicit dependency on a given resource.
     /// The annotation accepts a list of resource references, delimited by commas.
     /// 
-    /// Note that references to resources outside the Chart aren't supported.
+    /// A reference to an object of the chart's CRDs, or of a hook that is applied earlier (e.g. a `pre-install` hook),
+    /// is honored. A reference to any other resource outside the Chart is assumed to exist in the cluster already,
+    /// and isn't waited for.
     /// 
     /// **Resource reference**
     /// 
@@ -217,12 +235,24 @@ namespace Pulumi.Kubernetes.Helm.V4
     [KubernetesResourceType("kubernetes:helm.sh/v4:Chart")]
     public partial class Chart : global::Pulumi.ComponentResource
     {
+        /// <summary>
+        /// The rendered notes (NOTES.txt) of the Chart.
+        /// </summary>
+        [Output("notes")]
+        public Output<string> Notes { get; private set; } = null!;
+
         /// <summary>
         /// Resources created by the Chart.
         /// </summary>
         [Output("resources")]
         public Output<ImmutableArray<object>> Resources { get; private set; } = null!;
 
+        /// <summary>
+        /// The objects of the Chart that weren't registered because of the `include` and `exclude` selectors, e.g. `v1/Namespace:my-namespace`.
+        /// </summary>
+        [Output("skipped")]
+        public Output<ImmutableArray<string>> Skipped { get; private set; } = null!;
+
 
         /// <summary>
         /// Create a Chart resource with the given unique name, arguments, and options.
@@ -254,12 +284,36 @@ namespace Pulumi.Kubernetes.Types.Inputs.Helm.V4
 
     public class ChartArgs : global::Pulumi.ResourceArgs
     {
+        /// <summary>
+        /// Restricts the chart's `lookup` function to a read-only view of the cluster, scoped to the given kinds and namespaces. Lookups are cached for the duration of the deployment, and the values of looked up Secrets are marked as secret wherever they appear in the rendered resources. By default, the `lookup` function has the provider's access to the cluster.
+        /// </summary>
+        [Input("allowClusterLookup")]
+        public Input<Pulumi.Kubernetes.Types.Inputs.Helm.V4.ClusterLookupArgs>? AllowClusterLookup { get; set; }
+
+        /// <summary>
+        /// Set this to true to apply the chart's install and upgrade hooks (resources annotated with `helm.sh/hook`) in order, as Helm does. The chart is installed if its first resource doesn't exist in the cluster yet, and upgraded otherwise. The `pre-install` or `pre-upgrade` hooks are applied before the chart's other resources, and the `post-install` or `post-upgrade` hooks after them, in order of `helm.sh/hook-weight`. Upgrade hooks (e.g. migration Jobs) run again whenever the rendered chart changes, their previous resources being deleted first, as with the default `before-hook-creation` delete policy. The resources of install hooks are left in the cluster once the chart is upgraded. The `hook-succeeded` and `hook-failed` delete policies are not supported: the resources of a hook are managed like the chart's other resources, so they remain in the cluster (and in the stack) once the hook has run, and a warning is produced. Delete and rollback hooks are not supported either, and produce a warning. Test hooks are always excluded. This setting has no effect in render mode; see `includeHooks`.
+        /// </summary>
+        [Input("applyHooks")]
+        public Input<bool>? ApplyHooks { get; set; }
+
         /// <summary>
         /// Chart name to be installed. A path may be used.
         /// </summary>
         [Input("chart", required: true)]
         public Input<string> Chart { get; set; } = null!;
 
+        /// <summary>
+        /// The policy for the CRDs in the chart's `crds/` directory: `create` creates the CRDs and leaves them unchanged on upgrade (CRDs that exist already, and that Pulumi doesn't manage, are left as they are), `createReplace` creates the CRDs and updates them on upgrade, `patch` applies the CRDs as server-side apply patches (`CustomResourceDefinitionPatch`), and `skip` neither creates nor updates them. With a policy, the CRDs are applied before the rest of the chart, and they are never deleted, neither when a new chart version drops them nor when the chart is deleted. By default, the CRDs are managed like the chart's other resources.
+        /// </summary>
+        [Input("crdPolicy")]
+        public Input<string>? CrdPolicy { get; set; }
+
+        /// <summary>
+        /// How to resolve the chart's dependencies. By default, missing dependencies are fetched when `dependencyUpdate` is set or a `Chart.lock` is present. Values are: `locked` (require a `Chart.lock` that matches `Chart.yaml`, and fetch only the locked versions), `vendor` (resolve the dependencies into the `charts/` directory of a local chart, to be committed alongside the program; a preview resolves them into a temporary copy of the chart instead), and `offline` (never fetch dependencies; they must already be present in the chart).
+        /// </summary>
+        [Input("dependencyMode")]
+        public Input<string>? DependencyMode { get; set; }
+
         /// <summary>
         /// Run helm dependency update before installing the chart.
         /// </summary>
@@ -272,8 +326,32 @@ namespace Pulumi.Kubernetes.Types.Inputs.Helm.V4
         [Input("devel")]
         public Input<bool>? Devel { get; set; }
 
+        [Input("exclude")]
+        private InputList<Pulumi.Kubernetes.Types.Inputs.Helm.V4.SelectorArgs>? _exclude;
+
         /// <summary>
-        /// By default, Helm hook resources (those annotated with `helm.sh/hook`) are omitted from the rendered output. When the provider is configured with `renderYamlToDirectory`, set this to true to include hook resources in the rendered manifests so that another tool (e.g. Argo CD) can apply them. Test hooks (`helm.sh/hook: test`) are always excluded. This setting has no effect outside of render mode, where hooks are not supported.
+        /// Selects the objects not to register, among the included objects. The skipped objects are reported in the `skipped` output.
+        /// </summary>
+        public InputList<Pulumi.Kubernetes.Types.Inputs.Helm.V4.SelectorArgs> Exclude
+        {
+            get => _exclude ?? (_exclude = new InputList<Pulumi.Kubernetes.Types.Inputs.Helm.V4.SelectorArgs>());
+            set => _exclude = value;
+        }
+
+        [Input("include")]
+        private InputList<Pulumi.Kubernetes.Types.Inputs.Helm.V4.SelectorArgs>? _include;
+
+        /// <summary>
+        /// Selects the objects to register. An object is registered if it matches any of the selectors; by default, all objects are registered.
+        /// </summary>
+        public InputList<Pulumi.Kubernetes.Types.Inputs.Helm.V4.SelectorArgs> Include
+        {
+            get => _include ?? (_include = new InputList<Pulumi.Kubernetes.Types.Inputs.Helm.V4.SelectorArgs>());
+            set => _include = value;
+        }
+
+        /// <summary>
+        /// By default, Helm hook resources (those annotated with `helm.sh/hook`) are omitted from the rendered output. When the provider is configured with `renderYamlToDirectory`, set this to true to include hook resources in the rendered manifests so that another tool (e.g. Argo CD) can apply them. Test hooks (`helm.sh/hook: test`) are always excluded. This setting has no effect outside of render mode, where `applyHooks` applies the hooks instead.
         /// </summary>
         [Input("includeHooks")]
         public Input<bool>? IncludeHooks { get; set; }
@@ -308,6 +386,18 @@ namespace Pulumi.Kubernetes.Types.Inputs.Helm.V4
         [Input("postRenderer")]
         public Input<Pulumi.Kubernetes.Types.Inputs.Helm.V4.PostRendererArgs>? PostRenderer { get; set; }
 
+        [Input("postRenderers")]
+        private InputList<Pulumi.Kubernetes.Types.Inputs.Helm.V4.BuiltinPostRendererArgs>? _postRenderers;
+
+        /// <summary>
+        /// Built-in post-renderers to apply to the rendered manifests, in order. Built-in post-renderers run in-process and don't require an executable. They run after the `postRenderer` command, if any.
+        /// </summary>
+        public InputList<Pulumi.Kubernetes.Types.Inputs.Helm.V4.BuiltinPostRendererArgs> PostRenderers
+        {
+            get => _postRenderers ?? (_postRenderers = new InputList<Pulumi.Kubernetes.Types.Inputs.Helm.V4.BuiltinPostRendererArgs>());
+            set => _postRenderers = value;
+        }
+
         /// <summary>
         /// Specification defining the Helm chart repository to use.
         /// </summary>
@@ -356,6 +446,18 @@ namespace Pulumi.Kubernetes.Types.Inputs.Helm.V4
             set => _values = value;
         }
 
+        [Input("valuesFrom")]
+        private InputList<Pulumi.Kubernetes.Types.Inputs.Helm.V4.ValuesReferenceArgs>? _valuesFrom;
+
+        /// <summary>
+        /// References to values held in ConfigMaps and Secrets, which are read from the cluster when the chart is rendered. The referenced values are merged in order, after `valueYamlFiles` and before `values`. Values read from Secrets are marked as secret wherever they appear in the rendered resources, unless `values` overrides them.
+        /// </summary>
+        public InputList<Pulumi.Kubernetes.Types.Inputs.Helm.V4.ValuesReferenceArgs> ValuesFrom
+        {
+            get => _valuesFrom ?? (_valuesFrom = new InputList<Pulumi.Kubernetes.Types.Inputs.Helm.V4.ValuesReferenceArgs>());
+            set => _valuesFrom = value;
+        }
+
         /// <summary>
         /// Verify the chart's integrity.
         /// </summary>
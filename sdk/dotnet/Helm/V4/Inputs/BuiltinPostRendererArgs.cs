@@ -0,0 +1,35 @@
+// *** WARNING: this file was generated by pulumigen. ***
+// *** Do not edit by hand unless you're certain you know what you are doing! ***
+
+using System;
+using System.Collections.Generic;
+using System.Collections.Immutable;
+using System.Threading.Tasks;
+using Pulumi.Serialization;
+
+namespace Pulumi.Kubernetes.Types.Inputs.Helm.V4
+{
+
+    /// <summary>
+    /// Specification of a built-in post-renderer. Exactly one of the post-renderers must be specified.
+    /// </summary>
+    public class BuiltinPostRendererArgs : global::Pulumi.ResourceArgs
+    {
+        /// <summary>
+        /// Applies a set of Kustomize patches to the rendered manifests.
+        /// </summary>
+        [Input("kustomize")]
+        public Input<Pulumi.Kubernetes.Types.Inputs.Helm.V4.KustomizePostRendererArgs>? Kustomize { get; set; }
+
+        /// <summary>
+        /// Injects labels and annotations into the rendered objects.
+        /// </summary>
+        [Input("metadata")]
+        public Input<Pulumi.Kubernetes.Types.Inputs.Helm.V4.MetadataPostRendererArgs>? Metadata { get; set; }
+
+        public BuiltinPostRendererArgs()
+        {
+        }
+        public static new BuiltinPostRendererArgs Empty => new BuiltinPostRendererArgs();
+    }
+}
@@ -0,0 +1,47 @@
+// *** WARNING: this file was generated by pulumigen. ***
+// *** Do not edit by hand unless you're certain you know what you are doing! ***
+
+using System;
+using System.Collections.Generic;
+using System.Collections.Immutable;
+using System.Threading.Tasks;
+using Pulumi.Serialization;
+
+namespace Pulumi.Kubernetes.Types.Inputs.Helm.V4
+{
+
+    /// <summary>
+    /// The scope of the cluster data that a chart may read with the `lookup` function.
+    /// </summary>
+    public class ClusterLookupArgs : global::Pulumi.ResourceArgs
+    {
+        [Input("kinds")]
+        private InputList<string>? _kinds;
+
+        /// <summary>
+        /// The kinds of objects that may be looked up, either as `Kind` or as `group/Kind` (e.g. `apps/Deployment`). All kinds may be looked up by default.
+        /// </summary>
+        public InputList<string> Kinds
+        {
+            get => _kinds ?? (_kinds = new InputList<string>());
+            set => _kinds = value;
+        }
+
+        [Input("namespaces")]
+        private InputList<string>? _namespaces;
+
+        /// <summary>
+        /// The namespaces in which objects may be looked up, or `*` for all namespaces. Only the release namespace is allowed by default. Cluster-scoped objects may be looked up regardless.
+        /// </summary>
+        public InputList<string> Namespaces
+        {
+            get => _namespaces ?? (_namespaces = new InputList<string>());
+            set => _namespaces = value;
+        }
+
+        public ClusterLookupArgs()
+        {
+        }
+        public static new ClusterLookupArgs Empty => new ClusterLookupArgs();
+    }
+}
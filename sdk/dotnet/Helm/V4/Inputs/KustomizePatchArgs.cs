@@ -0,0 +1,35 @@
+// *** WARNING: this file was generated by pulumigen. ***
+// *** Do not edit by hand unless you're certain you know what you are doing! ***
+
+using System;
+using System.Collections.Generic;
+using System.Collections.Immutable;
+using System.Threading.Tasks;
+using Pulumi.Serialization;
+
+namespace Pulumi.Kubernetes.Types.Inputs.Helm.V4
+{
+
+    /// <summary>
+    /// A Kustomize patch, either a strategic merge patch or a JSON 6902 patch.
+    /// </summary>
+    public class KustomizePatchArgs : global::Pulumi.ResourceArgs
+    {
+        /// <summary>
+        /// The content of the patch, in YAML or JSON.
+        /// </summary>
+        [Input("patch", required: true)]
+        public Input<string> Patch { get; set; } = null!;
+
+        /// <summary>
+        /// Selects the resources to patch. A strategic merge patch may omit the target, in which case the patch itself identifies the resource to patch.
+        /// </summary>
+        [Input("target")]
+        public Input<Pulumi.Kubernetes.Types.Inputs.Helm.V4.KustomizePatchTargetArgs>? Target { get; set; }
+
+        public KustomizePatchArgs()
+        {
+        }
+        public static new KustomizePatchArgs Empty => new KustomizePatchArgs();
+    }
+}
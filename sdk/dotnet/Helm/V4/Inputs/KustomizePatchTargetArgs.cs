@@ -0,0 +1,65 @@
+// *** WARNING: this file was generated by pulumigen. ***
+// *** Do not edit by hand unless you're certain you know what you are doing! ***
+
+using System;
+using System.Collections.Generic;
+using System.Collections.Immutable;
+using System.Threading.Tasks;
+using Pulumi.Serialization;
+
+namespace Pulumi.Kubernetes.Types.Inputs.Helm.V4
+{
+
+    /// <summary>
+    /// Selects the resources that a Kustomize patch applies to.
+    /// </summary>
+    public class KustomizePatchTargetArgs : global::Pulumi.ResourceArgs
+    {
+        /// <summary>
+        /// An annotation selector that the resources must match.
+        /// </summary>
+        [Input("annotationSelector")]
+        public Input<string>? AnnotationSelector { get; set; }
+
+        /// <summary>
+        /// The API group of the resources.
+        /// </summary>
+        [Input("group")]
+        public Input<string>? Group { get; set; }
+
+        /// <summary>
+        /// The kind of the resources.
+        /// </summary>
+        [Input("kind")]
+        public Input<string>? Kind { get; set; }
+
+        /// <summary>
+        /// A label selector that the resources must match.
+        /// </summary>
+        [Input("labelSelector")]
+        public Input<string>? LabelSelector { get; set; }
+
+        /// <summary>
+        /// The name of the resources (a regular expression).
+        /// </summary>
+        [Input("name")]
+        public Input<string>? Name { get; set; }
+
+        /// <summary>
+        /// The namespace of the resources.
+        /// </summary>
+        [Input("namespace")]
+        public Input<string>? Namespace { get; set; }
+
+        /// <summary>
+        /// The API version of the resources.
+        /// </summary>
+        [Input("version")]
+        public Input<string>? Version { get; set; }
+
+        public KustomizePatchTargetArgs()
+        {
+        }
+        public static new KustomizePatchTargetArgs Empty => new KustomizePatchTargetArgs();
+    }
+}
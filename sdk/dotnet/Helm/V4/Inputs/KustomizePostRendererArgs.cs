@@ -0,0 +1,35 @@
+// *** WARNING: this file was generated by pulumigen. ***
+// *** Do not edit by hand unless you're certain you know what you are doing! ***
+
+using System;
+using System.Collections.Generic;
+using System.Collections.Immutable;
+using System.Threading.Tasks;
+using Pulumi.Serialization;
+
+namespace Pulumi.Kubernetes.Types.Inputs.Helm.V4
+{
+
+    /// <summary>
+    /// A post-renderer that applies a set of Kustomize patches to the rendered manifests.
+    /// </summary>
+    public class KustomizePostRendererArgs : global::Pulumi.ResourceArgs
+    {
+        [Input("patches", required: true)]
+        private InputList<Pulumi.Kubernetes.Types.Inputs.Helm.V4.KustomizePatchArgs>? _patches;
+
+        /// <summary>
+        /// The patches to apply.
+        /// </summary>
+        public InputList<Pulumi.Kubernetes.Types.Inputs.Helm.V4.KustomizePatchArgs> Patches
+        {
+            get => _patches ?? (_patches = new InputList<Pulumi.Kubernetes.Types.Inputs.Helm.V4.KustomizePatchArgs>());
+            set => _patches = value;
+        }
+
+        public KustomizePostRendererArgs()
+        {
+        }
+        public static new KustomizePostRendererArgs Empty => new KustomizePostRendererArgs();
+    }
+}
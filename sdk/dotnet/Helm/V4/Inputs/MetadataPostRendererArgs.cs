@@ -0,0 +1,65 @@
+// *** WARNING: this file was generated by pulumigen. ***
+// *** Do not edit by hand unless you're certain you know what you are doing! ***
+
+using System;
+using System.Collections.Generic;
+using System.Collections.Immutable;
+using System.Threading.Tasks;
+using Pulumi.Serialization;
+
+namespace Pulumi.Kubernetes.Types.Inputs.Helm.V4
+{
+
+    /// <summary>
+    /// A post-renderer that injects labels and annotations into the metadata of the rendered objects. Pod templates and selectors are not affected.
+    /// </summary>
+    public class MetadataPostRendererArgs : global::Pulumi.ResourceArgs
+    {
+        [Input("annotations")]
+        private InputMap<string>? _annotations;
+
+        /// <summary>
+        /// Annotations to inject.
+        /// </summary>
+        public InputMap<string> Annotations
+        {
+            get => _annotations ?? (_annotations = new InputMap<string>());
+            set => _annotations = value;
+        }
+
+        [Input("kinds")]
+        private InputList<string>? _kinds;
+
+        /// <summary>
+        /// Restricts the injection to objects of the given kinds. All objects are affected by default.
+        /// </summary>
+        public InputList<string> Kinds
+        {
+            get => _kinds ?? (_kinds = new InputList<string>());
+            set => _kinds = value;
+        }
+
+        [Input("labels")]
+        private InputMap<string>? _labels;
+
+        /// <summary>
+        /// Labels to inject.
+        /// </summary>
+        public InputMap<string> Labels
+        {
+            get => _labels ?? (_labels = new InputMap<string>());
+            set => _labels = value;
+        }
+
+        /// <summary>
+        /// Replace existing labels and annotations with the same key. By default, the values set by the chart take precedence.
+        /// </summary>
+        [Input("overwrite")]
+        public Input<bool>? Overwrite { get; set; }
+
+        public MetadataPostRendererArgs()
+        {
+        }
+        public static new MetadataPostRendererArgs Empty => new MetadataPostRendererArgs();
+    }
+}
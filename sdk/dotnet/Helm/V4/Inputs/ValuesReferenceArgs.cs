@@ -0,0 +1,59 @@
+// *** WARNING: this file was generated by pulumigen. ***
+// *** Do not edit by hand unless you're certain you know what you are doing! ***
+
+using System;
+using System.Collections.Generic;
+using System.Collections.Immutable;
+using System.Threading.Tasks;
+using Pulumi.Serialization;
+
+namespace Pulumi.Kubernetes.Types.Inputs.Helm.V4
+{
+
+    /// <summary>
+    /// A reference to Helm values held in a ConfigMap or a Secret.
+    /// </summary>
+    public class ValuesReferenceArgs : global::Pulumi.ResourceArgs
+    {
+        /// <summary>
+        /// The kind of the object holding the values: `ConfigMap` or `Secret`.
+        /// </summary>
+        [Input("kind", required: true)]
+        public Input<string> Kind { get; set; } = null!;
+
+        /// <summary>
+        /// The name of the object.
+        /// </summary>
+        [Input("name", required: true)]
+        public Input<string> Name { get; set; } = null!;
+
+        /// <summary>
+        /// The namespace of the object. Defaults to the release namespace.
+        /// </summary>
+        [Input("namespace")]
+        public Input<string>? Namespace { get; set; }
+
+        /// <summary>
+        /// Ignore the reference if the object or the key doesn't exist.
+        /// </summary>
+        [Input("optional")]
+        public Input<bool>? Optional { get; set; }
+
+        /// <summary>
+        /// The path (in `--set` notation, e.g. `auth.password`) at which to set the value of the key as a string. By default, the value of the key is a YAML document that is merged into the values.
+        /// </summary>
+        [Input("targetPath")]
+        public Input<string>? TargetPath { get; set; }
+
+        /// <summary>
+        /// The data key holding the values. Defaults to `values.yaml`.
+        /// </summary>
+        [Input("valuesKey")]
+        public Input<string>? ValuesKey { get; set; }
+
+        public ValuesReferenceArgs()
+        {
+        }
+        public static new ValuesReferenceArgs Empty => new ValuesReferenceArgs();
+    }
+}
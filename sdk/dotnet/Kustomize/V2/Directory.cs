@@ -12,6 +12,34 @@ namespace Pulumi.Kubernetes.Kustomize.V2
     /// <summary>
     /// Directory is a component representing a collection of resources described by a kustomize directory (kustomization).
     /// 
+    /// ## Patches
+    /// The objects may be patched with `patches`, before they're registered, without a transform in the program. Each patch
+    /// is a strategic merge patch or a JSON 6902 patch, and its `target` selects the objects to patch by group, version,
+    /// kind, name, namespace, label selector or annotation selector. A strategic merge patch may omit the target, in which
+    /// case the patch itself identifies the object to patch.
+    /// 
+    /// ## Selecting objects
+    /// The objects to register may be selected with `include` and `exclude`, e.g. to skip a Namespace that's managed
+    /// separately. Each selector matches objects by `group`, `version`, `kind`, `name` (a glob pattern), `namespace` and
+    /// `labelSelector`. An object is registered if it matches any of the included selectors (or if there are none), and none
+    /// of the excluded selectors. The skipped objects are reported in the `skipped` output.
+    /// 
+    /// ## Dependency ordering
+    /// Pulumi uses heuristics to determine which order to apply and delete objects within the Directory, and supports the
+    /// `config.kubernetes.io/depends-on` annotation to declare an explicit dependency on a given resource.
+    /// 
+    /// Workloads depend on the ConfigMaps and Secrets in the same namespace that their pods reference (by volumes, `envFrom`,
+    /// `env` or `imagePullSecrets`), so that the referenced configuration is created first. This includes the ConfigMaps and
+    /// Secrets generated by kustomize, whose names have a hash suffix.
+    /// 
+    /// Admission webhook configurations depend on the Services that back them and on the workloads that those Services
+    /// select, and the objects that a webhook intercepts depend on the webhook configuration, so that the webhook is ready
+    /// before the objects are created. Webhooks whose `failurePolicy` is `Ignore` don't affect the ordering, and the objects
+    /// in the namespace of the webhook's Service are assumed to be part of the webhook's installation.
+    /// 
+    /// A `config.kubernetes.io/depends-on` reference to a resource outside the Directory is assumed to exist in the cluster
+    /// already, and isn't waited for.
+    /// 
     /// ## Example Usage
     /// ### Local Kustomize Directory
     /// ```csharp
@@ -57,6 +85,12 @@ namespace Pulumi.Kubernetes.Kustomize.V2
         [Output("resources")]
         public Output<ImmutableArray<object>> Resources { get; private set; } = null!;
 
+        /// <summary>
+        /// The objects of the Directory that weren't registered because of the `include` and `exclude` selectors, e.g. `v1/Namespace:my-namespace`.
+        /// </summary>
+        [Output("skipped")]
+        public Output<ImmutableArray<string>> Skipped { get; private set; } = null!;
+
 
         /// <summary>
         /// Create a Directory resource with the given unique name, arguments, and options.
@@ -93,9 +127,82 @@ namespace Pulumi.Kubernetes.Types.Inputs.Kustomize.V2
         /// git repository.
         /// Example: ./helloWorld
         /// Example: https://github.com/kubernetes-sigs/kustomize/tree/master/examples/helloWorld
+        /// When `kustomization` or `files` is set, the directory is a path within the inline files, and defaults to the root of the files.
+        /// </summary>
+        [Input("directory")]
+        public Input<string>? Directory { get; set; }
+
+        /// <summary>
+        /// Enables kustomize's alpha plugins (e.g. KRM functions). Defaults to `true`.
+        /// </summary>
+        [Input("enableAlphaPlugins")]
+        public Input<bool>? EnableAlphaPlugins { get; set; }
+
+        /// <summary>
+        /// Enables the inflation of the `helmCharts` of the kustomizations. Defaults to `true`.
+        /// </summary>
+        [Input("enableHelm")]
+        public Input<bool>? EnableHelm { get; set; }
+
+        [Input("exclude")]
+        private InputList<Pulumi.Kubernetes.Types.Inputs.Kustomize.V2.SelectorArgs>? _exclude;
+
+        /// <summary>
+        /// Selects the objects not to register, among the included objects. The skipped objects are reported in the `skipped` output.
+        /// </summary>
+        public InputList<Pulumi.Kubernetes.Types.Inputs.Kustomize.V2.SelectorArgs> Exclude
+        {
+            get => _exclude ?? (_exclude = new InputList<Pulumi.Kubernetes.Types.Inputs.Kustomize.V2.SelectorArgs>());
+            set => _exclude = value;
+        }
+
+        [Input("files")]
+        private InputMap<string>? _files;
+
+        /// <summary>
+        /// Additional files for an inline kustomization (e.g. patches, generator inputs and other kustomizations), keyed by their paths relative to the root of the files. The files are materialised in memory, so the kustomization can't refer to files on disk; remote bases and Helm charts from repositories may be used.
+        /// </summary>
+        public InputMap<string> Files
+        {
+            get => _files ?? (_files = new InputMap<string>());
+            set => _files = value;
+        }
+
+        /// <summary>
+        /// The helm binary with which kustomize inflates the `helmCharts` of the kustomizations. By default, the charts are inflated in-process, using the Helm settings of the provider.
+        /// </summary>
+        [Input("helmCommand")]
+        public Input<string>? HelmCommand { get; set; }
+
+        [Input("include")]
+        private InputList<Pulumi.Kubernetes.Types.Inputs.Kustomize.V2.SelectorArgs>? _include;
+
+        /// <summary>
+        /// Selects the objects to register. An object is registered if it matches any of the selectors; by default, all objects are registered.
         /// </summary>
-        [Input("directory", required: true)]
-        public Input<string> Directory { get; set; } = null!;
+        public InputList<Pulumi.Kubernetes.Types.Inputs.Kustomize.V2.SelectorArgs> Include
+        {
+            get => _include ?? (_include = new InputList<Pulumi.Kubernetes.Types.Inputs.Kustomize.V2.SelectorArgs>());
+            set => _include = value;
+        }
+
+        [Input("kustomization")]
+        private InputMap<object>? _kustomization;
+
+        /// <summary>
+        /// An inline kustomization (the content of a `kustomization.yaml` file) to apply instead of a kustomization on disk. It is materialised in memory, together with the `files`, in the `directory`.
+        /// </summary>
+        public InputMap<object> Kustomization
+        {
+            get => _kustomization ?? (_kustomization = new InputMap<object>());
+            set => _kustomization = value;
+        }
+
+        /// <summary>
+        /// The load restrictions of kustomize, either `LoadRestrictionsNone` or `LoadRestrictionsRootOnly`. With `LoadRestrictionsRootOnly`, the files of a kustomization (including the values files of its Helm charts) must be in or below its directory. Defaults to `LoadRestrictionsNone`.
+        /// </summary>
+        [Input("loadRestrictor")]
+        public Input<string>? LoadRestrictor { get; set; }
 
         /// <summary>
         /// The default namespace to apply to the resources. Defaults to the provider's namespace.
@@ -103,6 +210,24 @@ namespace Pulumi.Kubernetes.Types.Inputs.Kustomize.V2
         [Input("namespace")]
         public Input<string>? Namespace { get; set; }
 
+        [Input("patches")]
+        private InputList<Pulumi.Kubernetes.Types.Inputs.Kustomize.V2.PatchArgs>? _patches;
+
+        /// <summary>
+        /// Patches to apply to the objects that the kustomization produces before they're registered, in order. The patches are applied in-process, with kustomize.
+        /// </summary>
+        public InputList<Pulumi.Kubernetes.Types.Inputs.Kustomize.V2.PatchArgs> Patches
+        {
+            get => _patches ?? (_patches = new InputList<Pulumi.Kubernetes.Types.Inputs.Kustomize.V2.PatchArgs>());
+            set => _patches = value;
+        }
+
+        /// <summary>
+        /// Options for fetching the remote git bases of the kustomizations.
+        /// </summary>
+        [Input("remoteBases")]
+        public Input<Pulumi.Kubernetes.Types.Inputs.Kustomize.V2.RemoteBasesOptsArgs>? RemoteBases { get; set; }
+
         /// <summary>
         /// A prefix for the auto-generated resource names. Defaults to the name of the Directory resource. Example: A resource created with resourcePrefix="foo" would produce a resource named "foo:resourceName".
         /// </summary>
@@ -0,0 +1,35 @@
+// *** WARNING: this file was generated by pulumigen. ***
+// *** Do not edit by hand unless you're certain you know what you are doing! ***
+
+using System;
+using System.Collections.Generic;
+using System.Collections.Immutable;
+using System.Threading.Tasks;
+using Pulumi.Serialization;
+
+namespace Pulumi.Kubernetes.Types.Inputs.Kustomize.V2
+{
+
+    /// <summary>
+    /// Options for fetching the remote git bases of kustomizations.
+    /// </summary>
+    public class RemoteBasesOptsArgs : global::Pulumi.ResourceArgs
+    {
+        /// <summary>
+        /// Disables the cache of remote bases, such that kustomize clones them for every build. By default, a repository is cloned once per commit into the user's cache directory, and refs are resolved to commits once per deployment. Defaults to `false`.
+        /// </summary>
+        [Input("disableCache")]
+        public Input<bool>? DisableCache { get; set; }
+
+        /// <summary>
+        /// Requires the remote bases to be pinned to a ref (e.g. `?ref=v1.0.0`). Defaults to `false`.
+        /// </summary>
+        [Input("requirePinnedRefs")]
+        public Input<bool>? RequirePinnedRefs { get; set; }
+
+        public RemoteBasesOptsArgs()
+        {
+        }
+        public static new RemoteBasesOptsArgs Empty => new RemoteBasesOptsArgs();
+    }
+}
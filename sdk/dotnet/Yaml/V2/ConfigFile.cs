@@ -13,6 +13,24 @@ namespace Pulumi.Kubernetes.Yaml.V2
     /// ConfigFile creates a set of Kubernetes resources from a remote or on-disk Kubernetes YAML file.
     /// (If you have in-memory YAML a ConfigGroup may be more appropriate.)
     /// 
+    /// ## Templating
+    /// The manifests may be templated with `values`, e.g. to set image tags or namespaces per stack. By default, they're
+    /// rendered as Go templates with the Sprig functions, and the values are available as `.Values`
+    /// (e.g. `{{ .Values.image.tag }}`). With `templateEngine: envsubst`, `${VAR}` and `${VAR:-default}` references to the
+    /// values are substituted instead (e.g. `${image.tag}`), and references to missing values are left as is.
+    /// 
+    /// ## Patches
+    /// The objects may be patched with `patches`, before they're registered, without a transform in the program. Each patch
+    /// is a strategic merge patch or a JSON 6902 patch, and its `target` selects the objects to patch by group, version,
+    /// kind, name, namespace, label selector or annotation selector. A strategic merge patch may omit the target, in which
+    /// case the patch itself identifies the object to patch.
+    /// 
+    /// ## Selecting objects
+    /// The objects to register may be selected with `include` and `exclude`, e.g. to skip a Namespace that's managed
+    /// separately. Each selector matches objects by `group`, `version`, `kind`, `name` (a glob pattern), `namespace` and
+    /// `labelSelector`. An object is registered if it matches any of the included selectors (or if there are none), and none
+    /// of the excluded selectors. The skipped objects are reported in the `skipped` output.
+    /// 
     /// ## Dependency ordering
     /// Sometimes resources must be applied in a specific order. For example, a namespace resource must be
     /// created before any namespaced resources, or a Custom Resource Definition (CRD) must be pre-installed.
@@ -20,11 +38,19 @@ namespace Pulumi.Kubernetes.Yaml.V2
     /// Pulumi uses heuristics to determine which order to apply and delete objects within the ConfigFile.  Pulumi also
     /// waits for each object to be fully reconciled, unless `skipAwait` is enabled.
     /// 
+    /// Workloads depend on the ConfigMaps and Secrets in the same namespace that their pods reference (by volumes, `envFrom`,
+    /// `env` or `imagePullSecrets`), so that the referenced configuration is created first.
+    /// 
+    /// Admission webhook configurations depend on the Services that back them and on the workloads that those Services
+    /// select, and the objects that a webhook intercepts depend on the webhook configuration, so that the webhook is ready
+    /// before the objects are created. Webhooks whose `failurePolicy` is `Ignore` don't affect the ordering, and the objects
+    /// in the namespace of the webhook's Service are assumed to be part of the webhook's installation.
+    /// 
     /// ### Explicit Dependency Ordering
     /// Pulumi supports the `config.kubernetes.io/depends-on` annotation to declare an explicit dependency on a given resource.
     /// The annotation accepts a list of resource references, delimited by commas.
     /// 
-    /// Note that references to resources outside the ConfigFile aren't supported.
+    /// A reference to a resource outside the ConfigFile is assumed to exist in the cluster already, and isn't waited for.
     /// 
     /// **Resource reference**
     /// 
@@ -74,6 +100,12 @@ namespace Pulumi.Kubernetes.Yaml.V2
         [Output("resources")]
         public Output<ImmutableArray<object>> Resources { get; private set; } = null!;
 
+        /// <summary>
+        /// The objects of the ConfigFile that weren't registered because of the `include` and `exclude` selectors, e.g. `v1/Namespace:my-namespace`.
+        /// </summary>
+        [Output("skipped")]
+        public Output<ImmutableArray<string>> Skipped { get; private set; } = null!;
+
 
         /// <summary>
         /// Create a ConfigFile resource with the given unique name, arguments, and options.
@@ -105,12 +137,48 @@ namespace Pulumi.Kubernetes.Types.Inputs.Yaml.V2
 
     public class ConfigFileArgs : global::Pulumi.ResourceArgs
     {
+        [Input("exclude")]
+        private InputList<Pulumi.Kubernetes.Types.Inputs.Yaml.V2.SelectorArgs>? _exclude;
+
         /// <summary>
-        /// Path or URL to a Kubernetes manifest file. File must exist.
+        /// Selects the objects not to register, among the included objects. The skipped objects are reported in the `skipped` output.
+        /// </summary>
+        public InputList<Pulumi.Kubernetes.Types.Inputs.Yaml.V2.SelectorArgs> Exclude
+        {
+            get => _exclude ?? (_exclude = new InputList<Pulumi.Kubernetes.Types.Inputs.Yaml.V2.SelectorArgs>());
+            set => _exclude = value;
+        }
+
+        /// <summary>
+        /// Path or URL to a Kubernetes manifest file. File must exist. A URL may be pinned to the SHA-256 digest of its content with a `#sha256=&lt;digest&gt;` fragment; the content is verified, and cached so that it's downloaded only once.
         /// </summary>
         [Input("file", required: true)]
         public Input<string> File { get; set; } = null!;
 
+        [Input("include")]
+        private InputList<Pulumi.Kubernetes.Types.Inputs.Yaml.V2.SelectorArgs>? _include;
+
+        /// <summary>
+        /// Selects the objects to register. An object is registered if it matches any of the selectors; by default, all objects are registered.
+        /// </summary>
+        public InputList<Pulumi.Kubernetes.Types.Inputs.Yaml.V2.SelectorArgs> Include
+        {
+            get => _include ?? (_include = new InputList<Pulumi.Kubernetes.Types.Inputs.Yaml.V2.SelectorArgs>());
+            set => _include = value;
+        }
+
+        [Input("patches")]
+        private InputList<Pulumi.Kubernetes.Types.Inputs.Yaml.V2.PatchArgs>? _patches;
+
+        /// <summary>
+        /// Patches to apply to the objects of the manifest before they're registered, in order. The patches are applied in-process, with kustomize.
+        /// </summary>
+        public InputList<Pulumi.Kubernetes.Types.Inputs.Yaml.V2.PatchArgs> Patches
+        {
+            get => _patches ?? (_patches = new InputList<Pulumi.Kubernetes.Types.Inputs.Yaml.V2.PatchArgs>());
+            set => _patches = value;
+        }
+
         /// <summary>
         /// A prefix for the auto-generated resource names. Defaults to the name of the ConfigFile. Example: A resource created with resourcePrefix="foo" would produce a resource named "foo-resourceName".
         /// </summary>
@@ -123,6 +191,24 @@ namespace Pulumi.Kubernetes.Types.Inputs.Yaml.V2
         [Input("skipAwait")]
         public Input<bool>? SkipAwait { get; set; }
 
+        /// <summary>
+        /// The engine with which to template the manifest: `goTemplate` for Go templates, or `envsubst` for `${VAR}` and `${VAR:-default}` references to the values (by dotted paths, e.g. `${image.tag}`). References to missing values without a default are left as is, and `$${VAR}` escapes a reference. Defaults to `goTemplate` when `values` is set; otherwise, the manifest isn't templated.
+        /// </summary>
+        [Input("templateEngine")]
+        public Input<string>? TemplateEngine { get; set; }
+
+        [Input("values")]
+        private InputMap<object>? _values;
+
+        /// <summary>
+        /// Values with which to template the manifest before it's parsed. With the `goTemplate` engine, the values are available as `.Values`, along with the Sprig functions.
+        /// </summary>
+        public InputMap<object> Values
+        {
+            get => _values ?? (_values = new InputMap<object>());
+            set => _values = value;
+        }
+
         public ConfigFileArgs()
         {
         }
@@ -18,6 +18,24 @@ namespace Pulumi.Kubernetes.Yaml.V2
     /// 3. Using a literal string containing YAML, or a list of such strings:
     /// 4. Any combination of files, patterns, or YAML strings:
     /// 
+    /// ## Templating
+    /// The manifests may be templated with `values`, e.g. to set image tags or namespaces per stack. By default, they're
+    /// rendered as Go templates with the Sprig functions, and the values are available as `.Values`
+    /// (e.g. `{{ .Values.image.tag }}`). With `templateEngine: envsubst`, `${VAR}` and `${VAR:-default}` references to the
+    /// values are substituted instead (e.g. `${image.tag}`), and references to missing values are left as is.
+    /// 
+    /// ## Patches
+    /// The objects may be patched with `patches`, before they're registered, without a transform in the program. Each patch
+    /// is a strategic merge patch or a JSON 6902 patch, and its `target` selects the objects to patch by group, version,
+    /// kind, name, namespace, label selector or annotation selector. A strategic merge patch may omit the target, in which
+    /// case the patch itself identifies the object to patch.
+    /// 
+    /// ## Selecting objects
+    /// The objects to register may be selected with `include` and `exclude`, e.g. to skip a Namespace that's managed
+    /// separately. Each selector matches objects by `group`, `version`, `kind`, `name` (a glob pattern), `namespace` and
+    /// `labelSelector`. An object is registered if it matches any of the included selectors (or if there are none), and none
+    /// of the excluded selectors. The skipped objects are reported in the `skipped` output.
+    /// 
     /// ## Dependency ordering
     /// Sometimes resources must be applied in a specific order. For example, a namespace resource must be
     /// created before any namespaced resources, or a Custom Resource Definition (CRD) must be pre-installed.
@@ -25,11 +43,19 @@ namespace Pulumi.Kubernetes.Yaml.V2
     /// Pulumi uses heuristics to determine which order to apply and delete objects within the ConfigGroup.  Pulumi also
     /// waits for each object to be fully reconciled, unless `skipAwait` is enabled.
     /// 
+    /// Workloads depend on the ConfigMaps and Secrets in the same namespace that their pods reference (by volumes, `envFrom`,
+    /// `env` or `imagePullSecrets`), so that the referenced configuration is created first.
+    /// 
+    /// Admission webhook configurations depend on the Services that back them and on the workloads that those Services
+    /// select, and the objects that a webhook intercepts depend on the webhook configuration, so that the webhook is ready
+    /// before the objects are created. Webhooks whose `failurePolicy` is `Ignore` don't affect the ordering, and the objects
+    /// in the namespace of the webhook's Service are assumed to be part of the webhook's installation.
+    /// 
     /// ### Explicit Dependency Ordering
     /// Pulumi supports the `config.kubernetes.io/depends-on` annotation to declare an explicit dependency on a given resource.
     /// The annotation accepts a list of resource references, delimited by commas.
     /// 
-    /// Note that references to resources outside the ConfigGroup aren't supported.
+    /// A reference to a resource outside the ConfigGroup is assumed to exist in the cluster already, and isn't waited for.
     /// 
     /// **Resource reference**
     /// 
@@ -141,6 +167,12 @@ namespace Pulumi.Kubernetes.Yaml.V2
         [Output("resources")]
         public Output<ImmutableArray<object>> Resources { get; private set; } = null!;
 
+        /// <summary>
+        /// The objects of the ConfigGroup that weren't registered because of the `include` and `exclude` selectors, e.g. `v1/Namespace:my-namespace`.
+        /// </summary>
+        [Output("skipped")]
+        public Output<ImmutableArray<string>> Skipped { get; private set; } = null!;
+
 
         /// <summary>
         /// Create a ConfigGroup resource with the given unique name, arguments, and options.
@@ -172,11 +204,23 @@ namespace Pulumi.Kubernetes.Types.Inputs.Yaml.V2
 
     public class ConfigGroupArgs : global::Pulumi.ResourceArgs
     {
+        [Input("exclude")]
+        private InputList<Pulumi.Kubernetes.Types.Inputs.Yaml.V2.SelectorArgs>? _exclude;
+
+        /// <summary>
+        /// Selects the objects not to register, among the included objects. The skipped objects are reported in the `skipped` output.
+        /// </summary>
+        public InputList<Pulumi.Kubernetes.Types.Inputs.Yaml.V2.SelectorArgs> Exclude
+        {
+            get => _exclude ?? (_exclude = new InputList<Pulumi.Kubernetes.Types.Inputs.Yaml.V2.SelectorArgs>());
+            set => _exclude = value;
+        }
+
         [Input("files")]
         private InputList<string>? _files;
 
         /// <summary>
-        /// Set of paths and/or URLs to Kubernetes manifest files. Supports glob patterns.
+        /// Set of paths and/or URLs to Kubernetes manifest files. Supports glob patterns. A URL may be pinned to the SHA-256 digest of its content with a `#sha256=&lt;digest&gt;` fragment; the content is verified, and cached so that it's downloaded only once.
         /// </summary>
         public InputList<string> Files
         {
@@ -184,6 +228,18 @@ namespace Pulumi.Kubernetes.Types.Inputs.Yaml.V2
             set => _files = value;
         }
 
+        [Input("include")]
+        private InputList<Pulumi.Kubernetes.Types.Inputs.Yaml.V2.SelectorArgs>? _include;
+
+        /// <summary>
+        /// Selects the objects to register. An object is registered if it matches any of the selectors; by default, all objects are registered.
+        /// </summary>
+        public InputList<Pulumi.Kubernetes.Types.Inputs.Yaml.V2.SelectorArgs> Include
+        {
+            get => _include ?? (_include = new InputList<Pulumi.Kubernetes.Types.Inputs.Yaml.V2.SelectorArgs>());
+            set => _include = value;
+        }
+
         [Input("objs")]
         private InputList<object>? _objs;
 
@@ -196,6 +252,18 @@ namespace Pulumi.Kubernetes.Types.Inputs.Yaml.V2
             set => _objs = value;
         }
 
+        [Input("patches")]
+        private InputList<Pulumi.Kubernetes.Types.Inputs.Yaml.V2.PatchArgs>? _patches;
+
+        /// <summary>
+        /// Patches to apply to the objects (including `objs`) before they're registered, in order. The patches are applied in-process, with kustomize.
+        /// </summary>
+        public InputList<Pulumi.Kubernetes.Types.Inputs.Yaml.V2.PatchArgs> Patches
+        {
+            get => _patches ?? (_patches = new InputList<Pulumi.Kubernetes.Types.Inputs.Yaml.V2.PatchArgs>());
+            set => _patches = value;
+        }
+
         /// <summary>
         /// A prefix for the auto-generated resource names. Defaults to the name of the ConfigGroup. Example: A resource created with resourcePrefix="foo" would produce a resource named "foo-resourceName".
         /// </summary>
@@ -208,6 +276,24 @@ namespace Pulumi.Kubernetes.Types.Inputs.Yaml.V2
         [Input("skipAwait")]
         public Input<bool>? SkipAwait { get; set; }
 
+        /// <summary>
+        /// The engine with which to template the files and YAML literals: `goTemplate` for Go templates, or `envsubst` for `${VAR}` and `${VAR:-default}` references to the values (by dotted paths, e.g. `${image.tag}`). References to missing values without a default are left as is, and `$${VAR}` escapes a reference. Defaults to `goTemplate` when `values` is set; otherwise, the files and YAML literals aren't templated.
+        /// </summary>
+        [Input("templateEngine")]
+        public Input<string>? TemplateEngine { get; set; }
+
+        [Input("values")]
+        private InputMap<object>? _values;
+
+        /// <summary>
+        /// Values with which to template the files and YAML literals (but not `objs`) before they're parsed. With the `goTemplate` engine, the values are available as `.Values`, along with the Sprig functions.
+        /// </summary>
+        public InputMap<object> Values
+        {
+            get => _values ?? (_values = new InputMap<object>());
+            set => _values = value;
+        }
+
         /// <summary>
         /// A Kubernetes YAML manifest containing Kubernetes resource configuration(s).
         /// </summary>
@@ -0,0 +1,35 @@
+// *** WARNING: this file was generated by pulumigen. ***
+// *** Do not edit by hand unless you're certain you know what you are doing! ***
+
+using System;
+using System.Collections.Generic;
+using System.Collections.Immutable;
+using System.Threading.Tasks;
+using Pulumi.Serialization;
+
+namespace Pulumi.Kubernetes.Types.Inputs.Yaml.V2
+{
+
+    /// <summary>
+    /// A patch to apply to the objects of the component before they're registered, either a strategic merge patch or a JSON 6902 patch.
+    /// </summary>
+    public class PatchArgs : global::Pulumi.ResourceArgs
+    {
+        /// <summary>
+        /// The content of the patch, in YAML or JSON.
+        /// </summary>
+        [Input("patch", required: true)]
+        public Input<string> Patch { get; set; } = null!;
+
+        /// <summary>
+        /// Selects the objects to patch. A strategic merge patch may omit the target, in which case the patch itself identifies the object to patch.
+        /// </summary>
+        [Input("target")]
+        public Input<Pulumi.Kubernetes.Types.Inputs.Yaml.V2.PatchTargetArgs>? Target { get; set; }
+
+        public PatchArgs()
+        {
+        }
+        public static new PatchArgs Empty => new PatchArgs();
+    }
+}
@@ -0,0 +1,65 @@
+// *** WARNING: this file was generated by pulumigen. ***
+// *** Do not edit by hand unless you're certain you know what you are doing! ***
+
+using System;
+using System.Collections.Generic;
+using System.Collections.Immutable;
+using System.Threading.Tasks;
+using Pulumi.Serialization;
+
+namespace Pulumi.Kubernetes.Types.Inputs.Yaml.V2
+{
+
+    /// <summary>
+    /// Selects the objects that a patch applies to, by kind, name, namespace, labels and annotations.
+    /// </summary>
+    public class PatchTargetArgs : global::Pulumi.ResourceArgs
+    {
+        /// <summary>
+        /// An annotation selector that the objects must match.
+        /// </summary>
+        [Input("annotationSelector")]
+        public Input<string>? AnnotationSelector { get; set; }
+
+        /// <summary>
+        /// The API group of the objects.
+        /// </summary>
+        [Input("group")]
+        public Input<string>? Group { get; set; }
+
+        /// <summary>
+        /// The kind of the objects.
+        /// </summary>
+        [Input("kind")]
+        public Input<string>? Kind { get; set; }
+
+        /// <summary>
+        /// A label selector that the objects must match.
+        /// </summary>
+        [Input("labelSelector")]
+        public Input<string>? LabelSelector { get; set; }
+
+        /// <summary>
+        /// The name of the objects (a regular expression).
+        /// </summary>
+        [Input("name")]
+        public Input<string>? Name { get; set; }
+
+        /// <summary>
+        /// The namespace of the objects.
+        /// </summary>
+        [Input("namespace")]
+        public Input<string>? Namespace { get; set; }
+
+        /// <summary>
+        /// The API version of the objects.
+        /// </summary>
+        [Input("version")]
+        public Input<string>? Version { get; set; }
+
+        public PatchTargetArgs()
+        {
+        }
+        public static new PatchTargetArgs Empty => new PatchTargetArgs();
+    }
+}
@@ -0,0 +1,59 @@
+// *** WARNING: this file was generated by pulumigen. ***
+// *** Do not edit by hand unless you're certain you know what you are doing! ***
+
+using System;
+using System.Collections.Generic;
+using System.Collections.Immutable;
+using System.Threading.Tasks;
+using Pulumi.Serialization;
+
+namespace Pulumi.Kubernetes.Types.Inputs.Yaml.V2
+{
+
+    /// <summary>
+    /// Selects objects by group, version, kind, name, namespace and labels. The fields that aren't set match any object.
+    /// </summary>
+    public class SelectorArgs : global::Pulumi.ResourceArgs
+    {
+        /// <summary>
+        /// The API group of the objects.
+        /// </summary>
+        [Input("group")]
+        public Input<string>? Group { get; set; }
+
+        /// <summary>
+        /// The kind of the objects.
+        /// </summary>
+        [Input("kind")]
+        public Input<string>? Kind { get; set; }
+
+        /// <summary>
+        /// A label selector that the objects must match, e.g. `app=nginx,tier!=cache`.
+        /// </summary>
+        [Input("labelSelector")]
+        public Input<string>? LabelSelector { get; set; }
+
+        /// <summary>
+        /// A glob pattern that the name of the objects must match, e.g. `*-psp`.
+        /// </summary>
+        [Input("name")]
+        public Input<string>? Name { get; set; }
+
+        /// <summary>
+        /// The namespace of the objects.
+        /// </summary>
+        [Input("namespace")]
+        public Input<string>? Namespace { get; set; }
+
+        /// <summary>
+        /// The API version of the objects.
+        /// </summary>
+        [Input("version")]
+        public Input<string>? Version { get; set; }
+
+        public SelectorArgs()
+        {
+        }
+        public static new SelectorArgs Empty => new SelectorArgs();
+    }
+}
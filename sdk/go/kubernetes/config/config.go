@@ -89,6 +89,15 @@ func GetKubeconfig(ctx *pulumi.Context) string {
 	return config.Get(ctx, "kubernetes:kubeconfig")
 }
 
+// If present and set to true, the manifests of `yaml/v2.ConfigGroup` and `yaml/v2.ConfigFile` that are given by URL are read from the on-disk manifest cache only, and never downloaded. A manifest that isn't cached is an error.
+//
+// This config can be specified in the following ways, using this precedence:
+// 1. This `manifestCacheOffline` parameter.
+// 2. The `PULUMI_K8S_MANIFEST_CACHE_OFFLINE` environment variable.
+func GetManifestCacheOffline(ctx *pulumi.Context) bool {
+	return config.GetBool(ctx, "kubernetes:manifestCacheOffline")
+}
+
 // If present, the default namespace to use. This flag is ignored for cluster-scoped resources.
 //
 // A namespace can be specified in multiple places, and the precedence is as follows:
@@ -99,6 +108,26 @@ func GetNamespace(ctx *pulumi.Context) string {
 	return config.Get(ctx, "kubernetes:namespace")
 }
 
+// A comma-separated list of age recipients (e.g. `age1...`) to which to encrypt the Secrets rendered to the directory specified by renderYamlToDirectory. The `data` and `stringData` of the Secrets are encrypted with SOPS, such that the manifests may be committed to git and decrypted by SOPS or by Flux's kustomize-controller with the recipients' age keys. When the recipients change, the Secrets are rendered again for the new recipients. A Secret that is rendered again unchanged (e.g. with alwaysRender) keeps its ciphertext, so that its file doesn't change. Not supported with the `namespaces` renderYamlFormat, since `sops -d` can't decrypt a file of several SOPS documents. Only valid when renderYamlToDirectory is set.
+func GetRenderYamlAgeRecipients(ctx *pulumi.Context) string {
+	return config.Get(ctx, "kubernetes:renderYamlAgeRecipients")
+}
+
+// The layout of the manifests rendered to the directory specified by renderYamlToDirectory. One of:
+// - `files` (the default): each object in its own file, in the `0-crd` and `1-manifest` directories.
+// - `namespaces`: the objects of each namespace in a single multi-document file named after the namespace, and the objects without a namespace in `_cluster.yaml`.
+// - `kustomization`: each object in its own file, as with `files`, along with a `kustomization.yaml` that lists the files.
+//
+// The objects of a multi-document file, and the files of a `kustomization.yaml`, are in a deterministic dependency order. Only valid when renderYamlToDirectory is set.
+func GetRenderYamlFormat(ctx *pulumi.Context) string {
+	return config.Get(ctx, "kubernetes:renderYamlFormat")
+}
+
+// The path of a JSON file from which to resolve the unknown values of the manifests rendered to the directory specified by renderYamlToDirectory, such as a generated name or an output of another stack. The file is either a deployment, as exported by `pulumi stack export --show-secrets`, from which an unknown value is resolved from the same property of the resource with the same URN, or a JSON object of resolved values keyed by resource URN and then by property path (e.g. `spec.loadBalancerIP`). With a snapshot, previews show the resolved values, although they never write the manifests. The unknown values that aren't resolved are rendered as `${unresolved:<property path>}` placeholders, and listed in `render-report.txt`. Only valid when renderYamlToDirectory is set.
+func GetRenderYamlSnapshot(ctx *pulumi.Context) string {
+	return config.Get(ctx, "kubernetes:renderYamlSnapshot")
+}
+
 // BETA FEATURE - If present, render resource manifests to this directory. In this mode, resources will not
 // be created on a Kubernetes cluster, but the rendered manifests will be kept in sync with changes
 // to the Pulumi program. This feature is in developer preview, and is disabled by default.
@@ -111,7 +140,8 @@ func GetNamespace(ctx *pulumi.Context) string {
 // Note that some computed Outputs such as status fields will not be populated
 // since the resources are not created on a Kubernetes cluster. These Output values will remain undefined,
 // and may result in an error if they are referenced by other resources. Also note that any secret values
-// used in these resources will be rendered in plaintext to the resulting YAML.
+// used in these resources will be rendered in plaintext to the resulting YAML, except for the data of
+// Secrets when renderYamlAgeRecipients is set.
 func GetRenderYamlToDirectory(ctx *pulumi.Context) string {
 	return config.Get(ctx, "kubernetes:renderYamlToDirectory")
 }
@@ -19,6 +19,11 @@ import (
 // a library to perform the orchestration of the resources. As a result, the full spectrum of Helm features are supported
 // natively.
 //
+// When the provider renders manifests (`renderYamlToDirectory`), a `Release` isn't installed. Instead, its chart is
+// rendered client-side, as with `helm template`, and its objects are written to the render directory alongside the other
+// resources, in the layout of `renderYamlFormat`. The chart's hooks are written apart from its objects, to
+// `hooks/<namespace>-<name>.yaml`, in the order of their weights.
+//
 // You may also want to consider the `Chart` resource as an alternative method for managing helm charts. For more information about the trade-offs between these options see: [Choosing the right Helm resource for your use case](https://www.pulumi.com/registry/packages/kubernetes/how-to-guides/choosing-the-right-helm-resource-for-your-use-case)
 //
 // ## Example Usage
@@ -309,6 +314,8 @@ type Release struct {
 	CleanupOnFail pulumi.BoolPtrOutput `pulumi:"cleanupOnFail"`
 	// Create the namespace if it does not exist.
 	CreateNamespace pulumi.BoolPtrOutput `pulumi:"createNamespace"`
+	// How to resolve the chart's dependencies. By default, missing dependencies are fetched when `dependencyUpdate` is set. Values are: `locked` (require a `Chart.lock` that matches `Chart.yaml`, and fetch only the locked versions), `vendor` (resolve the dependencies into the `charts/` directory of a local chart, to be committed alongside the program; a preview resolves them into a temporary copy of the chart instead), and `offline` (never fetch dependencies; they must already be present in the chart).
+	DependencyMode pulumi.StringPtrOutput `pulumi:"dependencyMode"`
 	// Run helm dependency update before installing the chart.
 	DependencyUpdate pulumi.BoolPtrOutput `pulumi:"dependencyUpdate"`
 	// Add a custom description
@@ -335,6 +342,8 @@ type Release struct {
 	Name pulumi.StringPtrOutput `pulumi:"name"`
 	// Namespace to install the release into.
 	Namespace pulumi.StringPtrOutput `pulumi:"namespace"`
+	// The rendered notes (NOTES.txt) of the deployed release.
+	Notes pulumi.StringPtrOutput `pulumi:"notes"`
 	// Postrender command to run.
 	Postrender pulumi.StringPtrOutput `pulumi:"postrender"`
 	// Perform pods restart during upgrade/rollback.
@@ -426,6 +435,8 @@ type releaseArgs struct {
 	Compat        *string `pulumi:"compat"`
 	// Create the namespace if it does not exist.
 	CreateNamespace *bool `pulumi:"createNamespace"`
+	// How to resolve the chart's dependencies. By default, missing dependencies are fetched when `dependencyUpdate` is set. Values are: `locked` (require a `Chart.lock` that matches `Chart.yaml`, and fetch only the locked versions), `vendor` (resolve the dependencies into the `charts/` directory of a local chart, to be committed alongside the program; a preview resolves them into a temporary copy of the chart instead), and `offline` (never fetch dependencies; they must already be present in the chart).
+	DependencyMode *string `pulumi:"dependencyMode"`
 	// Run helm dependency update before installing the chart.
 	DependencyUpdate *bool `pulumi:"dependencyUpdate"`
 	// Add a custom description
@@ -499,6 +510,8 @@ type ReleaseArgs struct {
 	Compat        pulumi.StringPtrInput
 	// Create the namespace if it does not exist.
 	CreateNamespace pulumi.BoolPtrInput
+	// How to resolve the chart's dependencies. By default, missing dependencies are fetched when `dependencyUpdate` is set. Values are: `locked` (require a `Chart.lock` that matches `Chart.yaml`, and fetch only the locked versions), `vendor` (resolve the dependencies into the `charts/` directory of a local chart, to be committed alongside the program; a preview resolves them into a temporary copy of the chart instead), and `offline` (never fetch dependencies; they must already be present in the chart).
+	DependencyMode pulumi.StringPtrInput
 	// Run helm dependency update before installing the chart.
 	DependencyUpdate pulumi.BoolPtrInput
 	// Add a custom description
@@ -671,6 +684,11 @@ func (o ReleaseOutput) CreateNamespace() pulumi.BoolPtrOutput {
 	return o.ApplyT(func(v *Release) pulumi.BoolPtrOutput { return v.CreateNamespace }).(pulumi.BoolPtrOutput)
 }
 
+// How to resolve the chart's dependencies. By default, missing dependencies are fetched when `dependencyUpdate` is set. Values are: `locked` (require a `Chart.lock` that matches `Chart.yaml`, and fetch only the locked versions), `vendor` (resolve the dependencies into the `charts/` directory of a local chart, to be committed alongside the program; a preview resolves them into a temporary copy of the chart instead), and `offline` (never fetch dependencies; they must already be present in the chart).
+func (o ReleaseOutput) DependencyMode() pulumi.StringPtrOutput {
+	return o.ApplyT(func(v *Release) pulumi.StringPtrOutput { return v.DependencyMode }).(pulumi.StringPtrOutput)
+}
+
 // Run helm dependency update before installing the chart.
 func (o ReleaseOutput) DependencyUpdate() pulumi.BoolPtrOutput {
 	return o.ApplyT(func(v *Release) pulumi.BoolPtrOutput { return v.DependencyUpdate }).(pulumi.BoolPtrOutput)
@@ -736,6 +754,11 @@ func (o ReleaseOutput) Namespace() pulumi.StringPtrOutput {
 	return o.ApplyT(func(v *Release) pulumi.StringPtrOutput { return v.Namespace }).(pulumi.StringPtrOutput)
 }
 
+// The rendered notes (NOTES.txt) of the deployed release.
+func (o ReleaseOutput) Notes() pulumi.StringPtrOutput {
+	return o.ApplyT(func(v *Release) pulumi.StringPtrOutput { return v.Notes }).(pulumi.StringPtrOutput)
+}
+
 // Postrender command to run.
 func (o ReleaseOutput) Postrender() pulumi.StringPtrOutput {
 	return o.ApplyT(func(v *Release) pulumi.StringPtrOutput { return v.Postrender }).(pulumi.StringPtrOutput)
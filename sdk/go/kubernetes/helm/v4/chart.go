@@ -77,6 +77,14 @@ import (
 //
 // Use the `postRenderer` input to pipe the rendered manifest through a [post-rendering command](https://helm.sh/docs/topics/advanced/#post-rendering).
 //
+// ### Selecting Objects
+//
+// Use the `include` and `exclude` inputs to select the objects of the chart to register, e.g. to skip a Namespace
+// that's managed separately. Each selector matches objects by `group`, `version`, `kind`, `name` (a glob pattern),
+// `namespace` and `labelSelector`. An object is registered if it matches any of the included selectors (or if there are
+// none), and none of the excluded selectors. The selectors apply to the CRDs and hooks, too. The skipped objects are
+// reported in the `skipped` output.
+//
 // ### Resource Ordering
 //
 // Sometimes resources must be applied in a specific order. For example, a namespace resource must be
@@ -85,10 +93,20 @@ import (
 // Pulumi uses heuristics to determine which order to apply and delete objects within the Chart.  Pulumi also
 // waits for each object to be fully reconciled, unless `skipAwait` is enabled.
 //
+// Workloads depend on the ConfigMaps and Secrets in the same namespace that their pods reference (by volumes, `envFrom`,
+// `env` or `imagePullSecrets`), so that the referenced configuration is created first.
+//
+// Admission webhook configurations depend on the Services that back them and on the workloads that those Services
+// select, and the objects that a webhook intercepts depend on the webhook configuration, so that the webhook is ready
+// before the objects are created. Webhooks whose `failurePolicy` is `Ignore` don't affect the ordering, and the objects
+// in the namespace of the webhook's Service are assumed to be part of the webhook's installation.
+//
 // Pulumi supports the `config.kubernetes.io/depends-on` annotation to declare an explicit dependency on a given resource.
 // The annotation accepts a list of resource references, delimited by commas.
 //
-// Note that references to resources outside the Chart aren't supported.
+// A reference to an object of the chart's CRDs, or of a hook that is applied earlier (e.g. a `pre-install` hook),
+// is honored. A reference to any other resource outside the Chart is assumed to exist in the cluster already,
+// and isn't waited for.
 //
 // **Resource reference**
 //
@@ -255,8 +273,12 @@ import (
 type Chart struct {
 	pulumi.ResourceState
 
+	// The rendered notes (NOTES.txt) of the Chart.
+	Notes pulumi.StringPtrOutput `pulumi:"notes"`
 	// Resources created by the Chart.
 	Resources pulumi.ArrayOutput `pulumi:"resources"`
+	// The objects of the Chart that weren't registered because of the `include` and `exclude` selectors, e.g. `v1/Namespace:my-namespace`.
+	Skipped pulumi.StringArrayOutput `pulumi:"skipped"`
 }
 
 // NewChart registers a new resource with the given unique name, arguments, and options.
@@ -279,13 +301,25 @@ func NewChart(ctx *pulumi.Context,
 }
 
 type chartArgs struct {
+	// Restricts the chart's `lookup` function to a read-only view of the cluster, scoped to the given kinds and namespaces. Lookups are cached for the duration of the deployment, and the values of looked up Secrets are marked as secret wherever they appear in the rendered resources. By default, the `lookup` function has the provider's access to the cluster.
+	AllowClusterLookup *ClusterLookup `pulumi:"allowClusterLookup"`
+	// Set this to true to apply the chart's install and upgrade hooks (resources annotated with `helm.sh/hook`) in order, as Helm does. The chart is installed if its first resource doesn't exist in the cluster yet, and upgraded otherwise. The `pre-install` or `pre-upgrade` hooks are applied before the chart's other resources, and the `post-install` or `post-upgrade` hooks after them, in order of `helm.sh/hook-weight`. Upgrade hooks (e.g. migration Jobs) run again whenever the rendered chart changes, their previous resources being deleted first, as with the default `before-hook-creation` delete policy. The resources of install hooks are left in the cluster once the chart is upgraded. The `hook-succeeded` and `hook-failed` delete policies are not supported: the resources of a hook are managed like the chart's other resources, so they remain in the cluster (and in the stack) once the hook has run, and a warning is produced. Delete and rollback hooks are not supported either, and produce a warning. Test hooks are always excluded. This setting has no effect in render mode; see `includeHooks`.
+	ApplyHooks *bool `pulumi:"applyHooks"`
 	// Chart name to be installed. A path may be used.
 	Chart string `pulumi:"chart"`
+	// The policy for the CRDs in the chart's `crds/` directory: `create` creates the CRDs and leaves them unchanged on upgrade (CRDs that exist already, and that Pulumi doesn't manage, are left as they are), `createReplace` creates the CRDs and updates them on upgrade, `patch` applies the CRDs as server-side apply patches (`CustomResourceDefinitionPatch`), and `skip` neither creates nor updates them. With a policy, the CRDs are applied before the rest of the chart, and they are never deleted, neither when a new chart version drops them nor when the chart is deleted. By default, the CRDs are managed like the chart's other resources.
+	CrdPolicy *string `pulumi:"crdPolicy"`
+	// How to resolve the chart's dependencies. By default, missing dependencies are fetched when `dependencyUpdate` is set or a `Chart.lock` is present. Values are: `locked` (require a `Chart.lock` that matches `Chart.yaml`, and fetch only the locked versions), `vendor` (resolve the dependencies into the `charts/` directory of a local chart, to be committed alongside the program; a preview resolves them into a temporary copy of the chart instead), and `offline` (never fetch dependencies; they must already be present in the chart).
+	DependencyMode *string `pulumi:"dependencyMode"`
 	// Run helm dependency update before installing the chart.
 	DependencyUpdate *bool `pulumi:"dependencyUpdate"`
 	// Use chart development versions, too. Equivalent to version '>0.0.0-0'. If `version` is set, this is ignored.
 	Devel *bool `pulumi:"devel"`
-	// By default, Helm hook resources (those annotated with `helm.sh/hook`) are omitted from the rendered output. When the provider is configured with `renderYamlToDirectory`, set this to true to include hook resources in the rendered manifests so that another tool (e.g. Argo CD) can apply them. Test hooks (`helm.sh/hook: test`) are always excluded. This setting has no effect outside of render mode, where hooks are not supported.
+	// Selects the objects not to register, among the included objects. The skipped objects are reported in the `skipped` output.
+	Exclude []Selector `pulumi:"exclude"`
+	// Selects the objects to register. An object is registered if it matches any of the selectors; by default, all objects are registered.
+	Include []Selector `pulumi:"include"`
+	// By default, Helm hook resources (those annotated with `helm.sh/hook`) are omitted from the rendered output. When the provider is configured with `renderYamlToDirectory`, set this to true to include hook resources in the rendered manifests so that another tool (e.g. Argo CD) can apply them. Test hooks (`helm.sh/hook: test`) are always excluded. This setting has no effect outside of render mode, where `applyHooks` applies the hooks instead.
 	IncludeHooks *bool `pulumi:"includeHooks"`
 	// Location of public keys used for verification. Used only if `verify` is true
 	Keyring pulumi.AssetOrArchive `pulumi:"keyring"`
@@ -297,6 +331,8 @@ type chartArgs struct {
 	PlainHttp *bool `pulumi:"plainHttp"`
 	// Specification defining the post-renderer to use.
 	PostRenderer *PostRenderer `pulumi:"postRenderer"`
+	// Built-in post-renderers to apply to the rendered manifests, in order. Built-in post-renderers run in-process and don't require an executable. They run after the `postRenderer` command, if any.
+	PostRenderers []BuiltinPostRenderer `pulumi:"postRenderers"`
 	// Specification defining the Helm chart repository to use.
 	RepositoryOpts *RepositoryOpts `pulumi:"repositoryOpts"`
 	// An optional prefix for the auto-generated resource names. Example: A resource created with resourcePrefix="foo" would produce a resource named "foo:resourceName".
@@ -309,6 +345,8 @@ type chartArgs struct {
 	ValueYamlFiles []pulumi.AssetOrArchive `pulumi:"valueYamlFiles"`
 	// Custom values set for the release.
 	Values map[string]interface{} `pulumi:"values"`
+	// References to values held in ConfigMaps and Secrets, which are read from the cluster when the chart is rendered. The referenced values are merged in order, after `valueYamlFiles` and before `values`. Values read from Secrets are marked as secret wherever they appear in the rendered resources, unless `values` overrides them.
+	ValuesFrom []ValuesReference `pulumi:"valuesFrom"`
 	// Verify the chart's integrity.
 	Verify *bool `pulumi:"verify"`
 	// Specify the chart version to install. If this is not specified, the latest version is installed.
@@ -317,13 +355,25 @@ type chartArgs struct {
 
 // The set of arguments for constructing a Chart resource.
 type ChartArgs struct {
+	// Restricts the chart's `lookup` function to a read-only view of the cluster, scoped to the given kinds and namespaces. Lookups are cached for the duration of the deployment, and the values of looked up Secrets are marked as secret wherever they appear in the rendered resources. By default, the `lookup` function has the provider's access to the cluster.
+	AllowClusterLookup ClusterLookupPtrInput
+	// Set this to true to apply the chart's install and upgrade hooks (resources annotated with `helm.sh/hook`) in order, as Helm does. The chart is installed if its first resource doesn't exist in the cluster yet, and upgraded otherwise. The `pre-install` or `pre-upgrade` hooks are applied before the chart's other resources, and the `post-install` or `post-upgrade` hooks after them, in order of `helm.sh/hook-weight`. Upgrade hooks (e.g. migration Jobs) run again whenever the rendered chart changes, their previous resources being deleted first, as with the default `before-hook-creation` delete policy. The resources of install hooks are left in the cluster once the chart is upgraded. The `hook-succeeded` and `hook-failed` delete policies are not supported: the resources of a hook are managed like the chart's other resources, so they remain in the cluster (and in the stack) once the hook has run, and a warning is produced. Delete and rollback hooks are not supported either, and produce a warning. Test hooks are always excluded. This setting has no effect in render mode; see `includeHooks`.
+	ApplyHooks pulumi.BoolPtrInput
 	// Chart name to be installed. A path may be used.
 	Chart pulumi.StringInput
+	// The policy for the CRDs in the chart's `crds/` directory: `create` creates the CRDs and leaves them unchanged on upgrade (CRDs that exist already, and that Pulumi doesn't manage, are left as they are), `createReplace` creates the CRDs and updates them on upgrade, `patch` applies the CRDs as server-side apply patches (`CustomResourceDefinitionPatch`), and `skip` neither creates nor updates them. With a policy, the CRDs are applied before the rest of the chart, and they are never deleted, neither when a new chart version drops them nor when the chart is deleted. By default, the CRDs are managed like the chart's other resources.
+	CrdPolicy pulumi.StringPtrInput
+	// How to resolve the chart's dependencies. By default, missing dependencies are fetched when `dependencyUpdate` is set or a `Chart.lock` is present. Values are: `locked` (require a `Chart.lock` that matches `Chart.yaml`, and fetch only the locked versions), `vendor` (resolve the dependencies into the `charts/` directory of a local chart, to be committed alongside the program; a preview resolves them into a temporary copy of the chart instead), and `offline` (never fetch dependencies; they must already be present in the chart).
+	DependencyMode pulumi.StringPtrInput
 	// Run helm dependency update before installing the chart.
 	DependencyUpdate pulumi.BoolPtrInput
 	// Use chart development versions, too. Equivalent to version '>0.0.0-0'. If `version` is set, this is ignored.
 	Devel pulumi.BoolPtrInput
-	// By default, Helm hook resources (those annotated with `helm.sh/hook`) are omitted from the rendered output. When the provider is configured with `renderYamlToDirectory`, set this to true to include hook resources in the rendered manifests so that another tool (e.g. Argo CD) can apply them. Test hooks (`helm.sh/hook: test`) are always excluded. This setting has no effect outside of render mode, where hooks are not supported.
+	// Selects the objects not to register, among the included objects. The skipped objects are reported in the `skipped` output.
+	Exclude SelectorArrayInput
+	// Selects the objects to register. An object is registered if it matches any of the selectors; by default, all objects are registered.
+	Include SelectorArrayInput
+	// By default, Helm hook resources (those annotated with `helm.sh/hook`) are omitted from the rendered output. When the provider is configured with `renderYamlToDirectory`, set this to true to include hook resources in the rendered manifests so that another tool (e.g. Argo CD) can apply them. Test hooks (`helm.sh/hook: test`) are always excluded. This setting has no effect outside of render mode, where `applyHooks` applies the hooks instead.
 	IncludeHooks pulumi.BoolPtrInput
 	// Location of public keys used for verification. Used only if `verify` is true
 	Keyring pulumi.AssetOrArchiveInput
@@ -335,6 +385,8 @@ type ChartArgs struct {
 	PlainHttp pulumi.BoolPtrInput
 	// Specification defining the post-renderer to use.
 	PostRenderer PostRendererPtrInput
+	// Built-in post-renderers to apply to the rendered manifests, in order. Built-in post-renderers run in-process and don't require an executable. They run after the `postRenderer` command, if any.
+	PostRenderers BuiltinPostRendererArrayInput
 	// Specification defining the Helm chart repository to use.
 	RepositoryOpts RepositoryOptsPtrInput
 	// An optional prefix for the auto-generated resource names. Example: A resource created with resourcePrefix="foo" would produce a resource named "foo:resourceName".
@@ -347,6 +399,8 @@ type ChartArgs struct {
 	ValueYamlFiles pulumi.AssetOrArchiveArrayInput
 	// Custom values set for the release.
 	Values pulumi.MapInput
+	// References to values held in ConfigMaps and Secrets, which are read from the cluster when the chart is rendered. The referenced values are merged in order, after `valueYamlFiles` and before `values`. Values read from Secrets are marked as secret wherever they appear in the rendered resources, unless `values` overrides them.
+	ValuesFrom ValuesReferenceArrayInput
 	// Verify the chart's integrity.
 	Verify pulumi.BoolPtrInput
 	// Specify the chart version to install. If this is not specified, the latest version is installed.
@@ -440,11 +494,21 @@ func (o ChartOutput) ToChartOutputWithContext(ctx context.Context) ChartOutput {
 	return o
 }
 
+// The rendered notes (NOTES.txt) of the Chart.
+func (o ChartOutput) Notes() pulumi.StringPtrOutput {
+	return o.ApplyT(func(v *Chart) pulumi.StringPtrOutput { return v.Notes }).(pulumi.StringPtrOutput)
+}
+
 // Resources created by the Chart.
 func (o ChartOutput) Resources() pulumi.ArrayOutput {
 	return o.ApplyT(func(v *Chart) pulumi.ArrayOutput { return v.Resources }).(pulumi.ArrayOutput)
 }
 
+// The objects of the Chart that weren't registered because of the `include` and `exclude` selectors, e.g. `v1/Namespace:my-namespace`.
+func (o ChartOutput) Skipped() pulumi.StringArrayOutput {
+	return o.ApplyT(func(v *Chart) pulumi.StringArrayOutput { return v.Skipped }).(pulumi.StringArrayOutput)
+}
+
 type ChartArrayOutput struct{ *pulumi.OutputState }
 
 func (ChartArrayOutput) ElementType() reflect.Type {
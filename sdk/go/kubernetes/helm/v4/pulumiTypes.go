@@ -13,6 +13,974 @@ import (
 
 var _ = utilities.GetEnvOrDefault
 
+// Specification of a built-in post-renderer. Exactly one of the post-renderers must be specified.
+type BuiltinPostRenderer struct {
+	// Applies a set of Kustomize patches to the rendered manifests.
+	Kustomize *KustomizePostRenderer `pulumi:"kustomize"`
+	// Injects labels and annotations into the rendered objects.
+	Metadata *MetadataPostRenderer `pulumi:"metadata"`
+}
+
+// BuiltinPostRendererInput is an input type that accepts BuiltinPostRendererArgs and BuiltinPostRendererOutput values.
+// You can construct a concrete instance of `BuiltinPostRendererInput` via:
+//
+//	BuiltinPostRendererArgs{...}
+type BuiltinPostRendererInput interface {
+	pulumi.Input
+
+	ToBuiltinPostRendererOutput() BuiltinPostRendererOutput
+	ToBuiltinPostRendererOutputWithContext(context.Context) BuiltinPostRendererOutput
+}
+
+// Specification of a built-in post-renderer. Exactly one of the post-renderers must be specified.
+type BuiltinPostRendererArgs struct {
+	// Applies a set of Kustomize patches to the rendered manifests.
+	Kustomize KustomizePostRendererPtrInput `pulumi:"kustomize"`
+	// Injects labels and annotations into the rendered objects.
+	Metadata MetadataPostRendererPtrInput `pulumi:"metadata"`
+}
+
+func (BuiltinPostRendererArgs) ElementType() reflect.Type {
+	return reflect.TypeOf((*BuiltinPostRenderer)(nil)).Elem()
+}
+
+func (i BuiltinPostRendererArgs) ToBuiltinPostRendererOutput() BuiltinPostRendererOutput {
+	return i.ToBuiltinPostRendererOutputWithContext(context.Background())
+}
+
+func (i BuiltinPostRendererArgs) ToBuiltinPostRendererOutputWithContext(ctx context.Context) BuiltinPostRendererOutput {
+	return pulumi.ToOutputWithContext(ctx, i).(BuiltinPostRendererOutput)
+}
+
+// BuiltinPostRendererArrayInput is an input type that accepts BuiltinPostRendererArray and BuiltinPostRendererArrayOutput values.
+// You can construct a concrete instance of `BuiltinPostRendererArrayInput` via:
+//
+//	BuiltinPostRendererArray{ BuiltinPostRendererArgs{...} }
+type BuiltinPostRendererArrayInput interface {
+	pulumi.Input
+
+	ToBuiltinPostRendererArrayOutput() BuiltinPostRendererArrayOutput
+	ToBuiltinPostRendererArrayOutputWithContext(context.Context) BuiltinPostRendererArrayOutput
+}
+
+type BuiltinPostRendererArray []BuiltinPostRendererInput
+
+func (BuiltinPostRendererArray) ElementType() reflect.Type {
+	return reflect.TypeOf((*[]BuiltinPostRenderer)(nil)).Elem()
+}
+
+func (i BuiltinPostRendererArray) ToBuiltinPostRendererArrayOutput() BuiltinPostRendererArrayOutput {
+	return i.ToBuiltinPostRendererArrayOutputWithContext(context.Background())
+}
+
+func (i BuiltinPostRendererArray) ToBuiltinPostRendererArrayOutputWithContext(ctx context.Context) BuiltinPostRendererArrayOutput {
+	return pulumi.ToOutputWithContext(ctx, i).(BuiltinPostRendererArrayOutput)
+}
+
+// Specification of a built-in post-renderer. Exactly one of the post-renderers must be specified.
+type BuiltinPostRendererOutput struct{ *pulumi.OutputState }
+
+func (BuiltinPostRendererOutput) ElementType() reflect.Type {
+	return reflect.TypeOf((*BuiltinPostRenderer)(nil)).Elem()
+}
+
+func (o BuiltinPostRendererOutput) ToBuiltinPostRendererOutput() BuiltinPostRendererOutput {
+	return o
+}
+
+func (o BuiltinPostRendererOutput) ToBuiltinPostRendererOutputWithContext(ctx context.Context) BuiltinPostRendererOutput {
+	return o
+}
+
+// Applies a set of Kustomize patches to the rendered manifests.
+func (o BuiltinPostRendererOutput) Kustomize() KustomizePostRendererPtrOutput {
+	return o.ApplyT(func(v BuiltinPostRenderer) *KustomizePostRenderer { return v.Kustomize }).(KustomizePostRendererPtrOutput)
+}
+
+// Injects labels and annotations into the rendered objects.
+func (o BuiltinPostRendererOutput) Metadata() MetadataPostRendererPtrOutput {
+	return o.ApplyT(func(v BuiltinPostRenderer) *MetadataPostRenderer { return v.Metadata }).(MetadataPostRendererPtrOutput)
+}
+
+type BuiltinPostRendererArrayOutput struct{ *pulumi.OutputState }
+
+func (BuiltinPostRendererArrayOutput) ElementType() reflect.Type {
+	return reflect.TypeOf((*[]BuiltinPostRenderer)(nil)).Elem()
+}
+
+func (o BuiltinPostRendererArrayOutput) ToBuiltinPostRendererArrayOutput() BuiltinPostRendererArrayOutput {
+	return o
+}
+
+func (o BuiltinPostRendererArrayOutput) ToBuiltinPostRendererArrayOutputWithContext(ctx context.Context) BuiltinPostRendererArrayOutput {
+	return o
+}
+
+func (o BuiltinPostRendererArrayOutput) Index(i pulumi.IntInput) BuiltinPostRendererOutput {
+	return pulumi.All(o, i).ApplyT(func(vs []interface{}) BuiltinPostRenderer {
+		return vs[0].([]BuiltinPostRenderer)[vs[1].(int)]
+	}).(BuiltinPostRendererOutput)
+}
+
+// The scope of the cluster data that a chart may read with the `lookup` function.
+type ClusterLookup struct {
+	// The kinds of objects that may be looked up, either as `Kind` or as `group/Kind` (e.g. `apps/Deployment`). All kinds may be looked up by default.
+	Kinds []string `pulumi:"kinds"`
+	// The namespaces in which objects may be looked up, or `*` for all namespaces. Only the release namespace is allowed by default. Cluster-scoped objects may be looked up regardless.
+	Namespaces []string `pulumi:"namespaces"`
+}
+
+// ClusterLookupInput is an input type that accepts ClusterLookupArgs and ClusterLookupOutput values.
+// You can construct a concrete instance of `ClusterLookupInput` via:
+//
+//	ClusterLookupArgs{...}
+type ClusterLookupInput interface {
+	pulumi.Input
+
+	ToClusterLookupOutput() ClusterLookupOutput
+	ToClusterLookupOutputWithContext(context.Context) ClusterLookupOutput
+}
+
+// The scope of the cluster data that a chart may read with the `lookup` function.
+type ClusterLookupArgs struct {
+	// The kinds of objects that may be looked up, either as `Kind` or as `group/Kind` (e.g. `apps/Deployment`). All kinds may be looked up by default.
+	Kinds pulumi.StringArrayInput `pulumi:"kinds"`
+	// The namespaces in which objects may be looked up, or `*` for all namespaces. Only the release namespace is allowed by default. Cluster-scoped objects may be looked up regardless.
+	Namespaces pulumi.StringArrayInput `pulumi:"namespaces"`
+}
+
+func (ClusterLookupArgs) ElementType() reflect.Type {
+	return reflect.TypeOf((*ClusterLookup)(nil)).Elem()
+}
+
+func (i ClusterLookupArgs) ToClusterLookupOutput() ClusterLookupOutput {
+	return i.ToClusterLookupOutputWithContext(context.Background())
+}
+
+func (i ClusterLookupArgs) ToClusterLookupOutputWithContext(ctx context.Context) ClusterLookupOutput {
+	return pulumi.ToOutputWithContext(ctx, i).(ClusterLookupOutput)
+}
+
+func (i ClusterLookupArgs) ToClusterLookupPtrOutput() ClusterLookupPtrOutput {
+	return i.ToClusterLookupPtrOutputWithContext(context.Background())
+}
+
+func (i ClusterLookupArgs) ToClusterLookupPtrOutputWithContext(ctx context.Context) ClusterLookupPtrOutput {
+	return pulumi.ToOutputWithContext(ctx, i).(ClusterLookupOutput).ToClusterLookupPtrOutputWithContext(ctx)
+}
+
+// ClusterLookupPtrInput is an input type that accepts ClusterLookupArgs, ClusterLookupPtr and ClusterLookupPtrOutput values.
+// You can construct a concrete instance of `ClusterLookupPtrInput` via:
+//
+//	        ClusterLookupArgs{...}
+//
+//	or:
+//
+//	        nil
+type ClusterLookupPtrInput interface {
+	pulumi.Input
+
+	ToClusterLookupPtrOutput() ClusterLookupPtrOutput
+	ToClusterLookupPtrOutputWithContext(context.Context) ClusterLookupPtrOutput
+}
+
+type clusterLookupPtrType ClusterLookupArgs
+
+func ClusterLookupPtr(v *ClusterLookupArgs) ClusterLookupPtrInput {
+	return (*clusterLookupPtrType)(v)
+}
+
+func (*clusterLookupPtrType) ElementType() reflect.Type {
+	return reflect.TypeOf((**ClusterLookup)(nil)).Elem()
+}
+
+func (i *clusterLookupPtrType) ToClusterLookupPtrOutput() ClusterLookupPtrOutput {
+	return i.ToClusterLookupPtrOutputWithContext(context.Background())
+}
+
+func (i *clusterLookupPtrType) ToClusterLookupPtrOutputWithContext(ctx context.Context) ClusterLookupPtrOutput {
+	return pulumi.ToOutputWithContext(ctx, i).(ClusterLookupPtrOutput)
+}
+
+// The scope of the cluster data that a chart may read with the `lookup` function.
+type ClusterLookupOutput struct{ *pulumi.OutputState }
+
+func (ClusterLookupOutput) ElementType() reflect.Type {
+	return reflect.TypeOf((*ClusterLookup)(nil)).Elem()
+}
+
+func (o ClusterLookupOutput) ToClusterLookupOutput() ClusterLookupOutput {
+	return o
+}
+
+func (o ClusterLookupOutput) ToClusterLookupOutputWithContext(ctx context.Context) ClusterLookupOutput {
+	return o
+}
+
+func (o ClusterLookupOutput) ToClusterLookupPtrOutput() ClusterLookupPtrOutput {
+	return o.ToClusterLookupPtrOutputWithContext(context.Background())
+}
+
+func (o ClusterLookupOutput) ToClusterLookupPtrOutputWithContext(ctx context.Context) ClusterLookupPtrOutput {
+	return o.ApplyTWithContext(ctx, func(_ context.Context, v ClusterLookup) *ClusterLookup {
+		return &v
+	}).(ClusterLookupPtrOutput)
+}
+
+// The kinds of objects that may be looked up, either as `Kind` or as `group/Kind` (e.g. `apps/Deployment`). All kinds may be looked up by default.
+func (o ClusterLookupOutput) Kinds() pulumi.StringArrayOutput {
+	return o.ApplyT(func(v ClusterLookup) []string { return v.Kinds }).(pulumi.StringArrayOutput)
+}
+
+// The namespaces in which objects may be looked up, or `*` for all namespaces. Only the release namespace is allowed by default. Cluster-scoped objects may be looked up regardless.
+func (o ClusterLookupOutput) Namespaces() pulumi.StringArrayOutput {
+	return o.ApplyT(func(v ClusterLookup) []string { return v.Namespaces }).(pulumi.StringArrayOutput)
+}
+
+type ClusterLookupPtrOutput struct{ *pulumi.OutputState }
+
+func (ClusterLookupPtrOutput) ElementType() reflect.Type {
+	return reflect.TypeOf((**ClusterLookup)(nil)).Elem()
+}
+
+func (o ClusterLookupPtrOutput) ToClusterLookupPtrOutput() ClusterLookupPtrOutput {
+	return o
+}
+
+func (o ClusterLookupPtrOutput) ToClusterLookupPtrOutputWithContext(ctx context.Context) ClusterLookupPtrOutput {
+	return o
+}
+
+func (o ClusterLookupPtrOutput) Elem() ClusterLookupOutput {
+	return o.ApplyT(func(v *ClusterLookup) ClusterLookup {
+		if v != nil {
+			return *v
+		}
+		var ret ClusterLookup
+		return ret
+	}).(ClusterLookupOutput)
+}
+
+// The kinds of objects that may be looked up, either as `Kind` or as `group/Kind` (e.g. `apps/Deployment`). All kinds may be looked up by default.
+func (o ClusterLookupPtrOutput) Kinds() pulumi.StringArrayOutput {
+	return o.ApplyT(func(v *ClusterLookup) []string {
+		if v == nil {
+			return nil
+		}
+		return v.Kinds
+	}).(pulumi.StringArrayOutput)
+}
+
+// The namespaces in which objects may be looked up, or `*` for all namespaces. Only the release namespace is allowed by default. Cluster-scoped objects may be looked up regardless.
+func (o ClusterLookupPtrOutput) Namespaces() pulumi.StringArrayOutput {
+	return o.ApplyT(func(v *ClusterLookup) []string {
+		if v == nil {
+			return nil
+		}
+		return v.Namespaces
+	}).(pulumi.StringArrayOutput)
+}
+
+// A Kustomize patch, either a strategic merge patch or a JSON 6902 patch.
+type KustomizePatch struct {
+	// The content of the patch, in YAML or JSON.
+	Patch string `pulumi:"patch"`
+	// Selects the resources to patch. A strategic merge patch may omit the target, in which case the patch itself identifies the resource to patch.
+	Target *KustomizePatchTarget `pulumi:"target"`
+}
+
+// KustomizePatchInput is an input type that accepts KustomizePatchArgs and KustomizePatchOutput values.
+// You can construct a concrete instance of `KustomizePatchInput` via:
+//
+//	KustomizePatchArgs{...}
+type KustomizePatchInput interface {
+	pulumi.Input
+
+	ToKustomizePatchOutput() KustomizePatchOutput
+	ToKustomizePatchOutputWithContext(context.Context) KustomizePatchOutput
+}
+
+// A Kustomize patch, either a strategic merge patch or a JSON 6902 patch.
+type KustomizePatchArgs struct {
+	// The content of the patch, in YAML or JSON.
+	Patch pulumi.StringInput `pulumi:"patch"`
+	// Selects the resources to patch. A strategic merge patch may omit the target, in which case the patch itself identifies the resource to patch.
+	Target KustomizePatchTargetPtrInput `pulumi:"target"`
+}
+
+func (KustomizePatchArgs) ElementType() reflect.Type {
+	return reflect.TypeOf((*KustomizePatch)(nil)).Elem()
+}
+
+func (i KustomizePatchArgs) ToKustomizePatchOutput() KustomizePatchOutput {
+	return i.ToKustomizePatchOutputWithContext(context.Background())
+}
+
+func (i KustomizePatchArgs) ToKustomizePatchOutputWithContext(ctx context.Context) KustomizePatchOutput {
+	return pulumi.ToOutputWithContext(ctx, i).(KustomizePatchOutput)
+}
+
+// KustomizePatchArrayInput is an input type that accepts KustomizePatchArray and KustomizePatchArrayOutput values.
+// You can construct a concrete instance of `KustomizePatchArrayInput` via:
+//
+//	KustomizePatchArray{ KustomizePatchArgs{...} }
+type KustomizePatchArrayInput interface {
+	pulumi.Input
+
+	ToKustomizePatchArrayOutput() KustomizePatchArrayOutput
+	ToKustomizePatchArrayOutputWithContext(context.Context) KustomizePatchArrayOutput
+}
+
+type KustomizePatchArray []KustomizePatchInput
+
+func (KustomizePatchArray) ElementType() reflect.Type {
+	return reflect.TypeOf((*[]KustomizePatch)(nil)).Elem()
+}
+
+func (i KustomizePatchArray) ToKustomizePatchArrayOutput() KustomizePatchArrayOutput {
+	return i.ToKustomizePatchArrayOutputWithContext(context.Background())
+}
+
+func (i KustomizePatchArray) ToKustomizePatchArrayOutputWithContext(ctx context.Context) KustomizePatchArrayOutput {
+	return pulumi.ToOutputWithContext(ctx, i).(KustomizePatchArrayOutput)
+}
+
+// A Kustomize patch, either a strategic merge patch or a JSON 6902 patch.
+type KustomizePatchOutput struct{ *pulumi.OutputState }
+
+func (KustomizePatchOutput) ElementType() reflect.Type {
+	return reflect.TypeOf((*KustomizePatch)(nil)).Elem()
+}
+
+func (o KustomizePatchOutput) ToKustomizePatchOutput() KustomizePatchOutput {
+	return o
+}
+
+func (o KustomizePatchOutput) ToKustomizePatchOutputWithContext(ctx context.Context) KustomizePatchOutput {
+	return o
+}
+
+// The content of the patch, in YAML or JSON.
+func (o KustomizePatchOutput) Patch() pulumi.StringOutput {
+	return o.ApplyT(func(v KustomizePatch) string { return v.Patch }).(pulumi.StringOutput)
+}
+
+// Selects the resources to patch. A strategic merge patch may omit the target, in which case the patch itself identifies the resource to patch.
+func (o KustomizePatchOutput) Target() KustomizePatchTargetPtrOutput {
+	return o.ApplyT(func(v KustomizePatch) *KustomizePatchTarget { return v.Target }).(KustomizePatchTargetPtrOutput)
+}
+
+type KustomizePatchArrayOutput struct{ *pulumi.OutputState }
+
+func (KustomizePatchArrayOutput) ElementType() reflect.Type {
+	return reflect.TypeOf((*[]KustomizePatch)(nil)).Elem()
+}
+
+func (o KustomizePatchArrayOutput) ToKustomizePatchArrayOutput() KustomizePatchArrayOutput {
+	return o
+}
+
+func (o KustomizePatchArrayOutput) ToKustomizePatchArrayOutputWithContext(ctx context.Context) KustomizePatchArrayOutput {
+	return o
+}
+
+func (o KustomizePatchArrayOutput) Index(i pulumi.IntInput) KustomizePatchOutput {
+	return pulumi.All(o, i).ApplyT(func(vs []interface{}) KustomizePatch {
+		return vs[0].([]KustomizePatch)[vs[1].(int)]
+	}).(KustomizePatchOutput)
+}
+
+// Selects the resources that a Kustomize patch applies to.
+type KustomizePatchTarget struct {
+	// An annotation selector that the resources must match.
+	AnnotationSelector *string `pulumi:"annotationSelector"`
+	// The API group of the resources.
+	Group *string `pulumi:"group"`
+	// The kind of the resources.
+	Kind *string `pulumi:"kind"`
+	// A label selector that the resources must match.
+	LabelSelector *string `pulumi:"labelSelector"`
+	// The name of the resources (a regular expression).
+	Name *string `pulumi:"name"`
+	// The namespace of the resources.
+	Namespace *string `pulumi:"namespace"`
+	// The API version of the resources.
+	Version *string `pulumi:"version"`
+}
+
+// KustomizePatchTargetInput is an input type that accepts KustomizePatchTargetArgs and KustomizePatchTargetOutput values.
+// You can construct a concrete instance of `KustomizePatchTargetInput` via:
+//
+//	KustomizePatchTargetArgs{...}
+type KustomizePatchTargetInput interface {
+	pulumi.Input
+
+	ToKustomizePatchTargetOutput() KustomizePatchTargetOutput
+	ToKustomizePatchTargetOutputWithContext(context.Context) KustomizePatchTargetOutput
+}
+
+// Selects the resources that a Kustomize patch applies to.
+type KustomizePatchTargetArgs struct {
+	// An annotation selector that the resources must match.
+	AnnotationSelector pulumi.StringPtrInput `pulumi:"annotationSelector"`
+	// The API group of the resources.
+	Group pulumi.StringPtrInput `pulumi:"group"`
+	// The kind of the resources.
+	Kind pulumi.StringPtrInput `pulumi:"kind"`
+	// A label selector that the resources must match.
+	LabelSelector pulumi.StringPtrInput `pulumi:"labelSelector"`
+	// The name of the resources (a regular expression).
+	Name pulumi.StringPtrInput `pulumi:"name"`
+	// The namespace of the resources.
+	Namespace pulumi.StringPtrInput `pulumi:"namespace"`
+	// The API version of the resources.
+	Version pulumi.StringPtrInput `pulumi:"version"`
+}
+
+func (KustomizePatchTargetArgs) ElementType() reflect.Type {
+	return reflect.TypeOf((*KustomizePatchTarget)(nil)).Elem()
+}
+
+func (i KustomizePatchTargetArgs) ToKustomizePatchTargetOutput() KustomizePatchTargetOutput {
+	return i.ToKustomizePatchTargetOutputWithContext(context.Background())
+}
+
+func (i KustomizePatchTargetArgs) ToKustomizePatchTargetOutputWithContext(ctx context.Context) KustomizePatchTargetOutput {
+	return pulumi.ToOutputWithContext(ctx, i).(KustomizePatchTargetOutput)
+}
+
+func (i KustomizePatchTargetArgs) ToKustomizePatchTargetPtrOutput() KustomizePatchTargetPtrOutput {
+	return i.ToKustomizePatchTargetPtrOutputWithContext(context.Background())
+}
+
+func (i KustomizePatchTargetArgs) ToKustomizePatchTargetPtrOutputWithContext(ctx context.Context) KustomizePatchTargetPtrOutput {
+	return pulumi.ToOutputWithContext(ctx, i).(KustomizePatchTargetOutput).ToKustomizePatchTargetPtrOutputWithContext(ctx)
+}
+
+// KustomizePatchTargetPtrInput is an input type that accepts KustomizePatchTargetArgs, KustomizePatchTargetPtr and KustomizePatchTargetPtrOutput values.
+// You can construct a concrete instance of `KustomizePatchTargetPtrInput` via:
+//
+//	        KustomizePatchTargetArgs{...}
+//
+//	or:
+//
+//	        nil
+type KustomizePatchTargetPtrInput interface {
+	pulumi.Input
+
+	ToKustomizePatchTargetPtrOutput() KustomizePatchTargetPtrOutput
+	ToKustomizePatchTargetPtrOutputWithContext(context.Context) KustomizePatchTargetPtrOutput
+}
+
+type kustomizePatchTargetPtrType KustomizePatchTargetArgs
+
+func KustomizePatchTargetPtr(v *KustomizePatchTargetArgs) KustomizePatchTargetPtrInput {
+	return (*kustomizePatchTargetPtrType)(v)
+}
+
+func (*kustomizePatchTargetPtrType) ElementType() reflect.Type {
+	return reflect.TypeOf((**KustomizePatchTarget)(nil)).Elem()
+}
+
+func (i *kustomizePatchTargetPtrType) ToKustomizePatchTargetPtrOutput() KustomizePatchTargetPtrOutput {
+	return i.ToKustomizePatchTargetPtrOutputWithContext(context.Background())
+}
+
+func (i *kustomizePatchTargetPtrType) ToKustomizePatchTargetPtrOutputWithContext(ctx context.Context) KustomizePatchTargetPtrOutput {
+	return pulumi.ToOutputWithContext(ctx, i).(KustomizePatchTargetPtrOutput)
+}
+
+// Selects the resources that a Kustomize patch applies to.
+type KustomizePatchTargetOutput struct{ *pulumi.OutputState }
+
+func (KustomizePatchTargetOutput) ElementType() reflect.Type {
+	return reflect.TypeOf((*KustomizePatchTarget)(nil)).Elem()
+}
+
+func (o KustomizePatchTargetOutput) ToKustomizePatchTargetOutput() KustomizePatchTargetOutput {
+	return o
+}
+
+func (o KustomizePatchTargetOutput) ToKustomizePatchTargetOutputWithContext(ctx context.Context) KustomizePatchTargetOutput {
+	return o
+}
+
+func (o KustomizePatchTargetOutput) ToKustomizePatchTargetPtrOutput() KustomizePatchTargetPtrOutput {
+	return o.ToKustomizePatchTargetPtrOutputWithContext(context.Background())
+}
+
+func (o KustomizePatchTargetOutput) ToKustomizePatchTargetPtrOutputWithContext(ctx context.Context) KustomizePatchTargetPtrOutput {
+	return o.ApplyTWithContext(ctx, func(_ context.Context, v KustomizePatchTarget) *KustomizePatchTarget {
+		return &v
+	}).(KustomizePatchTargetPtrOutput)
+}
+
+// An annotation selector that the resources must match.
+func (o KustomizePatchTargetOutput) AnnotationSelector() pulumi.StringPtrOutput {
+	return o.ApplyT(func(v KustomizePatchTarget) *string { return v.AnnotationSelector }).(pulumi.StringPtrOutput)
+}
+
+// The API group of the resources.
+func (o KustomizePatchTargetOutput) Group() pulumi.StringPtrOutput {
+	return o.ApplyT(func(v KustomizePatchTarget) *string { return v.Group }).(pulumi.StringPtrOutput)
+}
+
+// The kind of the resources.
+func (o KustomizePatchTargetOutput) Kind() pulumi.StringPtrOutput {
+	return o.ApplyT(func(v KustomizePatchTarget) *string { return v.Kind }).(pulumi.StringPtrOutput)
+}
+
+// A label selector that the resources must match.
+func (o KustomizePatchTargetOutput) LabelSelector() pulumi.StringPtrOutput {
+	return o.ApplyT(func(v KustomizePatchTarget) *string { return v.LabelSelector }).(pulumi.StringPtrOutput)
+}
+
+// The name of the resources (a regular expression).
+func (o KustomizePatchTargetOutput) Name() pulumi.StringPtrOutput {
+	return o.ApplyT(func(v KustomizePatchTarget) *string { return v.Name }).(pulumi.StringPtrOutput)
+}
+
+// The namespace of the resources.
+func (o KustomizePatchTargetOutput) Namespace() pulumi.StringPtrOutput {
+	return o.ApplyT(func(v KustomizePatchTarget) *string { return v.Namespace }).(pulumi.StringPtrOutput)
+}
+
+// The API version of the resources.
+func (o KustomizePatchTargetOutput) Version() pulumi.StringPtrOutput {
+	return o.ApplyT(func(v KustomizePatchTarget) *string { return v.Version }).(pulumi.StringPtrOutput)
+}
+
+type KustomizePatchTargetPtrOutput struct{ *pulumi.OutputState }
+
+func (KustomizePatchTargetPtrOutput) ElementType() reflect.Type {
+	return reflect.TypeOf((**KustomizePatchTarget)(nil)).Elem()
+}
+
+func (o KustomizePatchTargetPtrOutput) ToKustomizePatchTargetPtrOutput() KustomizePatchTargetPtrOutput {
+	return o
+}
+
+func (o KustomizePatchTargetPtrOutput) ToKustomizePatchTargetPtrOutputWithContext(ctx context.Context) KustomizePatchTargetPtrOutput {
+	return o
+}
+
+func (o KustomizePatchTargetPtrOutput) Elem() KustomizePatchTargetOutput {
+	return o.ApplyT(func(v *KustomizePatchTarget) KustomizePatchTarget {
+		if v != nil {
+			return *v
+		}
+		var ret KustomizePatchTarget
+		return ret
+	}).(KustomizePatchTargetOutput)
+}
+
+// An annotation selector that the resources must match.
+func (o KustomizePatchTargetPtrOutput) AnnotationSelector() pulumi.StringPtrOutput {
+	return o.ApplyT(func(v *KustomizePatchTarget) *string {
+		if v == nil {
+			return nil
+		}
+		return v.AnnotationSelector
+	}).(pulumi.StringPtrOutput)
+}
+
+// The API group of the resources.
+func (o KustomizePatchTargetPtrOutput) Group() pulumi.StringPtrOutput {
+	return o.ApplyT(func(v *KustomizePatchTarget) *string {
+		if v == nil {
+			return nil
+		}
+		return v.Group
+	}).(pulumi.StringPtrOutput)
+}
+
+// The kind of the resources.
+func (o KustomizePatchTargetPtrOutput) Kind() pulumi.StringPtrOutput {
+	return o.ApplyT(func(v *KustomizePatchTarget) *string {
+		if v == nil {
+			return nil
+		}
+		return v.Kind
+	}).(pulumi.StringPtrOutput)
+}
+
+// A label selector that the resources must match.
+func (o KustomizePatchTargetPtrOutput) LabelSelector() pulumi.StringPtrOutput {
+	return o.ApplyT(func(v *KustomizePatchTarget) *string {
+		if v == nil {
+			return nil
+		}
+		return v.LabelSelector
+	}).(pulumi.StringPtrOutput)
+}
+
+// The name of the resources (a regular expression).
+func (o KustomizePatchTargetPtrOutput) Name() pulumi.StringPtrOutput {
+	return o.ApplyT(func(v *KustomizePatchTarget) *string {
+		if v == nil {
+			return nil
+		}
+		return v.Name
+	}).(pulumi.StringPtrOutput)
+}
+
+// The namespace of the resources.
+func (o KustomizePatchTargetPtrOutput) Namespace() pulumi.StringPtrOutput {
+	return o.ApplyT(func(v *KustomizePatchTarget) *string {
+		if v == nil {
+			return nil
+		}
+		return v.Namespace
+	}).(pulumi.StringPtrOutput)
+}
+
+// The API version of the resources.
+func (o KustomizePatchTargetPtrOutput) Version() pulumi.StringPtrOutput {
+	return o.ApplyT(func(v *KustomizePatchTarget) *string {
+		if v == nil {
+			return nil
+		}
+		return v.Version
+	}).(pulumi.StringPtrOutput)
+}
+
+// A post-renderer that applies a set of Kustomize patches to the rendered manifests.
+type KustomizePostRenderer struct {
+	// The patches to apply.
+	Patches []KustomizePatch `pulumi:"patches"`
+}
+
+// KustomizePostRendererInput is an input type that accepts KustomizePostRendererArgs and KustomizePostRendererOutput values.
+// You can construct a concrete instance of `KustomizePostRendererInput` via:
+//
+//	KustomizePostRendererArgs{...}
+type KustomizePostRendererInput interface {
+	pulumi.Input
+
+	ToKustomizePostRendererOutput() KustomizePostRendererOutput
+	ToKustomizePostRendererOutputWithContext(context.Context) KustomizePostRendererOutput
+}
+
+// A post-renderer that applies a set of Kustomize patches to the rendered manifests.
+type KustomizePostRendererArgs struct {
+	// The patches to apply.
+	Patches KustomizePatchArrayInput `pulumi:"patches"`
+}
+
+func (KustomizePostRendererArgs) ElementType() reflect.Type {
+	return reflect.TypeOf((*KustomizePostRenderer)(nil)).Elem()
+}
+
+func (i KustomizePostRendererArgs) ToKustomizePostRendererOutput() KustomizePostRendererOutput {
+	return i.ToKustomizePostRendererOutputWithContext(context.Background())
+}
+
+func (i KustomizePostRendererArgs) ToKustomizePostRendererOutputWithContext(ctx context.Context) KustomizePostRendererOutput {
+	return pulumi.ToOutputWithContext(ctx, i).(KustomizePostRendererOutput)
+}
+
+func (i KustomizePostRendererArgs) ToKustomizePostRendererPtrOutput() KustomizePostRendererPtrOutput {
+	return i.ToKustomizePostRendererPtrOutputWithContext(context.Background())
+}
+
+func (i KustomizePostRendererArgs) ToKustomizePostRendererPtrOutputWithContext(ctx context.Context) KustomizePostRendererPtrOutput {
+	return pulumi.ToOutputWithContext(ctx, i).(KustomizePostRendererOutput).ToKustomizePostRendererPtrOutputWithContext(ctx)
+}
+
+// KustomizePostRendererPtrInput is an input type that accepts KustomizePostRendererArgs, KustomizePostRendererPtr and KustomizePostRendererPtrOutput values.
+// You can construct a concrete instance of `KustomizePostRendererPtrInput` via:
+//
+//	        KustomizePostRendererArgs{...}
+//
+//	or:
+//
+//	        nil
+type KustomizePostRendererPtrInput interface {
+	pulumi.Input
+
+	ToKustomizePostRendererPtrOutput() KustomizePostRendererPtrOutput
+	ToKustomizePostRendererPtrOutputWithContext(context.Context) KustomizePostRendererPtrOutput
+}
+
+type kustomizePostRendererPtrType KustomizePostRendererArgs
+
+func KustomizePostRendererPtr(v *KustomizePostRendererArgs) KustomizePostRendererPtrInput {
+	return (*kustomizePostRendererPtrType)(v)
+}
+
+func (*kustomizePostRendererPtrType) ElementType() reflect.Type {
+	return reflect.TypeOf((**KustomizePostRenderer)(nil)).Elem()
+}
+
+func (i *kustomizePostRendererPtrType) ToKustomizePostRendererPtrOutput() KustomizePostRendererPtrOutput {
+	return i.ToKustomizePostRendererPtrOutputWithContext(context.Background())
+}
+
+func (i *kustomizePostRendererPtrType) ToKustomizePostRendererPtrOutputWithContext(ctx context.Context) KustomizePostRendererPtrOutput {
+	return pulumi.ToOutputWithContext(ctx, i).(KustomizePostRendererPtrOutput)
+}
+
+// A post-renderer that applies a set of Kustomize patches to the rendered manifests.
+type KustomizePostRendererOutput struct{ *pulumi.OutputState }
+
+func (KustomizePostRendererOutput) ElementType() reflect.Type {
+	return reflect.TypeOf((*KustomizePostRenderer)(nil)).Elem()
+}
+
+func (o KustomizePostRendererOutput) ToKustomizePostRendererOutput() KustomizePostRendererOutput {
+	return o
+}
+
+func (o KustomizePostRendererOutput) ToKustomizePostRendererOutputWithContext(ctx context.Context) KustomizePostRendererOutput {
+	return o
+}
+
+func (o KustomizePostRendererOutput) ToKustomizePostRendererPtrOutput() KustomizePostRendererPtrOutput {
+	return o.ToKustomizePostRendererPtrOutputWithContext(context.Background())
+}
+
+func (o KustomizePostRendererOutput) ToKustomizePostRendererPtrOutputWithContext(ctx context.Context) KustomizePostRendererPtrOutput {
+	return o.ApplyTWithContext(ctx, func(_ context.Context, v KustomizePostRenderer) *KustomizePostRenderer {
+		return &v
+	}).(KustomizePostRendererPtrOutput)
+}
+
+// The patches to apply.
+func (o KustomizePostRendererOutput) Patches() KustomizePatchArrayOutput {
+	return o.ApplyT(func(v KustomizePostRenderer) []KustomizePatch { return v.Patches }).(KustomizePatchArrayOutput)
+}
+
+type KustomizePostRendererPtrOutput struct{ *pulumi.OutputState }
+
+func (KustomizePostRendererPtrOutput) ElementType() reflect.Type {
+	return reflect.TypeOf((**KustomizePostRenderer)(nil)).Elem()
+}
+
+func (o KustomizePostRendererPtrOutput) ToKustomizePostRendererPtrOutput() KustomizePostRendererPtrOutput {
+	return o
+}
+
+func (o KustomizePostRendererPtrOutput) ToKustomizePostRendererPtrOutputWithContext(ctx context.Context) KustomizePostRendererPtrOutput {
+	return o
+}
+
+func (o KustomizePostRendererPtrOutput) Elem() KustomizePostRendererOutput {
+	return o.ApplyT(func(v *KustomizePostRenderer) KustomizePostRenderer {
+		if v != nil {
+			return *v
+		}
+		var ret KustomizePostRenderer
+		return ret
+	}).(KustomizePostRendererOutput)
+}
+
+// The patches to apply.
+func (o KustomizePostRendererPtrOutput) Patches() KustomizePatchArrayOutput {
+	return o.ApplyT(func(v *KustomizePostRenderer) []KustomizePatch {
+		if v == nil {
+			return nil
+		}
+		return v.Patches
+	}).(KustomizePatchArrayOutput)
+}
+
+// A post-renderer that injects labels and annotations into the metadata of the rendered objects. Pod templates and selectors are not affected.
+type MetadataPostRenderer struct {
+	// Annotations to inject.
+	Annotations map[string]string `pulumi:"annotations"`
+	// Restricts the injection to objects of the given kinds. All objects are affected by default.
+	Kinds []string `pulumi:"kinds"`
+	// Labels to inject.
+	Labels map[string]string `pulumi:"labels"`
+	// Replace existing labels and annotations with the same key. By default, the values set by the chart take precedence.
+	Overwrite *bool `pulumi:"overwrite"`
+}
+
+// MetadataPostRendererInput is an input type that accepts MetadataPostRendererArgs and MetadataPostRendererOutput values.
+// You can construct a concrete instance of `MetadataPostRendererInput` via:
+//
+//	MetadataPostRendererArgs{...}
+type MetadataPostRendererInput interface {
+	pulumi.Input
+
+	ToMetadataPostRendererOutput() MetadataPostRendererOutput
+	ToMetadataPostRendererOutputWithContext(context.Context) MetadataPostRendererOutput
+}
+
+// A post-renderer that injects labels and annotations into the metadata of the rendered objects. Pod templates and selectors are not affected.
+type MetadataPostRendererArgs struct {
+	// Annotations to inject.
+	Annotations pulumi.StringMapInput `pulumi:"annotations"`
+	// Restricts the injection to objects of the given kinds. All objects are affected by default.
+	Kinds pulumi.StringArrayInput `pulumi:"kinds"`
+	// Labels to inject.
+	Labels pulumi.StringMapInput `pulumi:"labels"`
+	// Replace existing labels and annotations with the same key. By default, the values set by the chart take precedence.
+	Overwrite pulumi.BoolPtrInput `pulumi:"overwrite"`
+}
+
+func (MetadataPostRendererArgs) ElementType() reflect.Type {
+	return reflect.TypeOf((*MetadataPostRenderer)(nil)).Elem()
+}
+
+func (i MetadataPostRendererArgs) ToMetadataPostRendererOutput() MetadataPostRendererOutput {
+	return i.ToMetadataPostRendererOutputWithContext(context.Background())
+}
+
+func (i MetadataPostRendererArgs) ToMetadataPostRendererOutputWithContext(ctx context.Context) MetadataPostRendererOutput {
+	return pulumi.ToOutputWithContext(ctx, i).(MetadataPostRendererOutput)
+}
+
+func (i MetadataPostRendererArgs) ToMetadataPostRendererPtrOutput() MetadataPostRendererPtrOutput {
+	return i.ToMetadataPostRendererPtrOutputWithContext(context.Background())
+}
+
+func (i MetadataPostRendererArgs) ToMetadataPostRendererPtrOutputWithContext(ctx context.Context) MetadataPostRendererPtrOutput {
+	return pulumi.ToOutputWithContext(ctx, i).(MetadataPostRendererOutput).ToMetadataPostRendererPtrOutputWithContext(ctx)
+}
+
+// MetadataPostRendererPtrInput is an input type that accepts MetadataPostRendererArgs, MetadataPostRendererPtr and MetadataPostRendererPtrOutput values.
+// You can construct a concrete instance of `MetadataPostRendererPtrInput` via:
+//
+//	        MetadataPostRendererArgs{...}
+//
+//	or:
+//
+//	        nil
+type MetadataPostRendererPtrInput interface {
+	pulumi.Input
+
+	ToMetadataPostRendererPtrOutput() MetadataPostRendererPtrOutput
+	ToMetadataPostRendererPtrOutputWithContext(context.Context) MetadataPostRendererPtrOutput
+}
+
+type metadataPostRendererPtrType MetadataPostRendererArgs
+
+func MetadataPostRendererPtr(v *MetadataPostRendererArgs) MetadataPostRendererPtrInput {
+	return (*metadataPostRendererPtrType)(v)
+}
+
+func (*metadataPostRendererPtrType) ElementType() reflect.Type {
+	return reflect.TypeOf((**MetadataPostRenderer)(nil)).Elem()
+}
+
+func (i *metadataPostRendererPtrType) ToMetadataPostRendererPtrOutput() MetadataPostRendererPtrOutput {
+	return i.ToMetadataPostRendererPtrOutputWithContext(context.Background())
+}
+
+func (i *metadataPostRendererPtrType) ToMetadataPostRendererPtrOutputWithContext(ctx context.Context) MetadataPostRendererPtrOutput {
+	return pulumi.ToOutputWithContext(ctx, i).(MetadataPostRendererPtrOutput)
+}
+
+// A post-renderer that injects labels and annotations into the metadata of the rendered objects. Pod templates and selectors are not affected.
+type MetadataPostRendererOutput struct{ *pulumi.OutputState }
+
+func (MetadataPostRendererOutput) ElementType() reflect.Type {
+	return reflect.TypeOf((*MetadataPostRenderer)(nil)).Elem()
+}
+
+func (o MetadataPostRendererOutput) ToMetadataPostRendererOutput() MetadataPostRendererOutput {
+	return o
+}
+
+func (o MetadataPostRendererOutput) ToMetadataPostRendererOutputWithContext(ctx context.Context) MetadataPostRendererOutput {
+	return o
+}
+
+func (o MetadataPostRendererOutput) ToMetadataPostRendererPtrOutput() MetadataPostRendererPtrOutput {
+	return o.ToMetadataPostRendererPtrOutputWithContext(context.Background())
+}
+
+func (o MetadataPostRendererOutput) ToMetadataPostRendererPtrOutputWithContext(ctx context.Context) MetadataPostRendererPtrOutput {
+	return o.ApplyTWithContext(ctx, func(_ context.Context, v MetadataPostRenderer) *MetadataPostRenderer {
+		return &v
+	}).(MetadataPostRendererPtrOutput)
+}
+
+// Annotations to inject.
+func (o MetadataPostRendererOutput) Annotations() pulumi.StringMapOutput {
+	return o.ApplyT(func(v MetadataPostRenderer) map[string]string { return v.Annotations }).(pulumi.StringMapOutput)
+}
+
+// Restricts the injection to objects of the given kinds. All objects are affected by default.
+func (o MetadataPostRendererOutput) Kinds() pulumi.StringArrayOutput {
+	return o.ApplyT(func(v MetadataPostRenderer) []string { return v.Kinds }).(pulumi.StringArrayOutput)
+}
+
+// Labels to inject.
+func (o MetadataPostRendererOutput) Labels() pulumi.StringMapOutput {
+	return o.ApplyT(func(v MetadataPostRenderer) map[string]string { return v.Labels }).(pulumi.StringMapOutput)
+}
+
+// Replace existing labels and annotations with the same key. By default, the values set by the chart take precedence.
+func (o MetadataPostRendererOutput) Overwrite() pulumi.BoolPtrOutput {
+	return o.ApplyT(func(v MetadataPostRenderer) *bool { return v.Overwrite }).(pulumi.BoolPtrOutput)
+}
+
+type MetadataPostRendererPtrOutput struct{ *pulumi.OutputState }
+
+func (MetadataPostRendererPtrOutput) ElementType() reflect.Type {
+	return reflect.TypeOf((**MetadataPostRenderer)(nil)).Elem()
+}
+
+func (o MetadataPostRendererPtrOutput) ToMetadataPostRendererPtrOutput() MetadataPostRendererPtrOutput {
+	return o
+}
+
+func (o MetadataPostRendererPtrOutput) ToMetadataPostRendererPtrOutputWithContext(ctx context.Context) MetadataPostRendererPtrOutput {
+	return o
+}
+
+func (o MetadataPostRendererPtrOutput) Elem() MetadataPostRendererOutput {
+	return o.ApplyT(func(v *MetadataPostRenderer) MetadataPostRenderer {
+		if v != nil {
+			return *v
+		}
+		var ret MetadataPostRenderer
+		return ret
+	}).(MetadataPostRendererOutput)
+}
+
+// Annotations to inject.
+func (o MetadataPostRendererPtrOutput) Annotations() pulumi.StringMapOutput {
+	return o.ApplyT(func(v *MetadataPostRenderer) map[string]string {
+		if v == nil {
+			return nil
+		}
+		return v.Annotations
+	}).(pulumi.StringMapOutput)
+}
+
+// Restricts the injection to objects of the given kinds. All objects are affected by default.
+func (o MetadataPostRendererPtrOutput) Kinds() pulumi.StringArrayOutput {
+	return o.ApplyT(func(v *MetadataPostRenderer) []string {
+		if v == nil {
+			return nil
+		}
+		return v.Kinds
+	}).(pulumi.StringArrayOutput)
+}
+
+// Labels to inject.
+func (o MetadataPostRendererPtrOutput) Labels() pulumi.StringMapOutput {
+	return o.ApplyT(func(v *MetadataPostRenderer) map[string]string {
+		if v == nil {
+			return nil
+		}
+		return v.Labels
+	}).(pulumi.StringMapOutput)
+}
+
+// Replace existing labels and annotations with the same key. By default, the values set by the chart take precedence.
+func (o MetadataPostRendererPtrOutput) Overwrite() pulumi.BoolPtrOutput {
+	return o.ApplyT(func(v *MetadataPostRenderer) *bool {
+		if v == nil {
+			return nil
+		}
+		return v.Overwrite
+	}).(pulumi.BoolPtrOutput)
+}
+
 // Specification defining the post-renderer to use.
 type PostRenderer struct {
 	// Arguments to pass to the post-renderer command.
@@ -407,13 +1375,335 @@ func (o RepositoryOptsPtrOutput) Username() pulumi.StringPtrOutput {
 	}).(pulumi.StringPtrOutput)
 }
 
+// Selects objects by group, version, kind, name, namespace and labels. The fields that aren't set match any object.
+type Selector struct {
+	// The API group of the objects.
+	Group *string `pulumi:"group"`
+	// The kind of the objects.
+	Kind *string `pulumi:"kind"`
+	// A label selector that the objects must match, e.g. `app=nginx,tier!=cache`.
+	LabelSelector *string `pulumi:"labelSelector"`
+	// A glob pattern that the name of the objects must match, e.g. `*-psp`.
+	Name *string `pulumi:"name"`
+	// The namespace of the objects.
+	Namespace *string `pulumi:"namespace"`
+	// The API version of the objects.
+	Version *string `pulumi:"version"`
+}
+
+// SelectorInput is an input type that accepts SelectorArgs and SelectorOutput values.
+// You can construct a concrete instance of `SelectorInput` via:
+//
+//	SelectorArgs{...}
+type SelectorInput interface {
+	pulumi.Input
+
+	ToSelectorOutput() SelectorOutput
+	ToSelectorOutputWithContext(context.Context) SelectorOutput
+}
+
+// Selects objects by group, version, kind, name, namespace and labels. The fields that aren't set match any object.
+type SelectorArgs struct {
+	// The API group of the objects.
+	Group pulumi.StringPtrInput `pulumi:"group"`
+	// The kind of the objects.
+	Kind pulumi.StringPtrInput `pulumi:"kind"`
+	// A label selector that the objects must match, e.g. `app=nginx,tier!=cache`.
+	LabelSelector pulumi.StringPtrInput `pulumi:"labelSelector"`
+	// A glob pattern that the name of the objects must match, e.g. `*-psp`.
+	Name pulumi.StringPtrInput `pulumi:"name"`
+	// The namespace of the objects.
+	Namespace pulumi.StringPtrInput `pulumi:"namespace"`
+	// The API version of the objects.
+	Version pulumi.StringPtrInput `pulumi:"version"`
+}
+
+func (SelectorArgs) ElementType() reflect.Type {
+	return reflect.TypeOf((*Selector)(nil)).Elem()
+}
+
+func (i SelectorArgs) ToSelectorOutput() SelectorOutput {
+	return i.ToSelectorOutputWithContext(context.Background())
+}
+
+func (i SelectorArgs) ToSelectorOutputWithContext(ctx context.Context) SelectorOutput {
+	return pulumi.ToOutputWithContext(ctx, i).(SelectorOutput)
+}
+
+// SelectorArrayInput is an input type that accepts SelectorArray and SelectorArrayOutput values.
+// You can construct a concrete instance of `SelectorArrayInput` via:
+//
+//	SelectorArray{ SelectorArgs{...} }
+type SelectorArrayInput interface {
+	pulumi.Input
+
+	ToSelectorArrayOutput() SelectorArrayOutput
+	ToSelectorArrayOutputWithContext(context.Context) SelectorArrayOutput
+}
+
+type SelectorArray []SelectorInput
+
+func (SelectorArray) ElementType() reflect.Type {
+	return reflect.TypeOf((*[]Selector)(nil)).Elem()
+}
+
+func (i SelectorArray) ToSelectorArrayOutput() SelectorArrayOutput {
+	return i.ToSelectorArrayOutputWithContext(context.Background())
+}
+
+func (i SelectorArray) ToSelectorArrayOutputWithContext(ctx context.Context) SelectorArrayOutput {
+	return pulumi.ToOutputWithContext(ctx, i).(SelectorArrayOutput)
+}
+
+// Selects objects by group, version, kind, name, namespace and labels. The fields that aren't set match any object.
+type SelectorOutput struct{ *pulumi.OutputState }
+
+func (SelectorOutput) ElementType() reflect.Type {
+	return reflect.TypeOf((*Selector)(nil)).Elem()
+}
+
+func (o SelectorOutput) ToSelectorOutput() SelectorOutput {
+	return o
+}
+
+func (o SelectorOutput) ToSelectorOutputWithContext(ctx context.Context) SelectorOutput {
+	return o
+}
+
+// The API group of the objects.
+func (o SelectorOutput) Group() pulumi.StringPtrOutput {
+	return o.ApplyT(func(v Selector) *string { return v.Group }).(pulumi.StringPtrOutput)
+}
+
+// The kind of the objects.
+func (o SelectorOutput) Kind() pulumi.StringPtrOutput {
+	return o.ApplyT(func(v Selector) *string { return v.Kind }).(pulumi.StringPtrOutput)
+}
+
+// A label selector that the objects must match, e.g. `app=nginx,tier!=cache`.
+func (o SelectorOutput) LabelSelector() pulumi.StringPtrOutput {
+	return o.ApplyT(func(v Selector) *string { return v.LabelSelector }).(pulumi.StringPtrOutput)
+}
+
+// A glob pattern that the name of the objects must match, e.g. `*-psp`.
+func (o SelectorOutput) Name() pulumi.StringPtrOutput {
+	return o.ApplyT(func(v Selector) *string { return v.Name }).(pulumi.StringPtrOutput)
+}
+
+// The namespace of the objects.
+func (o SelectorOutput) Namespace() pulumi.StringPtrOutput {
+	return o.ApplyT(func(v Selector) *string { return v.Namespace }).(pulumi.StringPtrOutput)
+}
+
+// The API version of the objects.
+func (o SelectorOutput) Version() pulumi.StringPtrOutput {
+	return o.ApplyT(func(v Selector) *string { return v.Version }).(pulumi.StringPtrOutput)
+}
+
+type SelectorArrayOutput struct{ *pulumi.OutputState }
+
+func (SelectorArrayOutput) ElementType() reflect.Type {
+	return reflect.TypeOf((*[]Selector)(nil)).Elem()
+}
+
+func (o SelectorArrayOutput) ToSelectorArrayOutput() SelectorArrayOutput {
+	return o
+}
+
+func (o SelectorArrayOutput) ToSelectorArrayOutputWithContext(ctx context.Context) SelectorArrayOutput {
+	return o
+}
+
+func (o SelectorArrayOutput) Index(i pulumi.IntInput) SelectorOutput {
+	return pulumi.All(o, i).ApplyT(func(vs []interface{}) Selector {
+		return vs[0].([]Selector)[vs[1].(int)]
+	}).(SelectorOutput)
+}
+
+// A reference to Helm values held in a ConfigMap or a Secret.
+type ValuesReference struct {
+	// The kind of the object holding the values: `ConfigMap` or `Secret`.
+	Kind string `pulumi:"kind"`
+	// The name of the object.
+	Name string `pulumi:"name"`
+	// The namespace of the object. Defaults to the release namespace.
+	Namespace *string `pulumi:"namespace"`
+	// Ignore the reference if the object or the key doesn't exist.
+	Optional *bool `pulumi:"optional"`
+	// The path (in `--set` notation, e.g. `auth.password`) at which to set the value of the key as a string. By default, the value of the key is a YAML document that is merged into the values.
+	TargetPath *string `pulumi:"targetPath"`
+	// The data key holding the values. Defaults to `values.yaml`.
+	ValuesKey *string `pulumi:"valuesKey"`
+}
+
+// ValuesReferenceInput is an input type that accepts ValuesReferenceArgs and ValuesReferenceOutput values.
+// You can construct a concrete instance of `ValuesReferenceInput` via:
+//
+//	ValuesReferenceArgs{...}
+type ValuesReferenceInput interface {
+	pulumi.Input
+
+	ToValuesReferenceOutput() ValuesReferenceOutput
+	ToValuesReferenceOutputWithContext(context.Context) ValuesReferenceOutput
+}
+
+// A reference to Helm values held in a ConfigMap or a Secret.
+type ValuesReferenceArgs struct {
+	// The kind of the object holding the values: `ConfigMap` or `Secret`.
+	Kind pulumi.StringInput `pulumi:"kind"`
+	// The name of the object.
+	Name pulumi.StringInput `pulumi:"name"`
+	// The namespace of the object. Defaults to the release namespace.
+	Namespace pulumi.StringPtrInput `pulumi:"namespace"`
+	// Ignore the reference if the object or the key doesn't exist.
+	Optional pulumi.BoolPtrInput `pulumi:"optional"`
+	// The path (in `--set` notation, e.g. `auth.password`) at which to set the value of the key as a string. By default, the value of the key is a YAML document that is merged into the values.
+	TargetPath pulumi.StringPtrInput `pulumi:"targetPath"`
+	// The data key holding the values. Defaults to `values.yaml`.
+	ValuesKey pulumi.StringPtrInput `pulumi:"valuesKey"`
+}
+
+func (ValuesReferenceArgs) ElementType() reflect.Type {
+	return reflect.TypeOf((*ValuesReference)(nil)).Elem()
+}
+
+func (i ValuesReferenceArgs) ToValuesReferenceOutput() ValuesReferenceOutput {
+	return i.ToValuesReferenceOutputWithContext(context.Background())
+}
+
+func (i ValuesReferenceArgs) ToValuesReferenceOutputWithContext(ctx context.Context) ValuesReferenceOutput {
+	return pulumi.ToOutputWithContext(ctx, i).(ValuesReferenceOutput)
+}
+
+// ValuesReferenceArrayInput is an input type that accepts ValuesReferenceArray and ValuesReferenceArrayOutput values.
+// You can construct a concrete instance of `ValuesReferenceArrayInput` via:
+//
+//	ValuesReferenceArray{ ValuesReferenceArgs{...} }
+type ValuesReferenceArrayInput interface {
+	pulumi.Input
+
+	ToValuesReferenceArrayOutput() ValuesReferenceArrayOutput
+	ToValuesReferenceArrayOutputWithContext(context.Context) ValuesReferenceArrayOutput
+}
+
+type ValuesReferenceArray []ValuesReferenceInput
+
+func (ValuesReferenceArray) ElementType() reflect.Type {
+	return reflect.TypeOf((*[]ValuesReference)(nil)).Elem()
+}
+
+func (i ValuesReferenceArray) ToValuesReferenceArrayOutput() ValuesReferenceArrayOutput {
+	return i.ToValuesReferenceArrayOutputWithContext(context.Background())
+}
+
+func (i ValuesReferenceArray) ToValuesReferenceArrayOutputWithContext(ctx context.Context) ValuesReferenceArrayOutput {
+	return pulumi.ToOutputWithContext(ctx, i).(ValuesReferenceArrayOutput)
+}
+
+// A reference to Helm values held in a ConfigMap or a Secret.
+type ValuesReferenceOutput struct{ *pulumi.OutputState }
+
+func (ValuesReferenceOutput) ElementType() reflect.Type {
+	return reflect.TypeOf((*ValuesReference)(nil)).Elem()
+}
+
+func (o ValuesReferenceOutput) ToValuesReferenceOutput() ValuesReferenceOutput {
+	return o
+}
+
+func (o ValuesReferenceOutput) ToValuesReferenceOutputWithContext(ctx context.Context) ValuesReferenceOutput {
+	return o
+}
+
+// The kind of the object holding the values: `ConfigMap` or `Secret`.
+func (o ValuesReferenceOutput) Kind() pulumi.StringOutput {
+	return o.ApplyT(func(v ValuesReference) string { return v.Kind }).(pulumi.StringOutput)
+}
+
+// The name of the object.
+func (o ValuesReferenceOutput) Name() pulumi.StringOutput {
+	return o.ApplyT(func(v ValuesReference) string { return v.Name }).(pulumi.StringOutput)
+}
+
+// The namespace of the object. Defaults to the release namespace.
+func (o ValuesReferenceOutput) Namespace() pulumi.StringPtrOutput {
+	return o.ApplyT(func(v ValuesReference) *string { return v.Namespace }).(pulumi.StringPtrOutput)
+}
+
+// Ignore the reference if the object or the key doesn't exist.
+func (o ValuesReferenceOutput) Optional() pulumi.BoolPtrOutput {
+	return o.ApplyT(func(v ValuesReference) *bool { return v.Optional }).(pulumi.BoolPtrOutput)
+}
+
+// The path (in `--set` notation, e.g. `auth.password`) at which to set the value of the key as a string. By default, the value of the key is a YAML document that is merged into the values.
+func (o ValuesReferenceOutput) TargetPath() pulumi.StringPtrOutput {
+	return o.ApplyT(func(v ValuesReference) *string { return v.TargetPath }).(pulumi.StringPtrOutput)
+}
+
+// The data key holding the values. Defaults to `values.yaml`.
+func (o ValuesReferenceOutput) ValuesKey() pulumi.StringPtrOutput {
+	return o.ApplyT(func(v ValuesReference) *string { return v.ValuesKey }).(pulumi.StringPtrOutput)
+}
+
+type ValuesReferenceArrayOutput struct{ *pulumi.OutputState }
+
+func (ValuesReferenceArrayOutput) ElementType() reflect.Type {
+	return reflect.TypeOf((*[]ValuesReference)(nil)).Elem()
+}
+
+func (o ValuesReferenceArrayOutput) ToValuesReferenceArrayOutput() ValuesReferenceArrayOutput {
+	return o
+}
+
+func (o ValuesReferenceArrayOutput) ToValuesReferenceArrayOutputWithContext(ctx context.Context) ValuesReferenceArrayOutput {
+	return o
+}
+
+func (o ValuesReferenceArrayOutput) Index(i pulumi.IntInput) ValuesReferenceOutput {
+	return pulumi.All(o, i).ApplyT(func(vs []interface{}) ValuesReference {
+		return vs[0].([]ValuesReference)[vs[1].(int)]
+	}).(ValuesReferenceOutput)
+}
+
 func init() {
+	pulumi.RegisterInputType(reflect.TypeOf((*BuiltinPostRendererInput)(nil)).Elem(), BuiltinPostRendererArgs{})
+	pulumi.RegisterInputType(reflect.TypeOf((*BuiltinPostRendererArrayInput)(nil)).Elem(), BuiltinPostRendererArray{})
+	pulumi.RegisterInputType(reflect.TypeOf((*ClusterLookupInput)(nil)).Elem(), ClusterLookupArgs{})
+	pulumi.RegisterInputType(reflect.TypeOf((*ClusterLookupPtrInput)(nil)).Elem(), ClusterLookupArgs{})
+	pulumi.RegisterInputType(reflect.TypeOf((*KustomizePatchInput)(nil)).Elem(), KustomizePatchArgs{})
+	pulumi.RegisterInputType(reflect.TypeOf((*KustomizePatchArrayInput)(nil)).Elem(), KustomizePatchArray{})
+	pulumi.RegisterInputType(reflect.TypeOf((*KustomizePatchTargetInput)(nil)).Elem(), KustomizePatchTargetArgs{})
+	pulumi.RegisterInputType(reflect.TypeOf((*KustomizePatchTargetPtrInput)(nil)).Elem(), KustomizePatchTargetArgs{})
+	pulumi.RegisterInputType(reflect.TypeOf((*KustomizePostRendererInput)(nil)).Elem(), KustomizePostRendererArgs{})
+	pulumi.RegisterInputType(reflect.TypeOf((*KustomizePostRendererPtrInput)(nil)).Elem(), KustomizePostRendererArgs{})
+	pulumi.RegisterInputType(reflect.TypeOf((*MetadataPostRendererInput)(nil)).Elem(), MetadataPostRendererArgs{})
+	pulumi.RegisterInputType(reflect.TypeOf((*MetadataPostRendererPtrInput)(nil)).Elem(), MetadataPostRendererArgs{})
 	pulumi.RegisterInputType(reflect.TypeOf((*PostRendererInput)(nil)).Elem(), PostRendererArgs{})
 	pulumi.RegisterInputType(reflect.TypeOf((*PostRendererPtrInput)(nil)).Elem(), PostRendererArgs{})
 	pulumi.RegisterInputType(reflect.TypeOf((*RepositoryOptsInput)(nil)).Elem(), RepositoryOptsArgs{})
 	pulumi.RegisterInputType(reflect.TypeOf((*RepositoryOptsPtrInput)(nil)).Elem(), RepositoryOptsArgs{})
+	pulumi.RegisterInputType(reflect.TypeOf((*SelectorInput)(nil)).Elem(), SelectorArgs{})
+	pulumi.RegisterInputType(reflect.TypeOf((*SelectorArrayInput)(nil)).Elem(), SelectorArray{})
+	pulumi.RegisterInputType(reflect.TypeOf((*ValuesReferenceInput)(nil)).Elem(), ValuesReferenceArgs{})
+	pulumi.RegisterInputType(reflect.TypeOf((*ValuesReferenceArrayInput)(nil)).Elem(), ValuesReferenceArray{})
+	pulumi.RegisterOutputType(BuiltinPostRendererOutput{})
+	pulumi.RegisterOutputType(BuiltinPostRendererArrayOutput{})
+	pulumi.RegisterOutputType(ClusterLookupOutput{})
+	pulumi.RegisterOutputType(ClusterLookupPtrOutput{})
+	pulumi.RegisterOutputType(KustomizePatchOutput{})
+	pulumi.RegisterOutputType(KustomizePatchArrayOutput{})
+	pulumi.RegisterOutputType(KustomizePatchTargetOutput{})
+	pulumi.RegisterOutputType(KustomizePatchTargetPtrOutput{})
+	pulumi.RegisterOutputType(KustomizePostRendererOutput{})
+	pulumi.RegisterOutputType(KustomizePostRendererPtrOutput{})
+	pulumi.RegisterOutputType(MetadataPostRendererOutput{})
+	pulumi.RegisterOutputType(MetadataPostRendererPtrOutput{})
 	pulumi.RegisterOutputType(PostRendererOutput{})
 	pulumi.RegisterOutputType(PostRendererPtrOutput{})
 	pulumi.RegisterOutputType(RepositoryOptsOutput{})
 	pulumi.RegisterOutputType(RepositoryOptsPtrOutput{})
+	pulumi.RegisterOutputType(SelectorOutput{})
+	pulumi.RegisterOutputType(SelectorArrayOutput{})
+	pulumi.RegisterOutputType(ValuesReferenceOutput{})
+	pulumi.RegisterOutputType(ValuesReferenceArrayOutput{})
 }
@@ -7,13 +7,40 @@ import (
 	"context"
 	"reflect"
 
-	"errors"
 	"github.com/pulumi/pulumi-kubernetes/sdk/v4/go/kubernetes/utilities"
 	"github.com/pulumi/pulumi/sdk/v3/go/pulumi"
 )
 
 // Directory is a component representing a collection of resources described by a kustomize directory (kustomization).
 //
+// ## Patches
+// The objects may be patched with `patches`, before they're registered, without a transform in the program. Each patch
+// is a strategic merge patch or a JSON 6902 patch, and its `target` selects the objects to patch by group, version,
+// kind, name, namespace, label selector or annotation selector. A strategic merge patch may omit the target, in which
+// case the patch itself identifies the object to patch.
+//
+// ## Selecting objects
+// The objects to register may be selected with `include` and `exclude`, e.g. to skip a Namespace that's managed
+// separately. Each selector matches objects by `group`, `version`, `kind`, `name` (a glob pattern), `namespace` and
+// `labelSelector`. An object is registered if it matches any of the included selectors (or if there are none), and none
+// of the excluded selectors. The skipped objects are reported in the `skipped` output.
+//
+// ## Dependency ordering
+// Pulumi uses heuristics to determine which order to apply and delete objects within the Directory, and supports the
+// `config.kubernetes.io/depends-on` annotation to declare an explicit dependency on a given resource.
+//
+// Workloads depend on the ConfigMaps and Secrets in the same namespace that their pods reference (by volumes, `envFrom`,
+// `env` or `imagePullSecrets`), so that the referenced configuration is created first. This includes the ConfigMaps and
+// Secrets generated by kustomize, whose names have a hash suffix.
+//
+// Admission webhook configurations depend on the Services that back them and on the workloads that those Services
+// select, and the objects that a webhook intercepts depend on the webhook configuration, so that the webhook is ready
+// before the objects are created. Webhooks whose `failurePolicy` is `Ignore` don't affect the ordering, and the objects
+// in the namespace of the webhook's Service are assumed to be part of the webhook's installation.
+//
+// A `config.kubernetes.io/depends-on` reference to a resource outside the Directory is assumed to exist in the cluster
+// already, and isn't waited for.
+//
 // ## Example Usage
 // ### Local Kustomize Directory
 // ```go
@@ -74,18 +101,17 @@ type Directory struct {
 
 	// Resources created by the Directory resource.
 	Resources pulumi.ArrayOutput `pulumi:"resources"`
+	// The objects of the Directory that weren't registered because of the `include` and `exclude` selectors, e.g. `v1/Namespace:my-namespace`.
+	Skipped pulumi.StringArrayOutput `pulumi:"skipped"`
 }
 
 // NewDirectory registers a new resource with the given unique name, arguments, and options.
 func NewDirectory(ctx *pulumi.Context,
 	name string, args *DirectoryArgs, opts ...pulumi.ResourceOption) (*Directory, error) {
 	if args == nil {
-		return nil, errors.New("missing one or more required arguments")
+		args = &DirectoryArgs{}
 	}
 
-	if args.Directory == nil {
-		return nil, errors.New("invalid value for required argument 'Directory'")
-	}
 	opts = utilities.PkgResourceDefaultOpts(opts)
 	var resource Directory
 	err := ctx.RegisterRemoteComponentResource("kubernetes:kustomize/v2:Directory", name, args, &resource, opts...)
@@ -100,9 +126,30 @@ type directoryArgs struct {
 	// git repository.
 	// Example: ./helloWorld
 	// Example: https://github.com/kubernetes-sigs/kustomize/tree/master/examples/helloWorld
-	Directory string `pulumi:"directory"`
+	// When `kustomization` or `files` is set, the directory is a path within the inline files, and defaults to the root of the files.
+	Directory *string `pulumi:"directory"`
+	// Enables kustomize's alpha plugins (e.g. KRM functions). Defaults to `true`.
+	EnableAlphaPlugins *bool `pulumi:"enableAlphaPlugins"`
+	// Enables the inflation of the `helmCharts` of the kustomizations. Defaults to `true`.
+	EnableHelm *bool `pulumi:"enableHelm"`
+	// Selects the objects not to register, among the included objects. The skipped objects are reported in the `skipped` output.
+	Exclude []Selector `pulumi:"exclude"`
+	// Additional files for an inline kustomization (e.g. patches, generator inputs and other kustomizations), keyed by their paths relative to the root of the files. The files are materialised in memory, so the kustomization can't refer to files on disk; remote bases and Helm charts from repositories may be used.
+	Files map[string]string `pulumi:"files"`
+	// The helm binary with which kustomize inflates the `helmCharts` of the kustomizations. By default, the charts are inflated in-process, using the Helm settings of the provider.
+	HelmCommand *string `pulumi:"helmCommand"`
+	// Selects the objects to register. An object is registered if it matches any of the selectors; by default, all objects are registered.
+	Include []Selector `pulumi:"include"`
+	// An inline kustomization (the content of a `kustomization.yaml` file) to apply instead of a kustomization on disk. It is materialised in memory, together with the `files`, in the `directory`.
+	Kustomization map[string]interface{} `pulumi:"kustomization"`
+	// The load restrictions of kustomize, either `LoadRestrictionsNone` or `LoadRestrictionsRootOnly`. With `LoadRestrictionsRootOnly`, the files of a kustomization (including the values files of its Helm charts) must be in or below its directory. Defaults to `LoadRestrictionsNone`.
+	LoadRestrictor *string `pulumi:"loadRestrictor"`
 	// The default namespace to apply to the resources. Defaults to the provider's namespace.
 	Namespace *string `pulumi:"namespace"`
+	// Patches to apply to the objects that the kustomization produces before they're registered, in order. The patches are applied in-process, with kustomize.
+	Patches []Patch `pulumi:"patches"`
+	// Options for fetching the remote git bases of the kustomizations.
+	RemoteBases *RemoteBasesOpts `pulumi:"remoteBases"`
 	// A prefix for the auto-generated resource names. Defaults to the name of the Directory resource. Example: A resource created with resourcePrefix="foo" would produce a resource named "foo:resourceName".
 	ResourcePrefix *string `pulumi:"resourcePrefix"`
 	// Indicates that child resources should skip the await logic. Defaults to `false`.
@@ -115,9 +162,30 @@ type DirectoryArgs struct {
 	// git repository.
 	// Example: ./helloWorld
 	// Example: https://github.com/kubernetes-sigs/kustomize/tree/master/examples/helloWorld
-	Directory pulumi.StringInput
+	// When `kustomization` or `files` is set, the directory is a path within the inline files, and defaults to the root of the files.
+	Directory pulumi.StringPtrInput
+	// Enables kustomize's alpha plugins (e.g. KRM functions). Defaults to `true`.
+	EnableAlphaPlugins pulumi.BoolPtrInput
+	// Enables the inflation of the `helmCharts` of the kustomizations. Defaults to `true`.
+	EnableHelm pulumi.BoolPtrInput
+	// Selects the objects not to register, among the included objects. The skipped objects are reported in the `skipped` output.
+	Exclude SelectorArrayInput
+	// Additional files for an inline kustomization (e.g. patches, generator inputs and other kustomizations), keyed by their paths relative to the root of the files. The files are materialised in memory, so the kustomization can't refer to files on disk; remote bases and Helm charts from repositories may be used.
+	Files pulumi.StringMapInput
+	// The helm binary with which kustomize inflates the `helmCharts` of the kustomizations. By default, the charts are inflated in-process, using the Helm settings of the provider.
+	HelmCommand pulumi.StringPtrInput
+	// Selects the objects to register. An object is registered if it matches any of the selectors; by default, all objects are registered.
+	Include SelectorArrayInput
+	// An inline kustomization (the content of a `kustomization.yaml` file) to apply instead of a kustomization on disk. It is materialised in memory, together with the `files`, in the `directory`.
+	Kustomization pulumi.MapInput
+	// The load restrictions of kustomize, either `LoadRestrictionsNone` or `LoadRestrictionsRootOnly`. With `LoadRestrictionsRootOnly`, the files of a kustomization (including the values files of its Helm charts) must be in or below its directory. Defaults to `LoadRestrictionsNone`.
+	LoadRestrictor pulumi.StringPtrInput
 	// The default namespace to apply to the resources. Defaults to the provider's namespace.
 	Namespace pulumi.StringPtrInput
+	// Patches to apply to the objects that the kustomization produces before they're registered, in order. The patches are applied in-process, with kustomize.
+	Patches PatchArrayInput
+	// Options for fetching the remote git bases of the kustomizations.
+	RemoteBases RemoteBasesOptsPtrInput
 	// A prefix for the auto-generated resource names. Defaults to the name of the Directory resource. Example: A resource created with resourcePrefix="foo" would produce a resource named "foo:resourceName".
 	ResourcePrefix pulumi.StringPtrInput
 	// Indicates that child resources should skip the await logic. Defaults to `false`.
@@ -216,6 +284,11 @@ func (o DirectoryOutput) Resources() pulumi.ArrayOutput {
 	return o.ApplyT(func(v *Directory) pulumi.ArrayOutput { return v.Resources }).(pulumi.ArrayOutput)
 }
 
+// The objects of the Directory that weren't registered because of the `include` and `exclude` selectors, e.g. `v1/Namespace:my-namespace`.
+func (o DirectoryOutput) Skipped() pulumi.StringArrayOutput {
+	return o.ApplyT(func(v *Directory) pulumi.StringArrayOutput { return v.Skipped }).(pulumi.StringArrayOutput)
+}
+
 type DirectoryArrayOutput struct{ *pulumi.OutputState }
 
 func (DirectoryArrayOutput) ElementType() reflect.Type {
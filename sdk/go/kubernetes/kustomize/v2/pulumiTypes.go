@@ -0,0 +1,700 @@
+// Code generated by pulumigen DO NOT EDIT.
+// *** WARNING: Do not edit by hand unless you're certain you know what you are doing! ***
+
+package v2
+
+import (
+	"context"
+	"reflect"
+
+	"github.com/pulumi/pulumi-kubernetes/sdk/v4/go/kubernetes/utilities"
+	"github.com/pulumi/pulumi/sdk/v3/go/pulumi"
+)
+
+var _ = utilities.GetEnvOrDefault
+
+// A patch to apply to the objects of the component before they're registered, either a strategic merge patch or a JSON 6902 patch.
+type Patch struct {
+	// The content of the patch, in YAML or JSON.
+	Patch string `pulumi:"patch"`
+	// Selects the objects to patch. A strategic merge patch may omit the target, in which case the patch itself identifies the object to patch.
+	Target *PatchTarget `pulumi:"target"`
+}
+
+// PatchInput is an input type that accepts PatchArgs and PatchOutput values.
+// You can construct a concrete instance of `PatchInput` via:
+//
+//	PatchArgs{...}
+type PatchInput interface {
+	pulumi.Input
+
+	ToPatchOutput() PatchOutput
+	ToPatchOutputWithContext(context.Context) PatchOutput
+}
+
+// A patch to apply to the objects of the component before they're registered, either a strategic merge patch or a JSON 6902 patch.
+type PatchArgs struct {
+	// The content of the patch, in YAML or JSON.
+	Patch pulumi.StringInput `pulumi:"patch"`
+	// Selects the objects to patch. A strategic merge patch may omit the target, in which case the patch itself identifies the object to patch.
+	Target PatchTargetPtrInput `pulumi:"target"`
+}
+
+func (PatchArgs) ElementType() reflect.Type {
+	return reflect.TypeOf((*Patch)(nil)).Elem()
+}
+
+func (i PatchArgs) ToPatchOutput() PatchOutput {
+	return i.ToPatchOutputWithContext(context.Background())
+}
+
+func (i PatchArgs) ToPatchOutputWithContext(ctx context.Context) PatchOutput {
+	return pulumi.ToOutputWithContext(ctx, i).(PatchOutput)
+}
+
+// PatchArrayInput is an input type that accepts PatchArray and PatchArrayOutput values.
+// You can construct a concrete instance of `PatchArrayInput` via:
+//
+//	PatchArray{ PatchArgs{...} }
+type PatchArrayInput interface {
+	pulumi.Input
+
+	ToPatchArrayOutput() PatchArrayOutput
+	ToPatchArrayOutputWithContext(context.Context) PatchArrayOutput
+}
+
+type PatchArray []PatchInput
+
+func (PatchArray) ElementType() reflect.Type {
+	return reflect.TypeOf((*[]Patch)(nil)).Elem()
+}
+
+func (i PatchArray) ToPatchArrayOutput() PatchArrayOutput {
+	return i.ToPatchArrayOutputWithContext(context.Background())
+}
+
+func (i PatchArray) ToPatchArrayOutputWithContext(ctx context.Context) PatchArrayOutput {
+	return pulumi.ToOutputWithContext(ctx, i).(PatchArrayOutput)
+}
+
+// A patch to apply to the objects of the component before they're registered, either a strategic merge patch or a JSON 6902 patch.
+type PatchOutput struct{ *pulumi.OutputState }
+
+func (PatchOutput) ElementType() reflect.Type {
+	return reflect.TypeOf((*Patch)(nil)).Elem()
+}
+
+func (o PatchOutput) ToPatchOutput() PatchOutput {
+	return o
+}
+
+func (o PatchOutput) ToPatchOutputWithContext(ctx context.Context) PatchOutput {
+	return o
+}
+
+// The content of the patch, in YAML or JSON.
+func (o PatchOutput) Patch() pulumi.StringOutput {
+	return o.ApplyT(func(v Patch) string { return v.Patch }).(pulumi.StringOutput)
+}
+
+// Selects the objects to patch. A strategic merge patch may omit the target, in which case the patch itself identifies the object to patch.
+func (o PatchOutput) Target() PatchTargetPtrOutput {
+	return o.ApplyT(func(v Patch) *PatchTarget { return v.Target }).(PatchTargetPtrOutput)
+}
+
+type PatchArrayOutput struct{ *pulumi.OutputState }
+
+func (PatchArrayOutput) ElementType() reflect.Type {
+	return reflect.TypeOf((*[]Patch)(nil)).Elem()
+}
+
+func (o PatchArrayOutput) ToPatchArrayOutput() PatchArrayOutput {
+	return o
+}
+
+func (o PatchArrayOutput) ToPatchArrayOutputWithContext(ctx context.Context) PatchArrayOutput {
+	return o
+}
+
+func (o PatchArrayOutput) Index(i pulumi.IntInput) PatchOutput {
+	return pulumi.All(o, i).ApplyT(func(vs []interface{}) Patch {
+		return vs[0].([]Patch)[vs[1].(int)]
+	}).(PatchOutput)
+}
+
+// Selects the objects that a patch applies to, by kind, name, namespace, labels and annotations.
+type PatchTarget struct {
+	// An annotation selector that the objects must match.
+	AnnotationSelector *string `pulumi:"annotationSelector"`
+	// The API group of the objects.
+	Group *string `pulumi:"group"`
+	// The kind of the objects.
+	Kind *string `pulumi:"kind"`
+	// A label selector that the objects must match.
+	LabelSelector *string `pulumi:"labelSelector"`
+	// The name of the objects (a regular expression).
+	Name *string `pulumi:"name"`
+	// The namespace of the objects.
+	Namespace *string `pulumi:"namespace"`
+	// The API version of the objects.
+	Version *string `pulumi:"version"`
+}
+
+// PatchTargetInput is an input type that accepts PatchTargetArgs and PatchTargetOutput values.
+// You can construct a concrete instance of `PatchTargetInput` via:
+//
+//	PatchTargetArgs{...}
+type PatchTargetInput interface {
+	pulumi.Input
+
+	ToPatchTargetOutput() PatchTargetOutput
+	ToPatchTargetOutputWithContext(context.Context) PatchTargetOutput
+}
+
+// Selects the objects that a patch applies to, by kind, name, namespace, labels and annotations.
+type PatchTargetArgs struct {
+	// An annotation selector that the objects must match.
+	AnnotationSelector pulumi.StringPtrInput `pulumi:"annotationSelector"`
+	// The API group of the objects.
+	Group pulumi.StringPtrInput `pulumi:"group"`
+	// The kind of the objects.
+	Kind pulumi.StringPtrInput `pulumi:"kind"`
+	// A label selector that the objects must match.
+	LabelSelector pulumi.StringPtrInput `pulumi:"labelSelector"`
+	// The name of the objects (a regular expression).
+	Name pulumi.StringPtrInput `pulumi:"name"`
+	// The namespace of the objects.
+	Namespace pulumi.StringPtrInput `pulumi:"namespace"`
+	// The API version of the objects.
+	Version pulumi.StringPtrInput `pulumi:"version"`
+}
+
+func (PatchTargetArgs) ElementType() reflect.Type {
+	return reflect.TypeOf((*PatchTarget)(nil)).Elem()
+}
+
+func (i PatchTargetArgs) ToPatchTargetOutput() PatchTargetOutput {
+	return i.ToPatchTargetOutputWithContext(context.Background())
+}
+
+func (i PatchTargetArgs) ToPatchTargetOutputWithContext(ctx context.Context) PatchTargetOutput {
+	return pulumi.ToOutputWithContext(ctx, i).(PatchTargetOutput)
+}
+
+func (i PatchTargetArgs) ToPatchTargetPtrOutput() PatchTargetPtrOutput {
+	return i.ToPatchTargetPtrOutputWithContext(context.Background())
+}
+
+func (i PatchTargetArgs) ToPatchTargetPtrOutputWithContext(ctx context.Context) PatchTargetPtrOutput {
+	return pulumi.ToOutputWithContext(ctx, i).(PatchTargetOutput).ToPatchTargetPtrOutputWithContext(ctx)
+}
+
+// PatchTargetPtrInput is an input type that accepts PatchTargetArgs, PatchTargetPtr and PatchTargetPtrOutput values.
+// You can construct a concrete instance of `PatchTargetPtrInput` via:
+//
+//	        PatchTargetArgs{...}
+//
+//	or:
+//
+//	        nil
+type PatchTargetPtrInput interface {
+	pulumi.Input
+
+	ToPatchTargetPtrOutput() PatchTargetPtrOutput
+	ToPatchTargetPtrOutputWithContext(context.Context) PatchTargetPtrOutput
+}
+
+type patchTargetPtrType PatchTargetArgs
+
+func PatchTargetPtr(v *PatchTargetArgs) PatchTargetPtrInput {
+	return (*patchTargetPtrType)(v)
+}
+
+func (*patchTargetPtrType) ElementType() reflect.Type {
+	return reflect.TypeOf((**PatchTarget)(nil)).Elem()
+}
+
+func (i *patchTargetPtrType) ToPatchTargetPtrOutput() PatchTargetPtrOutput {
+	return i.ToPatchTargetPtrOutputWithContext(context.Background())
+}
+
+func (i *patchTargetPtrType) ToPatchTargetPtrOutputWithContext(ctx context.Context) PatchTargetPtrOutput {
+	return pulumi.ToOutputWithContext(ctx, i).(PatchTargetPtrOutput)
+}
+
+// Selects the objects that a patch applies to, by kind, name, namespace, labels and annotations.
+type PatchTargetOutput struct{ *pulumi.OutputState }
+
+func (PatchTargetOutput) ElementType() reflect.Type {
+	return reflect.TypeOf((*PatchTarget)(nil)).Elem()
+}
+
+func (o PatchTargetOutput) ToPatchTargetOutput() PatchTargetOutput {
+	return o
+}
+
+func (o PatchTargetOutput) ToPatchTargetOutputWithContext(ctx context.Context) PatchTargetOutput {
+	return o
+}
+
+func (o PatchTargetOutput) ToPatchTargetPtrOutput() PatchTargetPtrOutput {
+	return o.ToPatchTargetPtrOutputWithContext(context.Background())
+}
+
+func (o PatchTargetOutput) ToPatchTargetPtrOutputWithContext(ctx context.Context) PatchTargetPtrOutput {
+	return o.ApplyTWithContext(ctx, func(_ context.Context, v PatchTarget) *PatchTarget {
+		return &v
+	}).(PatchTargetPtrOutput)
+}
+
+// An annotation selector that the objects must match.
+func (o PatchTargetOutput) AnnotationSelector() pulumi.StringPtrOutput {
+	return o.ApplyT(func(v PatchTarget) *string { return v.AnnotationSelector }).(pulumi.StringPtrOutput)
+}
+
+// The API group of the objects.
+func (o PatchTargetOutput) Group() pulumi.StringPtrOutput {
+	return o.ApplyT(func(v PatchTarget) *string { return v.Group }).(pulumi.StringPtrOutput)
+}
+
+// The kind of the objects.
+func (o PatchTargetOutput) Kind() pulumi.StringPtrOutput {
+	return o.ApplyT(func(v PatchTarget) *string { return v.Kind }).(pulumi.StringPtrOutput)
+}
+
+// A label selector that the objects must match.
+func (o PatchTargetOutput) LabelSelector() pulumi.StringPtrOutput {
+	return o.ApplyT(func(v PatchTarget) *string { return v.LabelSelector }).(pulumi.StringPtrOutput)
+}
+
+// The name of the objects (a regular expression).
+func (o PatchTargetOutput) Name() pulumi.StringPtrOutput {
+	return o.ApplyT(func(v PatchTarget) *string { return v.Name }).(pulumi.StringPtrOutput)
+}
+
+// The namespace of the objects.
+func (o PatchTargetOutput) Namespace() pulumi.StringPtrOutput {
+	return o.ApplyT(func(v PatchTarget) *string { return v.Namespace }).(pulumi.StringPtrOutput)
+}
+
+// The API version of the objects.
+func (o PatchTargetOutput) Version() pulumi.StringPtrOutput {
+	return o.ApplyT(func(v PatchTarget) *string { return v.Version }).(pulumi.StringPtrOutput)
+}
+
+type PatchTargetPtrOutput struct{ *pulumi.OutputState }
+
+func (PatchTargetPtrOutput) ElementType() reflect.Type {
+	return reflect.TypeOf((**PatchTarget)(nil)).Elem()
+}
+
+func (o PatchTargetPtrOutput) ToPatchTargetPtrOutput() PatchTargetPtrOutput {
+	return o
+}
+
+func (o PatchTargetPtrOutput) ToPatchTargetPtrOutputWithContext(ctx context.Context) PatchTargetPtrOutput {
+	return o
+}
+
+func (o PatchTargetPtrOutput) Elem() PatchTargetOutput {
+	return o.ApplyT(func(v *PatchTarget) PatchTarget {
+		if v != nil {
+			return *v
+		}
+		var ret PatchTarget
+		return ret
+	}).(PatchTargetOutput)
+}
+
+// An annotation selector that the objects must match.
+func (o PatchTargetPtrOutput) AnnotationSelector() pulumi.StringPtrOutput {
+	return o.ApplyT(func(v *PatchTarget) *string {
+		if v == nil {
+			return nil
+		}
+		return v.AnnotationSelector
+	}).(pulumi.StringPtrOutput)
+}
+
+// The API group of the objects.
+func (o PatchTargetPtrOutput) Group() pulumi.StringPtrOutput {
+	return o.ApplyT(func(v *PatchTarget) *string {
+		if v == nil {
+			return nil
+		}
+		return v.Group
+	}).(pulumi.StringPtrOutput)
+}
+
+// The kind of the objects.
+func (o PatchTargetPtrOutput) Kind() pulumi.StringPtrOutput {
+	return o.ApplyT(func(v *PatchTarget) *string {
+		if v == nil {
+			return nil
+		}
+		return v.Kind
+	}).(pulumi.StringPtrOutput)
+}
+
+// A label selector that the objects must match.
+func (o PatchTargetPtrOutput) LabelSelector() pulumi.StringPtrOutput {
+	return o.ApplyT(func(v *PatchTarget) *string {
+		if v == nil {
+			return nil
+		}
+		return v.LabelSelector
+	}).(pulumi.StringPtrOutput)
+}
+
+// The name of the objects (a regular expression).
+func (o PatchTargetPtrOutput) Name() pulumi.StringPtrOutput {
+	return o.ApplyT(func(v *PatchTarget) *string {
+		if v == nil {
+			return nil
+		}
+		return v.Name
+	}).(pulumi.StringPtrOutput)
+}
+
+// The namespace of the objects.
+func (o PatchTargetPtrOutput) Namespace() pulumi.StringPtrOutput {
+	return o.ApplyT(func(v *PatchTarget) *string {
+		if v == nil {
+			return nil
+		}
+		return v.Namespace
+	}).(pulumi.StringPtrOutput)
+}
+
+// The API version of the objects.
+func (o PatchTargetPtrOutput) Version() pulumi.StringPtrOutput {
+	return o.ApplyT(func(v *PatchTarget) *string {
+		if v == nil {
+			return nil
+		}
+		return v.Version
+	}).(pulumi.StringPtrOutput)
+}
+
+// Options for fetching the remote git bases of kustomizations.
+type RemoteBasesOpts struct {
+	// Disables the cache of remote bases, such that kustomize clones them for every build. By default, a repository is cloned once per commit into the user's cache directory, and refs are resolved to commits once per deployment. Defaults to `false`.
+	DisableCache *bool `pulumi:"disableCache"`
+	// Requires the remote bases to be pinned to a ref (e.g. `?ref=v1.0.0`). Defaults to `false`.
+	RequirePinnedRefs *bool `pulumi:"requirePinnedRefs"`
+}
+
+// RemoteBasesOptsInput is an input type that accepts RemoteBasesOptsArgs and RemoteBasesOptsOutput values.
+// You can construct a concrete instance of `RemoteBasesOptsInput` via:
+//
+//	RemoteBasesOptsArgs{...}
+type RemoteBasesOptsInput interface {
+	pulumi.Input
+
+	ToRemoteBasesOptsOutput() RemoteBasesOptsOutput
+	ToRemoteBasesOptsOutputWithContext(context.Context) RemoteBasesOptsOutput
+}
+
+// Options for fetching the remote git bases of kustomizations.
+type RemoteBasesOptsArgs struct {
+	// Disables the cache of remote bases, such that kustomize clones them for every build. By default, a repository is cloned once per commit into the user's cache directory, and refs are resolved to commits once per deployment. Defaults to `false`.
+	DisableCache pulumi.BoolPtrInput `pulumi:"disableCache"`
+	// Requires the remote bases to be pinned to a ref (e.g. `?ref=v1.0.0`). Defaults to `false`.
+	RequirePinnedRefs pulumi.BoolPtrInput `pulumi:"requirePinnedRefs"`
+}
+
+func (RemoteBasesOptsArgs) ElementType() reflect.Type {
+	return reflect.TypeOf((*RemoteBasesOpts)(nil)).Elem()
+}
+
+func (i RemoteBasesOptsArgs) ToRemoteBasesOptsOutput() RemoteBasesOptsOutput {
+	return i.ToRemoteBasesOptsOutputWithContext(context.Background())
+}
+
+func (i RemoteBasesOptsArgs) ToRemoteBasesOptsOutputWithContext(ctx context.Context) RemoteBasesOptsOutput {
+	return pulumi.ToOutputWithContext(ctx, i).(RemoteBasesOptsOutput)
+}
+
+func (i RemoteBasesOptsArgs) ToRemoteBasesOptsPtrOutput() RemoteBasesOptsPtrOutput {
+	return i.ToRemoteBasesOptsPtrOutputWithContext(context.Background())
+}
+
+func (i RemoteBasesOptsArgs) ToRemoteBasesOptsPtrOutputWithContext(ctx context.Context) RemoteBasesOptsPtrOutput {
+	return pulumi.ToOutputWithContext(ctx, i).(RemoteBasesOptsOutput).ToRemoteBasesOptsPtrOutputWithContext(ctx)
+}
+
+// RemoteBasesOptsPtrInput is an input type that accepts RemoteBasesOptsArgs, RemoteBasesOptsPtr and RemoteBasesOptsPtrOutput values.
+// You can construct a concrete instance of `RemoteBasesOptsPtrInput` via:
+//
+//	        RemoteBasesOptsArgs{...}
+//
+//	or:
+//
+//	        nil
+type RemoteBasesOptsPtrInput interface {
+	pulumi.Input
+
+	ToRemoteBasesOptsPtrOutput() RemoteBasesOptsPtrOutput
+	ToRemoteBasesOptsPtrOutputWithContext(context.Context) RemoteBasesOptsPtrOutput
+}
+
+type remoteBasesOptsPtrType RemoteBasesOptsArgs
+
+func RemoteBasesOptsPtr(v *RemoteBasesOptsArgs) RemoteBasesOptsPtrInput {
+	return (*remoteBasesOptsPtrType)(v)
+}
+
+func (*remoteBasesOptsPtrType) ElementType() reflect.Type {
+	return reflect.TypeOf((**RemoteBasesOpts)(nil)).Elem()
+}
+
+func (i *remoteBasesOptsPtrType) ToRemoteBasesOptsPtrOutput() RemoteBasesOptsPtrOutput {
+	return i.ToRemoteBasesOptsPtrOutputWithContext(context.Background())
+}
+
+func (i *remoteBasesOptsPtrType) ToRemoteBasesOptsPtrOutputWithContext(ctx context.Context) RemoteBasesOptsPtrOutput {
+	return pulumi.ToOutputWithContext(ctx, i).(RemoteBasesOptsPtrOutput)
+}
+
+// Options for fetching the remote git bases of kustomizations.
+type RemoteBasesOptsOutput struct{ *pulumi.OutputState }
+
+func (RemoteBasesOptsOutput) ElementType() reflect.Type {
+	return reflect.TypeOf((*RemoteBasesOpts)(nil)).Elem()
+}
+
+func (o RemoteBasesOptsOutput) ToRemoteBasesOptsOutput() RemoteBasesOptsOutput {
+	return o
+}
+
+func (o RemoteBasesOptsOutput) ToRemoteBasesOptsOutputWithContext(ctx context.Context) RemoteBasesOptsOutput {
+	return o
+}
+
+func (o RemoteBasesOptsOutput) ToRemoteBasesOptsPtrOutput() RemoteBasesOptsPtrOutput {
+	return o.ToRemoteBasesOptsPtrOutputWithContext(context.Background())
+}
+
+func (o RemoteBasesOptsOutput) ToRemoteBasesOptsPtrOutputWithContext(ctx context.Context) RemoteBasesOptsPtrOutput {
+	return o.ApplyTWithContext(ctx, func(_ context.Context, v RemoteBasesOpts) *RemoteBasesOpts {
+		return &v
+	}).(RemoteBasesOptsPtrOutput)
+}
+
+// Disables the cache of remote bases, such that kustomize clones them for every build. By default, a repository is cloned once per commit into the user's cache directory, and refs are resolved to commits once per deployment. Defaults to `false`.
+func (o RemoteBasesOptsOutput) DisableCache() pulumi.BoolPtrOutput {
+	return o.ApplyT(func(v RemoteBasesOpts) *bool { return v.DisableCache }).(pulumi.BoolPtrOutput)
+}
+
+// Requires the remote bases to be pinned to a ref (e.g. `?ref=v1.0.0`). Defaults to `false`.
+func (o RemoteBasesOptsOutput) RequirePinnedRefs() pulumi.BoolPtrOutput {
+	return o.ApplyT(func(v RemoteBasesOpts) *bool { return v.RequirePinnedRefs }).(pulumi.BoolPtrOutput)
+}
+
+type RemoteBasesOptsPtrOutput struct{ *pulumi.OutputState }
+
+func (RemoteBasesOptsPtrOutput) ElementType() reflect.Type {
+	return reflect.TypeOf((**RemoteBasesOpts)(nil)).Elem()
+}
+
+func (o RemoteBasesOptsPtrOutput) ToRemoteBasesOptsPtrOutput() RemoteBasesOptsPtrOutput {
+	return o
+}
+
+func (o RemoteBasesOptsPtrOutput) ToRemoteBasesOptsPtrOutputWithContext(ctx context.Context) RemoteBasesOptsPtrOutput {
+	return o
+}
+
+func (o RemoteBasesOptsPtrOutput) Elem() RemoteBasesOptsOutput {
+	return o.ApplyT(func(v *RemoteBasesOpts) RemoteBasesOpts {
+		if v != nil {
+			return *v
+		}
+		var ret RemoteBasesOpts
+		return ret
+	}).(RemoteBasesOptsOutput)
+}
+
+// Disables the cache of remote bases, such that kustomize clones them for every build. By default, a repository is cloned once per commit into the user's cache directory, and refs are resolved to commits once per deployment. Defaults to `false`.
+func (o RemoteBasesOptsPtrOutput) DisableCache() pulumi.BoolPtrOutput {
+	return o.ApplyT(func(v *RemoteBasesOpts) *bool {
+		if v == nil {
+			return nil
+		}
+		return v.DisableCache
+	}).(pulumi.BoolPtrOutput)
+}
+
+// Requires the remote bases to be pinned to a ref (e.g. `?ref=v1.0.0`). Defaults to `false`.
+func (o RemoteBasesOptsPtrOutput) RequirePinnedRefs() pulumi.BoolPtrOutput {
+	return o.ApplyT(func(v *RemoteBasesOpts) *bool {
+		if v == nil {
+			return nil
+		}
+		return v.RequirePinnedRefs
+	}).(pulumi.BoolPtrOutput)
+}
+
+// Selects objects by group, version, kind, name, namespace and labels. The fields that aren't set match any object.
+type Selector struct {
+	// The API group of the objects.
+	Group *string `pulumi:"group"`
+	// The kind of the objects.
+	Kind *string `pulumi:"kind"`
+	// A label selector that the objects must match, e.g. `app=nginx,tier!=cache`.
+	LabelSelector *string `pulumi:"labelSelector"`
+	// A glob pattern that the name of the objects must match, e.g. `*-psp`.
+	Name *string `pulumi:"name"`
+	// The namespace of the objects.
+	Namespace *string `pulumi:"namespace"`
+	// The API version of the objects.
+	Version *string `pulumi:"version"`
+}
+
+// SelectorInput is an input type that accepts SelectorArgs and SelectorOutput values.
+// You can construct a concrete instance of `SelectorInput` via:
+//
+//	SelectorArgs{...}
+type SelectorInput interface {
+	pulumi.Input
+
+	ToSelectorOutput() SelectorOutput
+	ToSelectorOutputWithContext(context.Context) SelectorOutput
+}
+
+// Selects objects by group, version, kind, name, namespace and labels. The fields that aren't set match any object.
+type SelectorArgs struct {
+	// The API group of the objects.
+	Group pulumi.StringPtrInput `pulumi:"group"`
+	// The kind of the objects.
+	Kind pulumi.StringPtrInput `pulumi:"kind"`
+	// A label selector that the objects must match, e.g. `app=nginx,tier!=cache`.
+	LabelSelector pulumi.StringPtrInput `pulumi:"labelSelector"`
+	// A glob pattern that the name of the objects must match, e.g. `*-psp`.
+	Name pulumi.StringPtrInput `pulumi:"name"`
+	// The namespace of the objects.
+	Namespace pulumi.StringPtrInput `pulumi:"namespace"`
+	// The API version of the objects.
+	Version pulumi.StringPtrInput `pulumi:"version"`
+}
+
+func (SelectorArgs) ElementType() reflect.Type {
+	return reflect.TypeOf((*Selector)(nil)).Elem()
+}
+
+func (i SelectorArgs) ToSelectorOutput() SelectorOutput {
+	return i.ToSelectorOutputWithContext(context.Background())
+}
+
+func (i SelectorArgs) ToSelectorOutputWithContext(ctx context.Context) SelectorOutput {
+	return pulumi.ToOutputWithContext(ctx, i).(SelectorOutput)
+}
+
+// SelectorArrayInput is an input type that accepts SelectorArray and SelectorArrayOutput values.
+// You can construct a concrete instance of `SelectorArrayInput` via:
+//
+//	SelectorArray{ SelectorArgs{...} }
+type SelectorArrayInput interface {
+	pulumi.Input
+
+	ToSelectorArrayOutput() SelectorArrayOutput
+	ToSelectorArrayOutputWithContext(context.Context) SelectorArrayOutput
+}
+
+type SelectorArray []SelectorInput
+
+func (SelectorArray) ElementType() reflect.Type {
+	return reflect.TypeOf((*[]Selector)(nil)).Elem()
+}
+
+func (i SelectorArray) ToSelectorArrayOutput() SelectorArrayOutput {
+	return i.ToSelectorArrayOutputWithContext(context.Background())
+}
+
+func (i SelectorArray) ToSelectorArrayOutputWithContext(ctx context.Context) SelectorArrayOutput {
+	return pulumi.ToOutputWithContext(ctx, i).(SelectorArrayOutput)
+}
+
+// Selects objects by group, version, kind, name, namespace and labels. The fields that aren't set match any object.
+type SelectorOutput struct{ *pulumi.OutputState }
+
+func (SelectorOutput) ElementType() reflect.Type {
+	return reflect.TypeOf((*Selector)(nil)).Elem()
+}
+
+func (o SelectorOutput) ToSelectorOutput() SelectorOutput {
+	return o
+}
+
+func (o SelectorOutput) ToSelectorOutputWithContext(ctx context.Context) SelectorOutput {
+	return o
+}
+
+// The API group of the objects.
+func (o SelectorOutput) Group() pulumi.StringPtrOutput {
+	return o.ApplyT(func(v Selector) *string { return v.Group }).(pulumi.StringPtrOutput)
+}
+
+// The kind of the objects.
+func (o SelectorOutput) Kind() pulumi.StringPtrOutput {
+	return o.ApplyT(func(v Selector) *string { return v.Kind }).(pulumi.StringPtrOutput)
+}
+
+// A label selector that the objects must match, e.g. `app=nginx,tier!=cache`.
+func (o SelectorOutput) LabelSelector() pulumi.StringPtrOutput {
+	return o.ApplyT(func(v Selector) *string { return v.LabelSelector }).(pulumi.StringPtrOutput)
+}
+
+// A glob pattern that the name of the objects must match, e.g. `*-psp`.
+func (o SelectorOutput) Name() pulumi.StringPtrOutput {
+	return o.ApplyT(func(v Selector) *string { return v.Name }).(pulumi.StringPtrOutput)
+}
+
+// The namespace of the objects.
+func (o SelectorOutput) Namespace() pulumi.StringPtrOutput {
+	return o.ApplyT(func(v Selector) *string { return v.Namespace }).(pulumi.StringPtrOutput)
+}
+
+// The API version of the objects.
+func (o SelectorOutput) Version() pulumi.StringPtrOutput {
+	return o.ApplyT(func(v Selector) *string { return v.Version }).(pulumi.StringPtrOutput)
+}
+
+type SelectorArrayOutput struct{ *pulumi.OutputState }
+
+func (SelectorArrayOutput) ElementType() reflect.Type {
+	return reflect.TypeOf((*[]Selector)(nil)).Elem()
+}
+
+func (o SelectorArrayOutput) ToSelectorArrayOutput() SelectorArrayOutput {
+	return o
+}
+
+func (o SelectorArrayOutput) ToSelectorArrayOutputWithContext(ctx context.Context) SelectorArrayOutput {
+	return o
+}
+
+func (o SelectorArrayOutput) Index(i pulumi.IntInput) SelectorOutput {
+	return pulumi.All(o, i).ApplyT(func(vs []interface{}) Selector {
+		return vs[0].([]Selector)[vs[1].(int)]
+	}).(SelectorOutput)
+}
+
+func init() {
+	pulumi.RegisterInputType(reflect.TypeOf((*PatchInput)(nil)).Elem(), PatchArgs{})
+	pulumi.RegisterInputType(reflect.TypeOf((*PatchArrayInput)(nil)).Elem(), PatchArray{})
+	pulumi.RegisterInputType(reflect.TypeOf((*PatchTargetInput)(nil)).Elem(), PatchTargetArgs{})
+	pulumi.RegisterInputType(reflect.TypeOf((*PatchTargetPtrInput)(nil)).Elem(), PatchTargetArgs{})
+	pulumi.RegisterInputType(reflect.TypeOf((*RemoteBasesOptsInput)(nil)).Elem(), RemoteBasesOptsArgs{})
+	pulumi.RegisterInputType(reflect.TypeOf((*RemoteBasesOptsPtrInput)(nil)).Elem(), RemoteBasesOptsArgs{})
+	pulumi.RegisterInputType(reflect.TypeOf((*SelectorInput)(nil)).Elem(), SelectorArgs{})
+	pulumi.RegisterInputType(reflect.TypeOf((*SelectorArrayInput)(nil)).Elem(), SelectorArray{})
+	pulumi.RegisterOutputType(PatchOutput{})
+	pulumi.RegisterOutputType(PatchArrayOutput{})
+	pulumi.RegisterOutputType(PatchTargetOutput{})
+	pulumi.RegisterOutputType(PatchTargetPtrOutput{})
+	pulumi.RegisterOutputType(RemoteBasesOptsOutput{})
+	pulumi.RegisterOutputType(RemoteBasesOptsPtrOutput{})
+	pulumi.RegisterOutputType(SelectorOutput{})
+	pulumi.RegisterOutputType(SelectorArrayOutput{})
+}
@@ -59,6 +59,11 @@ func NewProvider(ctx *pulumi.Context,
 			args.Kubeconfig = pulumi.StringPtr(d.(string))
 		}
 	}
+	if args.ManifestCacheOffline == nil {
+		if d := utilities.GetEnvOrDefault(nil, utilities.ParseEnvBool, "PULUMI_K8S_MANIFEST_CACHE_OFFLINE"); d != nil {
+			args.ManifestCacheOffline = pulumi.BoolPtr(d.(bool))
+		}
+	}
 	if args.SkipUpdateUnreachable == nil {
 		if d := utilities.GetEnvOrDefault(nil, utilities.ParseEnvBool, "PULUMI_K8S_SKIP_UPDATE_UNREACHABLE"); d != nil {
 			args.SkipUpdateUnreachable = pulumi.BoolPtr(d.(bool))
@@ -134,6 +139,8 @@ type providerArgs struct {
 	KubeClientSettings *KubeClientSettings `pulumi:"kubeClientSettings"`
 	// The contents of a kubeconfig file or the path to a kubeconfig file.
 	Kubeconfig *string `pulumi:"kubeconfig"`
+	// If present and set to true, the manifests of `yaml/v2.ConfigGroup` and `yaml/v2.ConfigFile` that are given by URL are read from the on-disk manifest cache only, and never downloaded. A manifest that isn't cached is an error.
+	ManifestCacheOffline *bool `pulumi:"manifestCacheOffline"`
 	// If present, the default namespace to use. This flag is ignored for cluster-scoped resources.
 	//
 	// A namespace can be specified in multiple places, and the precedence is as follows:
@@ -141,6 +148,17 @@ type providerArgs struct {
 	// 2. This `namespace` parameter.
 	// 3. `namespace` set for the active context in the kubeconfig.
 	Namespace *string `pulumi:"namespace"`
+	// A comma-separated list of age recipients (e.g. `age1...`) to which to encrypt the Secrets rendered to the directory specified by renderYamlToDirectory. The `data` and `stringData` of the Secrets are encrypted with SOPS, such that the manifests may be committed to git and decrypted by SOPS or by Flux's kustomize-controller with the recipients' age keys. When the recipients change, the Secrets are rendered again for the new recipients. A Secret that is rendered again unchanged (e.g. with alwaysRender) keeps its ciphertext, so that its file doesn't change. Not supported with the `namespaces` renderYamlFormat, since `sops -d` can't decrypt a file of several SOPS documents. Only valid when renderYamlToDirectory is set.
+	RenderYamlAgeRecipients *string `pulumi:"renderYamlAgeRecipients"`
+	// The layout of the manifests rendered to the directory specified by renderYamlToDirectory. One of:
+	// - `files` (the default): each object in its own file, in the `0-crd` and `1-manifest` directories.
+	// - `namespaces`: the objects of each namespace in a single multi-document file named after the namespace, and the objects without a namespace in `_cluster.yaml`.
+	// - `kustomization`: each object in its own file, as with `files`, along with a `kustomization.yaml` that lists the files.
+	//
+	// The objects of a multi-document file, and the files of a `kustomization.yaml`, are in a deterministic dependency order. Only valid when renderYamlToDirectory is set.
+	RenderYamlFormat *string `pulumi:"renderYamlFormat"`
+	// The path of a JSON file from which to resolve the unknown values of the manifests rendered to the directory specified by renderYamlToDirectory, such as a generated name or an output of another stack. The file is either a deployment, as exported by `pulumi stack export --show-secrets`, from which an unknown value is resolved from the same property of the resource with the same URN, or a JSON object of resolved values keyed by resource URN and then by property path (e.g. `spec.loadBalancerIP`). With a snapshot, previews show the resolved values, although they never write the manifests. The unknown values that aren't resolved are rendered as `${unresolved:<property path>}` placeholders, and listed in `render-report.txt`. Only valid when renderYamlToDirectory is set.
+	RenderYamlSnapshot *string `pulumi:"renderYamlSnapshot"`
 	// BETA FEATURE - If present, render resource manifests to this directory. In this mode, resources will not
 	// be created on a Kubernetes cluster, but the rendered manifests will be kept in sync with changes
 	// to the Pulumi program. This feature is in developer preview, and is disabled by default.
@@ -153,7 +171,8 @@ type providerArgs struct {
 	// Note that some computed Outputs such as status fields will not be populated
 	// since the resources are not created on a Kubernetes cluster. These Output values will remain undefined,
 	// and may result in an error if they are referenced by other resources. Also note that any secret values
-	// used in these resources will be rendered in plaintext to the resulting YAML.
+	// used in these resources will be rendered in plaintext to the resulting YAML, except for the data of
+	// Secrets when renderYamlAgeRecipients is set.
 	RenderYamlToDirectory *string `pulumi:"renderYamlToDirectory"`
 	// If present and set to true, the provider will skip resources update associated with an unreachable Kubernetes cluster from Pulumi state
 	SkipUpdateUnreachable *bool `pulumi:"skipUpdateUnreachable"`
@@ -218,6 +237,8 @@ type ProviderArgs struct {
 	KubeClientSettings KubeClientSettingsPtrInput
 	// The contents of a kubeconfig file or the path to a kubeconfig file.
 	Kubeconfig pulumi.StringPtrInput
+	// If present and set to true, the manifests of `yaml/v2.ConfigGroup` and `yaml/v2.ConfigFile` that are given by URL are read from the on-disk manifest cache only, and never downloaded. A manifest that isn't cached is an error.
+	ManifestCacheOffline pulumi.BoolPtrInput
 	// If present, the default namespace to use. This flag is ignored for cluster-scoped resources.
 	//
 	// A namespace can be specified in multiple places, and the precedence is as follows:
@@ -225,6 +246,17 @@ type ProviderArgs struct {
 	// 2. This `namespace` parameter.
 	// 3. `namespace` set for the active context in the kubeconfig.
 	Namespace pulumi.StringPtrInput
+	// A comma-separated list of age recipients (e.g. `age1...`) to which to encrypt the Secrets rendered to the directory specified by renderYamlToDirectory. The `data` and `stringData` of the Secrets are encrypted with SOPS, such that the manifests may be committed to git and decrypted by SOPS or by Flux's kustomize-controller with the recipients' age keys. When the recipients change, the Secrets are rendered again for the new recipients. A Secret that is rendered again unchanged (e.g. with alwaysRender) keeps its ciphertext, so that its file doesn't change. Not supported with the `namespaces` renderYamlFormat, since `sops -d` can't decrypt a file of several SOPS documents. Only valid when renderYamlToDirectory is set.
+	RenderYamlAgeRecipients pulumi.StringPtrInput
+	// The layout of the manifests rendered to the directory specified by renderYamlToDirectory. One of:
+	// - `files` (the default): each object in its own file, in the `0-crd` and `1-manifest` directories.
+	// - `namespaces`: the objects of each namespace in a single multi-document file named after the namespace, and the objects without a namespace in `_cluster.yaml`.
+	// - `kustomization`: each object in its own file, as with `files`, along with a `kustomization.yaml` that lists the files.
+	//
+	// The objects of a multi-document file, and the files of a `kustomization.yaml`, are in a deterministic dependency order. Only valid when renderYamlToDirectory is set.
+	RenderYamlFormat pulumi.StringPtrInput
+	// The path of a JSON file from which to resolve the unknown values of the manifests rendered to the directory specified by renderYamlToDirectory, such as a generated name or an output of another stack. The file is either a deployment, as exported by `pulumi stack export --show-secrets`, from which an unknown value is resolved from the same property of the resource with the same URN, or a JSON object of resolved values keyed by resource URN and then by property path (e.g. `spec.loadBalancerIP`). With a snapshot, previews show the resolved values, although they never write the manifests. The unknown values that aren't resolved are rendered as `${unresolved:<property path>}` placeholders, and listed in `render-report.txt`. Only valid when renderYamlToDirectory is set.
+	RenderYamlSnapshot pulumi.StringPtrInput
 	// BETA FEATURE - If present, render resource manifests to this directory. In this mode, resources will not
 	// be created on a Kubernetes cluster, but the rendered manifests will be kept in sync with changes
 	// to the Pulumi program. This feature is in developer preview, and is disabled by default.
@@ -237,7 +269,8 @@ type ProviderArgs struct {
 	// Note that some computed Outputs such as status fields will not be populated
 	// since the resources are not created on a Kubernetes cluster. These Output values will remain undefined,
 	// and may result in an error if they are referenced by other resources. Also note that any secret values
-	// used in these resources will be rendered in plaintext to the resulting YAML.
+	// used in these resources will be rendered in plaintext to the resulting YAML, except for the data of
+	// Secrets when renderYamlAgeRecipients is set.
 	RenderYamlToDirectory pulumi.StringPtrInput
 	// If present and set to true, the provider will skip resources update associated with an unreachable Kubernetes cluster from Pulumi state
 	SkipUpdateUnreachable pulumi.BoolPtrInput
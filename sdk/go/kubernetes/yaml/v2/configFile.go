@@ -15,6 +15,24 @@ import (
 // ConfigFile creates a set of Kubernetes resources from a remote or on-disk Kubernetes YAML file.
 // (If you have in-memory YAML a ConfigGroup may be more appropriate.)
 //
+// ## Templating
+// The manifests may be templated with `values`, e.g. to set image tags or namespaces per stack. By default, they're
+// rendered as Go templates with the Sprig functions, and the values are available as `.Values`
+// (e.g. `{{ .Values.image.tag }}`). With `templateEngine: envsubst`, `${VAR}` and `${VAR:-default}` references to the
+// values are substituted instead (e.g. `${image.tag}`), and references to missing values are left as is.
+//
+// ## Patches
+// The objects may be patched with `patches`, before they're registered, without a transform in the program. Each patch
+// is a strategic merge patch or a JSON 6902 patch, and its `target` selects the objects to patch by group, version,
+// kind, name, namespace, label selector or annotation selector. A strategic merge patch may omit the target, in which
+// case the patch itself identifies the object to patch.
+//
+// ## Selecting objects
+// The objects to register may be selected with `include` and `exclude`, e.g. to skip a Namespace that's managed
+// separately. Each selector matches objects by `group`, `version`, `kind`, `name` (a glob pattern), `namespace` and
+// `labelSelector`. An object is registered if it matches any of the included selectors (or if there are none), and none
+// of the excluded selectors. The skipped objects are reported in the `skipped` output.
+//
 // ## Dependency ordering
 // Sometimes resources must be applied in a specific order. For example, a namespace resource must be
 // created before any namespaced resources, or a Custom Resource Definition (CRD) must be pre-installed.
@@ -22,11 +40,19 @@ import (
 // Pulumi uses heuristics to determine which order to apply and delete objects within the ConfigFile.  Pulumi also
 // waits for each object to be fully reconciled, unless `skipAwait` is enabled.
 //
+// Workloads depend on the ConfigMaps and Secrets in the same namespace that their pods reference (by volumes, `envFrom`,
+// `env` or `imagePullSecrets`), so that the referenced configuration is created first.
+//
+// Admission webhook configurations depend on the Services that back them and on the workloads that those Services
+// select, and the objects that a webhook intercepts depend on the webhook configuration, so that the webhook is ready
+// before the objects are created. Webhooks whose `failurePolicy` is `Ignore` don't affect the ordering, and the objects
+// in the namespace of the webhook's Service are assumed to be part of the webhook's installation.
+//
 // ### Explicit Dependency Ordering
 // Pulumi supports the `config.kubernetes.io/depends-on` annotation to declare an explicit dependency on a given resource.
 // The annotation accepts a list of resource references, delimited by commas.
 //
-// Note that references to resources outside the ConfigFile aren't supported.
+// A reference to a resource outside the ConfigFile is assumed to exist in the cluster already, and isn't waited for.
 //
 // **Resource reference**
 //
@@ -80,6 +106,8 @@ type ConfigFile struct {
 
 	// Resources created by the ConfigFile.
 	Resources pulumi.ArrayOutput `pulumi:"resources"`
+	// The objects of the ConfigFile that weren't registered because of the `include` and `exclude` selectors, e.g. `v1/Namespace:my-namespace`.
+	Skipped pulumi.StringArrayOutput `pulumi:"skipped"`
 }
 
 // NewConfigFile registers a new resource with the given unique name, arguments, and options.
@@ -102,22 +130,42 @@ func NewConfigFile(ctx *pulumi.Context,
 }
 
 type configFileArgs struct {
-	// Path or URL to a Kubernetes manifest file. File must exist.
+	// Selects the objects not to register, among the included objects. The skipped objects are reported in the `skipped` output.
+	Exclude []Selector `pulumi:"exclude"`
+	// Path or URL to a Kubernetes manifest file. File must exist. A URL may be pinned to the SHA-256 digest of its content with a `#sha256=<digest>` fragment; the content is verified, and cached so that it's downloaded only once.
 	File string `pulumi:"file"`
+	// Selects the objects to register. An object is registered if it matches any of the selectors; by default, all objects are registered.
+	Include []Selector `pulumi:"include"`
+	// Patches to apply to the objects of the manifest before they're registered, in order. The patches are applied in-process, with kustomize.
+	Patches []Patch `pulumi:"patches"`
 	// A prefix for the auto-generated resource names. Defaults to the name of the ConfigFile. Example: A resource created with resourcePrefix="foo" would produce a resource named "foo-resourceName".
 	ResourcePrefix *string `pulumi:"resourcePrefix"`
 	// Indicates that child resources should skip the await logic. Defaults to `false`.
 	SkipAwait *bool `pulumi:"skipAwait"`
+	// The engine with which to template the manifest: `goTemplate` for Go templates, or `envsubst` for `${VAR}` and `${VAR:-default}` references to the values (by dotted paths, e.g. `${image.tag}`). References to missing values without a default are left as is, and `$${VAR}` escapes a reference. Defaults to `goTemplate` when `values` is set; otherwise, the manifest isn't templated.
+	TemplateEngine *string `pulumi:"templateEngine"`
+	// Values with which to template the manifest before it's parsed. With the `goTemplate` engine, the values are available as `.Values`, along with the Sprig functions.
+	Values map[string]interface{} `pulumi:"values"`
 }
 
 // The set of arguments for constructing a ConfigFile resource.
 type ConfigFileArgs struct {
-	// Path or URL to a Kubernetes manifest file. File must exist.
+	// Selects the objects not to register, among the included objects. The skipped objects are reported in the `skipped` output.
+	Exclude SelectorArrayInput
+	// Path or URL to a Kubernetes manifest file. File must exist. A URL may be pinned to the SHA-256 digest of its content with a `#sha256=<digest>` fragment; the content is verified, and cached so that it's downloaded only once.
 	File pulumi.StringInput
+	// Selects the objects to register. An object is registered if it matches any of the selectors; by default, all objects are registered.
+	Include SelectorArrayInput
+	// Patches to apply to the objects of the manifest before they're registered, in order. The patches are applied in-process, with kustomize.
+	Patches PatchArrayInput
 	// A prefix for the auto-generated resource names. Defaults to the name of the ConfigFile. Example: A resource created with resourcePrefix="foo" would produce a resource named "foo-resourceName".
 	ResourcePrefix pulumi.StringPtrInput
 	// Indicates that child resources should skip the await logic. Defaults to `false`.
 	SkipAwait pulumi.BoolPtrInput
+	// The engine with which to template the manifest: `goTemplate` for Go templates, or `envsubst` for `${VAR}` and `${VAR:-default}` references to the values (by dotted paths, e.g. `${image.tag}`). References to missing values without a default are left as is, and `$${VAR}` escapes a reference. Defaults to `goTemplate` when `values` is set; otherwise, the manifest isn't templated.
+	TemplateEngine pulumi.StringPtrInput
+	// Values with which to template the manifest before it's parsed. With the `goTemplate` engine, the values are available as `.Values`, along with the Sprig functions.
+	Values pulumi.MapInput
 }
 
 func (ConfigFileArgs) ElementType() reflect.Type {
@@ -212,6 +260,11 @@ func (o ConfigFileOutput) Resources() pulumi.ArrayOutput {
 	return o.ApplyT(func(v *ConfigFile) pulumi.ArrayOutput { return v.Resources }).(pulumi.ArrayOutput)
 }
 
+// The objects of the ConfigFile that weren't registered because of the `include` and `exclude` selectors, e.g. `v1/Namespace:my-namespace`.
+func (o ConfigFileOutput) Skipped() pulumi.StringArrayOutput {
+	return o.ApplyT(func(v *ConfigFile) pulumi.StringArrayOutput { return v.Skipped }).(pulumi.StringArrayOutput)
+}
+
 type ConfigFileArrayOutput struct{ *pulumi.OutputState }
 
 func (ConfigFileArrayOutput) ElementType() reflect.Type {
@@ -15,6 +15,11 @@ import * as utilities from "../../utilities";
  * a library to perform the orchestration of the resources. As a result, the full spectrum of Helm features are supported
  * natively.
  *
+ * When the provider renders manifests (`renderYamlToDirectory`), a `Release` isn't installed. Instead, its chart is
+ * rendered client-side, as with `helm template`, and its objects are written to the render directory alongside the other
+ * resources, in the layout of `renderYamlFormat`. The chart's hooks are written apart from its objects, to
+ * `hooks/<namespace>-<name>.yaml`, in the order of their weights.
+ *
  * You may also want to consider the `Chart` resource as an alternative method for managing helm charts. For more information about the trade-offs between these options see: [Choosing the right Helm resource for your use case](https://www.pulumi.com/registry/packages/kubernetes/how-to-guides/choosing-the-right-helm-resource-for-your-use-case)
  *
  * ## Example Usage
@@ -207,6 +212,10 @@ export class Release extends pulumi.CustomResource {
      * Create the namespace if it does not exist.
      */
     declare public readonly createNamespace: pulumi.Output<boolean>;
+    /**
+     * How to resolve the chart's dependencies. By default, missing dependencies are fetched when `dependencyUpdate` is set. Values are: `locked` (require a `Chart.lock` that matches `Chart.yaml`, and fetch only the locked versions), `vendor` (resolve the dependencies into the `charts/` directory of a local chart, to be committed alongside the program; a preview resolves them into a temporary copy of the chart instead), and `offline` (never fetch dependencies; they must already be present in the chart).
+     */
+    declare public readonly dependencyMode: pulumi.Output<string>;
     /**
      * Run helm dependency update before installing the chart.
      */
@@ -259,6 +268,10 @@ export class Release extends pulumi.CustomResource {
      * Namespace to install the release into.
      */
     declare public readonly namespace: pulumi.Output<string>;
+    /**
+     * The rendered notes (NOTES.txt) of the deployed release.
+     */
+    declare public /*out*/ readonly notes: pulumi.Output<string>;
     /**
      * Postrender command to run.
      */
@@ -348,6 +361,7 @@ export class Release extends pulumi.CustomResource {
             resourceInputs["cleanupOnFail"] = args?.cleanupOnFail;
             resourceInputs["compat"] = "true";
             resourceInputs["createNamespace"] = args?.createNamespace;
+            resourceInputs["dependencyMode"] = args?.dependencyMode;
             resourceInputs["dependencyUpdate"] = args?.dependencyUpdate;
             resourceInputs["description"] = args?.description;
             resourceInputs["devel"] = args?.devel;
@@ -377,6 +391,7 @@ export class Release extends pulumi.CustomResource {
             resourceInputs["verify"] = args?.verify;
             resourceInputs["version"] = args?.version;
             resourceInputs["waitForJobs"] = args?.waitForJobs;
+            resourceInputs["notes"] = undefined /*out*/;
             resourceInputs["status"] = undefined /*out*/;
         } else {
             resourceInputs["allowNullValues"] = undefined /*out*/;
@@ -384,6 +399,7 @@ export class Release extends pulumi.CustomResource {
             resourceInputs["chart"] = undefined /*out*/;
             resourceInputs["cleanupOnFail"] = undefined /*out*/;
             resourceInputs["createNamespace"] = undefined /*out*/;
+            resourceInputs["dependencyMode"] = undefined /*out*/;
             resourceInputs["dependencyUpdate"] = undefined /*out*/;
             resourceInputs["description"] = undefined /*out*/;
             resourceInputs["devel"] = undefined /*out*/;
@@ -397,6 +413,7 @@ export class Release extends pulumi.CustomResource {
             resourceInputs["maxHistory"] = undefined /*out*/;
             resourceInputs["name"] = undefined /*out*/;
             resourceInputs["namespace"] = undefined /*out*/;
+            resourceInputs["notes"] = undefined /*out*/;
             resourceInputs["postrender"] = undefined /*out*/;
             resourceInputs["recreatePods"] = undefined /*out*/;
             resourceInputs["renderSubchartNotes"] = undefined /*out*/;
@@ -445,6 +462,10 @@ export interface ReleaseArgs {
      * Create the namespace if it does not exist.
      */
     createNamespace?: pulumi.Input<boolean | undefined>;
+    /**
+     * How to resolve the chart's dependencies. By default, missing dependencies are fetched when `dependencyUpdate` is set. Values are: `locked` (require a `Chart.lock` that matches `Chart.yaml`, and fetch only the locked versions), `vendor` (resolve the dependencies into the `charts/` directory of a local chart, to be committed alongside the program; a preview resolves them into a temporary copy of the chart instead), and `offline` (never fetch dependencies; they must already be present in the chart).
+     */
+    dependencyMode?: pulumi.Input<string | undefined>;
     /**
      * Run helm dependency update before installing the chart.
      */
@@ -73,6 +73,14 @@ import * as utilities from "../../utilities";
  *
  * Use the `postRenderer` input to pipe the rendered manifest through a [post-rendering command](https://helm.sh/docs/topics/advanced/#post-rendering).
  *
+ * ### Selecting Objects
+ *
+ * Use the `include` and `exclude` inputs to select the objects of the chart to register, e.g. to skip a Namespace
+ * that's managed separately. Each selector matches objects by `group`, `version`, `kind`, `name` (a glob pattern),
+ * `namespace` and `labelSelector`. An object is registered if it matches any of the included selectors (or if there are
+ * none), and none of the excluded selectors. The selectors apply to the CRDs and hooks, too. The skipped objects are
+ * reported in the `skipped` output.
+ *
  * ### Resource Ordering
  *
  * Sometimes resources must be applied in a specific order. For example, a namespace resource must be
@@ -81,10 +89,20 @@ import * as utilities from "../../utilities";
  * Pulumi uses heuristics to determine which order to apply and delete objects within the Chart.  Pulumi also
  * waits for each object to be fully reconciled, unless `skipAwait` is enabled.
  *
+ * Workloads depend on the ConfigMaps and Secrets in the same namespace that their pods reference (by volumes, `envFrom`,
+ * `env` or `imagePullSecrets`), so that the referenced configuration is created first.
+ *
+ * Admission webhook configurations depend on the Services that back them and on the workloads that those Services
+ * select, and the objects that a webhook intercepts depend on the webhook configuration, so that the webhook is ready
+ * before the objects are created. Webhooks whose `failurePolicy` is `Ignore` don't affect the ordering, and the objects
+ * in the namespace of the webhook's Service are assumed to be part of the webhook's installation.
+ *
  * Pulumi supports the `config.kubernetes.io/depends-on` annotation to declare an explicit dependency on a given resource.
  * The annotation accepts a list of resource references, delimited by commas.
  *
- * Note that references to resources outside the Chart aren't supported.
+ * A reference to an object of the chart's CRDs, or of a hook that is applied earlier (e.g. a `pre-install` hook),
+ * is honored. A reference to any other resource outside the Chart is assumed to exist in the cluster already,
+ * and isn't waited for.
  *
  * **Resource reference**
  *
@@ -186,10 +204,18 @@ export class Chart extends pulumi.ComponentResource {
         return obj['__pulumiType'] === Chart.__pulumiType;
     }
 
+    /**
+     * The rendered notes (NOTES.txt) of the Chart.
+     */
+    declare public /*out*/ readonly notes: pulumi.Output<string>;
     /**
      * Resources created by the Chart.
      */
     declare public /*out*/ readonly resources: pulumi.Output<any[]>;
+    /**
+     * The objects of the Chart that weren't registered because of the `include` and `exclude` selectors, e.g. `v1/Namespace:my-namespace`.
+     */
+    declare public /*out*/ readonly skipped: pulumi.Output<string[]>;
 
     /**
      * Create a Chart resource with the given unique name, arguments, and options.
@@ -205,26 +231,38 @@ export class Chart extends pulumi.ComponentResource {
             if (args?.chart === undefined && !opts.urn) {
                 throw new Error("Missing required property 'chart'");
             }
+            resourceInputs["allowClusterLookup"] = args?.allowClusterLookup;
+            resourceInputs["applyHooks"] = args?.applyHooks;
             resourceInputs["chart"] = args?.chart;
+            resourceInputs["crdPolicy"] = args?.crdPolicy;
+            resourceInputs["dependencyMode"] = args?.dependencyMode;
             resourceInputs["dependencyUpdate"] = args?.dependencyUpdate;
             resourceInputs["devel"] = args?.devel;
+            resourceInputs["exclude"] = args?.exclude;
+            resourceInputs["include"] = args?.include;
             resourceInputs["includeHooks"] = args?.includeHooks;
             resourceInputs["keyring"] = args?.keyring;
             resourceInputs["name"] = args?.name;
             resourceInputs["namespace"] = args?.namespace;
             resourceInputs["plainHttp"] = args?.plainHttp;
             resourceInputs["postRenderer"] = args?.postRenderer;
+            resourceInputs["postRenderers"] = args?.postRenderers;
             resourceInputs["repositoryOpts"] = args?.repositoryOpts;
             resourceInputs["resourcePrefix"] = args?.resourcePrefix;
             resourceInputs["skipAwait"] = args?.skipAwait;
             resourceInputs["skipCrds"] = args?.skipCrds;
             resourceInputs["valueYamlFiles"] = args?.valueYamlFiles;
             resourceInputs["values"] = args?.values;
+            resourceInputs["valuesFrom"] = args?.valuesFrom;
             resourceInputs["verify"] = args?.verify;
             resourceInputs["version"] = args?.version;
+            resourceInputs["notes"] = undefined /*out*/;
             resourceInputs["resources"] = undefined /*out*/;
+            resourceInputs["skipped"] = undefined /*out*/;
         } else {
+            resourceInputs["notes"] = undefined /*out*/;
             resourceInputs["resources"] = undefined /*out*/;
+            resourceInputs["skipped"] = undefined /*out*/;
         }
         opts = pulumi.mergeOptions(utilities.resourceOptsDefaults(), opts);
         super(Chart.__pulumiType, name, resourceInputs, opts, true /*remote*/);
@@ -235,10 +273,26 @@ export class Chart extends pulumi.ComponentResource {
  * The set of arguments for constructing a Chart resource.
  */
 export interface ChartArgs {
+    /**
+     * Restricts the chart's `lookup` function to a read-only view of the cluster, scoped to the given kinds and namespaces. Lookups are cached for the duration of the deployment, and the values of looked up Secrets are marked as secret wherever they appear in the rendered resources. By default, the `lookup` function has the provider's access to the cluster.
+     */
+    allowClusterLookup?: pulumi.Input<inputs.helm.v4.ClusterLookup | undefined>;
+    /**
+     * Set this to true to apply the chart's install and upgrade hooks (resources annotated with `helm.sh/hook`) in order, as Helm does. The chart is installed if its first resource doesn't exist in the cluster yet, and upgraded otherwise. The `pre-install` or `pre-upgrade` hooks are applied before the chart's other resources, and the `post-install` or `post-upgrade` hooks after them, in order of `helm.sh/hook-weight`. Upgrade hooks (e.g. migration Jobs) run again whenever the rendered chart changes, their previous resources being deleted first, as with the default `before-hook-creation` delete policy. The resources of install hooks are left in the cluster once the chart is upgraded. The `hook-succeeded` and `hook-failed` delete policies are not supported: the resources of a hook are managed like the chart's other resources, so they remain in the cluster (and in the stack) once the hook has run, and a warning is produced. Delete and rollback hooks are not supported either, and produce a warning. Test hooks are always excluded. This setting has no effect in render mode; see `includeHooks`.
+     */
+    applyHooks?: pulumi.Input<boolean | undefined>;
     /**
      * Chart name to be installed. A path may be used.
      */
     chart: pulumi.Input<string>;
+    /**
+     * The policy for the CRDs in the chart's `crds/` directory: `create` creates the CRDs and leaves them unchanged on upgrade (CRDs that exist already, and that Pulumi doesn't manage, are left as they are), `createReplace` creates the CRDs and updates them on upgrade, `patch` applies the CRDs as server-side apply patches (`CustomResourceDefinitionPatch`), and `skip` neither creates nor updates them. With a policy, the CRDs are applied before the rest of the chart, and they are never deleted, neither when a new chart version drops them nor when the chart is deleted. By default, the CRDs are managed like the chart's other resources.
+     */
+    crdPolicy?: pulumi.Input<string | undefined>;
+    /**
+     * How to resolve the chart's dependencies. By default, missing dependencies are fetched when `dependencyUpdate` is set or a `Chart.lock` is present. Values are: `locked` (require a `Chart.lock` that matches `Chart.yaml`, and fetch only the locked versions), `vendor` (resolve the dependencies into the `charts/` directory of a local chart, to be committed alongside the program; a preview resolves them into a temporary copy of the chart instead), and `offline` (never fetch dependencies; they must already be present in the chart).
+     */
+    dependencyMode?: pulumi.Input<string | undefined>;
     /**
      * Run helm dependency update before installing the chart.
      */
@@ -248,7 +302,15 @@ export interface ChartArgs {
      */
     devel?: pulumi.Input<boolean | undefined>;
     /**
-     * By default, Helm hook resources (those annotated with `helm.sh/hook`) are omitted from the rendered output. When the provider is configured with `renderYamlToDirectory`, set this to true to include hook resources in the rendered manifests so that another tool (e.g. Argo CD) can apply them. Test hooks (`helm.sh/hook: test`) are always excluded. This setting has no effect outside of render mode, where hooks are not supported.
+     * Selects the objects not to register, among the included objects. The skipped objects are reported in the `skipped` output.
+     */
+    exclude?: pulumi.Input<pulumi.Input<inputs.helm.v4.Selector>[] | undefined>;
+    /**
+     * Selects the objects to register. An object is registered if it matches any of the selectors; by default, all objects are registered.
+     */
+    include?: pulumi.Input<pulumi.Input<inputs.helm.v4.Selector>[] | undefined>;
+    /**
+     * By default, Helm hook resources (those annotated with `helm.sh/hook`) are omitted from the rendered output. When the provider is configured with `renderYamlToDirectory`, set this to true to include hook resources in the rendered manifests so that another tool (e.g. Argo CD) can apply them. Test hooks (`helm.sh/hook: test`) are always excluded. This setting has no effect outside of render mode, where `applyHooks` applies the hooks instead.
      */
     includeHooks?: pulumi.Input<boolean | undefined>;
     /**
@@ -271,6 +333,10 @@ export interface ChartArgs {
      * Specification defining the post-renderer to use.
      */
     postRenderer?: pulumi.Input<inputs.helm.v4.PostRenderer | undefined>;
+    /**
+     * Built-in post-renderers to apply to the rendered manifests, in order. Built-in post-renderers run in-process and don't require an executable. They run after the `postRenderer` command, if any.
+     */
+    postRenderers?: pulumi.Input<pulumi.Input<inputs.helm.v4.BuiltinPostRenderer>[] | undefined>;
     /**
      * Specification defining the Helm chart repository to use.
      */
@@ -295,6 +361,10 @@ export interface ChartArgs {
      * Custom values set for the release.
      */
     values?: pulumi.Input<{[key: string]: any} | undefined>;
+    /**
+     * References to values held in ConfigMaps and Secrets, which are read from the cluster when the chart is rendered. The referenced values are merged in order, after `valueYamlFiles` and before `values`. Values read from Secrets are marked as secret wherever they appear in the rendered resources, unless `values` overrides them.
+     */
+    valuesFrom?: pulumi.Input<pulumi.Input<inputs.helm.v4.ValuesReference>[] | undefined>;
     /**
      * Verify the chart's integrity.
      */
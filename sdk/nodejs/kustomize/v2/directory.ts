@@ -2,11 +2,42 @@
 // *** Do not edit by hand unless you're certain you know what you are doing! ***
 
 import * as pulumi from "@pulumi/pulumi";
+import * as inputs from "../../types/input";
+import * as outputs from "../../types/output";
+import * as enums from "../../types/enums";
 import * as utilities from "../../utilities";
 
 /**
  * Directory is a component representing a collection of resources described by a kustomize directory (kustomization).
  *
+ * ## Patches
+ * The objects may be patched with `patches`, before they're registered, without a transform in the program. Each patch
+ * is a strategic merge patch or a JSON 6902 patch, and its `target` selects the objects to patch by group, version,
+ * kind, name, namespace, label selector or annotation selector. A strategic merge patch may omit the target, in which
+ * case the patch itself identifies the object to patch.
+ *
+ * ## Selecting objects
+ * The objects to register may be selected with `include` and `exclude`, e.g. to skip a Namespace that's managed
+ * separately. Each selector matches objects by `group`, `version`, `kind`, `name` (a glob pattern), `namespace` and
+ * `labelSelector`. An object is registered if it matches any of the included selectors (or if there are none), and none
+ * of the excluded selectors. The skipped objects are reported in the `skipped` output.
+ *
+ * ## Dependency ordering
+ * Pulumi uses heuristics to determine which order to apply and delete objects within the Directory, and supports the
+ * `config.kubernetes.io/depends-on` annotation to declare an explicit dependency on a given resource.
+ *
+ * Workloads depend on the ConfigMaps and Secrets in the same namespace that their pods reference (by volumes, `envFrom`,
+ * `env` or `imagePullSecrets`), so that the referenced configuration is created first. This includes the ConfigMaps and
+ * Secrets generated by kustomize, whose names have a hash suffix.
+ *
+ * Admission webhook configurations depend on the Services that back them and on the workloads that those Services
+ * select, and the objects that a webhook intercepts depend on the webhook configuration, so that the webhook is ready
+ * before the objects are created. Webhooks whose `failurePolicy` is `Ignore` don't affect the ordering, and the objects
+ * in the namespace of the webhook's Service are assumed to be part of the webhook's installation.
+ *
+ * A `config.kubernetes.io/depends-on` reference to a resource outside the Directory is assumed to exist in the cluster
+ * already, and isn't waited for.
+ *
  * ## Example Usage
  * ### Local Kustomize Directory
  * ```typescript
@@ -44,6 +75,10 @@ export class Directory extends pulumi.ComponentResource {
      * Resources created by the Directory resource.
      */
     declare public /*out*/ readonly resources: pulumi.Output<any[]>;
+    /**
+     * The objects of the Directory that weren't registered because of the `include` and `exclude` selectors, e.g. `v1/Namespace:my-namespace`.
+     */
+    declare public /*out*/ readonly skipped: pulumi.Output<string[]>;
 
     /**
      * Create a Directory resource with the given unique name, arguments, and options.
@@ -56,16 +91,25 @@ export class Directory extends pulumi.ComponentResource {
         let resourceInputs: pulumi.Inputs = {};
         opts = opts || {};
         if (!opts.id) {
-            if (args?.directory === undefined && !opts.urn) {
-                throw new Error("Missing required property 'directory'");
-            }
             resourceInputs["directory"] = args?.directory;
+            resourceInputs["enableAlphaPlugins"] = args?.enableAlphaPlugins;
+            resourceInputs["enableHelm"] = args?.enableHelm;
+            resourceInputs["exclude"] = args?.exclude;
+            resourceInputs["files"] = args?.files;
+            resourceInputs["helmCommand"] = args?.helmCommand;
+            resourceInputs["include"] = args?.include;
+            resourceInputs["kustomization"] = args?.kustomization;
+            resourceInputs["loadRestrictor"] = args?.loadRestrictor;
             resourceInputs["namespace"] = args?.namespace;
+            resourceInputs["patches"] = args?.patches;
+            resourceInputs["remoteBases"] = args?.remoteBases;
             resourceInputs["resourcePrefix"] = args?.resourcePrefix;
             resourceInputs["skipAwait"] = args?.skipAwait;
             resourceInputs["resources"] = undefined /*out*/;
+            resourceInputs["skipped"] = undefined /*out*/;
         } else {
             resourceInputs["resources"] = undefined /*out*/;
+            resourceInputs["skipped"] = undefined /*out*/;
         }
         opts = pulumi.mergeOptions(utilities.resourceOptsDefaults(), opts);
         super(Directory.__pulumiType, name, resourceInputs, opts, true /*remote*/);
@@ -81,12 +125,53 @@ export interface DirectoryArgs {
      * git repository.
      * Example: ./helloWorld
      * Example: https://github.com/kubernetes-sigs/kustomize/tree/master/examples/helloWorld
+     * When `kustomization` or `files` is set, the directory is a path within the inline files, and defaults to the root of the files.
+     */
+    directory?: pulumi.Input<string | undefined>;
+    /**
+     * Enables kustomize's alpha plugins (e.g. KRM functions). Defaults to `true`.
+     */
+    enableAlphaPlugins?: pulumi.Input<boolean | undefined>;
+    /**
+     * Enables the inflation of the `helmCharts` of the kustomizations. Defaults to `true`.
+     */
+    enableHelm?: pulumi.Input<boolean | undefined>;
+    /**
+     * Selects the objects not to register, among the included objects. The skipped objects are reported in the `skipped` output.
      */
-    directory: pulumi.Input<string>;
+    exclude?: pulumi.Input<pulumi.Input<inputs.kustomize.v2.Selector>[] | undefined>;
+    /**
+     * Additional files for an inline kustomization (e.g. patches, generator inputs and other kustomizations), keyed by their paths relative to the root of the files. The files are materialised in memory, so the kustomization can't refer to files on disk; remote bases and Helm charts from repositories may be used.
+     */
+    files?: pulumi.Input<{[key: string]: pulumi.Input<string>} | undefined>;
+    /**
+     * The helm binary with which kustomize inflates the `helmCharts` of the kustomizations. By default, the charts are inflated in-process, using the Helm settings of the provider.
+     */
+    helmCommand?: pulumi.Input<string | undefined>;
+    /**
+     * Selects the objects to register. An object is registered if it matches any of the selectors; by default, all objects are registered.
+     */
+    include?: pulumi.Input<pulumi.Input<inputs.kustomize.v2.Selector>[] | undefined>;
+    /**
+     * An inline kustomization (the content of a `kustomization.yaml` file) to apply instead of a kustomization on disk. It is materialised in memory, together with the `files`, in the `directory`.
+     */
+    kustomization?: pulumi.Input<{[key: string]: any} | undefined>;
+    /**
+     * The load restrictions of kustomize, either `LoadRestrictionsNone` or `LoadRestrictionsRootOnly`. With `LoadRestrictionsRootOnly`, the files of a kustomization (including the values files of its Helm charts) must be in or below its directory. Defaults to `LoadRestrictionsNone`.
+     */
+    loadRestrictor?: pulumi.Input<string | undefined>;
     /**
      * The default namespace to apply to the resources. Defaults to the provider's namespace.
      */
     namespace?: pulumi.Input<string | undefined>;
+    /**
+     * Patches to apply to the objects that the kustomization produces before they're registered, in order. The patches are applied in-process, with kustomize.
+     */
+    patches?: pulumi.Input<pulumi.Input<inputs.kustomize.v2.Patch>[] | undefined>;
+    /**
+     * Options for fetching the remote git bases of the kustomizations.
+     */
+    remoteBases?: pulumi.Input<inputs.kustomize.v2.RemoteBasesOpts | undefined>;
     /**
      * A prefix for the auto-generated resource names. Defaults to the name of the Directory resource. Example: A resource created with resourcePrefix="foo" would produce a resource named "foo:resourceName".
      */
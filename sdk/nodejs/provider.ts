@@ -49,7 +49,11 @@ export class Provider extends pulumi.ProviderResource {
             resourceInputs["helmReleaseSettings"] = pulumi.output(args ? pulumi.output(args.helmReleaseSettings).apply(v => v === undefined ? undefined : inputs.helmReleaseSettingsProvideDefaults(v)) : undefined).apply(JSON.stringify);
             resourceInputs["kubeClientSettings"] = pulumi.output(args ? pulumi.output(args.kubeClientSettings).apply(v => v === undefined ? undefined : inputs.kubeClientSettingsProvideDefaults(v)) : undefined).apply(JSON.stringify);
             resourceInputs["kubeconfig"] = (args?.kubeconfig) ?? utilities.getEnv("KUBECONFIG");
+            resourceInputs["manifestCacheOffline"] = pulumi.output((args?.manifestCacheOffline) ?? utilities.getEnvBoolean("PULUMI_K8S_MANIFEST_CACHE_OFFLINE")).apply(JSON.stringify);
             resourceInputs["namespace"] = args?.namespace;
+            resourceInputs["renderYamlAgeRecipients"] = args?.renderYamlAgeRecipients;
+            resourceInputs["renderYamlFormat"] = args?.renderYamlFormat;
+            resourceInputs["renderYamlSnapshot"] = args?.renderYamlSnapshot;
             resourceInputs["renderYamlToDirectory"] = args?.renderYamlToDirectory;
             resourceInputs["skipUpdateUnreachable"] = pulumi.output((args?.skipUpdateUnreachable) ?? utilities.getEnvBoolean("PULUMI_K8S_SKIP_UPDATE_UNREACHABLE")).apply(JSON.stringify);
             resourceInputs["suppressDeprecationWarnings"] = pulumi.output((args?.suppressDeprecationWarnings) ?? utilities.getEnvBoolean("PULUMI_K8S_SUPPRESS_DEPRECATION_WARNINGS")).apply(JSON.stringify);
@@ -134,6 +138,10 @@ export interface ProviderArgs {
      * The contents of a kubeconfig file or the path to a kubeconfig file.
      */
     kubeconfig?: pulumi.Input<string | undefined>;
+    /**
+     * If present and set to true, the manifests of `yaml/v2.ConfigGroup` and `yaml/v2.ConfigFile` that are given by URL are read from the on-disk manifest cache only, and never downloaded. A manifest that isn't cached is an error.
+     */
+    manifestCacheOffline?: pulumi.Input<boolean | undefined>;
     /**
      * If present, the default namespace to use. This flag is ignored for cluster-scoped resources.
      *
@@ -143,6 +151,23 @@ export interface ProviderArgs {
      * 3. `namespace` set for the active context in the kubeconfig.
      */
     namespace?: pulumi.Input<string | undefined>;
+    /**
+     * A comma-separated list of age recipients (e.g. `age1...`) to which to encrypt the Secrets rendered to the directory specified by renderYamlToDirectory. The `data` and `stringData` of the Secrets are encrypted with SOPS, such that the manifests may be committed to git and decrypted by SOPS or by Flux's kustomize-controller with the recipients' age keys. When the recipients change, the Secrets are rendered again for the new recipients. A Secret that is rendered again unchanged (e.g. with alwaysRender) keeps its ciphertext, so that its file doesn't change. Not supported with the `namespaces` renderYamlFormat, since `sops -d` can't decrypt a file of several SOPS documents. Only valid when renderYamlToDirectory is set.
+     */
+    renderYamlAgeRecipients?: pulumi.Input<string | undefined>;
+    /**
+     * The layout of the manifests rendered to the directory specified by renderYamlToDirectory. One of:
+     * - `files` (the default): each object in its own file, in the `0-crd` and `1-manifest` directories.
+     * - `namespaces`: the objects of each namespace in a single multi-document file named after the namespace, and the objects without a namespace in `_cluster.yaml`.
+     * - `kustomization`: each object in its own file, as with `files`, along with a `kustomization.yaml` that lists the files.
+     *
+     * The objects of a multi-document file, and the files of a `kustomization.yaml`, are in a deterministic dependency order. Only valid when renderYamlToDirectory is set.
+     */
+    renderYamlFormat?: pulumi.Input<string | undefined>;
+    /**
+     * The path of a JSON file from which to resolve the unknown values of the manifests rendered to the directory specified by renderYamlToDirectory, such as a generated name or an output of another stack. The file is either a deployment, as exported by `pulumi stack export --show-secrets`, from which an unknown value is resolved from the same property of the resource with the same URN, or a JSON object of resolved values keyed by resource URN and then by property path (e.g. `spec.loadBalancerIP`). With a snapshot, previews show the resolved values, although they never write the manifests. The unknown values that aren't resolved are rendered as `${unresolved:<property path>}` placeholders, and listed in `render-report.txt`. Only valid when renderYamlToDirectory is set.
+     */
+    renderYamlSnapshot?: pulumi.Input<string | undefined>;
     /**
      * BETA FEATURE - If present, render resource manifests to this directory. In this mode, resources will not
      * be created on a Kubernetes cluster, but the rendered manifests will be kept in sync with changes
@@ -156,7 +181,8 @@ export interface ProviderArgs {
      * Note that some computed Outputs such as status fields will not be populated
      * since the resources are not created on a Kubernetes cluster. These Output values will remain undefined,
      * and may result in an error if they are referenced by other resources. Also note that any secret values
-     * used in these resources will be rendered in plaintext to the resulting YAML.
+     * used in these resources will be rendered in plaintext to the resulting YAML, except for the data of
+     * Secrets when renderYamlAgeRecipients is set.
      */
     renderYamlToDirectory?: pulumi.Input<string | undefined>;
     /**
@@ -32083,6 +32083,11 @@ export namespace io {
     }
 }
 
+export namespace kustomize {
+    export namespace v2 {
+    }
+}
+
 export namespace meta {
     export namespace v1 {
         /**
@@ -48013,3 +48018,8 @@ export namespace storagemigration {
 
     }
 }
+
+export namespace yaml {
+    export namespace v2 {
+    }
+}
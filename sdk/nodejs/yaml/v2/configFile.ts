@@ -2,12 +2,33 @@
 // *** Do not edit by hand unless you're certain you know what you are doing! ***
 
 import * as pulumi from "@pulumi/pulumi";
+import * as inputs from "../../types/input";
+import * as outputs from "../../types/output";
+import * as enums from "../../types/enums";
 import * as utilities from "../../utilities";
 
 /**
  * ConfigFile creates a set of Kubernetes resources from a remote or on-disk Kubernetes YAML file.
  * (If you have in-memory YAML a ConfigGroup may be more appropriate.)
  *
+ * ## Templating
+ * The manifests may be templated with `values`, e.g. to set image tags or namespaces per stack. By default, they're
+ * rendered as Go templates with the Sprig functions, and the values are available as `.Values`
+ * (e.g. `{{ .Values.image.tag }}`). With `templateEngine: envsubst`, `${VAR}` and `${VAR:-default}` references to the
+ * values are substituted instead (e.g. `${image.tag}`), and references to missing values are left as is.
+ *
+ * ## Patches
+ * The objects may be patched with `patches`, before they're registered, without a transform in the program. Each patch
+ * is a strategic merge patch or a JSON 6902 patch, and its `target` selects the objects to patch by group, version,
+ * kind, name, namespace, label selector or annotation selector. A strategic merge patch may omit the target, in which
+ * case the patch itself identifies the object to patch.
+ *
+ * ## Selecting objects
+ * The objects to register may be selected with `include` and `exclude`, e.g. to skip a Namespace that's managed
+ * separately. Each selector matches objects by `group`, `version`, `kind`, `name` (a glob pattern), `namespace` and
+ * `labelSelector`. An object is registered if it matches any of the included selectors (or if there are none), and none
+ * of the excluded selectors. The skipped objects are reported in the `skipped` output.
+ *
  * ## Dependency ordering
  * Sometimes resources must be applied in a specific order. For example, a namespace resource must be
  * created before any namespaced resources, or a Custom Resource Definition (CRD) must be pre-installed.
@@ -15,11 +36,19 @@ import * as utilities from "../../utilities";
  * Pulumi uses heuristics to determine which order to apply and delete objects within the ConfigFile.  Pulumi also
  * waits for each object to be fully reconciled, unless `skipAwait` is enabled.
  *
+ * Workloads depend on the ConfigMaps and Secrets in the same namespace that their pods reference (by volumes, `envFrom`,
+ * `env` or `imagePullSecrets`), so that the referenced configuration is created first.
+ *
+ * Admission webhook configurations depend on the Services that back them and on the workloads that those Services
+ * select, and the objects that a webhook intercepts depend on the webhook configuration, so that the webhook is ready
+ * before the objects are created. Webhooks whose `failurePolicy` is `Ignore` don't affect the ordering, and the objects
+ * in the namespace of the webhook's Service are assumed to be part of the webhook's installation.
+ *
  * ### Explicit Dependency Ordering
  * Pulumi supports the `config.kubernetes.io/depends-on` annotation to declare an explicit dependency on a given resource.
  * The annotation accepts a list of resource references, delimited by commas.
  *
- * Note that references to resources outside the ConfigFile aren't supported.
+ * A reference to a resource outside the ConfigFile is assumed to exist in the cluster already, and isn't waited for.
  *
  * **Resource reference**
  *
@@ -73,6 +102,10 @@ export class ConfigFile extends pulumi.ComponentResource {
      * Resources created by the ConfigFile.
      */
     declare public /*out*/ readonly resources: pulumi.Output<any[]>;
+    /**
+     * The objects of the ConfigFile that weren't registered because of the `include` and `exclude` selectors, e.g. `v1/Namespace:my-namespace`.
+     */
+    declare public /*out*/ readonly skipped: pulumi.Output<string[]>;
 
     /**
      * Create a ConfigFile resource with the given unique name, arguments, and options.
@@ -88,12 +121,19 @@ export class ConfigFile extends pulumi.ComponentResource {
             if (args?.file === undefined && !opts.urn) {
                 throw new Error("Missing required property 'file'");
             }
+            resourceInputs["exclude"] = args?.exclude;
             resourceInputs["file"] = args?.file;
+            resourceInputs["include"] = args?.include;
+            resourceInputs["patches"] = args?.patches;
             resourceInputs["resourcePrefix"] = args?.resourcePrefix;
             resourceInputs["skipAwait"] = args?.skipAwait;
+            resourceInputs["templateEngine"] = args?.templateEngine;
+            resourceInputs["values"] = args?.values;
             resourceInputs["resources"] = undefined /*out*/;
+            resourceInputs["skipped"] = undefined /*out*/;
         } else {
             resourceInputs["resources"] = undefined /*out*/;
+            resourceInputs["skipped"] = undefined /*out*/;
         }
         opts = pulumi.mergeOptions(utilities.resourceOptsDefaults(), opts);
         super(ConfigFile.__pulumiType, name, resourceInputs, opts, true /*remote*/);
@@ -105,9 +145,21 @@ export class ConfigFile extends pulumi.ComponentResource {
  */
 export interface ConfigFileArgs {
     /**
-     * Path or URL to a Kubernetes manifest file. File must exist.
+     * Selects the objects not to register, among the included objects. The skipped objects are reported in the `skipped` output.
+     */
+    exclude?: pulumi.Input<pulumi.Input<inputs.yaml.v2.Selector>[] | undefined>;
+    /**
+     * Path or URL to a Kubernetes manifest file. File must exist. A URL may be pinned to the SHA-256 digest of its content with a `#sha256=<digest>` fragment; the content is verified, and cached so that it's downloaded only once.
      */
     file: pulumi.Input<string>;
+    /**
+     * Selects the objects to register. An object is registered if it matches any of the selectors; by default, all objects are registered.
+     */
+    include?: pulumi.Input<pulumi.Input<inputs.yaml.v2.Selector>[] | undefined>;
+    /**
+     * Patches to apply to the objects of the manifest before they're registered, in order. The patches are applied in-process, with kustomize.
+     */
+    patches?: pulumi.Input<pulumi.Input<inputs.yaml.v2.Patch>[] | undefined>;
     /**
      * A prefix for the auto-generated resource names. Defaults to the name of the ConfigFile. Example: A resource created with resourcePrefix="foo" would produce a resource named "foo-resourceName".
      */
@@ -116,4 +168,12 @@ export interface ConfigFileArgs {
      * Indicates that child resources should skip the await logic. Defaults to `false`.
      */
     skipAwait?: pulumi.Input<boolean | undefined>;
+    /**
+     * The engine with which to template the manifest: `goTemplate` for Go templates, or `envsubst` for `${VAR}` and `${VAR:-default}` references to the values (by dotted paths, e.g. `${image.tag}`). References to missing values without a default are left as is, and `$${VAR}` escapes a reference. Defaults to `goTemplate` when `values` is set; otherwise, the manifest isn't templated.
+     */
+    templateEngine?: pulumi.Input<string | undefined>;
+    /**
+     * Values with which to template the manifest before it's parsed. With the `goTemplate` engine, the values are available as `.Values`, along with the Sprig functions.
+     */
+    values?: pulumi.Input<{[key: string]: any} | undefined>;
 }
@@ -2,6 +2,9 @@
 // *** Do not edit by hand unless you're certain you know what you are doing! ***
 
 import * as pulumi from "@pulumi/pulumi";
+import * as inputs from "../../types/input";
+import * as outputs from "../../types/output";
+import * as enums from "../../types/enums";
 import * as utilities from "../../utilities";
 
 /**
@@ -13,6 +16,24 @@ import * as utilities from "../../utilities";
  * 3. Using a literal string containing YAML, or a list of such strings:
  * 4. Any combination of files, patterns, or YAML strings:
  *
+ * ## Templating
+ * The manifests may be templated with `values`, e.g. to set image tags or namespaces per stack. By default, they're
+ * rendered as Go templates with the Sprig functions, and the values are available as `.Values`
+ * (e.g. `{{ .Values.image.tag }}`). With `templateEngine: envsubst`, `${VAR}` and `${VAR:-default}` references to the
+ * values are substituted instead (e.g. `${image.tag}`), and references to missing values are left as is.
+ *
+ * ## Patches
+ * The objects may be patched with `patches`, before they're registered, without a transform in the program. Each patch
+ * is a strategic merge patch or a JSON 6902 patch, and its `target` selects the objects to patch by group, version,
+ * kind, name, namespace, label selector or annotation selector. A strategic merge patch may omit the target, in which
+ * case the patch itself identifies the object to patch.
+ *
+ * ## Selecting objects
+ * The objects to register may be selected with `include` and `exclude`, e.g. to skip a Namespace that's managed
+ * separately. Each selector matches objects by `group`, `version`, `kind`, `name` (a glob pattern), `namespace` and
+ * `labelSelector`. An object is registered if it matches any of the included selectors (or if there are none), and none
+ * of the excluded selectors. The skipped objects are reported in the `skipped` output.
+ *
  * ## Dependency ordering
  * Sometimes resources must be applied in a specific order. For example, a namespace resource must be
  * created before any namespaced resources, or a Custom Resource Definition (CRD) must be pre-installed.
@@ -20,11 +41,19 @@ import * as utilities from "../../utilities";
  * Pulumi uses heuristics to determine which order to apply and delete objects within the ConfigGroup.  Pulumi also
  * waits for each object to be fully reconciled, unless `skipAwait` is enabled.
  *
+ * Workloads depend on the ConfigMaps and Secrets in the same namespace that their pods reference (by volumes, `envFrom`,
+ * `env` or `imagePullSecrets`), so that the referenced configuration is created first.
+ *
+ * Admission webhook configurations depend on the Services that back them and on the workloads that those Services
+ * select, and the objects that a webhook intercepts depend on the webhook configuration, so that the webhook is ready
+ * before the objects are created. Webhooks whose `failurePolicy` is `Ignore` don't affect the ordering, and the objects
+ * in the namespace of the webhook's Service are assumed to be part of the webhook's installation.
+ *
  * ### Explicit Dependency Ordering
  * Pulumi supports the `config.kubernetes.io/depends-on` annotation to declare an explicit dependency on a given resource.
  * The annotation accepts a list of resource references, delimited by commas.
  *
- * Note that references to resources outside the ConfigGroup aren't supported.
+ * A reference to a resource outside the ConfigGroup is assumed to exist in the cluster already, and isn't waited for.
  *
  * **Resource reference**
  *
@@ -119,6 +148,10 @@ export class ConfigGroup extends pulumi.ComponentResource {
      * Resources created by the ConfigGroup.
      */
     declare public /*out*/ readonly resources: pulumi.Output<any[]>;
+    /**
+     * The objects of the ConfigGroup that weren't registered because of the `include` and `exclude` selectors, e.g. `v1/Namespace:my-namespace`.
+     */
+    declare public /*out*/ readonly skipped: pulumi.Output<string[]>;
 
     /**
      * Create a ConfigGroup resource with the given unique name, arguments, and options.
@@ -131,14 +164,21 @@ export class ConfigGroup extends pulumi.ComponentResource {
         let resourceInputs: pulumi.Inputs = {};
         opts = opts || {};
         if (!opts.id) {
+            resourceInputs["exclude"] = args?.exclude;
             resourceInputs["files"] = args?.files;
+            resourceInputs["include"] = args?.include;
             resourceInputs["objs"] = args?.objs;
+            resourceInputs["patches"] = args?.patches;
             resourceInputs["resourcePrefix"] = args?.resourcePrefix;
             resourceInputs["skipAwait"] = args?.skipAwait;
+            resourceInputs["templateEngine"] = args?.templateEngine;
+            resourceInputs["values"] = args?.values;
             resourceInputs["yaml"] = args?.yaml;
             resourceInputs["resources"] = undefined /*out*/;
+            resourceInputs["skipped"] = undefined /*out*/;
         } else {
             resourceInputs["resources"] = undefined /*out*/;
+            resourceInputs["skipped"] = undefined /*out*/;
         }
         opts = pulumi.mergeOptions(utilities.resourceOptsDefaults(), opts);
         super(ConfigGroup.__pulumiType, name, resourceInputs, opts, true /*remote*/);
@@ -150,13 +190,25 @@ export class ConfigGroup extends pulumi.ComponentResource {
  */
 export interface ConfigGroupArgs {
     /**
-     * Set of paths and/or URLs to Kubernetes manifest files. Supports glob patterns.
+     * Selects the objects not to register, among the included objects. The skipped objects are reported in the `skipped` output.
+     */
+    exclude?: pulumi.Input<pulumi.Input<inputs.yaml.v2.Selector>[] | undefined>;
+    /**
+     * Set of paths and/or URLs to Kubernetes manifest files. Supports glob patterns. A URL may be pinned to the SHA-256 digest of its content with a `#sha256=<digest>` fragment; the content is verified, and cached so that it's downloaded only once.
      */
     files?: pulumi.Input<pulumi.Input<string>[] | undefined>;
+    /**
+     * Selects the objects to register. An object is registered if it matches any of the selectors; by default, all objects are registered.
+     */
+    include?: pulumi.Input<pulumi.Input<inputs.yaml.v2.Selector>[] | undefined>;
     /**
      * Objects representing Kubernetes resource configurations.
      */
     objs?: pulumi.Input<any[] | undefined>;
+    /**
+     * Patches to apply to the objects (including `objs`) before they're registered, in order. The patches are applied in-process, with kustomize.
+     */
+    patches?: pulumi.Input<pulumi.Input<inputs.yaml.v2.Patch>[] | undefined>;
     /**
      * A prefix for the auto-generated resource names. Defaults to the name of the ConfigGroup. Example: A resource created with resourcePrefix="foo" would produce a resource named "foo-resourceName".
      */
@@ -165,6 +217,14 @@ export interface ConfigGroupArgs {
      * Indicates that child resources should skip the await logic. Defaults to `false`.
      */
     skipAwait?: pulumi.Input<boolean | undefined>;
+    /**
+     * The engine with which to template the files and YAML literals: `goTemplate` for Go templates, or `envsubst` for `${VAR}` and `${VAR:-default}` references to the values (by dotted paths, e.g. `${image.tag}`). References to missing values without a default are left as is, and `$${VAR}` escapes a reference. Defaults to `goTemplate` when `values` is set; otherwise, the files and YAML literals aren't templated.
+     */
+    templateEngine?: pulumi.Input<string | undefined>;
+    /**
+     * Values with which to template the files and YAML literals (but not `objs`) before they're parsed. With the `goTemplate` engine, the values are available as `.Values`, along with the Sprig functions.
+     */
+    values?: pulumi.Input<{[key: string]: any} | undefined>;
     /**
      * A Kubernetes YAML manifest containing Kubernetes resource configuration(s).
      */
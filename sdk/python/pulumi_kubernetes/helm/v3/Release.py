@@ -27,6 +27,7 @@ class ReleaseArgs:
                  cleanup_on_fail: pulumi.Input[Optional[_builtins.bool]] = None,
                  compat: pulumi.Input[Optional[_builtins.str]] = None,
                  create_namespace: pulumi.Input[Optional[_builtins.bool]] = None,
+                 dependency_mode: pulumi.Input[Optional[_builtins.str]] = None,
                  dependency_update: pulumi.Input[Optional[_builtins.bool]] = None,
                  description: pulumi.Input[Optional[_builtins.str]] = None,
                  devel: pulumi.Input[Optional[_builtins.bool]] = None,
@@ -64,6 +65,7 @@ class ReleaseArgs:
         :param pulumi.Input[_builtins.bool] atomic: If set, installation process purges chart on fail. `skipAwait` will be disabled automatically if atomic is used.
         :param pulumi.Input[_builtins.bool] cleanup_on_fail: Allow deletion of new resources created in this upgrade when upgrade fails.
         :param pulumi.Input[_builtins.bool] create_namespace: Create the namespace if it does not exist.
+        :param pulumi.Input[_builtins.str] dependency_mode: How to resolve the chart's dependencies. By default, missing dependencies are fetched when `dependencyUpdate` is set. Values are: `locked` (require a `Chart.lock` that matches `Chart.yaml`, and fetch only the locked versions), `vendor` (resolve the dependencies into the `charts/` directory of a local chart, to be committed alongside the program; a preview resolves them into a temporary copy of the chart instead), and `offline` (never fetch dependencies; they must already be present in the chart).
         :param pulumi.Input[_builtins.bool] dependency_update: Run helm dependency update before installing the chart.
         :param pulumi.Input[_builtins.str] description: Add a custom description
         :param pulumi.Input[_builtins.bool] devel: Use chart development versions, too. Equivalent to version '>0.0.0-0'. If `version` is set, this is ignored.
@@ -105,6 +107,8 @@ class ReleaseArgs:
             pulumi.set(__self__, "compat", 'true')
         if create_namespace is not None:
             pulumi.set(__self__, "create_namespace", create_namespace)
+        if dependency_mode is not None:
+            pulumi.set(__self__, "dependency_mode", dependency_mode)
         if dependency_update is not None:
             pulumi.set(__self__, "dependency_update", dependency_update)
         if description is not None:
@@ -233,6 +237,18 @@ class ReleaseArgs:
     def create_namespace(self, value: pulumi.Input[Optional[_builtins.bool]]):
         pulumi.set(self, "create_namespace", value)
 
+    @_builtins.property
+    @pulumi.getter(name="dependencyMode")
+    def dependency_mode(self) -> pulumi.Input[Optional[_builtins.str]]:
+        """
+        How to resolve the chart's dependencies. By default, missing dependencies are fetched when `dependencyUpdate` is set. Values are: `locked` (require a `Chart.lock` that matches `Chart.yaml`, and fetch only the locked versions), `vendor` (resolve the dependencies into the `charts/` directory of a local chart, to be committed alongside the program; a preview resolves them into a temporary copy of the chart instead), and `offline` (never fetch dependencies; they must already be present in the chart).
+        """
+        return pulumi.get(self, "dependency_mode")
+
+    @dependency_mode.setter
+    def dependency_mode(self, value: pulumi.Input[Optional[_builtins.str]]):
+        pulumi.set(self, "dependency_mode", value)
+
     @_builtins.property
     @pulumi.getter(name="dependencyUpdate")
     def dependency_update(self) -> pulumi.Input[Optional[_builtins.bool]]:
@@ -594,6 +610,7 @@ class Release(pulumi.CustomResource):
                  cleanup_on_fail: pulumi.Input[Optional[_builtins.bool]] = None,
                  compat: pulumi.Input[Optional[_builtins.str]] = None,
                  create_namespace: pulumi.Input[Optional[_builtins.bool]] = None,
+                 dependency_mode: pulumi.Input[Optional[_builtins.str]] = None,
                  dependency_update: pulumi.Input[Optional[_builtins.bool]] = None,
                  description: pulumi.Input[Optional[_builtins.str]] = None,
                  devel: pulumi.Input[Optional[_builtins.bool]] = None,
@@ -632,6 +649,11 @@ class Release(pulumi.CustomResource):
         a library to perform the orchestration of the resources. As a result, the full spectrum of Helm features are supported
         natively.
 
+        When the provider renders manifests (`renderYamlToDirectory`), a `Release` isn't installed. Instead, its chart is
+        rendered client-side, as with `helm template`, and its objects are written to the render directory alongside the other
+        resources, in the layout of `renderYamlFormat`. The chart's hooks are written apart from its objects, to
+        `hooks/<namespace>-<name>.yaml`, in the order of their weights.
+
         You may also want to consider the `Chart` resource as an alternative method for managing helm charts. For more information about the trade-offs between these options see: [Choosing the right Helm resource for your use case](https://www.pulumi.com/registry/packages/kubernetes/how-to-guides/choosing-the-right-helm-resource-for-your-use-case)
 
         ## Example Usage
@@ -797,6 +819,7 @@ class Release(pulumi.CustomResource):
         :param pulumi.Input[_builtins.str] chart: Chart name to be installed. A path may be used.
         :param pulumi.Input[_builtins.bool] cleanup_on_fail: Allow deletion of new resources created in this upgrade when upgrade fails.
         :param pulumi.Input[_builtins.bool] create_namespace: Create the namespace if it does not exist.
+        :param pulumi.Input[_builtins.str] dependency_mode: How to resolve the chart's dependencies. By default, missing dependencies are fetched when `dependencyUpdate` is set. Values are: `locked` (require a `Chart.lock` that matches `Chart.yaml`, and fetch only the locked versions), `vendor` (resolve the dependencies into the `charts/` directory of a local chart, to be committed alongside the program; a preview resolves them into a temporary copy of the chart instead), and `offline` (never fetch dependencies; they must already be present in the chart).
         :param pulumi.Input[_builtins.bool] dependency_update: Run helm dependency update before installing the chart.
         :param pulumi.Input[_builtins.str] description: Add a custom description
         :param pulumi.Input[_builtins.bool] devel: Use chart development versions, too. Equivalent to version '>0.0.0-0'. If `version` is set, this is ignored.
@@ -841,6 +864,11 @@ class Release(pulumi.CustomResource):
         a library to perform the orchestration of the resources. As a result, the full spectrum of Helm features are supported
         natively.
 
+        When the provider renders manifests (`renderYamlToDirectory`), a `Release` isn't installed. Instead, its chart is
+        rendered client-side, as with `helm template`, and its objects are written to the render directory alongside the other
+        resources, in the layout of `renderYamlFormat`. The chart's hooks are written apart from its objects, to
+        `hooks/<namespace>-<name>.yaml`, in the order of their weights.
+
         You may also want to consider the `Chart` resource as an alternative method for managing helm charts. For more information about the trade-offs between these options see: [Choosing the right Helm resource for your use case](https://www.pulumi.com/registry/packages/kubernetes/how-to-guides/choosing-the-right-helm-resource-for-your-use-case)
 
         ## Example Usage
@@ -1020,6 +1048,7 @@ class Release(pulumi.CustomResource):
                  cleanup_on_fail: pulumi.Input[Optional[_builtins.bool]] = None,
                  compat: pulumi.Input[Optional[_builtins.str]] = None,
                  create_namespace: pulumi.Input[Optional[_builtins.bool]] = None,
+                 dependency_mode: pulumi.Input[Optional[_builtins.str]] = None,
                  dependency_update: pulumi.Input[Optional[_builtins.bool]] = None,
                  description: pulumi.Input[Optional[_builtins.str]] = None,
                  devel: pulumi.Input[Optional[_builtins.bool]] = None,
@@ -1066,6 +1095,7 @@ class Release(pulumi.CustomResource):
             __props__.__dict__["cleanup_on_fail"] = cleanup_on_fail
             __props__.__dict__["compat"] = 'true'
             __props__.__dict__["create_namespace"] = create_namespace
+            __props__.__dict__["dependency_mode"] = dependency_mode
             __props__.__dict__["dependency_update"] = dependency_update
             __props__.__dict__["description"] = description
             __props__.__dict__["devel"] = devel
@@ -1095,6 +1125,7 @@ class Release(pulumi.CustomResource):
             __props__.__dict__["verify"] = verify
             __props__.__dict__["version"] = version
             __props__.__dict__["wait_for_jobs"] = wait_for_jobs
+            __props__.__dict__["notes"] = None
             __props__.__dict__["status"] = None
         super(Release, __self__).__init__(
             'kubernetes:helm.sh/v3:Release',
@@ -1123,6 +1154,7 @@ class Release(pulumi.CustomResource):
         __props__.__dict__["chart"] = None
         __props__.__dict__["cleanup_on_fail"] = None
         __props__.__dict__["create_namespace"] = None
+        __props__.__dict__["dependency_mode"] = None
         __props__.__dict__["dependency_update"] = None
         __props__.__dict__["description"] = None
         __props__.__dict__["devel"] = None
@@ -1136,6 +1168,7 @@ class Release(pulumi.CustomResource):
         __props__.__dict__["max_history"] = None
         __props__.__dict__["name"] = None
         __props__.__dict__["namespace"] = None
+        __props__.__dict__["notes"] = None
         __props__.__dict__["postrender"] = None
         __props__.__dict__["recreate_pods"] = None
         __props__.__dict__["render_subchart_notes"] = None
@@ -1195,6 +1228,14 @@ class Release(pulumi.CustomResource):
         """
         return pulumi.get(self, "create_namespace")
 
+    @_builtins.property
+    @pulumi.getter(name="dependencyMode")
+    def dependency_mode(self) -> pulumi.Output[Optional[_builtins.str]]:
+        """
+        How to resolve the chart's dependencies. By default, missing dependencies are fetched when `dependencyUpdate` is set. Values are: `locked` (require a `Chart.lock` that matches `Chart.yaml`, and fetch only the locked versions), `vendor` (resolve the dependencies into the `charts/` directory of a local chart, to be committed alongside the program; a preview resolves them into a temporary copy of the chart instead), and `offline` (never fetch dependencies; they must already be present in the chart).
+        """
+        return pulumi.get(self, "dependency_mode")
+
     @_builtins.property
     @pulumi.getter(name="dependencyUpdate")
     def dependency_update(self) -> pulumi.Output[Optional[_builtins.bool]]:
@@ -1299,6 +1340,14 @@ class Release(pulumi.CustomResource):
         """
         return pulumi.get(self, "namespace")
 
+    @_builtins.property
+    @pulumi.getter
+    def notes(self) -> pulumi.Output[Optional[_builtins.str]]:
+        """
+        The rendered notes (NOTES.txt) of the deployed release.
+        """
+        return pulumi.get(self, "notes")
+
     @_builtins.property
     @pulumi.getter
     def postrender(self) -> pulumi.Output[Optional[_builtins.str]]:
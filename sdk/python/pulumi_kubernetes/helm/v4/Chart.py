@@ -21,48 +21,76 @@ __all__ = ['ChartArgs', 'Chart']
 class ChartArgs:
     def __init__(__self__, *,
                  chart: pulumi.Input[_builtins.str],
+                 allow_cluster_lookup: pulumi.Input[Optional['ClusterLookupArgs']] = None,
+                 apply_hooks: pulumi.Input[Optional[_builtins.bool]] = None,
+                 crd_policy: pulumi.Input[Optional[_builtins.str]] = None,
+                 dependency_mode: pulumi.Input[Optional[_builtins.str]] = None,
                  dependency_update: pulumi.Input[Optional[_builtins.bool]] = None,
                  devel: pulumi.Input[Optional[_builtins.bool]] = None,
+                 exclude: pulumi.Input[Optional[Sequence[pulumi.Input['SelectorArgs']]]] = None,
+                 include: pulumi.Input[Optional[Sequence[pulumi.Input['SelectorArgs']]]] = None,
                  include_hooks: pulumi.Input[Optional[_builtins.bool]] = None,
                  keyring: pulumi.Input[Optional[Union[pulumi.Asset, pulumi.Archive]]] = None,
                  name: pulumi.Input[Optional[_builtins.str]] = None,
                  namespace: pulumi.Input[Optional[_builtins.str]] = None,
                  plain_http: pulumi.Input[Optional[_builtins.bool]] = None,
                  post_renderer: pulumi.Input[Optional['PostRendererArgs']] = None,
+                 post_renderers: pulumi.Input[Optional[Sequence[pulumi.Input['BuiltinPostRendererArgs']]]] = None,
                  repository_opts: pulumi.Input[Optional['RepositoryOptsArgs']] = None,
                  resource_prefix: pulumi.Input[Optional[_builtins.str]] = None,
                  skip_await: pulumi.Input[Optional[_builtins.bool]] = None,
                  skip_crds: pulumi.Input[Optional[_builtins.bool]] = None,
                  value_yaml_files: pulumi.Input[Optional[Sequence[pulumi.Input[Union[pulumi.Asset, pulumi.Archive]]]]] = None,
                  values: pulumi.Input[Optional[Mapping[str, Any]]] = None,
+                 values_from: pulumi.Input[Optional[Sequence[pulumi.Input['ValuesReferenceArgs']]]] = None,
                  verify: pulumi.Input[Optional[_builtins.bool]] = None,
                  version: pulumi.Input[Optional[_builtins.str]] = None):
         """
         The set of arguments for constructing a Chart resource.
 
         :param pulumi.Input[_builtins.str] chart: Chart name to be installed. A path may be used.
+        :param pulumi.Input['ClusterLookupArgs'] allow_cluster_lookup: Restricts the chart's `lookup` function to a read-only view of the cluster, scoped to the given kinds and namespaces. Lookups are cached for the duration of the deployment, and the values of looked up Secrets are marked as secret wherever they appear in the rendered resources. By default, the `lookup` function has the provider's access to the cluster.
+        :param pulumi.Input[_builtins.bool] apply_hooks: Set this to true to apply the chart's install and upgrade hooks (resources annotated with `helm.sh/hook`) in order, as Helm does. The chart is installed if its first resource doesn't exist in the cluster yet, and upgraded otherwise. The `pre-install` or `pre-upgrade` hooks are applied before the chart's other resources, and the `post-install` or `post-upgrade` hooks after them, in order of `helm.sh/hook-weight`. Upgrade hooks (e.g. migration Jobs) run again whenever the rendered chart changes, their previous resources being deleted first, as with the default `before-hook-creation` delete policy. The resources of install hooks are left in the cluster once the chart is upgraded. The `hook-succeeded` and `hook-failed` delete policies are not supported: the resources of a hook are managed like the chart's other resources, so they remain in the cluster (and in the stack) once the hook has run, and a warning is produced. Delete and rollback hooks are not supported either, and produce a warning. Test hooks are always excluded. This setting has no effect in render mode; see `includeHooks`.
+        :param pulumi.Input[_builtins.str] crd_policy: The policy for the CRDs in the chart's `crds/` directory: `create` creates the CRDs and leaves them unchanged on upgrade (CRDs that exist already, and that Pulumi doesn't manage, are left as they are), `createReplace` creates the CRDs and updates them on upgrade, `patch` applies the CRDs as server-side apply patches (`CustomResourceDefinitionPatch`), and `skip` neither creates nor updates them. With a policy, the CRDs are applied before the rest of the chart, and they are never deleted, neither when a new chart version drops them nor when the chart is deleted. By default, the CRDs are managed like the chart's other resources.
+        :param pulumi.Input[_builtins.str] dependency_mode: How to resolve the chart's dependencies. By default, missing dependencies are fetched when `dependencyUpdate` is set or a `Chart.lock` is present. Values are: `locked` (require a `Chart.lock` that matches `Chart.yaml`, and fetch only the locked versions), `vendor` (resolve the dependencies into the `charts/` directory of a local chart, to be committed alongside the program; a preview resolves them into a temporary copy of the chart instead), and `offline` (never fetch dependencies; they must already be present in the chart).
         :param pulumi.Input[_builtins.bool] dependency_update: Run helm dependency update before installing the chart.
         :param pulumi.Input[_builtins.bool] devel: Use chart development versions, too. Equivalent to version '>0.0.0-0'. If `version` is set, this is ignored.
-        :param pulumi.Input[_builtins.bool] include_hooks: By default, Helm hook resources (those annotated with `helm.sh/hook`) are omitted from the rendered output. When the provider is configured with `renderYamlToDirectory`, set this to true to include hook resources in the rendered manifests so that another tool (e.g. Argo CD) can apply them. Test hooks (`helm.sh/hook: test`) are always excluded. This setting has no effect outside of render mode, where hooks are not supported.
+        :param pulumi.Input[Sequence[pulumi.Input['SelectorArgs']]] exclude: Selects the objects not to register, among the included objects. The skipped objects are reported in the `skipped` output.
+        :param pulumi.Input[Sequence[pulumi.Input['SelectorArgs']]] include: Selects the objects to register. An object is registered if it matches any of the selectors; by default, all objects are registered.
+        :param pulumi.Input[_builtins.bool] include_hooks: By default, Helm hook resources (those annotated with `helm.sh/hook`) are omitted from the rendered output. When the provider is configured with `renderYamlToDirectory`, set this to true to include hook resources in the rendered manifests so that another tool (e.g. Argo CD) can apply them. Test hooks (`helm.sh/hook: test`) are always excluded. This setting has no effect outside of render mode, where `applyHooks` applies the hooks instead.
         :param pulumi.Input[Union[pulumi.Asset, pulumi.Archive]] keyring: Location of public keys used for verification. Used only if `verify` is true
         :param pulumi.Input[_builtins.str] name: Release name.
         :param pulumi.Input[_builtins.str] namespace: Namespace for the release.
         :param pulumi.Input[_builtins.bool] plain_http: Use insecure HTTP for the chart download instead of HTTPS.
         :param pulumi.Input['PostRendererArgs'] post_renderer: Specification defining the post-renderer to use.
+        :param pulumi.Input[Sequence[pulumi.Input['BuiltinPostRendererArgs']]] post_renderers: Built-in post-renderers to apply to the rendered manifests, in order. Built-in post-renderers run in-process and don't require an executable. They run after the `postRenderer` command, if any.
         :param pulumi.Input['RepositoryOptsArgs'] repository_opts: Specification defining the Helm chart repository to use.
         :param pulumi.Input[_builtins.str] resource_prefix: An optional prefix for the auto-generated resource names. Example: A resource created with resourcePrefix="foo" would produce a resource named "foo:resourceName".
         :param pulumi.Input[_builtins.bool] skip_await: By default, the provider waits until all resources are in a ready state before marking the release as successful. Setting this to true will skip such await logic.
         :param pulumi.Input[_builtins.bool] skip_crds: If set, no CRDs will be installed. By default, CRDs are installed if not already present.
         :param pulumi.Input[Sequence[pulumi.Input[Union[pulumi.Asset, pulumi.Archive]]]] value_yaml_files: List of assets (raw yaml files). Content is read and merged with values.
         :param pulumi.Input[Mapping[str, Any]] values: Custom values set for the release.
+        :param pulumi.Input[Sequence[pulumi.Input['ValuesReferenceArgs']]] values_from: References to values held in ConfigMaps and Secrets, which are read from the cluster when the chart is rendered. The referenced values are merged in order, after `valueYamlFiles` and before `values`. Values read from Secrets are marked as secret wherever they appear in the rendered resources, unless `values` overrides them.
         :param pulumi.Input[_builtins.bool] verify: Verify the chart's integrity.
         :param pulumi.Input[_builtins.str] version: Specify the chart version to install. If this is not specified, the latest version is installed.
         """
         pulumi.set(__self__, "chart", chart)
+        if allow_cluster_lookup is not None:
+            pulumi.set(__self__, "allow_cluster_lookup", allow_cluster_lookup)
+        if apply_hooks is not None:
+            pulumi.set(__self__, "apply_hooks", apply_hooks)
+        if crd_policy is not None:
+            pulumi.set(__self__, "crd_policy", crd_policy)
+        if dependency_mode is not None:
+            pulumi.set(__self__, "dependency_mode", dependency_mode)
         if dependency_update is not None:
             pulumi.set(__self__, "dependency_update", dependency_update)
         if devel is not None:
             pulumi.set(__self__, "devel", devel)
+        if exclude is not None:
+            pulumi.set(__self__, "exclude", exclude)
+        if include is not None:
+            pulumi.set(__self__, "include", include)
         if include_hooks is not None:
             pulumi.set(__self__, "include_hooks", include_hooks)
         if keyring is not None:
@@ -75,6 +103,8 @@ class ChartArgs:
             pulumi.set(__self__, "plain_http", plain_http)
         if post_renderer is not None:
             pulumi.set(__self__, "post_renderer", post_renderer)
+        if post_renderers is not None:
+            pulumi.set(__self__, "post_renderers", post_renderers)
         if repository_opts is not None:
             pulumi.set(__self__, "repository_opts", repository_opts)
         if resource_prefix is not None:
@@ -87,6 +117,8 @@ class ChartArgs:
             pulumi.set(__self__, "value_yaml_files", value_yaml_files)
         if values is not None:
             pulumi.set(__self__, "values", values)
+        if values_from is not None:
+            pulumi.set(__self__, "values_from", values_from)
         if verify is not None:
             pulumi.set(__self__, "verify", verify)
         if version is not None:
@@ -104,6 +136,54 @@ class ChartArgs:
     def chart(self, value: pulumi.Input[_builtins.str]):
         pulumi.set(self, "chart", value)
 
+    @_builtins.property
+    @pulumi.getter(name="allowClusterLookup")
+    def allow_cluster_lookup(self) -> pulumi.Input[Optional['ClusterLookupArgs']]:
+        """
+        Restricts the chart's `lookup` function to a read-only view of the cluster, scoped to the given kinds and namespaces. Lookups are cached for the duration of the deployment, and the values of looked up Secrets are marked as secret wherever they appear in the rendered resources. By default, the `lookup` function has the provider's access to the cluster.
+        """
+        return pulumi.get(self, "allow_cluster_lookup")
+
+    @allow_cluster_lookup.setter
+    def allow_cluster_lookup(self, value: pulumi.Input[Optional['ClusterLookupArgs']]):
+        pulumi.set(self, "allow_cluster_lookup", value)
+
+    @_builtins.property
+    @pulumi.getter(name="applyHooks")
+    def apply_hooks(self) -> pulumi.Input[Optional[_builtins.bool]]:
+        """
+        Set this to true to apply the chart's install and upgrade hooks (resources annotated with `helm.sh/hook`) in order, as Helm does. The chart is installed if its first resource doesn't exist in the cluster yet, and upgraded otherwise. The `pre-install` or `pre-upgrade` hooks are applied before the chart's other resources, and the `post-install` or `post-upgrade` hooks after them, in order of `helm.sh/hook-weight`. Upgrade hooks (e.g. migration Jobs) run again whenever the rendered chart changes, their previous resources being deleted first, as with the default `before-hook-creation` delete policy. The resources of install hooks are left in the cluster once the chart is upgraded. The `hook-succeeded` and `hook-failed` delete policies are not supported: the resources of a hook are managed like the chart's other resources, so they remain in the cluster (and in the stack) once the hook has run, and a warning is produced. Delete and rollback hooks are not supported either, and produce a warning. Test hooks are always excluded. This setting has no effect in render mode; see `includeHooks`.
+        """
+        return pulumi.get(self, "apply_hooks")
+
+    @apply_hooks.setter
+    def apply_hooks(self, value: pulumi.Input[Optional[_builtins.bool]]):
+        pulumi.set(self, "apply_hooks", value)
+
+    @_builtins.property
+    @pulumi.getter(name="crdPolicy")
+    def crd_policy(self) -> pulumi.Input[Optional[_builtins.str]]:
+        """
+        The policy for the CRDs in the chart's `crds/` directory: `create` creates the CRDs and leaves them unchanged on upgrade (CRDs that exist already, and that Pulumi doesn't manage, are left as they are), `createReplace` creates the CRDs and updates them on upgrade, `patch` applies the CRDs as server-side apply patches (`CustomResourceDefinitionPatch`), and `skip` neither creates nor updates them. With a policy, the CRDs are applied before the rest of the chart, and they are never deleted, neither when a new chart version drops them nor when the chart is deleted. By default, the CRDs are managed like the chart's other resources.
+        """
+        return pulumi.get(self, "crd_policy")
+
+    @crd_policy.setter
+    def crd_policy(self, value: pulumi.Input[Optional[_builtins.str]]):
+        pulumi.set(self, "crd_policy", value)
+
+    @_builtins.property
+    @pulumi.getter(name="dependencyMode")
+    def dependency_mode(self) -> pulumi.Input[Optional[_builtins.str]]:
+        """
+        How to resolve the chart's dependencies. By default, missing dependencies are fetched when `dependencyUpdate` is set or a `Chart.lock` is present. Values are: `locked` (require a `Chart.lock` that matches `Chart.yaml`, and fetch only the locked versions), `vendor` (resolve the dependencies into the `charts/` directory of a local chart, to be committed alongside the program; a preview resolves them into a temporary copy of the chart instead), and `offline` (never fetch dependencies; they must already be present in the chart).
+        """
+        return pulumi.get(self, "dependency_mode")
+
+    @dependency_mode.setter
+    def dependency_mode(self, value: pulumi.Input[Optional[_builtins.str]]):
+        pulumi.set(self, "dependency_mode", value)
+
     @_builtins.property
     @pulumi.getter(name="dependencyUpdate")
     def dependency_update(self) -> pulumi.Input[Optional[_builtins.bool]]:
@@ -128,11 +208,35 @@ class ChartArgs:
     def devel(self, value: pulumi.Input[Optional[_builtins.bool]]):
         pulumi.set(self, "devel", value)
 
+    @_builtins.property
+    @pulumi.getter
+    def exclude(self) -> pulumi.Input[Optional[Sequence[pulumi.Input['SelectorArgs']]]]:
+        """
+        Selects the objects not to register, among the included objects. The skipped objects are reported in the `skipped` output.
+        """
+        return pulumi.get(self, "exclude")
+
+    @exclude.setter
+    def exclude(self, value: pulumi.Input[Optional[Sequence[pulumi.Input['SelectorArgs']]]]):
+        pulumi.set(self, "exclude", value)
+
+    @_builtins.property
+    @pulumi.getter
+    def include(self) -> pulumi.Input[Optional[Sequence[pulumi.Input['SelectorArgs']]]]:
+        """
+        Selects the objects to register. An object is registered if it matches any of the selectors; by default, all objects are registered.
+        """
+        return pulumi.get(self, "include")
+
+    @include.setter
+    def include(self, value: pulumi.Input[Optional[Sequence[pulumi.Input['SelectorArgs']]]]):
+        pulumi.set(self, "include", value)
+
     @_builtins.property
     @pulumi.getter(name="includeHooks")
     def include_hooks(self) -> pulumi.Input[Optional[_builtins.bool]]:
         """
-        By default, Helm hook resources (those annotated with `helm.sh/hook`) are omitted from the rendered output. When the provider is configured with `renderYamlToDirectory`, set this to true to include hook resources in the rendered manifests so that another tool (e.g. Argo CD) can apply them. Test hooks (`helm.sh/hook: test`) are always excluded. This setting has no effect outside of render mode, where hooks are not supported.
+        By default, Helm hook resources (those annotated with `helm.sh/hook`) are omitted from the rendered output. When the provider is configured with `renderYamlToDirectory`, set this to true to include hook resources in the rendered manifests so that another tool (e.g. Argo CD) can apply them. Test hooks (`helm.sh/hook: test`) are always excluded. This setting has no effect outside of render mode, where `applyHooks` applies the hooks instead.
         """
         return pulumi.get(self, "include_hooks")
 
@@ -200,6 +304,18 @@ class ChartArgs:
     def post_renderer(self, value: pulumi.Input[Optional['PostRendererArgs']]):
         pulumi.set(self, "post_renderer", value)
 
+    @_builtins.property
+    @pulumi.getter(name="postRenderers")
+    def post_renderers(self) -> pulumi.Input[Optional[Sequence[pulumi.Input['BuiltinPostRendererArgs']]]]:
+        """
+        Built-in post-renderers to apply to the rendered manifests, in order. Built-in post-renderers run in-process and don't require an executable. They run after the `postRenderer` command, if any.
+        """
+        return pulumi.get(self, "post_renderers")
+
+    @post_renderers.setter
+    def post_renderers(self, value: pulumi.Input[Optional[Sequence[pulumi.Input['BuiltinPostRendererArgs']]]]):
+        pulumi.set(self, "post_renderers", value)
+
     @_builtins.property
     @pulumi.getter(name="repositoryOpts")
     def repository_opts(self) -> pulumi.Input[Optional['RepositoryOptsArgs']]:
@@ -272,6 +388,18 @@ class ChartArgs:
     def values(self, value: pulumi.Input[Optional[Mapping[str, Any]]]):
         pulumi.set(self, "values", value)
 
+    @_builtins.property
+    @pulumi.getter(name="valuesFrom")
+    def values_from(self) -> pulumi.Input[Optional[Sequence[pulumi.Input['ValuesReferenceArgs']]]]:
+        """
+        References to values held in ConfigMaps and Secrets, which are read from the cluster when the chart is rendered. The referenced values are merged in order, after `valueYamlFiles` and before `values`. Values read from Secrets are marked as secret wherever they appear in the rendered resources, unless `values` overrides them.
+        """
+        return pulumi.get(self, "values_from")
+
+    @values_from.setter
+    def values_from(self, value: pulumi.Input[Optional[Sequence[pulumi.Input['ValuesReferenceArgs']]]]):
+        pulumi.set(self, "values_from", value)
+
     @_builtins.property
     @pulumi.getter
     def verify(self) -> pulumi.Input[Optional[_builtins.bool]]:
@@ -303,21 +431,29 @@ class Chart(pulumi.ComponentResource):
     def __init__(__self__,
                  resource_name: str,
                  opts: Optional[pulumi.ResourceOptions] = None,
+                 allow_cluster_lookup: pulumi.Input[Optional[Union['ClusterLookupArgs', 'ClusterLookupArgsDict']]] = None,
+                 apply_hooks: pulumi.Input[Optional[_builtins.bool]] = None,
                  chart: pulumi.Input[Optional[_builtins.str]] = None,
+                 crd_policy: pulumi.Input[Optional[_builtins.str]] = None,
+                 dependency_mode: pulumi.Input[Optional[_builtins.str]] = None,
                  dependency_update: pulumi.Input[Optional[_builtins.bool]] = None,
                  devel: pulumi.Input[Optional[_builtins.bool]] = None,
+                 exclude: pulumi.Input[Optional[Sequence[pulumi.Input[Union['SelectorArgs', 'SelectorArgsDict']]]]] = None,
+                 include: pulumi.Input[Optional[Sequence[pulumi.Input[Union['SelectorArgs', 'SelectorArgsDict']]]]] = None,
                  include_hooks: pulumi.Input[Optional[_builtins.bool]] = None,
                  keyring: pulumi.Input[Optional[Union[pulumi.Asset, pulumi.Archive]]] = None,
                  name: pulumi.Input[Optional[_builtins.str]] = None,
                  namespace: pulumi.Input[Optional[_builtins.str]] = None,
                  plain_http: pulumi.Input[Optional[_builtins.bool]] = None,
                  post_renderer: pulumi.Input[Optional[Union['PostRendererArgs', 'PostRendererArgsDict']]] = None,
+                 post_renderers: pulumi.Input[Optional[Sequence[pulumi.Input[Union['BuiltinPostRendererArgs', 'BuiltinPostRendererArgsDict']]]]] = None,
                  repository_opts: pulumi.Input[Optional[Union['RepositoryOptsArgs', 'RepositoryOptsArgsDict']]] = None,
                  resource_prefix: pulumi.Input[Optional[_builtins.str]] = None,
                  skip_await: pulumi.Input[Optional[_builtins.bool]] = None,
                  skip_crds: pulumi.Input[Optional[_builtins.bool]] = None,
                  value_yaml_files: pulumi.Input[Optional[Sequence[pulumi.Input[Union[pulumi.Asset, pulumi.Archive]]]]] = None,
                  values: pulumi.Input[Optional[Mapping[str, Any]]] = None,
+                 values_from: pulumi.Input[Optional[Sequence[pulumi.Input[Union['ValuesReferenceArgs', 'ValuesReferenceArgsDict']]]]] = None,
                  verify: pulumi.Input[Optional[_builtins.bool]] = None,
                  version: pulumi.Input[Optional[_builtins.str]] = None,
                  __props__=None):
@@ -387,6 +523,14 @@ class Chart(pulumi.ComponentResource):
 
         Use the `postRenderer` input to pipe the rendered manifest through a [post-rendering command](https://helm.sh/docs/topics/advanced/#post-rendering).
 
+        ### Selecting Objects
+
+        Use the `include` and `exclude` inputs to select the objects of the chart to register, e.g. to skip a Namespace
+        that's managed separately. Each selector matches objects by `group`, `version`, `kind`, `name` (a glob pattern),
+        `namespace` and `labelSelector`. An object is registered if it matches any of the included selectors (or if there are
+        none), and none of the excluded selectors. The selectors apply to the CRDs and hooks, too. The skipped objects are
+        reported in the `skipped` output.
+
         ### Resource Ordering
 
         Sometimes resources must be applied in a specific order. For example, a namespace resource must be
@@ -395,10 +539,20 @@ class Chart(pulumi.ComponentResource):
         Pulumi uses heuristics to determine which order to apply and delete objects within the Chart.  Pulumi also
         waits for each object to be fully reconciled, unless `skipAwait` is enabled.
 
+        Workloads depend on the ConfigMaps and Secrets in the same namespace that their pods reference (by volumes, `envFrom`,
+        `env` or `imagePullSecrets`), so that the referenced configuration is created first.
+
+        Admission webhook configurations depend on the Services that back them and on the workloads that those Services
+        select, and the objects that a webhook intercepts depend on the webhook configuration, so that the webhook is ready
+        before the objects are created. Webhooks whose `failurePolicy` is `Ignore` don't affect the ordering, and the objects
+        in the namespace of the webhook's Service are assumed to be part of the webhook's installation.
+
         Pulumi supports the `config.kubernetes.io/depends-on` annotation to declare an explicit dependency on a given resource.
         The annotation accepts a list of resource references, delimited by commas.
 
-        Note that references to resources outside the Chart aren't supported.
+        A reference to an object of the chart's CRDs, or of a hook that is applied earlier (e.g. a `pre-install` hook),
+        is honored. A reference to any other resource outside the Chart is assumed to exist in the cluster already,
+        and isn't waited for.
 
         **Resource reference**
 
@@ -492,21 +646,29 @@ class Chart(pulumi.ComponentResource):
 
         :param str resource_name: The name of the resource.
         :param pulumi.ResourceOptions opts: Options for the resource.
+        :param pulumi.Input[Union['ClusterLookupArgs', 'ClusterLookupArgsDict']] allow_cluster_lookup: Restricts the chart's `lookup` function to a read-only view of the cluster, scoped to the given kinds and namespaces. Lookups are cached for the duration of the deployment, and the values of looked up Secrets are marked as secret wherever they appear in the rendered resources. By default, the `lookup` function has the provider's access to the cluster.
+        :param pulumi.Input[_builtins.bool] apply_hooks: Set this to true to apply the chart's install and upgrade hooks (resources annotated with `helm.sh/hook`) in order, as Helm does. The chart is installed if its first resource doesn't exist in the cluster yet, and upgraded otherwise. The `pre-install` or `pre-upgrade` hooks are applied before the chart's other resources, and the `post-install` or `post-upgrade` hooks after them, in order of `helm.sh/hook-weight`. Upgrade hooks (e.g. migration Jobs) run again whenever the rendered chart changes, their previous resources being deleted first, as with the default `before-hook-creation` delete policy. The resources of install hooks are left in the cluster once the chart is upgraded. The `hook-succeeded` and `hook-failed` delete policies are not supported: the resources of a hook are managed like the chart's other resources, so they remain in the cluster (and in the stack) once the hook has run, and a warning is produced. Delete and rollback hooks are not supported either, and produce a warning. Test hooks are always excluded. This setting has no effect in render mode; see `includeHooks`.
         :param pulumi.Input[_builtins.str] chart: Chart name to be installed. A path may be used.
+        :param pulumi.Input[_builtins.str] crd_policy: The policy for the CRDs in the chart's `crds/` directory: `create` creates the CRDs and leaves them unchanged on upgrade (CRDs that exist already, and that Pulumi doesn't manage, are left as they are), `createReplace` creates the CRDs and updates them on upgrade, `patch` applies the CRDs as server-side apply patches (`CustomResourceDefinitionPatch`), and `skip` neither creates nor updates them. With a policy, the CRDs are applied before the rest of the chart, and they are never deleted, neither when a new chart version drops them nor when the chart is deleted. By default, the CRDs are managed like the chart's other resources.
+        :param pulumi.Input[_builtins.str] dependency_mode: How to resolve the chart's dependencies. By default, missing dependencies are fetched when `dependencyUpdate` is set or a `Chart.lock` is present. Values are: `locked` (require a `Chart.lock` that matches `Chart.yaml`, and fetch only the locked versions), `vendor` (resolve the dependencies into the `charts/` directory of a local chart, to be committed alongside the program; a preview resolves them into a temporary copy of the chart instead), and `offline` (never fetch dependencies; they must already be present in the chart).
         :param pulumi.Input[_builtins.bool] dependency_update: Run helm dependency update before installing the chart.
         :param pulumi.Input[_builtins.bool] devel: Use chart development versions, too. Equivalent to version '>0.0.0-0'. If `version` is set, this is ignored.
-        :param pulumi.Input[_builtins.bool] include_hooks: By default, Helm hook resources (those annotated with `helm.sh/hook`) are omitted from the rendered output. When the provider is configured with `renderYamlToDirectory`, set this to true to include hook resources in the rendered manifests so that another tool (e.g. Argo CD) can apply them. Test hooks (`helm.sh/hook: test`) are always excluded. This setting has no effect outside of render mode, where hooks are not supported.
+        :param pulumi.Input[Sequence[pulumi.Input[Union['SelectorArgs', 'SelectorArgsDict']]]] exclude: Selects the objects not to register, among the included objects. The skipped objects are reported in the `skipped` output.
+        :param pulumi.Input[Sequence[pulumi.Input[Union['SelectorArgs', 'SelectorArgsDict']]]] include: Selects the objects to register. An object is registered if it matches any of the selectors; by default, all objects are registered.
+        :param pulumi.Input[_builtins.bool] include_hooks: By default, Helm hook resources (those annotated with `helm.sh/hook`) are omitted from the rendered output. When the provider is configured with `renderYamlToDirectory`, set this to true to include hook resources in the rendered manifests so that another tool (e.g. Argo CD) can apply them. Test hooks (`helm.sh/hook: test`) are always excluded. This setting has no effect outside of render mode, where `applyHooks` applies the hooks instead.
         :param pulumi.Input[Union[pulumi.Asset, pulumi.Archive]] keyring: Location of public keys used for verification. Used only if `verify` is true
         :param pulumi.Input[_builtins.str] name: Release name.
         :param pulumi.Input[_builtins.str] namespace: Namespace for the release.
         :param pulumi.Input[_builtins.bool] plain_http: Use insecure HTTP for the chart download instead of HTTPS.
         :param pulumi.Input[Union['PostRendererArgs', 'PostRendererArgsDict']] post_renderer: Specification defining the post-renderer to use.
+        :param pulumi.Input[Sequence[pulumi.Input[Union['BuiltinPostRendererArgs', 'BuiltinPostRendererArgsDict']]]] post_renderers: Built-in post-renderers to apply to the rendered manifests, in order. Built-in post-renderers run in-process and don't require an executable. They run after the `postRenderer` command, if any.
         :param pulumi.Input[Union['RepositoryOptsArgs', 'RepositoryOptsArgsDict']] repository_opts: Specification defining the Helm chart repository to use.
         :param pulumi.Input[_builtins.str] resource_prefix: An optional prefix for the auto-generated resource names. Example: A resource created with resourcePrefix="foo" would produce a resource named "foo:resourceName".
         :param pulumi.Input[_builtins.bool] skip_await: By default, the provider waits until all resources are in a ready state before marking the release as successful. Setting this to true will skip such await logic.
         :param pulumi.Input[_builtins.bool] skip_crds: If set, no CRDs will be installed. By default, CRDs are installed if not already present.
         :param pulumi.Input[Sequence[pulumi.Input[Union[pulumi.Asset, pulumi.Archive]]]] value_yaml_files: List of assets (raw yaml files). Content is read and merged with values.
         :param pulumi.Input[Mapping[str, Any]] values: Custom values set for the release.
+        :param pulumi.Input[Sequence[pulumi.Input[Union['ValuesReferenceArgs', 'ValuesReferenceArgsDict']]]] values_from: References to values held in ConfigMaps and Secrets, which are read from the cluster when the chart is rendered. The referenced values are merged in order, after `valueYamlFiles` and before `values`. Values read from Secrets are marked as secret wherever they appear in the rendered resources, unless `values` overrides them.
         :param pulumi.Input[_builtins.bool] verify: Verify the chart's integrity.
         :param pulumi.Input[_builtins.str] version: Specify the chart version to install. If this is not specified, the latest version is installed.
         """
@@ -582,6 +744,14 @@ class Chart(pulumi.ComponentResource):
 
         Use the `postRenderer` input to pipe the rendered manifest through a [post-rendering command](https://helm.sh/docs/topics/advanced/#post-rendering).
 
+        ### Selecting Objects
+
+        Use the `include` and `exclude` inputs to select the objects of the chart to register, e.g. to skip a Namespace
+        that's managed separately. Each selector matches objects by `group`, `version`, `kind`, `name` (a glob pattern),
+        `namespace` and `labelSelector`. An object is registered if it matches any of the included selectors (or if there are
+        none), and none of the excluded selectors. The selectors apply to the CRDs and hooks, too. The skipped objects are
+        reported in the `skipped` output.
+
         ### Resource Ordering
 
         Sometimes resources must be applied in a specific order. For example, a namespace resource must be
@@ -590,10 +760,20 @@ class Chart(pulumi.ComponentResource):
         Pulumi uses heuristics to determine which order to apply and delete objects within the Chart.  Pulumi also
         waits for each object to be fully reconciled, unless `skipAwait` is enabled.
 
+        Workloads depend on the ConfigMaps and Secrets in the same namespace that their pods reference (by volumes, `envFrom`,
+        `env` or `imagePullSecrets`), so that the referenced configuration is created first.
+
+        Admission webhook configurations depend on the Services that back them and on the workloads that those Services
+        select, and the objects that a webhook intercepts depend on the webhook configuration, so that the webhook is ready
+        before the objects are created. Webhooks whose `failurePolicy` is `Ignore` don't affect the ordering, and the objects
+        in the namespace of the webhook's Service are assumed to be part of the webhook's installation.
+
         Pulumi supports the `config.kubernetes.io/depends-on` annotation to declare an explicit dependency on a given resource.
         The annotation accepts a list of resource references, delimited by commas.
 
-        Note that references to resources outside the Chart aren't supported.
+        A reference to an object of the chart's CRDs, or of a hook that is applied earlier (e.g. a `pre-install` hook),
+        is honored. A reference to any other resource outside the Chart is assumed to exist in the cluster already,
+        and isn't waited for.
 
         **Resource reference**
 
@@ -700,21 +880,29 @@ class Chart(pulumi.ComponentResource):
     def _internal_init(__self__,
                  resource_name: str,
                  opts: Optional[pulumi.ResourceOptions] = None,
+                 allow_cluster_lookup: pulumi.Input[Optional[Union['ClusterLookupArgs', 'ClusterLookupArgsDict']]] = None,
+                 apply_hooks: pulumi.Input[Optional[_builtins.bool]] = None,
                  chart: pulumi.Input[Optional[_builtins.str]] = None,
+                 crd_policy: pulumi.Input[Optional[_builtins.str]] = None,
+                 dependency_mode: pulumi.Input[Optional[_builtins.str]] = None,
                  dependency_update: pulumi.Input[Optional[_builtins.bool]] = None,
                  devel: pulumi.Input[Optional[_builtins.bool]] = None,
+                 exclude: pulumi.Input[Optional[Sequence[pulumi.Input[Union['SelectorArgs', 'SelectorArgsDict']]]]] = None,
+                 include: pulumi.Input[Optional[Sequence[pulumi.Input[Union['SelectorArgs', 'SelectorArgsDict']]]]] = None,
                  include_hooks: pulumi.Input[Optional[_builtins.bool]] = None,
                  keyring: pulumi.Input[Optional[Union[pulumi.Asset, pulumi.Archive]]] = None,
                  name: pulumi.Input[Optional[_builtins.str]] = None,
                  namespace: pulumi.Input[Optional[_builtins.str]] = None,
                  plain_http: pulumi.Input[Optional[_builtins.bool]] = None,
                  post_renderer: pulumi.Input[Optional[Union['PostRendererArgs', 'PostRendererArgsDict']]] = None,
+                 post_renderers: pulumi.Input[Optional[Sequence[pulumi.Input[Union['BuiltinPostRendererArgs', 'BuiltinPostRendererArgsDict']]]]] = None,
                  repository_opts: pulumi.Input[Optional[Union['RepositoryOptsArgs', 'RepositoryOptsArgsDict']]] = None,
                  resource_prefix: pulumi.Input[Optional[_builtins.str]] = None,
                  skip_await: pulumi.Input[Optional[_builtins.bool]] = None,
                  skip_crds: pulumi.Input[Optional[_builtins.bool]] = None,
                  value_yaml_files: pulumi.Input[Optional[Sequence[pulumi.Input[Union[pulumi.Asset, pulumi.Archive]]]]] = None,
                  values: pulumi.Input[Optional[Mapping[str, Any]]] = None,
+                 values_from: pulumi.Input[Optional[Sequence[pulumi.Input[Union['ValuesReferenceArgs', 'ValuesReferenceArgsDict']]]]] = None,
                  verify: pulumi.Input[Optional[_builtins.bool]] = None,
                  version: pulumi.Input[Optional[_builtins.str]] = None,
                  __props__=None):
@@ -728,26 +916,36 @@ class Chart(pulumi.ComponentResource):
                 raise TypeError('__props__ is only valid when passed in combination with a valid opts.id to get an existing resource')
             __props__ = ChartArgs.__new__(ChartArgs)
 
+            __props__.__dict__["allow_cluster_lookup"] = allow_cluster_lookup
+            __props__.__dict__["apply_hooks"] = apply_hooks
             if chart is None and not opts.urn:
                 raise TypeError("Missing required property 'chart'")
             __props__.__dict__["chart"] = chart
+            __props__.__dict__["crd_policy"] = crd_policy
+            __props__.__dict__["dependency_mode"] = dependency_mode
             __props__.__dict__["dependency_update"] = dependency_update
             __props__.__dict__["devel"] = devel
+            __props__.__dict__["exclude"] = exclude
+            __props__.__dict__["include"] = include
             __props__.__dict__["include_hooks"] = include_hooks
             __props__.__dict__["keyring"] = keyring
             __props__.__dict__["name"] = name
             __props__.__dict__["namespace"] = namespace
             __props__.__dict__["plain_http"] = plain_http
             __props__.__dict__["post_renderer"] = post_renderer
+            __props__.__dict__["post_renderers"] = post_renderers
             __props__.__dict__["repository_opts"] = repository_opts
             __props__.__dict__["resource_prefix"] = resource_prefix
             __props__.__dict__["skip_await"] = skip_await
             __props__.__dict__["skip_crds"] = skip_crds
             __props__.__dict__["value_yaml_files"] = value_yaml_files
             __props__.__dict__["values"] = values
+            __props__.__dict__["values_from"] = values_from
             __props__.__dict__["verify"] = verify
             __props__.__dict__["version"] = version
+            __props__.__dict__["notes"] = None
             __props__.__dict__["resources"] = None
+            __props__.__dict__["skipped"] = None
         super(Chart, __self__).__init__(
             'kubernetes:helm.sh/v4:Chart',
             resource_name,
@@ -755,6 +953,14 @@ class Chart(pulumi.ComponentResource):
             opts,
             remote=True)
 
+    @_builtins.property
+    @pulumi.getter
+    def notes(self) -> pulumi.Output[Optional[_builtins.str]]:
+        """
+        The rendered notes (NOTES.txt) of the Chart.
+        """
+        return pulumi.get(self, "notes")
+
     @_builtins.property
     @pulumi.getter
     def resources(self) -> pulumi.Output[Optional[Sequence[Any]]]:
@@ -763,3 +969,11 @@ class Chart(pulumi.ComponentResource):
         """
         return pulumi.get(self, "resources")
 
+    @_builtins.property
+    @pulumi.getter
+    def skipped(self) -> pulumi.Output[Optional[Sequence[_builtins.str]]]:
+        """
+        The objects of the Chart that weren't registered because of the `include` and `exclude` selectors, e.g. `v1/Namespace:my-namespace`.
+        """
+        return pulumi.get(self, "skipped")
+
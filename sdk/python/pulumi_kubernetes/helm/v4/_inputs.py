@@ -15,12 +15,470 @@ else:
 from ... import _utilities
 
 __all__ = [
+    'BuiltinPostRendererArgs',
+    'BuiltinPostRendererArgsDict',
+    'ClusterLookupArgs',
+    'ClusterLookupArgsDict',
+    'KustomizePatchArgs',
+    'KustomizePatchArgsDict',
+    'KustomizePatchTargetArgs',
+    'KustomizePatchTargetArgsDict',
+    'KustomizePostRendererArgs',
+    'KustomizePostRendererArgsDict',
+    'MetadataPostRendererArgs',
+    'MetadataPostRendererArgsDict',
     'PostRendererArgs',
     'PostRendererArgsDict',
     'RepositoryOptsArgs',
     'RepositoryOptsArgsDict',
+    'SelectorArgs',
+    'SelectorArgsDict',
+    'ValuesReferenceArgs',
+    'ValuesReferenceArgsDict',
 ]
 
+class BuiltinPostRendererArgsDict(TypedDict):
+    """
+    Specification of a built-in post-renderer. Exactly one of the post-renderers must be specified.
+    """
+    kustomize: NotRequired[pulumi.Input[Optional['KustomizePostRendererArgsDict']]]
+    """
+    Applies a set of Kustomize patches to the rendered manifests.
+    """
+    metadata: NotRequired[pulumi.Input[Optional['MetadataPostRendererArgsDict']]]
+    """
+    Injects labels and annotations into the rendered objects.
+    """
+
+@pulumi.input_type
+class BuiltinPostRendererArgs:
+    def __init__(__self__, *,
+                 kustomize: pulumi.Input[Optional['KustomizePostRendererArgs']] = None,
+                 metadata: pulumi.Input[Optional['MetadataPostRendererArgs']] = None):
+        """
+        Specification of a built-in post-renderer. Exactly one of the post-renderers must be specified.
+
+        :param pulumi.Input['KustomizePostRendererArgs'] kustomize: Applies a set of Kustomize patches to the rendered manifests.
+        :param pulumi.Input['MetadataPostRendererArgs'] metadata: Injects labels and annotations into the rendered objects.
+        """
+        if kustomize is not None:
+            pulumi.set(__self__, "kustomize", kustomize)
+        if metadata is not None:
+            pulumi.set(__self__, "metadata", metadata)
+
+    @_builtins.property
+    @pulumi.getter
+    def kustomize(self) -> pulumi.Input[Optional['KustomizePostRendererArgs']]:
+        """
+        Applies a set of Kustomize patches to the rendered manifests.
+        """
+        return pulumi.get(self, "kustomize")
+
+    @kustomize.setter
+    def kustomize(self, value: pulumi.Input[Optional['KustomizePostRendererArgs']]):
+        pulumi.set(self, "kustomize", value)
+
+    @_builtins.property
+    @pulumi.getter
+    def metadata(self) -> pulumi.Input[Optional['MetadataPostRendererArgs']]:
+        """
+        Injects labels and annotations into the rendered objects.
+        """
+        return pulumi.get(self, "metadata")
+
+    @metadata.setter
+    def metadata(self, value: pulumi.Input[Optional['MetadataPostRendererArgs']]):
+        pulumi.set(self, "metadata", value)
+
+
+class ClusterLookupArgsDict(TypedDict):
+    """
+    The scope of the cluster data that a chart may read with the `lookup` function.
+    """
+    kinds: NotRequired[pulumi.Input[Optional[Sequence[pulumi.Input[_builtins.str]]]]]
+    """
+    The kinds of objects that may be looked up, either as `Kind` or as `group/Kind` (e.g. `apps/Deployment`). All kinds may be looked up by default.
+    """
+    namespaces: NotRequired[pulumi.Input[Optional[Sequence[pulumi.Input[_builtins.str]]]]]
+    """
+    The namespaces in which objects may be looked up, or `*` for all namespaces. Only the release namespace is allowed by default. Cluster-scoped objects may be looked up regardless.
+    """
+
+@pulumi.input_type
+class ClusterLookupArgs:
+    def __init__(__self__, *,
+                 kinds: pulumi.Input[Optional[Sequence[pulumi.Input[_builtins.str]]]] = None,
+                 namespaces: pulumi.Input[Optional[Sequence[pulumi.Input[_builtins.str]]]] = None):
+        """
+        The scope of the cluster data that a chart may read with the `lookup` function.
+
+        :param pulumi.Input[Sequence[pulumi.Input[_builtins.str]]] kinds: The kinds of objects that may be looked up, either as `Kind` or as `group/Kind` (e.g. `apps/Deployment`). All kinds may be looked up by default.
+        :param pulumi.Input[Sequence[pulumi.Input[_builtins.str]]] namespaces: The namespaces in which objects may be looked up, or `*` for all namespaces. Only the release namespace is allowed by default. Cluster-scoped objects may be looked up regardless.
+        """
+        if kinds is not None:
+            pulumi.set(__self__, "kinds", kinds)
+        if namespaces is not None:
+            pulumi.set(__self__, "namespaces", namespaces)
+
+    @_builtins.property
+    @pulumi.getter
+    def kinds(self) -> pulumi.Input[Optional[Sequence[pulumi.Input[_builtins.str]]]]:
+        """
+        The kinds of objects that may be looked up, either as `Kind` or as `group/Kind` (e.g. `apps/Deployment`). All kinds may be looked up by default.
+        """
+        return pulumi.get(self, "kinds")
+
+    @kinds.setter
+    def kinds(self, value: pulumi.Input[Optional[Sequence[pulumi.Input[_builtins.str]]]]):
+        pulumi.set(self, "kinds", value)
+
+    @_builtins.property
+    @pulumi.getter
+    def namespaces(self) -> pulumi.Input[Optional[Sequence[pulumi.Input[_builtins.str]]]]:
+        """
+        The namespaces in which objects may be looked up, or `*` for all namespaces. Only the release namespace is allowed by default. Cluster-scoped objects may be looked up regardless.
+        """
+        return pulumi.get(self, "namespaces")
+
+    @namespaces.setter
+    def namespaces(self, value: pulumi.Input[Optional[Sequence[pulumi.Input[_builtins.str]]]]):
+        pulumi.set(self, "namespaces", value)
+
+
+class KustomizePatchArgsDict(TypedDict):
+    """
+    A Kustomize patch, either a strategic merge patch or a JSON 6902 patch.
+    """
+    patch: pulumi.Input[_builtins.str]
+    """
+    The content of the patch, in YAML or JSON.
+    """
+    target: NotRequired[pulumi.Input[Optional['KustomizePatchTargetArgsDict']]]
+    """
+    Selects the resources to patch. A strategic merge patch may omit the target, in which case the patch itself identifies the resource to patch.
+    """
+
+@pulumi.input_type
+class KustomizePatchArgs:
+    def __init__(__self__, *,
+                 patch: pulumi.Input[_builtins.str],
+                 target: pulumi.Input[Optional['KustomizePatchTargetArgs']] = None):
+        """
+        A Kustomize patch, either a strategic merge patch or a JSON 6902 patch.
+
+        :param pulumi.Input[_builtins.str] patch: The content of the patch, in YAML or JSON.
+        :param pulumi.Input['KustomizePatchTargetArgs'] target: Selects the resources to patch. A strategic merge patch may omit the target, in which case the patch itself identifies the resource to patch.
+        """
+        pulumi.set(__self__, "patch", patch)
+        if target is not None:
+            pulumi.set(__self__, "target", target)
+
+    @_builtins.property
+    @pulumi.getter
+    def patch(self) -> pulumi.Input[_builtins.str]:
+        """
+        The content of the patch, in YAML or JSON.
+        """
+        return pulumi.get(self, "patch")
+
+    @patch.setter
+    def patch(self, value: pulumi.Input[_builtins.str]):
+        pulumi.set(self, "patch", value)
+
+    @_builtins.property
+    @pulumi.getter
+    def target(self) -> pulumi.Input[Optional['KustomizePatchTargetArgs']]:
+        """
+        Selects the resources to patch. A strategic merge patch may omit the target, in which case the patch itself identifies the resource to patch.
+        """
+        return pulumi.get(self, "target")
+
+    @target.setter
+    def target(self, value: pulumi.Input[Optional['KustomizePatchTargetArgs']]):
+        pulumi.set(self, "target", value)
+
+
+class KustomizePatchTargetArgsDict(TypedDict):
+    """
+    Selects the resources that a Kustomize patch applies to.
+    """
+    annotation_selector: NotRequired[pulumi.Input[Optional[_builtins.str]]]
+    """
+    An annotation selector that the resources must match.
+    """
+    group: NotRequired[pulumi.Input[Optional[_builtins.str]]]
+    """
+    The API group of the resources.
+    """
+    kind: NotRequired[pulumi.Input[Optional[_builtins.str]]]
+    """
+    The kind of the resources.
+    """
+    label_selector: NotRequired[pulumi.Input[Optional[_builtins.str]]]
+    """
+    A label selector that the resources must match.
+    """
+    name: NotRequired[pulumi.Input[Optional[_builtins.str]]]
+    """
+    The name of the resources (a regular expression).
+    """
+    namespace: NotRequired[pulumi.Input[Optional[_builtins.str]]]
+    """
+    The namespace of the resources.
+    """
+    version: NotRequired[pulumi.Input[Optional[_builtins.str]]]
+    """
+    The API version of the resources.
+    """
+
+@pulumi.input_type
+class KustomizePatchTargetArgs:
+    def __init__(__self__, *,
+                 annotation_selector: pulumi.Input[Optional[_builtins.str]] = None,
+                 group: pulumi.Input[Optional[_builtins.str]] = None,
+                 kind: pulumi.Input[Optional[_builtins.str]] = None,
+                 label_selector: pulumi.Input[Optional[_builtins.str]] = None,
+                 name: pulumi.Input[Optional[_builtins.str]] = None,
+                 namespace: pulumi.Input[Optional[_builtins.str]] = None,
+                 version: pulumi.Input[Optional[_builtins.str]] = None):
+        """
+        Selects the resources that a Kustomize patch applies to.
+
+        :param pulumi.Input[_builtins.str] annotation_selector: An annotation selector that the resources must match.
+        :param pulumi.Input[_builtins.str] group: The API group of the resources.
+        :param pulumi.Input[_builtins.str] kind: The kind of the resources.
+        :param pulumi.Input[_builtins.str] label_selector: A label selector that the resources must match.
+        :param pulumi.Input[_builtins.str] name: The name of the resources (a regular expression).
+        :param pulumi.Input[_builtins.str] namespace: The namespace of the resources.
+        :param pulumi.Input[_builtins.str] version: The API version of the resources.
+        """
+        if annotation_selector is not None:
+            pulumi.set(__self__, "annotation_selector", annotation_selector)
+        if group is not None:
+            pulumi.set(__self__, "group", group)
+        if kind is not None:
+            pulumi.set(__self__, "kind", kind)
+        if label_selector is not None:
+            pulumi.set(__self__, "label_selector", label_selector)
+        if name is not None:
+            pulumi.set(__self__, "name", name)
+        if namespace is not None:
+            pulumi.set(__self__, "namespace", namespace)
+        if version is not None:
+            pulumi.set(__self__, "version", version)
+
+    @_builtins.property
+    @pulumi.getter(name="annotationSelector")
+    def annotation_selector(self) -> pulumi.Input[Optional[_builtins.str]]:
+        """
+        An annotation selector that the resources must match.
+        """
+        return pulumi.get(self, "annotation_selector")
+
+    @annotation_selector.setter
+    def annotation_selector(self, value: pulumi.Input[Optional[_builtins.str]]):
+        pulumi.set(self, "annotation_selector", value)
+
+    @_builtins.property
+    @pulumi.getter
+    def group(self) -> pulumi.Input[Optional[_builtins.str]]:
+        """
+        The API group of the resources.
+        """
+        return pulumi.get(self, "group")
+
+    @group.setter
+    def group(self, value: pulumi.Input[Optional[_builtins.str]]):
+        pulumi.set(self, "group", value)
+
+    @_builtins.property
+    @pulumi.getter
+    def kind(self) -> pulumi.Input[Optional[_builtins.str]]:
+        """
+        The kind of the resources.
+        """
+        return pulumi.get(self, "kind")
+
+    @kind.setter
+    def kind(self, value: pulumi.Input[Optional[_builtins.str]]):
+        pulumi.set(self, "kind", value)
+
+    @_builtins.property
+    @pulumi.getter(name="labelSelector")
+    def label_selector(self) -> pulumi.Input[Optional[_builtins.str]]:
+        """
+        A label selector that the resources must match.
+        """
+        return pulumi.get(self, "label_selector")
+
+    @label_selector.setter
+    def label_selector(self, value: pulumi.Input[Optional[_builtins.str]]):
+        pulumi.set(self, "label_selector", value)
+
+    @_builtins.property
+    @pulumi.getter
+    def name(self) -> pulumi.Input[Optional[_builtins.str]]:
+        """
+        The name of the resources (a regular expression).
+        """
+        return pulumi.get(self, "name")
+
+    @name.setter
+    def name(self, value: pulumi.Input[Optional[_builtins.str]]):
+        pulumi.set(self, "name", value)
+
+    @_builtins.property
+    @pulumi.getter
+    def namespace(self) -> pulumi.Input[Optional[_builtins.str]]:
+        """
+        The namespace of the resources.
+        """
+        return pulumi.get(self, "namespace")
+
+    @namespace.setter
+    def namespace(self, value: pulumi.Input[Optional[_builtins.str]]):
+        pulumi.set(self, "namespace", value)
+
+    @_builtins.property
+    @pulumi.getter
+    def version(self) -> pulumi.Input[Optional[_builtins.str]]:
+        """
+        The API version of the resources.
+        """
+        return pulumi.get(self, "version")
+
+    @version.setter
+    def version(self, value: pulumi.Input[Optional[_builtins.str]]):
+        pulumi.set(self, "version", value)
+
+
+class KustomizePostRendererArgsDict(TypedDict):
+    """
+    A post-renderer that applies a set of Kustomize patches to the rendered manifests.
+    """
+    patches: pulumi.Input[Sequence[pulumi.Input['KustomizePatchArgsDict']]]
+    """
+    The patches to apply.
+    """
+
+@pulumi.input_type
+class KustomizePostRendererArgs:
+    def __init__(__self__, *,
+                 patches: pulumi.Input[Sequence[pulumi.Input['KustomizePatchArgs']]]):
+        """
+        A post-renderer that applies a set of Kustomize patches to the rendered manifests.
+
+        :param pulumi.Input[Sequence[pulumi.Input['KustomizePatchArgs']]] patches: The patches to apply.
+        """
+        pulumi.set(__self__, "patches", patches)
+
+    @_builtins.property
+    @pulumi.getter
+    def patches(self) -> pulumi.Input[Sequence[pulumi.Input['KustomizePatchArgs']]]:
+        """
+        The patches to apply.
+        """
+        return pulumi.get(self, "patches")
+
+    @patches.setter
+    def patches(self, value: pulumi.Input[Sequence[pulumi.Input['KustomizePatchArgs']]]):
+        pulumi.set(self, "patches", value)
+
+
+class MetadataPostRendererArgsDict(TypedDict):
+    """
+    A post-renderer that injects labels and annotations into the metadata of the rendered objects. Pod templates and selectors are not affected.
+    """
+    annotations: NotRequired[pulumi.Input[Optional[Mapping[str, pulumi.Input[_builtins.str]]]]]
+    """
+    Annotations to inject.
+    """
+    kinds: NotRequired[pulumi.Input[Optional[Sequence[pulumi.Input[_builtins.str]]]]]
+    """
+    Restricts the injection to objects of the given kinds. All objects are affected by default.
+    """
+    labels: NotRequired[pulumi.Input[Optional[Mapping[str, pulumi.Input[_builtins.str]]]]]
+    """
+    Labels to inject.
+    """
+    overwrite: NotRequired[pulumi.Input[Optional[_builtins.bool]]]
+    """
+    Replace existing labels and annotations with the same key. By default, the values set by the chart take precedence.
+    """
+
+@pulumi.input_type
+class MetadataPostRendererArgs:
+    def __init__(__self__, *,
+                 annotations: pulumi.Input[Optional[Mapping[str, pulumi.Input[_builtins.str]]]] = None,
+                 kinds: pulumi.Input[Optional[Sequence[pulumi.Input[_builtins.str]]]] = None,
+                 labels: pulumi.Input[Optional[Mapping[str, pulumi.Input[_builtins.str]]]] = None,
+                 overwrite: pulumi.Input[Optional[_builtins.bool]] = None):
+        """
+        A post-renderer that injects labels and annotations into the metadata of the rendered objects. Pod templates and selectors are not affected.
+
+        :param pulumi.Input[Mapping[str, pulumi.Input[_builtins.str]]] annotations: Annotations to inject.
+        :param pulumi.Input[Sequence[pulumi.Input[_builtins.str]]] kinds: Restricts the injection to objects of the given kinds. All objects are affected by default.
+        :param pulumi.Input[Mapping[str, pulumi.Input[_builtins.str]]] labels: Labels to inject.
+        :param pulumi.Input[_builtins.bool] overwrite: Replace existing labels and annotations with the same key. By default, the values set by the chart take precedence.
+        """
+        if annotations is not None:
+            pulumi.set(__self__, "annotations", annotations)
+        if kinds is not None:
+            pulumi.set(__self__, "kinds", kinds)
+        if labels is not None:
+            pulumi.set(__self__, "labels", labels)
+        if overwrite is not None:
+            pulumi.set(__self__, "overwrite", overwrite)
+
+    @_builtins.property
+    @pulumi.getter
+    def annotations(self) -> pulumi.Input[Optional[Mapping[str, pulumi.Input[_builtins.str]]]]:
+        """
+        Annotations to inject.
+        """
+        return pulumi.get(self, "annotations")
+
+    @annotations.setter
+    def annotations(self, value: pulumi.Input[Optional[Mapping[str, pulumi.Input[_builtins.str]]]]):
+        pulumi.set(self, "annotations", value)
+
+    @_builtins.property
+    @pulumi.getter
+    def kinds(self) -> pulumi.Input[Optional[Sequence[pulumi.Input[_builtins.str]]]]:
+        """
+        Restricts the injection to objects of the given kinds. All objects are affected by default.
+        """
+        return pulumi.get(self, "kinds")
+
+    @kinds.setter
+    def kinds(self, value: pulumi.Input[Optional[Sequence[pulumi.Input[_builtins.str]]]]):
+        pulumi.set(self, "kinds", value)
+
+    @_builtins.property
+    @pulumi.getter
+    def labels(self) -> pulumi.Input[Optional[Mapping[str, pulumi.Input[_builtins.str]]]]:
+        """
+        Labels to inject.
+        """
+        return pulumi.get(self, "labels")
+
+    @labels.setter
+    def labels(self, value: pulumi.Input[Optional[Mapping[str, pulumi.Input[_builtins.str]]]]):
+        pulumi.set(self, "labels", value)
+
+    @_builtins.property
+    @pulumi.getter
+    def overwrite(self) -> pulumi.Input[Optional[_builtins.bool]]:
+        """
+        Replace existing labels and annotations with the same key. By default, the values set by the chart take precedence.
+        """
+        return pulumi.get(self, "overwrite")
+
+    @overwrite.setter
+    def overwrite(self, value: pulumi.Input[Optional[_builtins.bool]]):
+        pulumi.set(self, "overwrite", value)
+
+
 class PostRendererArgsDict(TypedDict):
     """
     Specification defining the post-renderer to use.
@@ -208,3 +666,269 @@ class RepositoryOptsArgs:
         pulumi.set(self, "username", value)
 
 
+class SelectorArgsDict(TypedDict):
+    """
+    Selects objects by group, version, kind, name, namespace and labels. The fields that aren't set match any object.
+    """
+    group: NotRequired[pulumi.Input[Optional[_builtins.str]]]
+    """
+    The API group of the objects.
+    """
+    kind: NotRequired[pulumi.Input[Optional[_builtins.str]]]
+    """
+    The kind of the objects.
+    """
+    label_selector: NotRequired[pulumi.Input[Optional[_builtins.str]]]
+    """
+    A label selector that the objects must match, e.g. `app=nginx,tier!=cache`.
+    """
+    name: NotRequired[pulumi.Input[Optional[_builtins.str]]]
+    """
+    A glob pattern that the name of the objects must match, e.g. `*-psp`.
+    """
+    namespace: NotRequired[pulumi.Input[Optional[_builtins.str]]]
+    """
+    The namespace of the objects.
+    """
+    version: NotRequired[pulumi.Input[Optional[_builtins.str]]]
+    """
+    The API version of the objects.
+    """
+
+@pulumi.input_type
+class SelectorArgs:
+    def __init__(__self__, *,
+                 group: pulumi.Input[Optional[_builtins.str]] = None,
+                 kind: pulumi.Input[Optional[_builtins.str]] = None,
+                 label_selector: pulumi.Input[Optional[_builtins.str]] = None,
+                 name: pulumi.Input[Optional[_builtins.str]] = None,
+                 namespace: pulumi.Input[Optional[_builtins.str]] = None,
+                 version: pulumi.Input[Optional[_builtins.str]] = None):
+        """
+        Selects objects by group, version, kind, name, namespace and labels. The fields that aren't set match any object.
+
+        :param pulumi.Input[_builtins.str] group: The API group of the objects.
+        :param pulumi.Input[_builtins.str] kind: The kind of the objects.
+        :param pulumi.Input[_builtins.str] label_selector: A label selector that the objects must match, e.g. `app=nginx,tier!=cache`.
+        :param pulumi.Input[_builtins.str] name: A glob pattern that the name of the objects must match, e.g. `*-psp`.
+        :param pulumi.Input[_builtins.str] namespace: The namespace of the objects.
+        :param pulumi.Input[_builtins.str] version: The API version of the objects.
+        """
+        if group is not None:
+            pulumi.set(__self__, "group", group)
+        if kind is not None:
+            pulumi.set(__self__, "kind", kind)
+        if label_selector is not None:
+            pulumi.set(__self__, "label_selector", label_selector)
+        if name is not None:
+            pulumi.set(__self__, "name", name)
+        if namespace is not None:
+            pulumi.set(__self__, "namespace", namespace)
+        if version is not None:
+            pulumi.set(__self__, "version", version)
+
+    @_builtins.property
+    @pulumi.getter
+    def group(self) -> pulumi.Input[Optional[_builtins.str]]:
+        """
+        The API group of the objects.
+        """
+        return pulumi.get(self, "group")
+
+    @group.setter
+    def group(self, value: pulumi.Input[Optional[_builtins.str]]):
+        pulumi.set(self, "group", value)
+
+    @_builtins.property
+    @pulumi.getter
+    def kind(self) -> pulumi.Input[Optional[_builtins.str]]:
+        """
+        The kind of the objects.
+        """
+        return pulumi.get(self, "kind")
+
+    @kind.setter
+    def kind(self, value: pulumi.Input[Optional[_builtins.str]]):
+        pulumi.set(self, "kind", value)
+
+    @_builtins.property
+    @pulumi.getter(name="labelSelector")
+    def label_selector(self) -> pulumi.Input[Optional[_builtins.str]]:
+        """
+        A label selector that the objects must match, e.g. `app=nginx,tier!=cache`.
+        """
+        return pulumi.get(self, "label_selector")
+
+    @label_selector.setter
+    def label_selector(self, value: pulumi.Input[Optional[_builtins.str]]):
+        pulumi.set(self, "label_selector", value)
+
+    @_builtins.property
+    @pulumi.getter
+    def name(self) -> pulumi.Input[Optional[_builtins.str]]:
+        """
+        A glob pattern that the name of the objects must match, e.g. `*-psp`.
+        """
+        return pulumi.get(self, "name")
+
+    @name.setter
+    def name(self, value: pulumi.Input[Optional[_builtins.str]]):
+        pulumi.set(self, "name", value)
+
+    @_builtins.property
+    @pulumi.getter
+    def namespace(self) -> pulumi.Input[Optional[_builtins.str]]:
+        """
+        The namespace of the objects.
+        """
+        return pulumi.get(self, "namespace")
+
+    @namespace.setter
+    def namespace(self, value: pulumi.Input[Optional[_builtins.str]]):
+        pulumi.set(self, "namespace", value)
+
+    @_builtins.property
+    @pulumi.getter
+    def version(self) -> pulumi.Input[Optional[_builtins.str]]:
+        """
+        The API version of the objects.
+        """
+        return pulumi.get(self, "version")
+
+    @version.setter
+    def version(self, value: pulumi.Input[Optional[_builtins.str]]):
+        pulumi.set(self, "version", value)
+
+
+class ValuesReferenceArgsDict(TypedDict):
+    """
+    A reference to Helm values held in a ConfigMap or a Secret.
+    """
+    kind: pulumi.Input[_builtins.str]
+    """
+    The kind of the object holding the values: `ConfigMap` or `Secret`.
+    """
+    name: pulumi.Input[_builtins.str]
+    """
+    The name of the object.
+    """
+    namespace: NotRequired[pulumi.Input[Optional[_builtins.str]]]
+    """
+    The namespace of the object. Defaults to the release namespace.
+    """
+    optional: NotRequired[pulumi.Input[Optional[_builtins.bool]]]
+    """
+    Ignore the reference if the object or the key doesn't exist.
+    """
+    target_path: NotRequired[pulumi.Input[Optional[_builtins.str]]]
+    """
+    The path (in `--set` notation, e.g. `auth.password`) at which to set the value of the key as a string. By default, the value of the key is a YAML document that is merged into the values.
+    """
+    values_key: NotRequired[pulumi.Input[Optional[_builtins.str]]]
+    """
+    The data key holding the values. Defaults to `values.yaml`.
+    """
+
+@pulumi.input_type
+class ValuesReferenceArgs:
+    def __init__(__self__, *,
+                 kind: pulumi.Input[_builtins.str],
+                 name: pulumi.Input[_builtins.str],
+                 namespace: pulumi.Input[Optional[_builtins.str]] = None,
+                 optional: pulumi.Input[Optional[_builtins.bool]] = None,
+                 target_path: pulumi.Input[Optional[_builtins.str]] = None,
+                 values_key: pulumi.Input[Optional[_builtins.str]] = None):
+        """
+        A reference to Helm values held in a ConfigMap or a Secret.
+
+        :param pulumi.Input[_builtins.str] kind: The kind of the object holding the values: `ConfigMap` or `Secret`.
+        :param pulumi.Input[_builtins.str] name: The name of the object.
+        :param pulumi.Input[_builtins.str] namespace: The namespace of the object. Defaults to the release namespace.
+        :param pulumi.Input[_builtins.bool] optional: Ignore the reference if the object or the key doesn't exist.
+        :param pulumi.Input[_builtins.str] target_path: The path (in `--set` notation, e.g. `auth.password`) at which to set the value of the key as a string. By default, the value of the key is a YAML document that is merged into the values.
+        :param pulumi.Input[_builtins.str] values_key: The data key holding the values. Defaults to `values.yaml`.
+        """
+        pulumi.set(__self__, "kind", kind)
+        pulumi.set(__self__, "name", name)
+        if namespace is not None:
+            pulumi.set(__self__, "namespace", namespace)
+        if optional is not None:
+            pulumi.set(__self__, "optional", optional)
+        if target_path is not None:
+            pulumi.set(__self__, "target_path", target_path)
+        if values_key is not None:
+            pulumi.set(__self__, "values_key", values_key)
+
+    @_builtins.property
+    @pulumi.getter
+    def kind(self) -> pulumi.Input[_builtins.str]:
+        """
+        The kind of the object holding the values: `ConfigMap` or `Secret`.
+        """
+        return pulumi.get(self, "kind")
+
+    @kind.setter
+    def kind(self, value: pulumi.Input[_builtins.str]):
+        pulumi.set(self, "kind", value)
+
+    @_builtins.property
+    @pulumi.getter
+    def name(self) -> pulumi.Input[_builtins.str]:
+        """
+        The name of the object.
+        """
+        return pulumi.get(self, "name")
+
+    @name.setter
+    def name(self, value: pulumi.Input[_builtins.str]):
+        pulumi.set(self, "name", value)
+
+    @_builtins.property
+    @pulumi.getter
+    def namespace(self) -> pulumi.Input[Optional[_builtins.str]]:
+        """
+        The namespace of the object. Defaults to the release namespace.
+        """
+        return pulumi.get(self, "namespace")
+
+    @namespace.setter
+    def namespace(self, value: pulumi.Input[Optional[_builtins.str]]):
+        pulumi.set(self, "namespace", value)
+
+    @_builtins.property
+    @pulumi.getter
+    def optional(self) -> pulumi.Input[Optional[_builtins.bool]]:
+        """
+        Ignore the reference if the object or the key doesn't exist.
+        """
+        return pulumi.get(self, "optional")
+
+    @optional.setter
+    def optional(self, value: pulumi.Input[Optional[_builtins.bool]]):
+        pulumi.set(self, "optional", value)
+
+    @_builtins.property
+    @pulumi.getter(name="targetPath")
+    def target_path(self) -> pulumi.Input[Optional[_builtins.str]]:
+        """
+        The path (in `--set` notation, e.g. `auth.password`) at which to set the value of the key as a string. By default, the value of the key is a YAML document that is merged into the values.
+        """
+        return pulumi.get(self, "target_path")
+
+    @target_path.setter
+    def target_path(self, value: pulumi.Input[Optional[_builtins.str]]):
+        pulumi.set(self, "target_path", value)
+
+    @_builtins.property
+    @pulumi.getter(name="valuesKey")
+    def values_key(self) -> pulumi.Input[Optional[_builtins.str]]:
+        """
+        The data key holding the values. Defaults to `values.yaml`.
+        """
+        return pulumi.get(self, "values_key")
+
+    @values_key.setter
+    def values_key(self, value: pulumi.Input[Optional[_builtins.str]]):
+        pulumi.set(self, "values_key", value)
+
+
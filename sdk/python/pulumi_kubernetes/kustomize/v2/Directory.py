@@ -13,14 +13,25 @@ if sys.version_info >= (3, 11):
 else:
     from typing_extensions import NotRequired, TypedDict, TypeAlias
 from ... import _utilities
+from ._inputs import *
 
 __all__ = ['DirectoryArgs', 'Directory']
 
 @pulumi.input_type
 class DirectoryArgs:
     def __init__(__self__, *,
-                 directory: pulumi.Input[_builtins.str],
+                 directory: pulumi.Input[Optional[_builtins.str]] = None,
+                 enable_alpha_plugins: pulumi.Input[Optional[_builtins.bool]] = None,
+                 enable_helm: pulumi.Input[Optional[_builtins.bool]] = None,
+                 exclude: pulumi.Input[Optional[Sequence[pulumi.Input['SelectorArgs']]]] = None,
+                 files: pulumi.Input[Optional[Mapping[str, pulumi.Input[_builtins.str]]]] = None,
+                 helm_command: pulumi.Input[Optional[_builtins.str]] = None,
+                 include: pulumi.Input[Optional[Sequence[pulumi.Input['SelectorArgs']]]] = None,
+                 kustomization: pulumi.Input[Optional[Mapping[str, Any]]] = None,
+                 load_restrictor: pulumi.Input[Optional[_builtins.str]] = None,
                  namespace: pulumi.Input[Optional[_builtins.str]] = None,
+                 patches: pulumi.Input[Optional[Sequence[pulumi.Input['PatchArgs']]]] = None,
+                 remote_bases: pulumi.Input[Optional['RemoteBasesOptsArgs']] = None,
                  resource_prefix: pulumi.Input[Optional[_builtins.str]] = None,
                  skip_await: pulumi.Input[Optional[_builtins.bool]] = None):
         """
@@ -30,13 +41,45 @@ class DirectoryArgs:
                git repository.
                Example: ./helloWorld
                Example: https://github.com/kubernetes-sigs/kustomize/tree/master/examples/helloWorld
+               When `kustomization` or `files` is set, the directory is a path within the inline files, and defaults to the root of the files.
+        :param pulumi.Input[_builtins.bool] enable_alpha_plugins: Enables kustomize's alpha plugins (e.g. KRM functions). Defaults to `true`.
+        :param pulumi.Input[_builtins.bool] enable_helm: Enables the inflation of the `helmCharts` of the kustomizations. Defaults to `true`.
+        :param pulumi.Input[Sequence[pulumi.Input['SelectorArgs']]] exclude: Selects the objects not to register, among the included objects. The skipped objects are reported in the `skipped` output.
+        :param pulumi.Input[Mapping[str, pulumi.Input[_builtins.str]]] files: Additional files for an inline kustomization (e.g. patches, generator inputs and other kustomizations), keyed by their paths relative to the root of the files. The files are materialised in memory, so the kustomization can't refer to files on disk; remote bases and Helm charts from repositories may be used.
+        :param pulumi.Input[_builtins.str] helm_command: The helm binary with which kustomize inflates the `helmCharts` of the kustomizations. By default, the charts are inflated in-process, using the Helm settings of the provider.
+        :param pulumi.Input[Sequence[pulumi.Input['SelectorArgs']]] include: Selects the objects to register. An object is registered if it matches any of the selectors; by default, all objects are registered.
+        :param pulumi.Input[Mapping[str, Any]] kustomization: An inline kustomization (the content of a `kustomization.yaml` file) to apply instead of a kustomization on disk. It is materialised in memory, together with the `files`, in the `directory`.
+        :param pulumi.Input[_builtins.str] load_restrictor: The load restrictions of kustomize, either `LoadRestrictionsNone` or `LoadRestrictionsRootOnly`. With `LoadRestrictionsRootOnly`, the files of a kustomization (including the values files of its Helm charts) must be in or below its directory. Defaults to `LoadRestrictionsNone`.
         :param pulumi.Input[_builtins.str] namespace: The default namespace to apply to the resources. Defaults to the provider's namespace.
+        :param pulumi.Input[Sequence[pulumi.Input['PatchArgs']]] patches: Patches to apply to the objects that the kustomization produces before they're registered, in order. The patches are applied in-process, with kustomize.
+        :param pulumi.Input['RemoteBasesOptsArgs'] remote_bases: Options for fetching the remote git bases of the kustomizations.
         :param pulumi.Input[_builtins.str] resource_prefix: A prefix for the auto-generated resource names. Defaults to the name of the Directory resource. Example: A resource created with resourcePrefix="foo" would produce a resource named "foo:resourceName".
         :param pulumi.Input[_builtins.bool] skip_await: Indicates that child resources should skip the await logic. Defaults to `false`.
         """
-        pulumi.set(__self__, "directory", directory)
+        if directory is not None:
+            pulumi.set(__self__, "directory", directory)
+        if enable_alpha_plugins is not None:
+            pulumi.set(__self__, "enable_alpha_plugins", enable_alpha_plugins)
+        if enable_helm is not None:
+            pulumi.set(__self__, "enable_helm", enable_helm)
+        if exclude is not None:
+            pulumi.set(__self__, "exclude", exclude)
+        if files is not None:
+            pulumi.set(__self__, "files", files)
+        if helm_command is not None:
+            pulumi.set(__self__, "helm_command", helm_command)
+        if include is not None:
+            pulumi.set(__self__, "include", include)
+        if kustomization is not None:
+            pulumi.set(__self__, "kustomization", kustomization)
+        if load_restrictor is not None:
+            pulumi.set(__self__, "load_restrictor", load_restrictor)
         if namespace is not None:
             pulumi.set(__self__, "namespace", namespace)
+        if patches is not None:
+            pulumi.set(__self__, "patches", patches)
+        if remote_bases is not None:
+            pulumi.set(__self__, "remote_bases", remote_bases)
         if resource_prefix is not None:
             pulumi.set(__self__, "resource_prefix", resource_prefix)
         if skip_await is not None:
@@ -44,19 +87,116 @@ class DirectoryArgs:
 
     @_builtins.property
     @pulumi.getter
-    def directory(self) -> pulumi.Input[_builtins.str]:
+    def directory(self) -> pulumi.Input[Optional[_builtins.str]]:
         """
         The directory containing the kustomization to apply. The value can be a local directory or a folder in a
         git repository.
         Example: ./helloWorld
         Example: https://github.com/kubernetes-sigs/kustomize/tree/master/examples/helloWorld
+        When `kustomization` or `files` is set, the directory is a path within the inline files, and defaults to the root of the files.
         """
         return pulumi.get(self, "directory")
 
     @directory.setter
-    def directory(self, value: pulumi.Input[_builtins.str]):
+    def directory(self, value: pulumi.Input[Optional[_builtins.str]]):
         pulumi.set(self, "directory", value)
 
+    @_builtins.property
+    @pulumi.getter(name="enableAlphaPlugins")
+    def enable_alpha_plugins(self) -> pulumi.Input[Optional[_builtins.bool]]:
+        """
+        Enables kustomize's alpha plugins (e.g. KRM functions). Defaults to `true`.
+        """
+        return pulumi.get(self, "enable_alpha_plugins")
+
+    @enable_alpha_plugins.setter
+    def enable_alpha_plugins(self, value: pulumi.Input[Optional[_builtins.bool]]):
+        pulumi.set(self, "enable_alpha_plugins", value)
+
+    @_builtins.property
+    @pulumi.getter(name="enableHelm")
+    def enable_helm(self) -> pulumi.Input[Optional[_builtins.bool]]:
+        """
+        Enables the inflation of the `helmCharts` of the kustomizations. Defaults to `true`.
+        """
+        return pulumi.get(self, "enable_helm")
+
+    @enable_helm.setter
+    def enable_helm(self, value: pulumi.Input[Optional[_builtins.bool]]):
+        pulumi.set(self, "enable_helm", value)
+
+    @_builtins.property
+    @pulumi.getter
+    def exclude(self) -> pulumi.Input[Optional[Sequence[pulumi.Input['SelectorArgs']]]]:
+        """
+        Selects the objects not to register, among the included objects. The skipped objects are reported in the `skipped` output.
+        """
+        return pulumi.get(self, "exclude")
+
+    @exclude.setter
+    def exclude(self, value: pulumi.Input[Optional[Sequence[pulumi.Input['SelectorArgs']]]]):
+        pulumi.set(self, "exclude", value)
+
+    @_builtins.property
+    @pulumi.getter
+    def files(self) -> pulumi.Input[Optional[Mapping[str, pulumi.Input[_builtins.str]]]]:
+        """
+        Additional files for an inline kustomization (e.g. patches, generator inputs and other kustomizations), keyed by their paths relative to the root of the files. The files are materialised in memory, so the kustomization can't refer to files on disk; remote bases and Helm charts from repositories may be used.
+        """
+        return pulumi.get(self, "files")
+
+    @files.setter
+    def files(self, value: pulumi.Input[Optional[Mapping[str, pulumi.Input[_builtins.str]]]]):
+        pulumi.set(self, "files", value)
+
+    @_builtins.property
+    @pulumi.getter(name="helmCommand")
+    def helm_command(self) -> pulumi.Input[Optional[_builtins.str]]:
+        """
+        The helm binary with which kustomize inflates the `helmCharts` of the kustomizations. By default, the charts are inflated in-process, using the Helm settings of the provider.
+        """
+        return pulumi.get(self, "helm_command")
+
+    @helm_command.setter
+    def helm_command(self, value: pulumi.Input[Optional[_builtins.str]]):
+        pulumi.set(self, "helm_command", value)
+
+    @_builtins.property
+    @pulumi.getter
+    def include(self) -> pulumi.Input[Optional[Sequence[pulumi.Input['SelectorArgs']]]]:
+        """
+        Selects the objects to register. An object is registered if it matches any of the selectors; by default, all objects are registered.
+        """
+        return pulumi.get(self, "include")
+
+    @include.setter
+    def include(self, value: pulumi.Input[Optional[Sequence[pulumi.Input['SelectorArgs']]]]):
+        pulumi.set(self, "include", value)
+
+    @_builtins.property
+    @pulumi.getter
+    def kustomization(self) -> pulumi.Input[Optional[Mapping[str, Any]]]:
+        """
+        An inline kustomization (the content of a `kustomization.yaml` file) to apply instead of a kustomization on disk. It is materialised in memory, together with the `files`, in the `directory`.
+        """
+        return pulumi.get(self, "kustomization")
+
+    @kustomization.setter
+    def kustomization(self, value: pulumi.Input[Optional[Mapping[str, Any]]]):
+        pulumi.set(self, "kustomization", value)
+
+    @_builtins.property
+    @pulumi.getter(name="loadRestrictor")
+    def load_restrictor(self) -> pulumi.Input[Optional[_builtins.str]]:
+        """
+        The load restrictions of kustomize, either `LoadRestrictionsNone` or `LoadRestrictionsRootOnly`. With `LoadRestrictionsRootOnly`, the files of a kustomization (including the values files of its Helm charts) must be in or below its directory. Defaults to `LoadRestrictionsNone`.
+        """
+        return pulumi.get(self, "load_restrictor")
+
+    @load_restrictor.setter
+    def load_restrictor(self, value: pulumi.Input[Optional[_builtins.str]]):
+        pulumi.set(self, "load_restrictor", value)
+
     @_builtins.property
     @pulumi.getter
     def namespace(self) -> pulumi.Input[Optional[_builtins.str]]:
@@ -69,6 +209,30 @@ class DirectoryArgs:
     def namespace(self, value: pulumi.Input[Optional[_builtins.str]]):
         pulumi.set(self, "namespace", value)
 
+    @_builtins.property
+    @pulumi.getter
+    def patches(self) -> pulumi.Input[Optional[Sequence[pulumi.Input['PatchArgs']]]]:
+        """
+        Patches to apply to the objects that the kustomization produces before they're registered, in order. The patches are applied in-process, with kustomize.
+        """
+        return pulumi.get(self, "patches")
+
+    @patches.setter
+    def patches(self, value: pulumi.Input[Optional[Sequence[pulumi.Input['PatchArgs']]]]):
+        pulumi.set(self, "patches", value)
+
+    @_builtins.property
+    @pulumi.getter(name="remoteBases")
+    def remote_bases(self) -> pulumi.Input[Optional['RemoteBasesOptsArgs']]:
+        """
+        Options for fetching the remote git bases of the kustomizations.
+        """
+        return pulumi.get(self, "remote_bases")
+
+    @remote_bases.setter
+    def remote_bases(self, value: pulumi.Input[Optional['RemoteBasesOptsArgs']]):
+        pulumi.set(self, "remote_bases", value)
+
     @_builtins.property
     @pulumi.getter(name="resourcePrefix")
     def resource_prefix(self) -> pulumi.Input[Optional[_builtins.str]]:
@@ -101,13 +265,51 @@ class Directory(pulumi.ComponentResource):
                  resource_name: str,
                  opts: Optional[pulumi.ResourceOptions] = None,
                  directory: pulumi.Input[Optional[_builtins.str]] = None,
+                 enable_alpha_plugins: pulumi.Input[Optional[_builtins.bool]] = None,
+                 enable_helm: pulumi.Input[Optional[_builtins.bool]] = None,
+                 exclude: pulumi.Input[Optional[Sequence[pulumi.Input[Union['SelectorArgs', 'SelectorArgsDict']]]]] = None,
+                 files: pulumi.Input[Optional[Mapping[str, pulumi.Input[_builtins.str]]]] = None,
+                 helm_command: pulumi.Input[Optional[_builtins.str]] = None,
+                 include: pulumi.Input[Optional[Sequence[pulumi.Input[Union['SelectorArgs', 'SelectorArgsDict']]]]] = None,
+                 kustomization: pulumi.Input[Optional[Mapping[str, Any]]] = None,
+                 load_restrictor: pulumi.Input[Optional[_builtins.str]] = None,
                  namespace: pulumi.Input[Optional[_builtins.str]] = None,
+                 patches: pulumi.Input[Optional[Sequence[pulumi.Input[Union['PatchArgs', 'PatchArgsDict']]]]] = None,
+                 remote_bases: pulumi.Input[Optional[Union['RemoteBasesOptsArgs', 'RemoteBasesOptsArgsDict']]] = None,
                  resource_prefix: pulumi.Input[Optional[_builtins.str]] = None,
                  skip_await: pulumi.Input[Optional[_builtins.bool]] = None,
                  __props__=None):
         """
         Directory is a component representing a collection of resources described by a kustomize directory (kustomization).
 
+        ## Patches
+        The objects may be patched with `patches`, before they're registered, without a transform in the program. Each patch
+        is a strategic merge patch or a JSON 6902 patch, and its `target` selects the objects to patch by group, version,
+        kind, name, namespace, label selector or annotation selector. A strategic merge patch may omit the target, in which
+        case the patch itself identifies the object to patch.
+
+        ## Selecting objects
+        The objects to register may be selected with `include` and `exclude`, e.g. to skip a Namespace that's managed
+        separately. Each selector matches objects by `group`, `version`, `kind`, `name` (a glob pattern), `namespace` and
+        `labelSelector`. An object is registered if it matches any of the included selectors (or if there are none), and none
+        of the excluded selectors. The skipped objects are reported in the `skipped` output.
+
+        ## Dependency ordering
+        Pulumi uses heuristics to determine which order to apply and delete objects within the Directory, and supports the
+        `config.kubernetes.io/depends-on` annotation to declare an explicit dependency on a given resource.
+
+        Workloads depend on the ConfigMaps and Secrets in the same namespace that their pods reference (by volumes, `envFrom`,
+        `env` or `imagePullSecrets`), so that the referenced configuration is created first. This includes the ConfigMaps and
+        Secrets generated by kustomize, whose names have a hash suffix.
+
+        Admission webhook configurations depend on the Services that back them and on the workloads that those Services
+        select, and the objects that a webhook intercepts depend on the webhook configuration, so that the webhook is ready
+        before the objects are created. Webhooks whose `failurePolicy` is `Ignore` don't affect the ordering, and the objects
+        in the namespace of the webhook's Service are assumed to be part of the webhook's installation.
+
+        A `config.kubernetes.io/depends-on` reference to a resource outside the Directory is assumed to exist in the cluster
+        already, and isn't waited for.
+
         ## Example Usage
         ### Local Kustomize Directory
         ```python
@@ -135,7 +337,18 @@ class Directory(pulumi.ComponentResource):
                git repository.
                Example: ./helloWorld
                Example: https://github.com/kubernetes-sigs/kustomize/tree/master/examples/helloWorld
+               When `kustomization` or `files` is set, the directory is a path within the inline files, and defaults to the root of the files.
+        :param pulumi.Input[_builtins.bool] enable_alpha_plugins: Enables kustomize's alpha plugins (e.g. KRM functions). Defaults to `true`.
+        :param pulumi.Input[_builtins.bool] enable_helm: Enables the inflation of the `helmCharts` of the kustomizations. Defaults to `true`.
+        :param pulumi.Input[Sequence[pulumi.Input[Union['SelectorArgs', 'SelectorArgsDict']]]] exclude: Selects the objects not to register, among the included objects. The skipped objects are reported in the `skipped` output.
+        :param pulumi.Input[Mapping[str, pulumi.Input[_builtins.str]]] files: Additional files for an inline kustomization (e.g. patches, generator inputs and other kustomizations), keyed by their paths relative to the root of the files. The files are materialised in memory, so the kustomization can't refer to files on disk; remote bases and Helm charts from repositories may be used.
+        :param pulumi.Input[_builtins.str] helm_command: The helm binary with which kustomize inflates the `helmCharts` of the kustomizations. By default, the charts are inflated in-process, using the Helm settings of the provider.
+        :param pulumi.Input[Sequence[pulumi.Input[Union['SelectorArgs', 'SelectorArgsDict']]]] include: Selects the objects to register. An object is registered if it matches any of the selectors; by default, all objects are registered.
+        :param pulumi.Input[Mapping[str, Any]] kustomization: An inline kustomization (the content of a `kustomization.yaml` file) to apply instead of a kustomization on disk. It is materialised in memory, together with the `files`, in the `directory`.
+        :param pulumi.Input[_builtins.str] load_restrictor: The load restrictions of kustomize, either `LoadRestrictionsNone` or `LoadRestrictionsRootOnly`. With `LoadRestrictionsRootOnly`, the files of a kustomization (including the values files of its Helm charts) must be in or below its directory. Defaults to `LoadRestrictionsNone`.
         :param pulumi.Input[_builtins.str] namespace: The default namespace to apply to the resources. Defaults to the provider's namespace.
+        :param pulumi.Input[Sequence[pulumi.Input[Union['PatchArgs', 'PatchArgsDict']]]] patches: Patches to apply to the objects that the kustomization produces before they're registered, in order. The patches are applied in-process, with kustomize.
+        :param pulumi.Input[Union['RemoteBasesOptsArgs', 'RemoteBasesOptsArgsDict']] remote_bases: Options for fetching the remote git bases of the kustomizations.
         :param pulumi.Input[_builtins.str] resource_prefix: A prefix for the auto-generated resource names. Defaults to the name of the Directory resource. Example: A resource created with resourcePrefix="foo" would produce a resource named "foo:resourceName".
         :param pulumi.Input[_builtins.bool] skip_await: Indicates that child resources should skip the await logic. Defaults to `false`.
         """
@@ -143,11 +356,39 @@ class Directory(pulumi.ComponentResource):
     @overload
     def __init__(__self__,
                  resource_name: str,
-                 args: DirectoryArgs,
+                 args: Optional[DirectoryArgs] = None,
                  opts: Optional[pulumi.ResourceOptions] = None):
         """
         Directory is a component representing a collection of resources described by a kustomize directory (kustomization).
 
+        ## Patches
+        The objects may be patched with `patches`, before they're registered, without a transform in the program. Each patch
+        is a strategic merge patch or a JSON 6902 patch, and its `target` selects the objects to patch by group, version,
+        kind, name, namespace, label selector or annotation selector. A strategic merge patch may omit the target, in which
+        case the patch itself identifies the object to patch.
+
+        ## Selecting objects
+        The objects to register may be selected with `include` and `exclude`, e.g. to skip a Namespace that's managed
+        separately. Each selector matches objects by `group`, `version`, `kind`, `name` (a glob pattern), `namespace` and
+        `labelSelector`. An object is registered if it matches any of the included selectors (or if there are none), and none
+        of the excluded selectors. The skipped objects are reported in the `skipped` output.
+
+        ## Dependency ordering
+        Pulumi uses heuristics to determine which order to apply and delete objects within the Directory, and supports the
+        `config.kubernetes.io/depends-on` annotation to declare an explicit dependency on a given resource.
+
+        Workloads depend on the ConfigMaps and Secrets in the same namespace that their pods reference (by volumes, `envFrom`,
+        `env` or `imagePullSecrets`), so that the referenced configuration is created first. This includes the ConfigMaps and
+        Secrets generated by kustomize, whose names have a hash suffix.
+
+        Admission webhook configurations depend on the Services that back them and on the workloads that those Services
+        select, and the objects that a webhook intercepts depend on the webhook configuration, so that the webhook is ready
+        before the objects are created. Webhooks whose `failurePolicy` is `Ignore` don't affect the ordering, and the objects
+        in the namespace of the webhook's Service are assumed to be part of the webhook's installation.
+
+        A `config.kubernetes.io/depends-on` reference to a resource outside the Directory is assumed to exist in the cluster
+        already, and isn't waited for.
+
         ## Example Usage
         ### Local Kustomize Directory
         ```python
@@ -185,7 +426,17 @@ class Directory(pulumi.ComponentResource):
                  resource_name: str,
                  opts: Optional[pulumi.ResourceOptions] = None,
                  directory: pulumi.Input[Optional[_builtins.str]] = None,
+                 enable_alpha_plugins: pulumi.Input[Optional[_builtins.bool]] = None,
+                 enable_helm: pulumi.Input[Optional[_builtins.bool]] = None,
+                 exclude: pulumi.Input[Optional[Sequence[pulumi.Input[Union['SelectorArgs', 'SelectorArgsDict']]]]] = None,
+                 files: pulumi.Input[Optional[Mapping[str, pulumi.Input[_builtins.str]]]] = None,
+                 helm_command: pulumi.Input[Optional[_builtins.str]] = None,
+                 include: pulumi.Input[Optional[Sequence[pulumi.Input[Union['SelectorArgs', 'SelectorArgsDict']]]]] = None,
+                 kustomization: pulumi.Input[Optional[Mapping[str, Any]]] = None,
+                 load_restrictor: pulumi.Input[Optional[_builtins.str]] = None,
                  namespace: pulumi.Input[Optional[_builtins.str]] = None,
+                 patches: pulumi.Input[Optional[Sequence[pulumi.Input[Union['PatchArgs', 'PatchArgsDict']]]]] = None,
+                 remote_bases: pulumi.Input[Optional[Union['RemoteBasesOptsArgs', 'RemoteBasesOptsArgsDict']]] = None,
                  resource_prefix: pulumi.Input[Optional[_builtins.str]] = None,
                  skip_await: pulumi.Input[Optional[_builtins.bool]] = None,
                  __props__=None):
@@ -199,13 +450,22 @@ class Directory(pulumi.ComponentResource):
                 raise TypeError('__props__ is only valid when passed in combination with a valid opts.id to get an existing resource')
             __props__ = DirectoryArgs.__new__(DirectoryArgs)
 
-            if directory is None and not opts.urn:
-                raise TypeError("Missing required property 'directory'")
             __props__.__dict__["directory"] = directory
+            __props__.__dict__["enable_alpha_plugins"] = enable_alpha_plugins
+            __props__.__dict__["enable_helm"] = enable_helm
+            __props__.__dict__["exclude"] = exclude
+            __props__.__dict__["files"] = files
+            __props__.__dict__["helm_command"] = helm_command
+            __props__.__dict__["include"] = include
+            __props__.__dict__["kustomization"] = kustomization
+            __props__.__dict__["load_restrictor"] = load_restrictor
             __props__.__dict__["namespace"] = namespace
+            __props__.__dict__["patches"] = patches
+            __props__.__dict__["remote_bases"] = remote_bases
             __props__.__dict__["resource_prefix"] = resource_prefix
             __props__.__dict__["skip_await"] = skip_await
             __props__.__dict__["resources"] = None
+            __props__.__dict__["skipped"] = None
         super(Directory, __self__).__init__(
             'kubernetes:kustomize/v2:Directory',
             resource_name,
@@ -221,3 +481,11 @@ class Directory(pulumi.ComponentResource):
         """
         return pulumi.get(self, "resources")
 
+    @_builtins.property
+    @pulumi.getter
+    def skipped(self) -> pulumi.Output[Optional[Sequence[_builtins.str]]]:
+        """
+        The objects of the Directory that weren't registered because of the `include` and `exclude` selectors, e.g. `v1/Namespace:my-namespace`.
+        """
+        return pulumi.get(self, "skipped")
+
@@ -32,7 +32,11 @@ class ProviderArgs:
                  helm_release_settings: pulumi.Input[Optional['HelmReleaseSettingsArgs']] = None,
                  kube_client_settings: pulumi.Input[Optional['KubeClientSettingsArgs']] = None,
                  kubeconfig: pulumi.Input[Optional[_builtins.str]] = None,
+                 manifest_cache_offline: pulumi.Input[Optional[_builtins.bool]] = None,
                  namespace: pulumi.Input[Optional[_builtins.str]] = None,
+                 render_yaml_age_recipients: pulumi.Input[Optional[_builtins.str]] = None,
+                 render_yaml_format: pulumi.Input[Optional[_builtins.str]] = None,
+                 render_yaml_snapshot: pulumi.Input[Optional[_builtins.str]] = None,
                  render_yaml_to_directory: pulumi.Input[Optional[_builtins.str]] = None,
                  skip_update_unreachable: pulumi.Input[Optional[_builtins.bool]] = None,
                  suppress_deprecation_warnings: pulumi.Input[Optional[_builtins.bool]] = None,
@@ -74,12 +78,21 @@ class ProviderArgs:
         :param pulumi.Input['HelmReleaseSettingsArgs'] helm_release_settings: Options to configure the Helm Release resource.
         :param pulumi.Input['KubeClientSettingsArgs'] kube_client_settings: Options for tuning the Kubernetes client used by a Provider.
         :param pulumi.Input[_builtins.str] kubeconfig: The contents of a kubeconfig file or the path to a kubeconfig file.
+        :param pulumi.Input[_builtins.bool] manifest_cache_offline: If present and set to true, the manifests of `yaml/v2.ConfigGroup` and `yaml/v2.ConfigFile` that are given by URL are read from the on-disk manifest cache only, and never downloaded. A manifest that isn't cached is an error.
         :param pulumi.Input[_builtins.str] namespace: If present, the default namespace to use. This flag is ignored for cluster-scoped resources.
                
                A namespace can be specified in multiple places, and the precedence is as follows:
                1. `.metadata.namespace` set on the resource.
                2. This `namespace` parameter.
                3. `namespace` set for the active context in the kubeconfig.
+        :param pulumi.Input[_builtins.str] render_yaml_age_recipients: A comma-separated list of age recipients (e.g. `age1...`) to which to encrypt the Secrets rendered to the directory specified by renderYamlToDirectory. The `data` and `stringData` of the Secrets are encrypted with SOPS, such that the manifests may be committed to git and decrypted by SOPS or by Flux's kustomize-controller with the recipients' age keys. When the recipients change, the Secrets are rendered again for the new recipients. A Secret that is rendered again unchanged (e.g. with alwaysRender) keeps its ciphertext, so that its file doesn't change. Not supported with the `namespaces` renderYamlFormat, since `sops -d` can't decrypt a file of several SOPS documents. Only valid when renderYamlToDirectory is set.
+        :param pulumi.Input[_builtins.str] render_yaml_format: The layout of the manifests rendered to the directory specified by renderYamlToDirectory. One of:
+               - `files` (the default): each object in its own file, in the `0-crd` and `1-manifest` directories.
+               - `namespaces`: the objects of each namespace in a single multi-document file named after the namespace, and the objects without a namespace in `_cluster.yaml`.
+               - `kustomization`: each object in its own file, as with `files`, along with a `kustomization.yaml` that lists the files.
+               
+               The objects of a multi-document file, and the files of a `kustomization.yaml`, are in a deterministic dependency order. Only valid when renderYamlToDirectory is set.
+        :param pulumi.Input[_builtins.str] render_yaml_snapshot: The path of a JSON file from which to resolve the unknown values of the manifests rendered to the directory specified by renderYamlToDirectory, such as a generated name or an output of another stack. The file is either a deployment, as exported by `pulumi stack export --show-secrets`, from which an unknown value is resolved from the same property of the resource with the same URN, or a JSON object of resolved values keyed by resource URN and then by property path (e.g. `spec.loadBalancerIP`). With a snapshot, previews show the resolved values, although they never write the manifests. The unknown values that aren't resolved are rendered as `${unresolved:<property path>}` placeholders, and listed in `render-report.txt`. Only valid when renderYamlToDirectory is set.
         :param pulumi.Input[_builtins.str] render_yaml_to_directory: BETA FEATURE - If present, render resource manifests to this directory. In this mode, resources will not
                be created on a Kubernetes cluster, but the rendered manifests will be kept in sync with changes
                to the Pulumi program. This feature is in developer preview, and is disabled by default.
@@ -92,7 +105,8 @@ class ProviderArgs:
                Note that some computed Outputs such as status fields will not be populated
                since the resources are not created on a Kubernetes cluster. These Output values will remain undefined,
                and may result in an error if they are referenced by other resources. Also note that any secret values
-               used in these resources will be rendered in plaintext to the resulting YAML.
+               used in these resources will be rendered in plaintext to the resulting YAML, except for the data of
+               Secrets when renderYamlAgeRecipients is set.
         :param pulumi.Input[_builtins.bool] skip_update_unreachable: If present and set to true, the provider will skip resources update associated with an unreachable Kubernetes cluster from Pulumi state
         :param pulumi.Input[_builtins.bool] suppress_deprecation_warnings: If present and set to true, suppress apiVersion deprecation warnings from the CLI.
         :param pulumi.Input[_builtins.bool] suppress_helm_hook_warnings: If present and set to true, suppress unsupported Helm hook warnings from the CLI.
@@ -140,8 +154,18 @@ class ProviderArgs:
             kubeconfig = _utilities.get_env('KUBECONFIG')
         if kubeconfig is not None:
             pulumi.set(__self__, "kubeconfig", kubeconfig)
+        if manifest_cache_offline is None:
+            manifest_cache_offline = _utilities.get_env_bool('PULUMI_K8S_MANIFEST_CACHE_OFFLINE')
+        if manifest_cache_offline is not None:
+            pulumi.set(__self__, "manifest_cache_offline", manifest_cache_offline)
         if namespace is not None:
             pulumi.set(__self__, "namespace", namespace)
+        if render_yaml_age_recipients is not None:
+            pulumi.set(__self__, "render_yaml_age_recipients", render_yaml_age_recipients)
+        if render_yaml_format is not None:
+            pulumi.set(__self__, "render_yaml_format", render_yaml_format)
+        if render_yaml_snapshot is not None:
+            pulumi.set(__self__, "render_yaml_snapshot", render_yaml_snapshot)
         if render_yaml_to_directory is not None:
             pulumi.set(__self__, "render_yaml_to_directory", render_yaml_to_directory)
         if skip_update_unreachable is None:
@@ -326,6 +350,18 @@ class ProviderArgs:
     def kubeconfig(self, value: pulumi.Input[Optional[_builtins.str]]):
         pulumi.set(self, "kubeconfig", value)
 
+    @_builtins.property
+    @pulumi.getter(name="manifestCacheOffline")
+    def manifest_cache_offline(self) -> pulumi.Input[Optional[_builtins.bool]]:
+        """
+        If present and set to true, the manifests of `yaml/v2.ConfigGroup` and `yaml/v2.ConfigFile` that are given by URL are read from the on-disk manifest cache only, and never downloaded. A manifest that isn't cached is an error.
+        """
+        return pulumi.get(self, "manifest_cache_offline")
+
+    @manifest_cache_offline.setter
+    def manifest_cache_offline(self, value: pulumi.Input[Optional[_builtins.bool]]):
+        pulumi.set(self, "manifest_cache_offline", value)
+
     @_builtins.property
     @pulumi.getter
     def namespace(self) -> pulumi.Input[Optional[_builtins.str]]:
@@ -343,6 +379,47 @@ class ProviderArgs:
     def namespace(self, value: pulumi.Input[Optional[_builtins.str]]):
         pulumi.set(self, "namespace", value)
 
+    @_builtins.property
+    @pulumi.getter(name="renderYamlAgeRecipients")
+    def render_yaml_age_recipients(self) -> pulumi.Input[Optional[_builtins.str]]:
+        """
+        A comma-separated list of age recipients (e.g. `age1...`) to which to encrypt the Secrets rendered to the directory specified by renderYamlToDirectory. The `data` and `stringData` of the Secrets are encrypted with SOPS, such that the manifests may be committed to git and decrypted by SOPS or by Flux's kustomize-controller with the recipients' age keys. When the recipients change, the Secrets are rendered again for the new recipients. A Secret that is rendered again unchanged (e.g. with alwaysRender) keeps its ciphertext, so that its file doesn't change. Not supported with the `namespaces` renderYamlFormat, since `sops -d` can't decrypt a file of several SOPS documents. Only valid when renderYamlToDirectory is set.
+        """
+        return pulumi.get(self, "render_yaml_age_recipients")
+
+    @render_yaml_age_recipients.setter
+    def render_yaml_age_recipients(self, value: pulumi.Input[Optional[_builtins.str]]):
+        pulumi.set(self, "render_yaml_age_recipients", value)
+
+    @_builtins.property
+    @pulumi.getter(name="renderYamlFormat")
+    def render_yaml_format(self) -> pulumi.Input[Optional[_builtins.str]]:
+        """
+        The layout of the manifests rendered to the directory specified by renderYamlToDirectory. One of:
+        - `files` (the default): each object in its own file, in the `0-crd` and `1-manifest` directories.
+        - `namespaces`: the objects of each namespace in a single multi-document file named after the namespace, and the objects without a namespace in `_cluster.yaml`.
+        - `kustomization`: each object in its own file, as with `files`, along with a `kustomization.yaml` that lists the files.
+
+        The objects of a multi-document file, and the files of a `kustomization.yaml`, are in a deterministic dependency order. Only valid when renderYamlToDirectory is set.
+        """
+        return pulumi.get(self, "render_yaml_format")
+
+    @render_yaml_format.setter
+    def render_yaml_format(self, value: pulumi.Input[Optional[_builtins.str]]):
+        pulumi.set(self, "render_yaml_format", value)
+
+    @_builtins.property
+    @pulumi.getter(name="renderYamlSnapshot")
+    def render_yaml_snapshot(self) -> pulumi.Input[Optional[_builtins.str]]:
+        """
+        The path of a JSON file from which to resolve the unknown values of the manifests rendered to the directory specified by renderYamlToDirectory, such as a generated name or an output of another stack. The file is either a deployment, as exported by `pulumi stack export --show-secrets`, from which an unknown value is resolved from the same property of the resource with the same URN, or a JSON object of resolved values keyed by resource URN and then by property path (e.g. `spec.loadBalancerIP`). With a snapshot, previews show the resolved values, although they never write the manifests. The unknown values that aren't resolved are rendered as `${unresolved:<property path>}` placeholders, and listed in `render-report.txt`. Only valid when renderYamlToDirectory is set.
+        """
+        return pulumi.get(self, "render_yaml_snapshot")
+
+    @render_yaml_snapshot.setter
+    def render_yaml_snapshot(self, value: pulumi.Input[Optional[_builtins.str]]):
+        pulumi.set(self, "render_yaml_snapshot", value)
+
     @_builtins.property
     @pulumi.getter(name="renderYamlToDirectory")
     def render_yaml_to_directory(self) -> pulumi.Input[Optional[_builtins.str]]:
@@ -359,7 +436,8 @@ class ProviderArgs:
         Note that some computed Outputs such as status fields will not be populated
         since the resources are not created on a Kubernetes cluster. These Output values will remain undefined,
         and may result in an error if they are referenced by other resources. Also note that any secret values
-        used in these resources will be rendered in plaintext to the resulting YAML.
+        used in these resources will be rendered in plaintext to the resulting YAML, except for the data of
+        Secrets when renderYamlAgeRecipients is set.
         """
         return pulumi.get(self, "render_yaml_to_directory")
 
@@ -440,7 +518,11 @@ class Provider(pulumi.ProviderResource):
                  helm_release_settings: pulumi.Input[Optional[Union['HelmReleaseSettingsArgs', 'HelmReleaseSettingsArgsDict']]] = None,
                  kube_client_settings: pulumi.Input[Optional[Union['KubeClientSettingsArgs', 'KubeClientSettingsArgsDict']]] = None,
                  kubeconfig: pulumi.Input[Optional[_builtins.str]] = None,
+                 manifest_cache_offline: pulumi.Input[Optional[_builtins.bool]] = None,
                  namespace: pulumi.Input[Optional[_builtins.str]] = None,
+                 render_yaml_age_recipients: pulumi.Input[Optional[_builtins.str]] = None,
+                 render_yaml_format: pulumi.Input[Optional[_builtins.str]] = None,
+                 render_yaml_snapshot: pulumi.Input[Optional[_builtins.str]] = None,
                  render_yaml_to_directory: pulumi.Input[Optional[_builtins.str]] = None,
                  skip_update_unreachable: pulumi.Input[Optional[_builtins.bool]] = None,
                  suppress_deprecation_warnings: pulumi.Input[Optional[_builtins.bool]] = None,
@@ -486,12 +568,21 @@ class Provider(pulumi.ProviderResource):
         :param pulumi.Input[Union['HelmReleaseSettingsArgs', 'HelmReleaseSettingsArgsDict']] helm_release_settings: Options to configure the Helm Release resource.
         :param pulumi.Input[Union['KubeClientSettingsArgs', 'KubeClientSettingsArgsDict']] kube_client_settings: Options for tuning the Kubernetes client used by a Provider.
         :param pulumi.Input[_builtins.str] kubeconfig: The contents of a kubeconfig file or the path to a kubeconfig file.
+        :param pulumi.Input[_builtins.bool] manifest_cache_offline: If present and set to true, the manifests of `yaml/v2.ConfigGroup` and `yaml/v2.ConfigFile` that are given by URL are read from the on-disk manifest cache only, and never downloaded. A manifest that isn't cached is an error.
         :param pulumi.Input[_builtins.str] namespace: If present, the default namespace to use. This flag is ignored for cluster-scoped resources.
                
                A namespace can be specified in multiple places, and the precedence is as follows:
                1. `.metadata.namespace` set on the resource.
                2. This `namespace` parameter.
                3. `namespace` set for the active context in the kubeconfig.
+        :param pulumi.Input[_builtins.str] render_yaml_age_recipients: A comma-separated list of age recipients (e.g. `age1...`) to which to encrypt the Secrets rendered to the directory specified by renderYamlToDirectory. The `data` and `stringData` of the Secrets are encrypted with SOPS, such that the manifests may be committed to git and decrypted by SOPS or by Flux's kustomize-controller with the recipients' age keys. When the recipients change, the Secrets are rendered again for the new recipients. A Secret that is rendered again unchanged (e.g. with alwaysRender) keeps its ciphertext, so that its file doesn't change. Not supported with the `namespaces` renderYamlFormat, since `sops -d` can't decrypt a file of several SOPS documents. Only valid when renderYamlToDirectory is set.
+        :param pulumi.Input[_builtins.str] render_yaml_format: The layout of the manifests rendered to the directory specified by renderYamlToDirectory. One of:
+               - `files` (the default): each object in its own file, in the `0-crd` and `1-manifest` directories.
+               - `namespaces`: the objects of each namespace in a single multi-document file named after the namespace, and the objects without a namespace in `_cluster.yaml`.
+               - `kustomization`: each object in its own file, as with `files`, along with a `kustomization.yaml` that lists the files.
+               
+               The objects of a multi-document file, and the files of a `kustomization.yaml`, are in a deterministic dependency order. Only valid when renderYamlToDirectory is set.
+        :param pulumi.Input[_builtins.str] render_yaml_snapshot: The path of a JSON file from which to resolve the unknown values of the manifests rendered to the directory specified by renderYamlToDirectory, such as a generated name or an output of another stack. The file is either a deployment, as exported by `pulumi stack export --show-secrets`, from which an unknown value is resolved from the same property of the resource with the same URN, or a JSON object of resolved values keyed by resource URN and then by property path (e.g. `spec.loadBalancerIP`). With a snapshot, previews show the resolved values, although they never write the manifests. The unknown values that aren't resolved are rendered as `${unresolved:<property path>}` placeholders, and listed in `render-report.txt`. Only valid when renderYamlToDirectory is set.
         :param pulumi.Input[_builtins.str] render_yaml_to_directory: BETA FEATURE - If present, render resource manifests to this directory. In this mode, resources will not
                be created on a Kubernetes cluster, but the rendered manifests will be kept in sync with changes
                to the Pulumi program. This feature is in developer preview, and is disabled by default.
@@ -504,7 +595,8 @@ class Provider(pulumi.ProviderResource):
                Note that some computed Outputs such as status fields will not be populated
                since the resources are not created on a Kubernetes cluster. These Output values will remain undefined,
                and may result in an error if they are referenced by other resources. Also note that any secret values
-               used in these resources will be rendered in plaintext to the resulting YAML.
+               used in these resources will be rendered in plaintext to the resulting YAML, except for the data of
+               Secrets when renderYamlAgeRecipients is set.
         :param pulumi.Input[_builtins.bool] skip_update_unreachable: If present and set to true, the provider will skip resources update associated with an unreachable Kubernetes cluster from Pulumi state
         :param pulumi.Input[_builtins.bool] suppress_deprecation_warnings: If present and set to true, suppress apiVersion deprecation warnings from the CLI.
         :param pulumi.Input[_builtins.bool] suppress_helm_hook_warnings: If present and set to true, suppress unsupported Helm hook warnings from the CLI.
@@ -553,7 +645,11 @@ class Provider(pulumi.ProviderResource):
                  helm_release_settings: pulumi.Input[Optional[Union['HelmReleaseSettingsArgs', 'HelmReleaseSettingsArgsDict']]] = None,
                  kube_client_settings: pulumi.Input[Optional[Union['KubeClientSettingsArgs', 'KubeClientSettingsArgsDict']]] = None,
                  kubeconfig: pulumi.Input[Optional[_builtins.str]] = None,
+                 manifest_cache_offline: pulumi.Input[Optional[_builtins.bool]] = None,
                  namespace: pulumi.Input[Optional[_builtins.str]] = None,
+                 render_yaml_age_recipients: pulumi.Input[Optional[_builtins.str]] = None,
+                 render_yaml_format: pulumi.Input[Optional[_builtins.str]] = None,
+                 render_yaml_snapshot: pulumi.Input[Optional[_builtins.str]] = None,
                  render_yaml_to_directory: pulumi.Input[Optional[_builtins.str]] = None,
                  skip_update_unreachable: pulumi.Input[Optional[_builtins.bool]] = None,
                  suppress_deprecation_warnings: pulumi.Input[Optional[_builtins.bool]] = None,
@@ -592,7 +688,13 @@ class Provider(pulumi.ProviderResource):
             if kubeconfig is None:
                 kubeconfig = _utilities.get_env('KUBECONFIG')
             __props__.__dict__["kubeconfig"] = kubeconfig
+            if manifest_cache_offline is None:
+                manifest_cache_offline = _utilities.get_env_bool('PULUMI_K8S_MANIFEST_CACHE_OFFLINE')
+            __props__.__dict__["manifest_cache_offline"] = pulumi.Output.from_input(manifest_cache_offline).apply(pulumi.runtime.to_json) if manifest_cache_offline is not None else None
             __props__.__dict__["namespace"] = namespace
+            __props__.__dict__["render_yaml_age_recipients"] = render_yaml_age_recipients
+            __props__.__dict__["render_yaml_format"] = render_yaml_format
+            __props__.__dict__["render_yaml_snapshot"] = render_yaml_snapshot
             __props__.__dict__["render_yaml_to_directory"] = render_yaml_to_directory
             if skip_update_unreachable is None:
                 skip_update_unreachable = _utilities.get_env_bool('PULUMI_K8S_SKIP_UPDATE_UNREACHABLE')
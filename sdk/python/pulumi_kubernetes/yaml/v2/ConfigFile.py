@@ -13,6 +13,7 @@ if sys.version_info >= (3, 11):
 else:
     from typing_extensions import NotRequired, TypedDict, TypeAlias
 from ... import _utilities
+from ._inputs import *
 
 __all__ = ['ConfigFileArgs', 'ConfigFile']
 
@@ -20,26 +21,46 @@ __all__ = ['ConfigFileArgs', 'ConfigFile']
 class ConfigFileArgs:
     def __init__(__self__, *,
                  file: pulumi.Input[_builtins.str],
+                 exclude: pulumi.Input[Optional[Sequence[pulumi.Input['SelectorArgs']]]] = None,
+                 include: pulumi.Input[Optional[Sequence[pulumi.Input['SelectorArgs']]]] = None,
+                 patches: pulumi.Input[Optional[Sequence[pulumi.Input['PatchArgs']]]] = None,
                  resource_prefix: pulumi.Input[Optional[_builtins.str]] = None,
-                 skip_await: pulumi.Input[Optional[_builtins.bool]] = None):
+                 skip_await: pulumi.Input[Optional[_builtins.bool]] = None,
+                 template_engine: pulumi.Input[Optional[_builtins.str]] = None,
+                 values: pulumi.Input[Optional[Mapping[str, Any]]] = None):
         """
         The set of arguments for constructing a ConfigFile resource.
 
-        :param pulumi.Input[_builtins.str] file: Path or URL to a Kubernetes manifest file. File must exist.
+        :param pulumi.Input[_builtins.str] file: Path or URL to a Kubernetes manifest file. File must exist. A URL may be pinned to the SHA-256 digest of its content with a `#sha256=<digest>` fragment; the content is verified, and cached so that it's downloaded only once.
+        :param pulumi.Input[Sequence[pulumi.Input['SelectorArgs']]] exclude: Selects the objects not to register, among the included objects. The skipped objects are reported in the `skipped` output.
+        :param pulumi.Input[Sequence[pulumi.Input['SelectorArgs']]] include: Selects the objects to register. An object is registered if it matches any of the selectors; by default, all objects are registered.
+        :param pulumi.Input[Sequence[pulumi.Input['PatchArgs']]] patches: Patches to apply to the objects of the manifest before they're registered, in order. The patches are applied in-process, with kustomize.
         :param pulumi.Input[_builtins.str] resource_prefix: A prefix for the auto-generated resource names. Defaults to the name of the ConfigFile. Example: A resource created with resourcePrefix="foo" would produce a resource named "foo-resourceName".
         :param pulumi.Input[_builtins.bool] skip_await: Indicates that child resources should skip the await logic. Defaults to `false`.
+        :param pulumi.Input[_builtins.str] template_engine: The engine with which to template the manifest: `goTemplate` for Go templates, or `envsubst` for `${VAR}` and `${VAR:-default}` references to the values (by dotted paths, e.g. `${image.tag}`). References to missing values without a default are left as is, and `$${VAR}` escapes a reference. Defaults to `goTemplate` when `values` is set; otherwise, the manifest isn't templated.
+        :param pulumi.Input[Mapping[str, Any]] values: Values with which to template the manifest before it's parsed. With the `goTemplate` engine, the values are available as `.Values`, along with the Sprig functions.
         """
         pulumi.set(__self__, "file", file)
+        if exclude is not None:
+            pulumi.set(__self__, "exclude", exclude)
+        if include is not None:
+            pulumi.set(__self__, "include", include)
+        if patches is not None:
+            pulumi.set(__self__, "patches", patches)
         if resource_prefix is not None:
             pulumi.set(__self__, "resource_prefix", resource_prefix)
         if skip_await is not None:
             pulumi.set(__self__, "skip_await", skip_await)
+        if template_engine is not None:
+            pulumi.set(__self__, "template_engine", template_engine)
+        if values is not None:
+            pulumi.set(__self__, "values", values)
 
     @_builtins.property
     @pulumi.getter
     def file(self) -> pulumi.Input[_builtins.str]:
         """
-        Path or URL to a Kubernetes manifest file. File must exist.
+        Path or URL to a Kubernetes manifest file. File must exist. A URL may be pinned to the SHA-256 digest of its content with a `#sha256=<digest>` fragment; the content is verified, and cached so that it's downloaded only once.
         """
         return pulumi.get(self, "file")
 
@@ -47,6 +68,42 @@ class ConfigFileArgs:
     def file(self, value: pulumi.Input[_builtins.str]):
         pulumi.set(self, "file", value)
 
+    @_builtins.property
+    @pulumi.getter
+    def exclude(self) -> pulumi.Input[Optional[Sequence[pulumi.Input['SelectorArgs']]]]:
+        """
+        Selects the objects not to register, among the included objects. The skipped objects are reported in the `skipped` output.
+        """
+        return pulumi.get(self, "exclude")
+
+    @exclude.setter
+    def exclude(self, value: pulumi.Input[Optional[Sequence[pulumi.Input['SelectorArgs']]]]):
+        pulumi.set(self, "exclude", value)
+
+    @_builtins.property
+    @pulumi.getter
+    def include(self) -> pulumi.Input[Optional[Sequence[pulumi.Input['SelectorArgs']]]]:
+        """
+        Selects the objects to register. An object is registered if it matches any of the selectors; by default, all objects are registered.
+        """
+        return pulumi.get(self, "include")
+
+    @include.setter
+    def include(self, value: pulumi.Input[Optional[Sequence[pulumi.Input['SelectorArgs']]]]):
+        pulumi.set(self, "include", value)
+
+    @_builtins.property
+    @pulumi.getter
+    def patches(self) -> pulumi.Input[Optional[Sequence[pulumi.Input['PatchArgs']]]]:
+        """
+        Patches to apply to the objects of the manifest before they're registered, in order. The patches are applied in-process, with kustomize.
+        """
+        return pulumi.get(self, "patches")
+
+    @patches.setter
+    def patches(self, value: pulumi.Input[Optional[Sequence[pulumi.Input['PatchArgs']]]]):
+        pulumi.set(self, "patches", value)
+
     @_builtins.property
     @pulumi.getter(name="resourcePrefix")
     def resource_prefix(self) -> pulumi.Input[Optional[_builtins.str]]:
@@ -71,6 +128,30 @@ class ConfigFileArgs:
     def skip_await(self, value: pulumi.Input[Optional[_builtins.bool]]):
         pulumi.set(self, "skip_await", value)
 
+    @_builtins.property
+    @pulumi.getter(name="templateEngine")
+    def template_engine(self) -> pulumi.Input[Optional[_builtins.str]]:
+        """
+        The engine with which to template the manifest: `goTemplate` for Go templates, or `envsubst` for `${VAR}` and `${VAR:-default}` references to the values (by dotted paths, e.g. `${image.tag}`). References to missing values without a default are left as is, and `$${VAR}` escapes a reference. Defaults to `goTemplate` when `values` is set; otherwise, the manifest isn't templated.
+        """
+        return pulumi.get(self, "template_engine")
+
+    @template_engine.setter
+    def template_engine(self, value: pulumi.Input[Optional[_builtins.str]]):
+        pulumi.set(self, "template_engine", value)
+
+    @_builtins.property
+    @pulumi.getter
+    def values(self) -> pulumi.Input[Optional[Mapping[str, Any]]]:
+        """
+        Values with which to template the manifest before it's parsed. With the `goTemplate` engine, the values are available as `.Values`, along with the Sprig functions.
+        """
+        return pulumi.get(self, "values")
+
+    @values.setter
+    def values(self, value: pulumi.Input[Optional[Mapping[str, Any]]]):
+        pulumi.set(self, "values", value)
+
 
 @pulumi.type_token("kubernetes:yaml/v2:ConfigFile")
 class ConfigFile(pulumi.ComponentResource):
@@ -78,14 +159,37 @@ class ConfigFile(pulumi.ComponentResource):
     def __init__(__self__,
                  resource_name: str,
                  opts: Optional[pulumi.ResourceOptions] = None,
+                 exclude: pulumi.Input[Optional[Sequence[pulumi.Input[Union['SelectorArgs', 'SelectorArgsDict']]]]] = None,
                  file: pulumi.Input[Optional[_builtins.str]] = None,
+                 include: pulumi.Input[Optional[Sequence[pulumi.Input[Union['SelectorArgs', 'SelectorArgsDict']]]]] = None,
+                 patches: pulumi.Input[Optional[Sequence[pulumi.Input[Union['PatchArgs', 'PatchArgsDict']]]]] = None,
                  resource_prefix: pulumi.Input[Optional[_builtins.str]] = None,
                  skip_await: pulumi.Input[Optional[_builtins.bool]] = None,
+                 template_engine: pulumi.Input[Optional[_builtins.str]] = None,
+                 values: pulumi.Input[Optional[Mapping[str, Any]]] = None,
                  __props__=None):
         """
         ConfigFile creates a set of Kubernetes resources from a remote or on-disk Kubernetes YAML file.
         (If you have in-memory YAML a ConfigGroup may be more appropriate.)
 
+        ## Templating
+        The manifests may be templated with `values`, e.g. to set image tags or namespaces per stack. By default, they're
+        rendered as Go templates with the Sprig functions, and the values are available as `.Values`
+        (e.g. `{{ .Values.image.tag }}`). With `templateEngine: envsubst`, `${VAR}` and `${VAR:-default}` references to the
+        values are substituted instead (e.g. `${image.tag}`), and references to missing values are left as is.
+
+        ## Patches
+        The objects may be patched with `patches`, before they're registered, without a transform in the program. Each patch
+        is a strategic merge patch or a JSON 6902 patch, and its `target` selects the objects to patch by group, version,
+        kind, name, namespace, label selector or annotation selector. A strategic merge patch may omit the target, in which
+        case the patch itself identifies the object to patch.
+
+        ## Selecting objects
+        The objects to register may be selected with `include` and `exclude`, e.g. to skip a Namespace that's managed
+        separately. Each selector matches objects by `group`, `version`, `kind`, `name` (a glob pattern), `namespace` and
+        `labelSelector`. An object is registered if it matches any of the included selectors (or if there are none), and none
+        of the excluded selectors. The skipped objects are reported in the `skipped` output.
+
         ## Dependency ordering
         Sometimes resources must be applied in a specific order. For example, a namespace resource must be
         created before any namespaced resources, or a Custom Resource Definition (CRD) must be pre-installed.
@@ -93,11 +197,19 @@ class ConfigFile(pulumi.ComponentResource):
         Pulumi uses heuristics to determine which order to apply and delete objects within the ConfigFile.  Pulumi also
         waits for each object to be fully reconciled, unless `skipAwait` is enabled.
 
+        Workloads depend on the ConfigMaps and Secrets in the same namespace that their pods reference (by volumes, `envFrom`,
+        `env` or `imagePullSecrets`), so that the referenced configuration is created first.
+
+        Admission webhook configurations depend on the Services that back them and on the workloads that those Services
+        select, and the objects that a webhook intercepts depend on the webhook configuration, so that the webhook is ready
+        before the objects are created. Webhooks whose `failurePolicy` is `Ignore` don't affect the ordering, and the objects
+        in the namespace of the webhook's Service are assumed to be part of the webhook's installation.
+
         ### Explicit Dependency Ordering
         Pulumi supports the `config.kubernetes.io/depends-on` annotation to declare an explicit dependency on a given resource.
         The annotation accepts a list of resource references, delimited by commas.
 
-        Note that references to resources outside the ConfigFile aren't supported.
+        A reference to a resource outside the ConfigFile is assumed to exist in the cluster already, and isn't waited for.
 
         **Resource reference**
 
@@ -136,9 +248,14 @@ class ConfigFile(pulumi.ComponentResource):
 
         :param str resource_name: The name of the resource.
         :param pulumi.ResourceOptions opts: Options for the resource.
-        :param pulumi.Input[_builtins.str] file: Path or URL to a Kubernetes manifest file. File must exist.
+        :param pulumi.Input[Sequence[pulumi.Input[Union['SelectorArgs', 'SelectorArgsDict']]]] exclude: Selects the objects not to register, among the included objects. The skipped objects are reported in the `skipped` output.
+        :param pulumi.Input[_builtins.str] file: Path or URL to a Kubernetes manifest file. File must exist. A URL may be pinned to the SHA-256 digest of its content with a `#sha256=<digest>` fragment; the content is verified, and cached so that it's downloaded only once.
+        :param pulumi.Input[Sequence[pulumi.Input[Union['SelectorArgs', 'SelectorArgsDict']]]] include: Selects the objects to register. An object is registered if it matches any of the selectors; by default, all objects are registered.
+        :param pulumi.Input[Sequence[pulumi.Input[Union['PatchArgs', 'PatchArgsDict']]]] patches: Patches to apply to the objects of the manifest before they're registered, in order. The patches are applied in-process, with kustomize.
         :param pulumi.Input[_builtins.str] resource_prefix: A prefix for the auto-generated resource names. Defaults to the name of the ConfigFile. Example: A resource created with resourcePrefix="foo" would produce a resource named "foo-resourceName".
         :param pulumi.Input[_builtins.bool] skip_await: Indicates that child resources should skip the await logic. Defaults to `false`.
+        :param pulumi.Input[_builtins.str] template_engine: The engine with which to template the manifest: `goTemplate` for Go templates, or `envsubst` for `${VAR}` and `${VAR:-default}` references to the values (by dotted paths, e.g. `${image.tag}`). References to missing values without a default are left as is, and `$${VAR}` escapes a reference. Defaults to `goTemplate` when `values` is set; otherwise, the manifest isn't templated.
+        :param pulumi.Input[Mapping[str, Any]] values: Values with which to template the manifest before it's parsed. With the `goTemplate` engine, the values are available as `.Values`, along with the Sprig functions.
         """
         ...
     @overload
@@ -150,6 +267,24 @@ class ConfigFile(pulumi.ComponentResource):
         ConfigFile creates a set of Kubernetes resources from a remote or on-disk Kubernetes YAML file.
         (If you have in-memory YAML a ConfigGroup may be more appropriate.)
 
+        ## Templating
+        The manifests may be templated with `values`, e.g. to set image tags or namespaces per stack. By default, they're
+        rendered as Go templates with the Sprig functions, and the values are available as `.Values`
+        (e.g. `{{ .Values.image.tag }}`). With `templateEngine: envsubst`, `${VAR}` and `${VAR:-default}` references to the
+        values are substituted instead (e.g. `${image.tag}`), and references to missing values are left as is.
+
+        ## Patches
+        The objects may be patched with `patches`, before they're registered, without a transform in the program. Each patch
+        is a strategic merge patch or a JSON 6902 patch, and its `target` selects the objects to patch by group, version,
+        kind, name, namespace, label selector or annotation selector. A strategic merge patch may omit the target, in which
+        case the patch itself identifies the object to patch.
+
+        ## Selecting objects
+        The objects to register may be selected with `include` and `exclude`, e.g. to skip a Namespace that's managed
+        separately. Each selector matches objects by `group`, `version`, `kind`, `name` (a glob pattern), `namespace` and
+        `labelSelector`. An object is registered if it matches any of the included selectors (or if there are none), and none
+        of the excluded selectors. The skipped objects are reported in the `skipped` output.
+
         ## Dependency ordering
         Sometimes resources must be applied in a specific order. For example, a namespace resource must be
         created before any namespaced resources, or a Custom Resource Definition (CRD) must be pre-installed.
@@ -157,11 +292,19 @@ class ConfigFile(pulumi.ComponentResource):
         Pulumi uses heuristics to determine which order to apply and delete objects within the ConfigFile.  Pulumi also
         waits for each object to be fully reconciled, unless `skipAwait` is enabled.
 
+        Workloads depend on the ConfigMaps and Secrets in the same namespace that their pods reference (by volumes, `envFrom`,
+        `env` or `imagePullSecrets`), so that the referenced configuration is created first.
+
+        Admission webhook configurations depend on the Services that back them and on the workloads that those Services
+        select, and the objects that a webhook intercepts depend on the webhook configuration, so that the webhook is ready
+        before the objects are created. Webhooks whose `failurePolicy` is `Ignore` don't affect the ordering, and the objects
+        in the namespace of the webhook's Service are assumed to be part of the webhook's installation.
+
         ### Explicit Dependency Ordering
         Pulumi supports the `config.kubernetes.io/depends-on` annotation to declare an explicit dependency on a given resource.
         The annotation accepts a list of resource references, delimited by commas.
 
-        Note that references to resources outside the ConfigFile aren't supported.
+        A reference to a resource outside the ConfigFile is assumed to exist in the cluster already, and isn't waited for.
 
         **Resource reference**
 
@@ -213,9 +356,14 @@ class ConfigFile(pulumi.ComponentResource):
     def _internal_init(__self__,
                  resource_name: str,
                  opts: Optional[pulumi.ResourceOptions] = None,
+                 exclude: pulumi.Input[Optional[Sequence[pulumi.Input[Union['SelectorArgs', 'SelectorArgsDict']]]]] = None,
                  file: pulumi.Input[Optional[_builtins.str]] = None,
+                 include: pulumi.Input[Optional[Sequence[pulumi.Input[Union['SelectorArgs', 'SelectorArgsDict']]]]] = None,
+                 patches: pulumi.Input[Optional[Sequence[pulumi.Input[Union['PatchArgs', 'PatchArgsDict']]]]] = None,
                  resource_prefix: pulumi.Input[Optional[_builtins.str]] = None,
                  skip_await: pulumi.Input[Optional[_builtins.bool]] = None,
+                 template_engine: pulumi.Input[Optional[_builtins.str]] = None,
+                 values: pulumi.Input[Optional[Mapping[str, Any]]] = None,
                  __props__=None):
         opts = pulumi.ResourceOptions.merge(_utilities.get_resource_opts_defaults(), opts)
         if not isinstance(opts, pulumi.ResourceOptions):
@@ -227,12 +375,18 @@ class ConfigFile(pulumi.ComponentResource):
                 raise TypeError('__props__ is only valid when passed in combination with a valid opts.id to get an existing resource')
             __props__ = ConfigFileArgs.__new__(ConfigFileArgs)
 
+            __props__.__dict__["exclude"] = exclude
             if file is None and not opts.urn:
                 raise TypeError("Missing required property 'file'")
             __props__.__dict__["file"] = file
+            __props__.__dict__["include"] = include
+            __props__.__dict__["patches"] = patches
             __props__.__dict__["resource_prefix"] = resource_prefix
             __props__.__dict__["skip_await"] = skip_await
+            __props__.__dict__["template_engine"] = template_engine
+            __props__.__dict__["values"] = values
             __props__.__dict__["resources"] = None
+            __props__.__dict__["skipped"] = None
         super(ConfigFile, __self__).__init__(
             'kubernetes:yaml/v2:ConfigFile',
             resource_name,
@@ -248,3 +402,11 @@ class ConfigFile(pulumi.ComponentResource):
         """
         return pulumi.get(self, "resources")
 
+    @_builtins.property
+    @pulumi.getter
+    def skipped(self) -> pulumi.Output[Optional[Sequence[_builtins.str]]]:
+        """
+        The objects of the ConfigFile that weren't registered because of the `include` and `exclude` selectors, e.g. `v1/Namespace:my-namespace`.
+        """
+        return pulumi.get(self, "skipped")
+
@@ -13,42 +13,75 @@ if sys.version_info >= (3, 11):
 else:
     from typing_extensions import NotRequired, TypedDict, TypeAlias
 from ... import _utilities
+from ._inputs import *
 
 __all__ = ['ConfigGroupArgs', 'ConfigGroup']
 
 @pulumi.input_type
 class ConfigGroupArgs:
     def __init__(__self__, *,
+                 exclude: pulumi.Input[Optional[Sequence[pulumi.Input['SelectorArgs']]]] = None,
                  files: pulumi.Input[Optional[Sequence[pulumi.Input[_builtins.str]]]] = None,
+                 include: pulumi.Input[Optional[Sequence[pulumi.Input['SelectorArgs']]]] = None,
                  objs: pulumi.Input[Optional[Sequence[Any]]] = None,
+                 patches: pulumi.Input[Optional[Sequence[pulumi.Input['PatchArgs']]]] = None,
                  resource_prefix: pulumi.Input[Optional[_builtins.str]] = None,
                  skip_await: pulumi.Input[Optional[_builtins.bool]] = None,
+                 template_engine: pulumi.Input[Optional[_builtins.str]] = None,
+                 values: pulumi.Input[Optional[Mapping[str, Any]]] = None,
                  yaml: pulumi.Input[Optional[_builtins.str]] = None):
         """
         The set of arguments for constructing a ConfigGroup resource.
 
-        :param pulumi.Input[Sequence[pulumi.Input[_builtins.str]]] files: Set of paths and/or URLs to Kubernetes manifest files. Supports glob patterns.
+        :param pulumi.Input[Sequence[pulumi.Input['SelectorArgs']]] exclude: Selects the objects not to register, among the included objects. The skipped objects are reported in the `skipped` output.
+        :param pulumi.Input[Sequence[pulumi.Input[_builtins.str]]] files: Set of paths and/or URLs to Kubernetes manifest files. Supports glob patterns. A URL may be pinned to the SHA-256 digest of its content with a `#sha256=<digest>` fragment; the content is verified, and cached so that it's downloaded only once.
+        :param pulumi.Input[Sequence[pulumi.Input['SelectorArgs']]] include: Selects the objects to register. An object is registered if it matches any of the selectors; by default, all objects are registered.
         :param pulumi.Input[Sequence[Any]] objs: Objects representing Kubernetes resource configurations.
+        :param pulumi.Input[Sequence[pulumi.Input['PatchArgs']]] patches: Patches to apply to the objects (including `objs`) before they're registered, in order. The patches are applied in-process, with kustomize.
         :param pulumi.Input[_builtins.str] resource_prefix: A prefix for the auto-generated resource names. Defaults to the name of the ConfigGroup. Example: A resource created with resourcePrefix="foo" would produce a resource named "foo-resourceName".
         :param pulumi.Input[_builtins.bool] skip_await: Indicates that child resources should skip the await logic. Defaults to `false`.
+        :param pulumi.Input[_builtins.str] template_engine: The engine with which to template the files and YAML literals: `goTemplate` for Go templates, or `envsubst` for `${VAR}` and `${VAR:-default}` references to the values (by dotted paths, e.g. `${image.tag}`). References to missing values without a default are left as is, and `$${VAR}` escapes a reference. Defaults to `goTemplate` when `values` is set; otherwise, the files and YAML literals aren't templated.
+        :param pulumi.Input[Mapping[str, Any]] values: Values with which to template the files and YAML literals (but not `objs`) before they're parsed. With the `goTemplate` engine, the values are available as `.Values`, along with the Sprig functions.
         :param pulumi.Input[_builtins.str] yaml: A Kubernetes YAML manifest containing Kubernetes resource configuration(s).
         """
+        if exclude is not None:
+            pulumi.set(__self__, "exclude", exclude)
         if files is not None:
             pulumi.set(__self__, "files", files)
+        if include is not None:
+            pulumi.set(__self__, "include", include)
         if objs is not None:
             pulumi.set(__self__, "objs", objs)
+        if patches is not None:
+            pulumi.set(__self__, "patches", patches)
         if resource_prefix is not None:
             pulumi.set(__self__, "resource_prefix", resource_prefix)
         if skip_await is not None:
             pulumi.set(__self__, "skip_await", skip_await)
+        if template_engine is not None:
+            pulumi.set(__self__, "template_engine", template_engine)
+        if values is not None:
+            pulumi.set(__self__, "values", values)
         if yaml is not None:
             pulumi.set(__self__, "yaml", yaml)
 
+    @_builtins.property
+    @pulumi.getter
+    def exclude(self) -> pulumi.Input[Optional[Sequence[pulumi.Input['SelectorArgs']]]]:
+        """
+        Selects the objects not to register, among the included objects. The skipped objects are reported in the `skipped` output.
+        """
+        return pulumi.get(self, "exclude")
+
+    @exclude.setter
+    def exclude(self, value: pulumi.Input[Optional[Sequence[pulumi.Input['SelectorArgs']]]]):
+        pulumi.set(self, "exclude", value)
+
     @_builtins.property
     @pulumi.getter
     def files(self) -> pulumi.Input[Optional[Sequence[pulumi.Input[_builtins.str]]]]:
         """
-        Set of paths and/or URLs to Kubernetes manifest files. Supports glob patterns.
+        Set of paths and/or URLs to Kubernetes manifest files. Supports glob patterns. A URL may be pinned to the SHA-256 digest of its content with a `#sha256=<digest>` fragment; the content is verified, and cached so that it's downloaded only once.
         """
         return pulumi.get(self, "files")
 
@@ -56,6 +89,18 @@ class ConfigGroupArgs:
     def files(self, value: pulumi.Input[Optional[Sequence[pulumi.Input[_builtins.str]]]]):
         pulumi.set(self, "files", value)
 
+    @_builtins.property
+    @pulumi.getter
+    def include(self) -> pulumi.Input[Optional[Sequence[pulumi.Input['SelectorArgs']]]]:
+        """
+        Selects the objects to register. An object is registered if it matches any of the selectors; by default, all objects are registered.
+        """
+        return pulumi.get(self, "include")
+
+    @include.setter
+    def include(self, value: pulumi.Input[Optional[Sequence[pulumi.Input['SelectorArgs']]]]):
+        pulumi.set(self, "include", value)
+
     @_builtins.property
     @pulumi.getter
     def objs(self) -> pulumi.Input[Optional[Sequence[Any]]]:
@@ -68,6 +113,18 @@ class ConfigGroupArgs:
     def objs(self, value: pulumi.Input[Optional[Sequence[Any]]]):
         pulumi.set(self, "objs", value)
 
+    @_builtins.property
+    @pulumi.getter
+    def patches(self) -> pulumi.Input[Optional[Sequence[pulumi.Input['PatchArgs']]]]:
+        """
+        Patches to apply to the objects (including `objs`) before they're registered, in order. The patches are applied in-process, with kustomize.
+        """
+        return pulumi.get(self, "patches")
+
+    @patches.setter
+    def patches(self, value: pulumi.Input[Optional[Sequence[pulumi.Input['PatchArgs']]]]):
+        pulumi.set(self, "patches", value)
+
     @_builtins.property
     @pulumi.getter(name="resourcePrefix")
     def resource_prefix(self) -> pulumi.Input[Optional[_builtins.str]]:
@@ -92,6 +149,30 @@ class ConfigGroupArgs:
     def skip_await(self, value: pulumi.Input[Optional[_builtins.bool]]):
         pulumi.set(self, "skip_await", value)
 
+    @_builtins.property
+    @pulumi.getter(name="templateEngine")
+    def template_engine(self) -> pulumi.Input[Optional[_builtins.str]]:
+        """
+        The engine with which to template the files and YAML literals: `goTemplate` for Go templates, or `envsubst` for `${VAR}` and `${VAR:-default}` references to the values (by dotted paths, e.g. `${image.tag}`). References to missing values without a default are left as is, and `$${VAR}` escapes a reference. Defaults to `goTemplate` when `values` is set; otherwise, the files and YAML literals aren't templated.
+        """
+        return pulumi.get(self, "template_engine")
+
+    @template_engine.setter
+    def template_engine(self, value: pulumi.Input[Optional[_builtins.str]]):
+        pulumi.set(self, "template_engine", value)
+
+    @_builtins.property
+    @pulumi.getter
+    def values(self) -> pulumi.Input[Optional[Mapping[str, Any]]]:
+        """
+        Values with which to template the files and YAML literals (but not `objs`) before they're parsed. With the `goTemplate` engine, the values are available as `.Values`, along with the Sprig functions.
+        """
+        return pulumi.get(self, "values")
+
+    @values.setter
+    def values(self, value: pulumi.Input[Optional[Mapping[str, Any]]]):
+        pulumi.set(self, "values", value)
+
     @_builtins.property
     @pulumi.getter
     def yaml(self) -> pulumi.Input[Optional[_builtins.str]]:
@@ -111,10 +192,15 @@ class ConfigGroup(pulumi.ComponentResource):
     def __init__(__self__,
                  resource_name: str,
                  opts: Optional[pulumi.ResourceOptions] = None,
+                 exclude: pulumi.Input[Optional[Sequence[pulumi.Input[Union['SelectorArgs', 'SelectorArgsDict']]]]] = None,
                  files: pulumi.Input[Optional[Sequence[pulumi.Input[_builtins.str]]]] = None,
+                 include: pulumi.Input[Optional[Sequence[pulumi.Input[Union['SelectorArgs', 'SelectorArgsDict']]]]] = None,
                  objs: pulumi.Input[Optional[Sequence[Any]]] = None,
+                 patches: pulumi.Input[Optional[Sequence[pulumi.Input[Union['PatchArgs', 'PatchArgsDict']]]]] = None,
                  resource_prefix: pulumi.Input[Optional[_builtins.str]] = None,
                  skip_await: pulumi.Input[Optional[_builtins.bool]] = None,
+                 template_engine: pulumi.Input[Optional[_builtins.str]] = None,
+                 values: pulumi.Input[Optional[Mapping[str, Any]]] = None,
                  yaml: pulumi.Input[Optional[_builtins.str]] = None,
                  __props__=None):
         """
@@ -126,6 +212,24 @@ class ConfigGroup(pulumi.ComponentResource):
         3. Using a literal string containing YAML, or a list of such strings:
         4. Any combination of files, patterns, or YAML strings:
 
+        ## Templating
+        The manifests may be templated with `values`, e.g. to set image tags or namespaces per stack. By default, they're
+        rendered as Go templates with the Sprig functions, and the values are available as `.Values`
+        (e.g. `{{ .Values.image.tag }}`). With `templateEngine: envsubst`, `${VAR}` and `${VAR:-default}` references to the
+        values are substituted instead (e.g. `${image.tag}`), and references to missing values are left as is.
+
+        ## Patches
+        The objects may be patched with `patches`, before they're registered, without a transform in the program. Each patch
+        is a strategic merge patch or a JSON 6902 patch, and its `target` selects the objects to patch by group, version,
+        kind, name, namespace, label selector or annotation selector. A strategic merge patch may omit the target, in which
+        case the patch itself identifies the object to patch.
+
+        ## Selecting objects
+        The objects to register may be selected with `include` and `exclude`, e.g. to skip a Namespace that's managed
+        separately. Each selector matches objects by `group`, `version`, `kind`, `name` (a glob pattern), `namespace` and
+        `labelSelector`. An object is registered if it matches any of the included selectors (or if there are none), and none
+        of the excluded selectors. The skipped objects are reported in the `skipped` output.
+
         ## Dependency ordering
         Sometimes resources must be applied in a specific order. For example, a namespace resource must be
         created before any namespaced resources, or a Custom Resource Definition (CRD) must be pre-installed.
@@ -133,11 +237,19 @@ class ConfigGroup(pulumi.ComponentResource):
         Pulumi uses heuristics to determine which order to apply and delete objects within the ConfigGroup.  Pulumi also
         waits for each object to be fully reconciled, unless `skipAwait` is enabled.
 
+        Workloads depend on the ConfigMaps and Secrets in the same namespace that their pods reference (by volumes, `envFrom`,
+        `env` or `imagePullSecrets`), so that the referenced configuration is created first.
+
+        Admission webhook configurations depend on the Services that back them and on the workloads that those Services
+        select, and the objects that a webhook intercepts depend on the webhook configuration, so that the webhook is ready
+        before the objects are created. Webhooks whose `failurePolicy` is `Ignore` don't affect the ordering, and the objects
+        in the namespace of the webhook's Service are assumed to be part of the webhook's installation.
+
         ### Explicit Dependency Ordering
         Pulumi supports the `config.kubernetes.io/depends-on` annotation to declare an explicit dependency on a given resource.
         The annotation accepts a list of resource references, delimited by commas.
 
-        Note that references to resources outside the ConfigGroup aren't supported.
+        A reference to a resource outside the ConfigGroup is assumed to exist in the cluster already, and isn't waited for.
 
         **Resource reference**
 
@@ -220,10 +332,15 @@ class ConfigGroup(pulumi.ComponentResource):
 
         :param str resource_name: The name of the resource.
         :param pulumi.ResourceOptions opts: Options for the resource.
-        :param pulumi.Input[Sequence[pulumi.Input[_builtins.str]]] files: Set of paths and/or URLs to Kubernetes manifest files. Supports glob patterns.
+        :param pulumi.Input[Sequence[pulumi.Input[Union['SelectorArgs', 'SelectorArgsDict']]]] exclude: Selects the objects not to register, among the included objects. The skipped objects are reported in the `skipped` output.
+        :param pulumi.Input[Sequence[pulumi.Input[_builtins.str]]] files: Set of paths and/or URLs to Kubernetes manifest files. Supports glob patterns. A URL may be pinned to the SHA-256 digest of its content with a `#sha256=<digest>` fragment; the content is verified, and cached so that it's downloaded only once.
+        :param pulumi.Input[Sequence[pulumi.Input[Union['SelectorArgs', 'SelectorArgsDict']]]] include: Selects the objects to register. An object is registered if it matches any of the selectors; by default, all objects are registered.
         :param pulumi.Input[Sequence[Any]] objs: Objects representing Kubernetes resource configurations.
+        :param pulumi.Input[Sequence[pulumi.Input[Union['PatchArgs', 'PatchArgsDict']]]] patches: Patches to apply to the objects (including `objs`) before they're registered, in order. The patches are applied in-process, with kustomize.
         :param pulumi.Input[_builtins.str] resource_prefix: A prefix for the auto-generated resource names. Defaults to the name of the ConfigGroup. Example: A resource created with resourcePrefix="foo" would produce a resource named "foo-resourceName".
         :param pulumi.Input[_builtins.bool] skip_await: Indicates that child resources should skip the await logic. Defaults to `false`.
+        :param pulumi.Input[_builtins.str] template_engine: The engine with which to template the files and YAML literals: `goTemplate` for Go templates, or `envsubst` for `${VAR}` and `${VAR:-default}` references to the values (by dotted paths, e.g. `${image.tag}`). References to missing values without a default are left as is, and `$${VAR}` escapes a reference. Defaults to `goTemplate` when `values` is set; otherwise, the files and YAML literals aren't templated.
+        :param pulumi.Input[Mapping[str, Any]] values: Values with which to template the files and YAML literals (but not `objs`) before they're parsed. With the `goTemplate` engine, the values are available as `.Values`, along with the Sprig functions.
         :param pulumi.Input[_builtins.str] yaml: A Kubernetes YAML manifest containing Kubernetes resource configuration(s).
         """
         ...
@@ -241,6 +358,24 @@ class ConfigGroup(pulumi.ComponentResource):
         3. Using a literal string containing YAML, or a list of such strings:
         4. Any combination of files, patterns, or YAML strings:
 
+        ## Templating
+        The manifests may be templated with `values`, e.g. to set image tags or namespaces per stack. By default, they're
+        rendered as Go templates with the Sprig functions, and the values are available as `.Values`
+        (e.g. `{{ .Values.image.tag }}`). With `templateEngine: envsubst`, `${VAR}` and `${VAR:-default}` references to the
+        values are substituted instead (e.g. `${image.tag}`), and references to missing values are left as is.
+
+        ## Patches
+        The objects may be patched with `patches`, before they're registered, without a transform in the program. Each patch
+        is a strategic merge patch or a JSON 6902 patch, and its `target` selects the objects to patch by group, version,
+        kind, name, namespace, label selector or annotation selector. A strategic merge patch may omit the target, in which
+        case the patch itself identifies the object to patch.
+
+        ## Selecting objects
+        The objects to register may be selected with `include` and `exclude`, e.g. to skip a Namespace that's managed
+        separately. Each selector matches objects by `group`, `version`, `kind`, `name` (a glob pattern), `namespace` and
+        `labelSelector`. An object is registered if it matches any of the included selectors (or if there are none), and none
+        of the excluded selectors. The skipped objects are reported in the `skipped` output.
+
         ## Dependency ordering
         Sometimes resources must be applied in a specific order. For example, a namespace resource must be
         created before any namespaced resources, or a Custom Resource Definition (CRD) must be pre-installed.
@@ -248,11 +383,19 @@ class ConfigGroup(pulumi.ComponentResource):
         Pulumi uses heuristics to determine which order to apply and delete objects within the ConfigGroup.  Pulumi also
         waits for each object to be fully reconciled, unless `skipAwait` is enabled.
 
+        Workloads depend on the ConfigMaps and Secrets in the same namespace that their pods reference (by volumes, `envFrom`,
+        `env` or `imagePullSecrets`), so that the referenced configuration is created first.
+
+        Admission webhook configurations depend on the Services that back them and on the workloads that those Services
+        select, and the objects that a webhook intercepts depend on the webhook configuration, so that the webhook is ready
+        before the objects are created. Webhooks whose `failurePolicy` is `Ignore` don't affect the ordering, and the objects
+        in the namespace of the webhook's Service are assumed to be part of the webhook's installation.
+
         ### Explicit Dependency Ordering
         Pulumi supports the `config.kubernetes.io/depends-on` annotation to declare an explicit dependency on a given resource.
         The annotation accepts a list of resource references, delimited by commas.
 
-        Note that references to resources outside the ConfigGroup aren't supported.
+        A reference to a resource outside the ConfigGroup is assumed to exist in the cluster already, and isn't waited for.
 
         **Resource reference**
 
@@ -348,10 +491,15 @@ class ConfigGroup(pulumi.ComponentResource):
     def _internal_init(__self__,
                  resource_name: str,
                  opts: Optional[pulumi.ResourceOptions] = None,
+                 exclude: pulumi.Input[Optional[Sequence[pulumi.Input[Union['SelectorArgs', 'SelectorArgsDict']]]]] = None,
                  files: pulumi.Input[Optional[Sequence[pulumi.Input[_builtins.str]]]] = None,
+                 include: pulumi.Input[Optional[Sequence[pulumi.Input[Union['SelectorArgs', 'SelectorArgsDict']]]]] = None,
                  objs: pulumi.Input[Optional[Sequence[Any]]] = None,
+                 patches: pulumi.Input[Optional[Sequence[pulumi.Input[Union['PatchArgs', 'PatchArgsDict']]]]] = None,
                  resource_prefix: pulumi.Input[Optional[_builtins.str]] = None,
                  skip_await: pulumi.Input[Optional[_builtins.bool]] = None,
+                 template_engine: pulumi.Input[Optional[_builtins.str]] = None,
+                 values: pulumi.Input[Optional[Mapping[str, Any]]] = None,
                  yaml: pulumi.Input[Optional[_builtins.str]] = None,
                  __props__=None):
         opts = pulumi.ResourceOptions.merge(_utilities.get_resource_opts_defaults(), opts)
@@ -364,12 +512,18 @@ class ConfigGroup(pulumi.ComponentResource):
                 raise TypeError('__props__ is only valid when passed in combination with a valid opts.id to get an existing resource')
             __props__ = ConfigGroupArgs.__new__(ConfigGroupArgs)
 
+            __props__.__dict__["exclude"] = exclude
             __props__.__dict__["files"] = files
+            __props__.__dict__["include"] = include
             __props__.__dict__["objs"] = objs
+            __props__.__dict__["patches"] = patches
             __props__.__dict__["resource_prefix"] = resource_prefix
             __props__.__dict__["skip_await"] = skip_await
+            __props__.__dict__["template_engine"] = template_engine
+            __props__.__dict__["values"] = values
             __props__.__dict__["yaml"] = yaml
             __props__.__dict__["resources"] = None
+            __props__.__dict__["skipped"] = None
         super(ConfigGroup, __self__).__init__(
             'kubernetes:yaml/v2:ConfigGroup',
             resource_name,
@@ -385,3 +539,11 @@ class ConfigGroup(pulumi.ComponentResource):
         """
         return pulumi.get(self, "resources")
 
+    @_builtins.property
+    @pulumi.getter
+    def skipped(self) -> pulumi.Output[Optional[Sequence[_builtins.str]]]:
+        """
+        The objects of the ConfigGroup that weren't registered because of the `include` and `exclude` selectors, e.g. `v1/Namespace:my-namespace`.
+        """
+        return pulumi.get(self, "skipped")
+
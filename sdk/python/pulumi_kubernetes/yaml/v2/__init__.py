@@ -8,3 +8,4 @@ import typing
 # Export this package's modules as members:
 from .ConfigFile import *
 from .ConfigGroup import *
+from ._inputs import *
@@ -0,0 +1,366 @@
+# coding=utf-8
+# *** WARNING: this file was generated by pulumigen. ***
+# *** Do not edit by hand unless you're certain you know what you are doing! ***
+
+import builtins as _builtins
+import warnings
+import sys
+import pulumi
+import pulumi.runtime
+from typing import Any, Mapping, Optional, Sequence, Union, overload
+if sys.version_info >= (3, 11):
+    from typing import NotRequired, TypedDict, TypeAlias
+else:
+    from typing_extensions import NotRequired, TypedDict, TypeAlias
+from ... import _utilities
+
+__all__ = [
+    'PatchArgs',
+    'PatchArgsDict',
+    'PatchTargetArgs',
+    'PatchTargetArgsDict',
+    'SelectorArgs',
+    'SelectorArgsDict',
+]
+
+class PatchArgsDict(TypedDict):
+    """
+    A patch to apply to the objects of the component before they're registered, either a strategic merge patch or a JSON 6902 patch.
+    """
+    patch: pulumi.Input[_builtins.str]
+    """
+    The content of the patch, in YAML or JSON.
+    """
+    target: NotRequired[pulumi.Input[Optional['PatchTargetArgsDict']]]
+    """
+    Selects the objects to patch. A strategic merge patch may omit the target, in which case the patch itself identifies the object to patch.
+    """
+
+@pulumi.input_type
+class PatchArgs:
+    def __init__(__self__, *,
+                 patch: pulumi.Input[_builtins.str],
+                 target: pulumi.Input[Optional['PatchTargetArgs']] = None):
+        """
+        A patch to apply to the objects of the component before they're registered, either a strategic merge patch or a JSON 6902 patch.
+
+        :param pulumi.Input[_builtins.str] patch: The content of the patch, in YAML or JSON.
+        :param pulumi.Input['PatchTargetArgs'] target: Selects the objects to patch. A strategic merge patch may omit the target, in which case the patch itself identifies the object to patch.
+        """
+        pulumi.set(__self__, "patch", patch)
+        if target is not None:
+            pulumi.set(__self__, "target", target)
+
+    @_builtins.property
+    @pulumi.getter
+    def patch(self) -> pulumi.Input[_builtins.str]:
+        """
+        The content of the patch, in YAML or JSON.
+        """
+        return pulumi.get(self, "patch")
+
+    @patch.setter
+    def patch(self, value: pulumi.Input[_builtins.str]):
+        pulumi.set(self, "patch", value)
+
+    @_builtins.property
+    @pulumi.getter
+    def target(self) -> pulumi.Input[Optional['PatchTargetArgs']]:
+        """
+        Selects the objects to patch. A strategic merge patch may omit the target, in which case the patch itself identifies the object to patch.
+        """
+        return pulumi.get(self, "target")
+
+    @target.setter
+    def target(self, value: pulumi.Input[Optional['PatchTargetArgs']]):
+        pulumi.set(self, "target", value)
+
+
+class PatchTargetArgsDict(TypedDict):
+    """
+    Selects the objects that a patch applies to, by kind, name, namespace, labels and annotations.
+    """
+    annotation_selector: NotRequired[pulumi.Input[Optional[_builtins.str]]]
+    """
+    An annotation selector that the objects must match.
+    """
+    group: NotRequired[pulumi.Input[Optional[_builtins.str]]]
+    """
+    The API group of the objects.
+    """
+    kind: NotRequired[pulumi.Input[Optional[_builtins.str]]]
+    """
+    The kind of the objects.
+    """
+    label_selector: NotRequired[pulumi.Input[Optional[_builtins.str]]]
+    """
+    A label selector that the objects must match.
+    """
+    name: NotRequired[pulumi.Input[Optional[_builtins.str]]]
+    """
+    The name of the objects (a regular expression).
+    """
+    namespace: NotRequired[pulumi.Input[Optional[_builtins.str]]]
+    """
+    The namespace of the objects.
+    """
+    version: NotRequired[pulumi.Input[Optional[_builtins.str]]]
+    """
+    The API version of the objects.
+    """
+
+@pulumi.input_type
+class PatchTargetArgs:
+    def __init__(__self__, *,
+                 annotation_selector: pulumi.Input[Optional[_builtins.str]] = None,
+                 group: pulumi.Input[Optional[_builtins.str]] = None,
+                 kind: pulumi.Input[Optional[_builtins.str]] = None,
+                 label_selector: pulumi.Input[Optional[_builtins.str]] = None,
+                 name: pulumi.Input[Optional[_builtins.str]] = None,
+                 namespace: pulumi.Input[Optional[_builtins.str]] = None,
+                 version: pulumi.Input[Optional[_builtins.str]] = None):
+        """
+        Selects the objects that a patch applies to, by kind, name, namespace, labels and annotations.
+
+        :param pulumi.Input[_builtins.str] annotation_selector: An annotation selector that the objects must match.
+        :param pulumi.Input[_builtins.str] group: The API group of the objects.
+        :param pulumi.Input[_builtins.str] kind: The kind of the objects.
+        :param pulumi.Input[_builtins.str] label_selector: A label selector that the objects must match.
+        :param pulumi.Input[_builtins.str] name: The name of the objects (a regular expression).
+        :param pulumi.Input[_builtins.str] namespace: The namespace of the objects.
+        :param pulumi.Input[_builtins.str] version: The API version of the objects.
+        """
+        if annotation_selector is not None:
+            pulumi.set(__self__, "annotation_selector", annotation_selector)
+        if group is not None:
+            pulumi.set(__self__, "group", group)
+        if kind is not None:
+            pulumi.set(__self__, "kind", kind)
+        if label_selector is not None:
+            pulumi.set(__self__, "label_selector", label_selector)
+        if name is not None:
+            pulumi.set(__self__, "name", name)
+        if namespace is not None:
+            pulumi.set(__self__, "namespace", namespace)
+        if version is not None:
+            pulumi.set(__self__, "version", version)
+
+    @_builtins.property
+    @pulumi.getter(name="annotationSelector")
+    def annotation_selector(self) -> pulumi.Input[Optional[_builtins.str]]:
+        """
+        An annotation selector that the objects must match.
+        """
+        return pulumi.get(self, "annotation_selector")
+
+    @annotation_selector.setter
+    def annotation_selector(self, value: pulumi.Input[Optional[_builtins.str]]):
+        pulumi.set(self, "annotation_selector", value)
+
+    @_builtins.property
+    @pulumi.getter
+    def group(self) -> pulumi.Input[Optional[_builtins.str]]:
+        """
+        The API group of the objects.
+        """
+        return pulumi.get(self, "group")
+
+    @group.setter
+    def group(self, value: pulumi.Input[Optional[_builtins.str]]):
+        pulumi.set(self, "group", value)
+
+    @_builtins.property
+    @pulumi.getter
+    def kind(self) -> pulumi.Input[Optional[_builtins.str]]:
+        """
+        The kind of the objects.
+        """
+        return pulumi.get(self, "kind")
+
+    @kind.setter
+    def kind(self, value: pulumi.Input[Optional[_builtins.str]]):
+        pulumi.set(self, "kind", value)
+
+    @_builtins.property
+    @pulumi.getter(name="labelSelector")
+    def label_selector(self) -> pulumi.Input[Optional[_builtins.str]]:
+        """
+        A label selector that the objects must match.
+        """
+        return pulumi.get(self, "label_selector")
+
+    @label_selector.setter
+    def label_selector(self, value: pulumi.Input[Optional[_builtins.str]]):
+        pulumi.set(self, "label_selector", value)
+
+    @_builtins.property
+    @pulumi.getter
+    def name(self) -> pulumi.Input[Optional[_builtins.str]]:
+        """
+        The name of the objects (a regular expression).
+        """
+        return pulumi.get(self, "name")
+
+    @name.setter
+    def name(self, value: pulumi.Input[Optional[_builtins.str]]):
+        pulumi.set(self, "name", value)
+
+    @_builtins.property
+    @pulumi.getter
+    def namespace(self) -> pulumi.Input[Optional[_builtins.str]]:
+        """
+        The namespace of the objects.
+        """
+        return pulumi.get(self, "namespace")
+
+    @namespace.setter
+    def namespace(self, value: pulumi.Input[Optional[_builtins.str]]):
+        pulumi.set(self, "namespace", value)
+
+    @_builtins.property
+    @pulumi.getter
+    def version(self) -> pulumi.Input[Optional[_builtins.str]]:
+        """
+        The API version of the objects.
+        """
+        return pulumi.get(self, "version")
+
+    @version.setter
+    def version(self, value: pulumi.Input[Optional[_builtins.str]]):
+        pulumi.set(self, "version", value)
+
+
+class SelectorArgsDict(TypedDict):
+    """
+    Selects objects by group, version, kind, name, namespace and labels. The fields that aren't set match any object.
+    """
+    group: NotRequired[pulumi.Input[Optional[_builtins.str]]]
+    """
+    The API group of the objects.
+    """
+    kind: NotRequired[pulumi.Input[Optional[_builtins.str]]]
+    """
+    The kind of the objects.
+    """
+    label_selector: NotRequired[pulumi.Input[Optional[_builtins.str]]]
+    """
+    A label selector that the objects must match, e.g. `app=nginx,tier!=cache`.
+    """
+    name: NotRequired[pulumi.Input[Optional[_builtins.str]]]
+    """
+    A glob pattern that the name of the objects must match, e.g. `*-psp`.
+    """
+    namespace: NotRequired[pulumi.Input[Optional[_builtins.str]]]
+    """
+    The namespace of the objects.
+    """
+    version: NotRequired[pulumi.Input[Optional[_builtins.str]]]
+    """
+    The API version of the objects.
+    """
+
+@pulumi.input_type
+class SelectorArgs:
+    def __init__(__self__, *,
+                 group: pulumi.Input[Optional[_builtins.str]] = None,
+                 kind: pulumi.Input[Optional[_builtins.str]] = None,
+                 label_selector: pulumi.Input[Optional[_builtins.str]] = None,
+                 name: pulumi.Input[Optional[_builtins.str]] = None,
+                 namespace: pulumi.Input[Optional[_builtins.str]] = None,
+                 version: pulumi.Input[Optional[_builtins.str]] = None):
+        """
+        Selects objects by group, version, kind, name, namespace and labels. The fields that aren't set match any object.
+
+        :param pulumi.Input[_builtins.str] group: The API group of the objects.
+        :param pulumi.Input[_builtins.str] kind: The kind of the objects.
+        :param pulumi.Input[_builtins.str] label_selector: A label selector that the objects must match, e.g. `app=nginx,tier!=cache`.
+        :param pulumi.Input[_builtins.str] name: A glob pattern that the name of the objects must match, e.g. `*-psp`.
+        :param pulumi.Input[_builtins.str] namespace: The namespace of the objects.
+        :param pulumi.Input[_builtins.str] version: The API version of the objects.
+        """
+        if group is not None:
+            pulumi.set(__self__, "group", group)
+        if kind is not None:
+            pulumi.set(__self__, "kind", kind)
+        if label_selector is not None:
+            pulumi.set(__self__, "label_selector", label_selector)
+        if name is not None:
+            pulumi.set(__self__, "name", name)
+        if namespace is not None:
+            pulumi.set(__self__, "namespace", namespace)
+        if version is not None:
+            pulumi.set(__self__, "version", version)
+
+    @_builtins.property
+    @pulumi.getter
+    def group(self) -> pulumi.Input[Optional[_builtins.str]]:
+        """
+        The API group of the objects.
+        """
+        return pulumi.get(self, "group")
+
+    @group.setter
+    def group(self, value: pulumi.Input[Optional[_builtins.str]]):
+        pulumi.set(self, "group", value)
+
+    @_builtins.property
+    @pulumi.getter
+    def kind(self) -> pulumi.Input[Optional[_builtins.str]]:
+        """
+        The kind of the objects.
+        """
+        return pulumi.get(self, "kind")
+
+    @kind.setter
+    def kind(self, value: pulumi.Input[Optional[_builtins.str]]):
+        pulumi.set(self, "kind", value)
+
+    @_builtins.property
+    @pulumi.getter(name="labelSelector")
+    def label_selector(self) -> pulumi.Input[Optional[_builtins.str]]:
+        """
+        A label selector that the objects must match, e.g. `app=nginx,tier!=cache`.
+        """
+        return pulumi.get(self, "label_selector")
+
+    @label_selector.setter
+    def label_selector(self, value: pulumi.Input[Optional[_builtins.str]]):
+        pulumi.set(self, "label_selector", value)
+
+    @_builtins.property
+    @pulumi.getter
+    def name(self) -> pulumi.Input[Optional[_builtins.str]]:
+        """
+        A glob pattern that the name of the objects must match, e.g. `*-psp`.
+        """
+        return pulumi.get(self, "name")
+
+    @name.setter
+    def name(self, value: pulumi.Input[Optional[_builtins.str]]):
+        pulumi.set(self, "name", value)
+
+    @_builtins.property
+    @pulumi.getter
+    def namespace(self) -> pulumi.Input[Optional[_builtins.str]]:
+        """
+        The namespace of the objects.
+        """
+        return pulumi.get(self, "namespace")
+
+    @namespace.setter
+    def namespace(self, value: pulumi.Input[Optional[_builtins.str]]):
+        pulumi.set(self, "namespace", value)
+
+    @_builtins.property
+    @pulumi.getter
+    def version(self) -> pulumi.Input[Optional[_builtins.str]]:
+        """
+        The API version of the objects.
+        """
+        return pulumi.get(self, "version")
+
+    @version.setter
+    def version(self, value: pulumi.Input[Optional[_builtins.str]]):
+        pulumi.set(self, "version", value)
+
+